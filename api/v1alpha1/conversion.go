@@ -145,6 +145,11 @@ func Convert_v1alpha2_InterfaceConfig_To_v1alpha1_InterfaceConfig(in *v1alpha2.I
 	return autoConvert_v1alpha2_InterfaceConfig_To_v1alpha1_InterfaceConfig(in, out, s)
 }
 
+func Convert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices(in *v1alpha2.VirtualNetworkDevices, out *VirtualNetworkDevices, s conversion.Scope) error {
+	// Bonds, VLANs and bridges do not exist in v1alpha1 and are restored from the annotation.
+	return autoConvert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices(in, out, s)
+}
+
 func Convert_v1alpha2_RouteSpec_To_v1alpha1_RouteSpec(in *v1alpha2.RouteSpec, out *RouteSpec, s conversion.Scope) error {
 	if in == nil {
 		return nil
@@ -209,6 +214,7 @@ func Convert_v1alpha1_ProxmoxClusterStatus_To_v1alpha2_ProxmoxClusterStatus(in *
 
 func Convert_v1alpha2_ProxmoxClusterStatus_To_v1alpha1_ProxmoxClusterStatus(in *v1alpha2.ProxmoxClusterStatus, out *ProxmoxClusterStatus, s conversion.Scope) error {
 	// Accept WARNING: in.InClusterZoneRef does not exist in peer-type
+	// Accept WARNING: in.SDN does not exist in peer-type
 	if err := autoConvert_v1alpha2_ProxmoxClusterStatus_To_v1alpha1_ProxmoxClusterStatus(in, out, s); err != nil {
 		return err
 	}
@@ -219,6 +225,12 @@ func Convert_v1alpha2_ProxmoxClusterStatus_To_v1alpha1_ProxmoxClusterStatus(in *
 	return nil
 }
 
+func Convert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec(in *v1alpha2.ProxmoxMachineSpec, out *ProxmoxMachineSpec, s conversion.Scope) error {
+	// Accept WARNING: in.BootstrapDelivery does not exist in peer-type
+	// Accept WARNING: in.Firewall does not exist in peer-type
+	return autoConvert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec(in, out, s)
+}
+
 func Convert_v1alpha2_ProxmoxMachineStatus_To_v1alpha1_ProxmoxMachineStatus(in *v1alpha2.ProxmoxMachineStatus, out *ProxmoxMachineStatus, s conversion.Scope) error {
 	err := autoConvert_v1alpha2_ProxmoxMachineStatus_To_v1alpha1_ProxmoxMachineStatus(in, out, s)
 	if err != nil {
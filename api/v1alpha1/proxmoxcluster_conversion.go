@@ -40,7 +40,18 @@ func (src *ProxmoxCluster) ConvertTo(dstRaw conversion.Hub) error {
 
 	// Restore lossy fields
 	dst.Spec.ZoneConfigs = restored.Spec.ZoneConfigs
+	dst.Spec.SDN = restored.Spec.SDN
+	dst.Spec.Firewall = restored.Spec.Firewall
+	dst.Spec.KubeVIP = restored.Spec.KubeVIP
+	dst.Spec.ConfigDriftPolicy = restored.Spec.ConfigDriftPolicy
+	dst.Spec.TaskRetryPolicy = restored.Spec.TaskRetryPolicy
+	dst.Spec.OrphanedVMPolicy = restored.Spec.OrphanedVMPolicy
+	dst.Spec.HighAvailability = restored.Spec.HighAvailability
+	dst.Spec.Pool = restored.Spec.Pool
+	dst.Spec.ControlPlaneEndpointIPAM = restored.Spec.ControlPlaneEndpointIPAM
 	dst.Status.InClusterZoneRef = restored.Status.InClusterZoneRef
+	dst.Status.SDN = restored.Status.SDN
+	dst.Status.OrphanedVMs = restored.Status.OrphanedVMs
 
 	clusterv1.Convert_bool_To_Pointer_bool(src.Spec.ExternalManagedControlPlane, ok, restored.Spec.ExternalManagedControlPlane, &dst.Spec.ExternalManagedControlPlane)
 
@@ -39,6 +39,15 @@ func (src *ProxmoxClusterTemplate) ConvertTo(dstRaw conversion.Hub) error {
 
 	// Restore lossy fields
 	dst.Spec.Template.Spec.ZoneConfigs = restored.Spec.Template.Spec.ZoneConfigs
+	dst.Spec.Template.Spec.SDN = restored.Spec.Template.Spec.SDN
+	dst.Spec.Template.Spec.Firewall = restored.Spec.Template.Spec.Firewall
+	dst.Spec.Template.Spec.KubeVIP = restored.Spec.Template.Spec.KubeVIP
+	dst.Spec.Template.Spec.ConfigDriftPolicy = restored.Spec.Template.Spec.ConfigDriftPolicy
+	dst.Spec.Template.Spec.TaskRetryPolicy = restored.Spec.Template.Spec.TaskRetryPolicy
+	dst.Spec.Template.Spec.OrphanedVMPolicy = restored.Spec.Template.Spec.OrphanedVMPolicy
+	dst.Spec.Template.Spec.HighAvailability = restored.Spec.Template.Spec.HighAvailability
+	dst.Spec.Template.Spec.Pool = restored.Spec.Template.Spec.Pool
+	dst.Spec.Template.Spec.ControlPlaneEndpointIPAM = restored.Spec.Template.Spec.ControlPlaneEndpointIPAM
 
 	clusterv1.Convert_bool_To_Pointer_bool(src.Spec.Template.Spec.ExternalManagedControlPlane, ok, restored.Spec.Template.Spec.ExternalManagedControlPlane, &dst.Spec.Template.Spec.ExternalManagedControlPlane)
 
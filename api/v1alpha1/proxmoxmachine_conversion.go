@@ -48,6 +48,7 @@ func (src *ProxmoxMachine) ConvertTo(dstRaw conversion.Hub) error {
 	if dst.Status.VMStatus != nil && *dst.Status.VMStatus == "" {
 		dst.Status.VMStatus = nil
 	}
+	dst.Status.LastFailedTask = restored.Status.LastFailedTask
 
 	// Normalize ProxmoxMachineSpec after auto-conversion
 	normalizeProxmoxMachineSpec(&dst.Spec)
@@ -94,8 +95,14 @@ func restoreProxmoxMachineSpec(src *ProxmoxMachineSpec, dst *v1alpha2.ProxmoxMac
 	}
 
 	// restore fields that don't exist in v1alpha1
+	dst.BootstrapDelivery = restored.BootstrapDelivery
+	dst.Firewall = restored.Firewall
+	dst.TaskRetryPolicy = restored.TaskRetryPolicy
+	dst.HighAvailability = restored.HighAvailability
+
 	if dst.Network != nil && restored.Network != nil {
 		dst.Network.Zone = restored.Network.Zone
+		dst.Network.Renderer = restored.Network.Renderer
 
 		// Restore network device fields
 		for i := range dst.Network.NetworkDevices {
@@ -103,6 +110,12 @@ func restoreProxmoxMachineSpec(src *ProxmoxMachineSpec, dst *v1alpha2.ProxmoxMac
 				dst.Network.NetworkDevices[i].DefaultIPv4 = restored.Network.NetworkDevices[i].DefaultIPv4
 				dst.Network.NetworkDevices[i].DefaultIPv6 = restored.Network.NetworkDevices[i].DefaultIPv6
 				dst.Network.NetworkDevices[i].Queues = restored.Network.NetworkDevices[i].Queues
+				dst.Network.NetworkDevices[i].VNet = restored.Network.NetworkDevices[i].VNet
+				dst.Network.NetworkDevices[i].DHCP4 = restored.Network.NetworkDevices[i].DHCP4
+				dst.Network.NetworkDevices[i].DHCP6 = restored.Network.NetworkDevices[i].DHCP6
+				dst.Network.NetworkDevices[i].IPv6Mode = restored.Network.NetworkDevices[i].IPv6Mode
+				dst.Network.NetworkDevices[i].AcceptRA = restored.Network.NetworkDevices[i].AcceptRA
+				dst.Network.NetworkDevices[i].IPv6Token = restored.Network.NetworkDevices[i].IPv6Token
 			}
 		}
 	}
@@ -169,6 +182,11 @@ func restoreProxmoxMachineSpec(src *ProxmoxMachineSpec, dst *v1alpha2.ProxmoxMac
 
 		}
 
+		// Bonds, VLANs and bridges do not exist in v1alpha1.
+		dst.Network.Bonds = restored.Network.Bonds
+		dst.Network.VLANs = restored.Network.VLANs
+		dst.Network.Bridges = restored.Network.Bridges
+
 		// Is6 is a v1alpha2-only field on VRF routes/policies and is dropped by
 		// auto-conversion; restore it from the annotation. VRFs convert
 		// index-for-index, so dst and restored stay aligned.
@@ -169,11 +169,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha2.ProxmoxMachineSpec)(nil), (*ProxmoxMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec(a.(*v1alpha2.ProxmoxMachineSpec), b.(*ProxmoxMachineSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ProxmoxMachineTemplate)(nil), (*v1alpha2.ProxmoxMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ProxmoxMachineTemplate_To_v1alpha2_ProxmoxMachineTemplate(a.(*ProxmoxMachineTemplate), b.(*v1alpha2.ProxmoxMachineTemplate), scope)
 	}); err != nil {
@@ -294,11 +289,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha2.VirtualNetworkDevices)(nil), (*VirtualNetworkDevices)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices(a.(*v1alpha2.VirtualNetworkDevices), b.(*VirtualNetworkDevices), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddConversionFunc((*[]string)(nil), (*[]v1alpha2.NetName)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_Slice_string_To_Slice_v1alpha2_NetName(a.(*[]string), b.(*[]v1alpha2.NetName), scope)
 	}); err != nil {
@@ -409,6 +399,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha2.ProxmoxMachineSpec)(nil), (*ProxmoxMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec(a.(*v1alpha2.ProxmoxMachineSpec), b.(*ProxmoxMachineSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha2.ProxmoxMachineStatus)(nil), (*ProxmoxMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ProxmoxMachineStatus_To_v1alpha1_ProxmoxMachineStatus(a.(*v1alpha2.ProxmoxMachineStatus), b.(*ProxmoxMachineStatus), scope)
 	}); err != nil {
@@ -429,6 +424,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha2.VirtualNetworkDevices)(nil), (*VirtualNetworkDevices)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices(a.(*v1alpha2.VirtualNetworkDevices), b.(*VirtualNetworkDevices), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.Condition)(nil), (*v1.Condition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Condition_To_v1_Condition(a.(*v1beta1.Condition), b.(*v1.Condition), scope)
 	}); err != nil {
@@ -528,6 +528,11 @@ func Convert_v1alpha1_InterfaceConfig_To_v1alpha2_InterfaceConfig(in *InterfaceC
 
 func autoConvert_v1alpha2_InterfaceConfig_To_v1alpha1_InterfaceConfig(in *v1alpha2.InterfaceConfig, out *InterfaceConfig, s conversion.Scope) error {
 	// WARNING: in.IPPoolRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.DHCP4 requires manual conversion: does not exist in peer-type
+	// WARNING: in.DHCP6 requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6Mode requires manual conversion: does not exist in peer-type
+	// WARNING: in.AcceptRA requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6Token requires manual conversion: does not exist in peer-type
 	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
 	if err := Convert_v1alpha2_Routing_To_v1alpha1_Routing(&in.Routing, &out.Routing, s); err != nil {
 		return err
@@ -594,6 +599,7 @@ func autoConvert_v1alpha2_NetworkDevice_To_v1alpha1_NetworkDevice(in *v1alpha2.N
 	if err := v1.Convert_Pointer_string_To_string(&in.Bridge, &out.Bridge, s); err != nil {
 		return err
 	}
+	// WARNING: in.VNet requires manual conversion: does not exist in peer-type
 	// WARNING: in.DefaultIPv4 requires manual conversion: does not exist in peer-type
 	// WARNING: in.DefaultIPv6 requires manual conversion: does not exist in peer-type
 	out.Model = (*string)(unsafe.Pointer(in.Model))
@@ -628,6 +634,7 @@ func autoConvert_v1alpha1_NetworkSpec_To_v1alpha2_NetworkSpec(in *NetworkSpec, o
 
 func autoConvert_v1alpha2_NetworkSpec_To_v1alpha1_NetworkSpec(in *v1alpha2.NetworkSpec, out *NetworkSpec, s conversion.Scope) error {
 	// WARNING: in.Zone requires manual conversion: does not exist in peer-type
+	// WARNING: in.Renderer requires manual conversion: does not exist in peer-type
 	// WARNING: in.NetworkDevices requires manual conversion: does not exist in peer-type
 	if err := Convert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices(&in.VirtualNetworkDevices, &out.VirtualNetworkDevices, s); err != nil {
 		return err
@@ -864,6 +871,7 @@ func autoConvert_v1alpha1_ProxmoxClusterSpec_To_v1alpha2_ProxmoxClusterSpec(in *
 
 func autoConvert_v1alpha2_ProxmoxClusterSpec_To_v1alpha1_ProxmoxClusterSpec(in *v1alpha2.ProxmoxClusterSpec, out *ProxmoxClusterSpec, s conversion.Scope) error {
 	// WARNING: in.ControlPlaneEndpoint requires manual conversion: inconvertible types (github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2.APIEndpoint vs *sigs.k8s.io/cluster-api/api/core/v1beta1.APIEndpoint)
+	// WARNING: in.ControlPlaneEndpointIPAM requires manual conversion: does not exist in peer-type
 	if err := v1.Convert_Pointer_bool_To_bool(&in.ExternalManagedControlPlane, &out.ExternalManagedControlPlane, s); err != nil {
 		return err
 	}
@@ -897,6 +905,14 @@ func autoConvert_v1alpha2_ProxmoxClusterSpec_To_v1alpha1_ProxmoxClusterSpec(in *
 	}
 	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
 	// WARNING: in.ZoneConfigs requires manual conversion: does not exist in peer-type
+	// WARNING: in.SDN requires manual conversion: does not exist in peer-type
+	// WARNING: in.Firewall requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeVIP requires manual conversion: does not exist in peer-type
+	// WARNING: in.ConfigDriftPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.TaskRetryPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.OrphanedVMPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.HighAvailability requires manual conversion: does not exist in peer-type
+	// WARNING: in.Pool requires manual conversion: does not exist in peer-type
 	out.CredentialsRef = (*corev1.SecretReference)(unsafe.Pointer(in.CredentialsRef))
 	return nil
 }
@@ -953,6 +969,8 @@ func autoConvert_v1alpha2_ProxmoxClusterStatus_To_v1alpha1_ProxmoxClusterStatus(
 	} else {
 		out.NodeLocations = nil
 	}
+	// WARNING: in.SDN requires manual conversion: does not exist in peer-type
+	// WARNING: in.OrphanedVMs requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1250,16 +1268,15 @@ func autoConvert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec(in *
 	} else {
 		out.MetadataSettings = nil
 	}
+	// WARNING: in.BootstrapDelivery requires manual conversion: does not exist in peer-type
 	out.AllowedNodes = *(*[]string)(unsafe.Pointer(&in.AllowedNodes))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	// WARNING: in.Firewall requires manual conversion: does not exist in peer-type
+	// WARNING: in.TaskRetryPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.HighAvailability requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec is an autogenerated conversion function.
-func Convert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec(in *v1alpha2.ProxmoxMachineSpec, out *ProxmoxMachineSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha2_ProxmoxMachineSpec_To_v1alpha1_ProxmoxMachineSpec(in, out, s)
-}
-
 func autoConvert_v1alpha1_ProxmoxMachineStatus_To_v1alpha2_ProxmoxMachineStatus(in *ProxmoxMachineStatus, out *v1alpha2.ProxmoxMachineStatus, s conversion.Scope) error {
 	// WARNING: in.Ready requires manual conversion: does not exist in peer-type
 	out.Addresses = *(*[]v1beta2.MachineAddress)(unsafe.Pointer(&in.Addresses))
@@ -1327,6 +1344,7 @@ func autoConvert_v1alpha2_ProxmoxMachineStatus_To_v1alpha1_ProxmoxMachineStatus(
 	out.ProxmoxNode = (*string)(unsafe.Pointer(in.ProxmoxNode))
 	out.TaskRef = (*string)(unsafe.Pointer(in.TaskRef))
 	// WARNING: in.RetryAfter requires manual conversion: inconvertible types (*k8s.io/apimachinery/pkg/apis/meta/v1.Time vs k8s.io/apimachinery/pkg/apis/meta/v1.Time)
+	// WARNING: in.LastFailedTask requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1836,10 +1854,8 @@ func autoConvert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevice
 	} else {
 		out.VRFs = nil
 	}
+	// WARNING: in.Bonds requires manual conversion: does not exist in peer-type
+	// WARNING: in.VLANs requires manual conversion: does not exist in peer-type
+	// WARNING: in.Bridges requires manual conversion: does not exist in peer-type
 	return nil
 }
-
-// Convert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices is an autogenerated conversion function.
-func Convert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices(in *v1alpha2.VirtualNetworkDevices, out *VirtualNetworkDevices, s conversion.Scope) error {
-	return autoConvert_v1alpha2_VirtualNetworkDevices_To_v1alpha1_VirtualNetworkDevices(in, out, s)
-}
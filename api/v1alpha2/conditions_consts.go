@@ -43,6 +43,82 @@ const (
 
 	// ProxmoxClusterProxmoxAvailableDeletingReason documents a ProxmoxCluster being deleted.
 	ProxmoxClusterProxmoxAvailableDeletingReason = "Deleting"
+
+	// ProxmoxClusterSDNReadyCondition documents the status of the Proxmox SDN
+	// objects owned by the ProxmoxCluster. It is only set if spec.sdn is defined.
+	ProxmoxClusterSDNReadyCondition = "SDNReady"
+
+	// ProxmoxClusterSDNReadyConflictReason documents a SDN zone or VNet which already
+	// exists in Proxmox but is not owned by the ProxmoxCluster.
+	ProxmoxClusterSDNReadyConflictReason = "SDNConflict"
+
+	// ProxmoxClusterSDNReadyFailedReason documents a failure to create or apply the
+	// SDN configuration.
+	ProxmoxClusterSDNReadyFailedReason = "SDNFailed"
+
+	// ProxmoxClusterFirewallReadyCondition documents the status of the Proxmox IPSet
+	// owned by the ProxmoxCluster. It is only set if spec.firewall is defined.
+	ProxmoxClusterFirewallReadyCondition = "FirewallReady"
+
+	// ProxmoxClusterFirewallReadyConflictReason documents an IPSet which already
+	// exists in Proxmox but is not owned by the ProxmoxCluster.
+	ProxmoxClusterFirewallReadyConflictReason = "FirewallConflict"
+
+	// ProxmoxClusterFirewallReadyFailedReason documents a failure to update the IPSet.
+	ProxmoxClusterFirewallReadyFailedReason = "FirewallFailed"
+
+	// ProxmoxClusterPoolReadyCondition documents the status of the Proxmox resource pool
+	// owned by the ProxmoxCluster. It is only set if spec.pool is defined.
+	ProxmoxClusterPoolReadyCondition = "PoolReady"
+
+	// ProxmoxClusterPoolReadyConflictReason documents a resource pool which already
+	// exists in Proxmox but is not owned by the ProxmoxCluster.
+	ProxmoxClusterPoolReadyConflictReason = "PoolConflict"
+
+	// ProxmoxClusterPoolReadyFailedReason documents a failure to update the resource pool,
+	// its members or its ACLs.
+	ProxmoxClusterPoolReadyFailedReason = "PoolFailed"
+)
+
+// Conditions and Reasons for the pre-flight checks of a ProxmoxCluster.
+//
+// The checks validate the Proxmox environment against the specs of the cluster,
+// its ProxmoxMachineTemplates and the ProxmoxMachines which are not provisioned yet.
+// They only report problems and are not part of the Ready condition.
+const (
+	// ProxmoxClusterNodesAvailableCondition documents whether the allowed and source
+	// nodes exist and are online.
+	ProxmoxClusterNodesAvailableCondition = "NodesAvailable"
+
+	// ProxmoxClusterStoragesAvailableCondition documents whether the clone storages
+	// are enabled and active on the nodes the machines are scheduled on.
+	ProxmoxClusterStoragesAvailableCondition = "StoragesAvailable"
+
+	// ProxmoxClusterBridgesAvailableCondition documents whether the bridges of the network
+	// devices exist on the nodes and are VLAN aware where a VLAN tag is set.
+	ProxmoxClusterBridgesAvailableCondition = "BridgesAvailable"
+
+	// ProxmoxClusterTemplatesAvailableCondition documents whether the templates referenced
+	// by ID or selected by tags exist.
+	ProxmoxClusterTemplatesAvailableCondition = "TemplatesAvailable"
+
+	// ProxmoxClusterVMIDsAvailableCondition documents whether the VMID ranges have free IDs left.
+	ProxmoxClusterVMIDsAvailableCondition = "VMIDsAvailable"
+
+	// ProxmoxClusterPrivilegesAvailableCondition documents whether the Proxmox token has the
+	// privileges needed by the features the cluster and its machines use.
+	ProxmoxClusterPrivilegesAvailableCondition = "PrivilegesAvailable"
+
+	// ProxmoxClusterPreflightPassedReason documents a pre-flight check without problems.
+	ProxmoxClusterPreflightPassedReason = "PreflightPassed"
+
+	// ProxmoxClusterPreflightFailedReason documents a pre-flight check which found problems,
+	// they are listed in the message of the condition.
+	ProxmoxClusterPreflightFailedReason = "PreflightFailed"
+
+	// ProxmoxClusterPreflightErrorReason documents a pre-flight check which could not be run,
+	// e.g. because the Proxmox API returned an error.
+	ProxmoxClusterPreflightErrorReason = "PreflightError"
 )
 
 // Conditions and Reasons for ProxmoxMachine.
@@ -50,6 +126,7 @@ const (
 // The Ready condition is a summary condition that is set by the controller using
 // conditions.SetSummaryCondition and aggregates the following conditions:
 // - VirtualMachineProvisioned
+// - VirtualMachineHealthy (once observed)
 // - Paused (managed by CAPI).
 const (
 	// ProxmoxMachineVirtualMachineProvisionedCondition documents the status of the
@@ -65,6 +142,11 @@ const (
 	// and the controller automatically retries.
 	ProxmoxMachineVirtualMachineProvisionedCloningFailedReason = "CloningFailed"
 
+	// ProxmoxMachineVirtualMachineProvisionedRecloningReason documents a ProxmoxMachine
+	// whose clone task failed. The partially cloned virtual machine is deleted before
+	// the machine is cloned again.
+	ProxmoxMachineVirtualMachineProvisionedRecloningReason = "Recloning"
+
 	// ProxmoxMachineVirtualMachineProvisionedWaitingForDiskReconciliationReason documents
 	// a ProxmoxMachine waiting for the disks to be resized.
 	ProxmoxMachineVirtualMachineProvisionedWaitingForDiskReconciliationReason = "WaitingForDiskReconciliation"
@@ -116,3 +198,47 @@ const (
 	// during virtual machine deletion.
 	ProxmoxMachineVirtualMachineProvisionedDeletionFailedReason = "DeletionFailed"
 )
+
+// Conditions and Reasons for the runtime health of a provisioned ProxmoxMachine.
+const (
+	// ProxmoxMachineVirtualMachineHealthyCondition documents the runtime health of a
+	// provisioned virtual machine: its power state, the guest agent and the link
+	// state of its network devices.
+	ProxmoxMachineVirtualMachineHealthyCondition = "VirtualMachineHealthy"
+
+	// ProxmoxMachineVirtualMachineHealthyHealthyReason documents a virtual machine
+	// which is running with a responsive guest agent and connected network devices.
+	ProxmoxMachineVirtualMachineHealthyHealthyReason = "Healthy"
+
+	// ProxmoxMachineVirtualMachineHealthyNotRunningReason documents a virtual machine
+	// which is stopped, paused or suspended.
+	ProxmoxMachineVirtualMachineHealthyNotRunningReason = "VirtualMachineNotRunning"
+
+	// ProxmoxMachineVirtualMachineHealthyGuestAgentNotRespondingReason documents a
+	// virtual machine whose QEMU guest agent does not respond.
+	ProxmoxMachineVirtualMachineHealthyGuestAgentNotRespondingReason = "GuestAgentNotResponding"
+
+	// ProxmoxMachineVirtualMachineHealthyNetworkDisconnectedReason documents a
+	// virtual machine with a network device whose link is down.
+	ProxmoxMachineVirtualMachineHealthyNetworkDisconnectedReason = "NetworkDisconnected"
+)
+
+// Conditions and Reasons for the configuration drift of a provisioned ProxmoxMachine.
+const (
+	// ProxmoxMachineConfigDriftCondition documents whether the configuration of a
+	// provisioned virtual machine differs from the spec of the ProxmoxMachine, e.g.
+	// after it was changed in the Proxmox UI. The condition is True if there is drift.
+	ProxmoxMachineConfigDriftCondition = "ConfigDrift"
+
+	// ProxmoxMachineConfigDriftNoDriftReason documents a virtual machine whose
+	// configuration matches the spec.
+	ProxmoxMachineConfigDriftNoDriftReason = "NoConfigDrift"
+
+	// ProxmoxMachineConfigDriftDetectedReason documents a virtual machine whose
+	// configuration differs from the spec and is not corrected.
+	ProxmoxMachineConfigDriftDetectedReason = "ConfigDriftDetected"
+
+	// ProxmoxMachineConfigDriftCorrectingReason documents a virtual machine whose
+	// configuration is being reverted to the spec.
+	ProxmoxMachineConfigDriftCorrectingReason = "CorrectingConfigDrift"
+)
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"net"
 	"slices"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +45,12 @@ type ProxmoxClusterSpec struct {
 	// +optional
 	ControlPlaneEndpoint APIEndpoint `json:"controlPlaneEndpoint,omitempty,omitzero"`
 
+	// controlPlaneEndpointIPAM lets the ProxmoxCluster claim the host of the controlPlaneEndpoint
+	// through an IPAddressClaim, if controlPlaneEndpoint is not set. The claimed address is written
+	// back to controlPlaneEndpoint and the claim is released when the cluster is deleted.
+	// +optional
+	ControlPlaneEndpointIPAM *ControlPlaneEndpointIPAMSpec `json:"controlPlaneEndpointIPAM,omitempty"`
+
 	// externalManagedControlPlane can be enabled to allow externally managed Control Planes to patch the
 	// Proxmox cluster with the Load Balancer IP provided by Control Plane provider.
 	// +optional
@@ -87,6 +95,55 @@ type ProxmoxClusterSpec struct {
 	// +optional
 	ZoneConfigs []ZoneConfigSpec `json:"zoneConfig,omitempty"`
 
+	// sdn lets the ProxmoxCluster create and own a Proxmox SDN zone with VNets and subnets
+	// for the machines of this cluster. Network devices attach to a VNet by name and are
+	// assigned addresses from the InClusterIPPools derived from its subnets.
+	// The objects are removed from Proxmox when the cluster is deleted.
+	// +optional
+	SDN *SDNSpec `json:"sdn,omitempty"`
+
+	// firewall defines the Proxmox VE firewall of the machines of this cluster and a
+	// Proxmox IPSet containing the addresses of all machines.
+	// +optional
+	Firewall *ClusterFirewallSpec `json:"firewall,omitempty"`
+
+	// kubeVIP makes the provider add a kube-vip static pod to the bootstrap data of the
+	// control plane machines, which announces the host of the controlPlaneEndpoint.
+	// Only cloud-init and Ignition bootstrap data are supported.
+	// +optional
+	KubeVIP *KubeVIPSpec `json:"kubeVIP,omitempty"`
+
+	// configDriftPolicy defines how the machines of this cluster handle changes made to their
+	// virtual machines outside of the provider, e.g. in the Proxmox UI. Drift is always reported
+	// in the ConfigDrift condition of a ProxmoxMachine; enforce additionally reverts it.
+	// +kubebuilder:validation:Enum=report;enforce
+	// +kubebuilder:default=report
+	// +optional
+	ConfigDriftPolicy ConfigDriftPolicy `json:"configDriftPolicy,omitempty"`
+
+	// taskRetryPolicy defines how failed Proxmox tasks of the machines of this cluster
+	// are handled, unless a machine sets its own policy.
+	// +optional
+	TaskRetryPolicy *TaskRetryPolicy `json:"taskRetryPolicy,omitempty"`
+
+	// orphanedVMPolicy defines how virtual machines which carry the ownership tag of this cluster,
+	// but belong to none of its ProxmoxMachines, are handled. Orphaned virtual machines are always
+	// reported in status.orphanedVMs; the delete action additionally removes them after a grace period.
+	// +optional
+	OrphanedVMPolicy *OrphanedVMPolicy `json:"orphanedVMPolicy,omitempty"`
+
+	// highAvailability registers the virtual machines of this cluster as Proxmox HA resources,
+	// unless a machine sets its own highAvailability. By default only control plane machines
+	// are registered.
+	// +optional
+	HighAvailability *ClusterHighAvailabilitySpec `json:"highAvailability,omitempty"`
+
+	// pool makes the cluster manage a dedicated Proxmox resource pool, which all virtual machines
+	// of the cluster without a pool of their own are added to. The pool is removed from Proxmox
+	// when the cluster is deleted.
+	// +optional
+	Pool *ClusterPoolSpec `json:"pool,omitempty"`
+
 	// credentialsRef is a reference to a Secret that contains the credentials to use for provisioning this cluster. If not
 	// supplied then the credentials of the controller will be used.
 	// if no namespace is provided, the namespace of the ProxmoxCluster will be used.
@@ -123,6 +180,24 @@ func (v APIEndpoint) String() string {
 	return net.JoinHostPort(v.Host, fmt.Sprintf("%d", v.Port))
 }
 
+// ControlPlaneEndpointIPAMSpec defines how the control plane endpoint is claimed from IPAM.
+type ControlPlaneEndpointIPAMSpec struct {
+	// poolRef is a reference to the pool the endpoint is claimed from.
+	// Defaults to the IPv4 InClusterIPPool of the cluster, or its IPv6 InClusterIPPool
+	// if ipv4Config is not set.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.apiGroup == 'ipam.cluster.x-k8s.io'",message="poolRef allows only IPAM apiGroup ipam.cluster.x-k8s.io"
+	// +kubebuilder:validation:XValidation:rule="self.kind == 'InClusterIPPool' || self.kind == 'GlobalInClusterIPPool'",message="poolRef allows either InClusterIPPool or GlobalInClusterIPPool"
+	PoolRef *corev1.TypedLocalObjectReference `json:"poolRef,omitempty"`
+
+	// port is the port of the control plane endpoint.
+	// +optional
+	// +default=6443
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+}
+
 // ZoneConfigSpec is the Network Configuration for further deployment zones.
 type ZoneConfigSpec struct {
 	// zone is the name of your deployment zone.
@@ -175,6 +250,311 @@ type IPConfigSpec struct {
 	Metric *int32 `json:"metric,omitempty"`
 }
 
+// SDNZoneType is the type of a Proxmox SDN zone.
+// +kubebuilder:validation:Enum=simple;vlan;vxlan
+type SDNZoneType string
+
+const (
+	// SDNZoneTypeSimple is an isolated bridge local to each node.
+	SDNZoneTypeSimple SDNZoneType = "simple"
+
+	// SDNZoneTypeVLAN tags the VNets on an existing local bridge.
+	SDNZoneTypeVLAN SDNZoneType = "vlan"
+
+	// SDNZoneTypeVXLAN tunnels the VNets between the peers over UDP.
+	SDNZoneTypeVXLAN SDNZoneType = "vxlan"
+)
+
+// SDNSpec defines the Proxmox SDN objects owned by a ProxmoxCluster.
+type SDNSpec struct {
+	// zone is the SDN zone the VNets are created in.
+	// +required
+	Zone SDNZoneSpec `json:"zone,omitzero"`
+
+	// vnets are the virtual networks created in the zone.
+	// +required
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	VNets []SDNVNetSpec `json:"vnets,omitempty"`
+}
+
+// SDNZoneSpec defines a Proxmox SDN zone.
+// +kubebuilder:validation:XValidation:rule="self.type != 'vlan' || has(self.bridge)",message="bridge is required for vlan zones"
+// +kubebuilder:validation:XValidation:rule="self.type != 'vxlan' || has(self.peers)",message="peers are required for vxlan zones"
+type SDNZoneSpec struct {
+	// name is the name of the zone. Proxmox limits zone names to 8 characters.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9]{0,7}$`
+	Name string `json:"name,omitempty"`
+
+	// type is the type of the zone.
+	// +optional
+	// +default="simple"
+	Type SDNZoneType `json:"type,omitempty"`
+
+	// bridge is the local bridge the VNets of a vlan zone are attached to.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Bridge *string `json:"bridge,omitempty"`
+
+	// peers are the addresses of the Proxmox nodes taking part in a vxlan zone.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	Peers []string `json:"peers,omitempty"`
+
+	// mtu is the MTU of the zone. Defaults to the Proxmox default of the zone type.
+	// +optional
+	// +kubebuilder:validation:Minimum=576
+	// +kubebuilder:validation:Maximum=65520
+	MTU *int32 `json:"mtu,omitempty"`
+
+	// nodes restricts the zone to the given Proxmox nodes.
+	// +optional
+	// +listType=set
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// SDNVNetSpec defines a Proxmox SDN VNet.
+type SDNVNetSpec struct {
+	// name is the name of the VNet, which is also the name of the bridge on the
+	// Proxmox nodes. Proxmox limits VNet names to 8 characters.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9]{0,7}$`
+	Name string `json:"name,omitempty"`
+
+	// tag is the VLAN or VXLAN ID of the VNet. Required for vlan and vxlan zones.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16777215
+	Tag *int32 `json:"tag,omitempty"`
+
+	// subnets are the subnets of the VNet, at most one per IP family.
+	// An InClusterIPPool is created for each of them.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=2
+	Subnets []SDNSubnetSpec `json:"subnets,omitempty"`
+}
+
+// SDNSubnetSpec defines a subnet of a Proxmox SDN VNet.
+type SDNSubnetSpec struct {
+	// cidr is the network of the subnet, e.g. 10.0.0.0/24.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	CIDR string `json:"cidr,omitempty"`
+
+	// gateway is the gateway of the subnet. In simple zones it is configured on the Proxmox nodes.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Gateway string `json:"gateway,omitempty"`
+
+	// snat enables source NAT for traffic leaving the subnet through the Proxmox nodes.
+	// +optional
+	SNAT *bool `json:"snat,omitempty"`
+
+	// addresses are the IP addresses which are assigned to machines, as ranges or CIDRs.
+	// Defaults to the whole subnet.
+	// +optional
+	// +listType=set
+	Addresses []string `json:"addresses,omitempty"`
+
+	// metric is the route priority applied to the gateway.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Metric *int32 `json:"metric,omitempty"`
+}
+
+// ClusterFirewallSpec defines the firewall of the machines of a ProxmoxCluster.
+// The settings apply to all machines. Settings of a ProxmoxMachine take precedence,
+// its security groups and rules are evaluated after the ones of the cluster.
+type ClusterFirewallSpec struct {
+	FirewallSpec `json:",inline"`
+
+	// ipSet configures the Proxmox IPSet which contains the addresses of all machines
+	// of the cluster. It can be referenced in firewall rules by prefixing its name with '+'.
+	// +optional
+	IPSet *FirewallIPSetSpec `json:"ipSet,omitempty"`
+}
+
+// ClusterHighAvailabilitySpec defines the Proxmox HA resources of the machines of a ProxmoxCluster.
+type ClusterHighAvailabilitySpec struct {
+	HighAvailabilitySpec `json:",inline"`
+
+	// controlPlaneOnly limits the registration to control plane machines. Defaults to true.
+	// +optional
+	ControlPlaneOnly *bool `json:"controlPlaneOnly,omitempty"`
+}
+
+// ClusterPoolSpec defines the Proxmox resource pool of a ProxmoxCluster.
+type ClusterPoolSpec struct {
+	// name is the name of the pool. Defaults to capmox- followed by the name of the cluster.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// acls grant Proxmox users or groups a role on the pool, e.g. read-only access to
+	// the virtual machines of the cluster. Other ACLs on the pool are removed.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=atomic
+	// +optional
+	ACLs []PoolACL `json:"acls,omitempty"`
+}
+
+// PoolACL grants a Proxmox user or group a role on the pool of a ProxmoxCluster.
+// +kubebuilder:validation:XValidation:rule="has(self.user) != has(self.group)",message="exactly one of user and group must be set"
+type PoolACL struct {
+	// user is a Proxmox user including its realm, e.g. tenant@pve.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	User *string `json:"user,omitempty"`
+
+	// group is a Proxmox group.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Group *string `json:"group,omitempty"`
+
+	// role is the Proxmox role which is granted. Defaults to PVEAuditor, which allows read-only access.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Role *string `json:"role,omitempty"`
+}
+
+// GetRole returns the Proxmox role which is granted.
+func (a PoolACL) GetRole() string {
+	if a.Role == nil {
+		return "PVEAuditor"
+	}
+	return *a.Role
+}
+
+// FirewallIPSetSpec defines the IPSet of a ProxmoxCluster.
+type FirewallIPSetSpec struct {
+	// name is the name of the IPSet. Defaults to capmox- followed by the name of the cluster.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_-]+$`
+	Name *string `json:"name,omitempty"`
+
+	// additionalCIDRs are added to the IPSet next to the machine addresses,
+	// e.g. the address of a bastion host.
+	// +optional
+	// +listType=set
+	AdditionalCIDRs []string `json:"additionalCIDRs,omitempty"`
+}
+
+// KubeVIPMode is the way kube-vip announces the control plane endpoint.
+// +kubebuilder:validation:Enum=ARP;BGP
+type KubeVIPMode string
+
+const (
+	// KubeVIPModeARP announces the endpoint with ARP (or NDP) from the elected leader.
+	KubeVIPModeARP KubeVIPMode = "ARP"
+
+	// KubeVIPModeBGP announces the endpoint from all control plane machines to BGP peers.
+	KubeVIPModeBGP KubeVIPMode = "BGP"
+)
+
+// KubeVIPSpec defines the kube-vip static pod of the control plane machines.
+// +kubebuilder:validation:XValidation:rule="self.mode != 'BGP' || has(self.bgp)",message="bgp is required in BGP mode"
+type KubeVIPSpec struct {
+	// mode is the way kube-vip announces the control plane endpoint.
+	// +optional
+	// +default="ARP"
+	Mode KubeVIPMode `json:"mode,omitempty"`
+
+	// image is the kube-vip container image.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Image *string `json:"image,omitempty"`
+
+	// interface is the guest network interface the endpoint is announced on.
+	// Defaults to the interface carrying the default gateway.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Interface *string `json:"interface,omitempty"`
+
+	// bgp configures the BGP sessions of kube-vip. Required in BGP mode.
+	// +optional
+	BGP *KubeVIPBGPSpec `json:"bgp,omitempty"`
+}
+
+// KubeVIPBGPSpec defines the BGP sessions of kube-vip.
+type KubeVIPBGPSpec struct {
+	// as is the AS number of the control plane machines.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	AS int64 `json:"as,omitempty"`
+
+	// routerID is the BGP router ID. Defaults to the address of the interface.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	RouterID *string `json:"routerID,omitempty"`
+
+	// peers are the BGP peers the endpoint is announced to.
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	Peers []KubeVIPBGPPeer `json:"peers,omitempty"`
+}
+
+// KubeVIPBGPPeer defines a BGP peer of kube-vip.
+type KubeVIPBGPPeer struct {
+	// address is the IP address of the peer.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address,omitempty"`
+
+	// as is the AS number of the peer.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	AS int64 `json:"as,omitempty"`
+}
+
+// ConfigDriftPolicy defines how the configuration drift of a virtual machine is handled.
+type ConfigDriftPolicy string
+
+const (
+	// ConfigDriftPolicyReport reports configuration drift without changing the virtual machine.
+	ConfigDriftPolicyReport ConfigDriftPolicy = "report"
+
+	// ConfigDriftPolicyEnforce reverts configuration drift to the spec of the ProxmoxMachine.
+	ConfigDriftPolicyEnforce ConfigDriftPolicy = "enforce"
+)
+
+// OrphanedVMAction defines what happens to an orphaned virtual machine.
+type OrphanedVMAction string
+
+const (
+	// OrphanedVMActionReport reports orphaned virtual machines without changing them.
+	OrphanedVMActionReport OrphanedVMAction = "report"
+
+	// OrphanedVMActionDelete deletes orphaned virtual machines after the grace period.
+	OrphanedVMActionDelete OrphanedVMAction = "delete"
+)
+
+// DefaultOrphanedVMGracePeriodSeconds is the default time a virtual machine must have been
+// orphaned for before it is deleted.
+const DefaultOrphanedVMGracePeriodSeconds = 3600
+
+// OrphanedVMPolicy defines how orphaned virtual machines are handled.
+type OrphanedVMPolicy struct {
+	// action defines what happens to orphaned virtual machines: report only reports them,
+	// delete removes them from Proxmox once the grace period passed.
+	// +kubebuilder:validation:Enum=report;delete
+	// +kubebuilder:default=report
+	// +optional
+	Action OrphanedVMAction `json:"action,omitempty"`
+
+	// gracePeriodSeconds is the time a virtual machine must have been orphaned for
+	// before it is deleted. Defaults to 3600.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
+}
+
 // SchedulerHints allows to pass the scheduler instructions to (dis)allow over- or enforce underprovisioning of resources.
 type SchedulerHints struct {
 	// memoryAdjustment allows to adjust a node's memory by a given percentage.
@@ -201,7 +581,7 @@ func (sh *SchedulerHints) GetMemoryAdjustment() int64 {
 // ProxmoxClusterStatus defines the observed state of a ProxmoxCluster.
 type ProxmoxClusterStatus struct {
 	// conditions represents the observations of a ProxmoxCluster's current state.
-	// Known condition types are Ready, ProxmoxAvailable and Paused.
+	// Known condition types are Ready, ProxmoxAvailable, SDNReady, FirewallReady and Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -232,6 +612,51 @@ type ProxmoxClusterStatus struct {
 	// for different machines.
 	// +optional
 	NodeLocations *NodeLocations `json:"nodeLocations,omitempty"`
+
+	// sdn lists the Proxmox SDN objects which were created by this cluster.
+	// Only these are removed when the cluster is deleted.
+	// +optional
+	SDN *SDNStatus `json:"sdn,omitempty"`
+
+	// orphanedVMs lists the virtual machines which carry the ownership tag of this cluster,
+	// but belong to none of its ProxmoxMachines.
+	// +optional
+	// +listType=map
+	// +listMapKey=vmID
+	OrphanedVMs []OrphanedVM `json:"orphanedVMs,omitempty"`
+}
+
+// OrphanedVM is a virtual machine which carries the ownership tag of a ProxmoxCluster,
+// but belongs to none of its ProxmoxMachines.
+type OrphanedVM struct {
+	// vmID is the ID of the virtual machine.
+	// +required
+	VMID int64 `json:"vmID"`
+
+	// name is the name of the virtual machine.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// node is the Proxmox node which hosts the virtual machine.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// detectedAt is the time the virtual machine was first found to be orphaned.
+	// +required
+	DetectedAt metav1.Time `json:"detectedAt"`
+}
+
+// SDNStatus holds the Proxmox SDN objects owned by a ProxmoxCluster.
+// +kubebuilder:validation:MinProperties=1
+type SDNStatus struct {
+	// zone is the name of the zone created by the cluster.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// vnets are the names of the VNets created by the cluster.
+	// +optional
+	// +listType=set
+	VNets []string `json:"vnets,omitempty"`
 }
 
 // ProxmoxClusterInitializationStatus provides observations of the ProxmoxCluster initialization process.
@@ -413,6 +838,59 @@ func (c *ProxmoxCluster) SetInClusterIPPoolRef(pool client.Object) {
 	c.AddInClusterZoneRef(pool)
 }
 
+// GetFirewallIPSetName returns the name of the Proxmox IPSet of the cluster, or an empty
+// string if the cluster does not define a firewall.
+func (c *ProxmoxCluster) GetFirewallIPSetName() string {
+	if c.Spec.Firewall == nil {
+		return ""
+	}
+	if c.Spec.Firewall.IPSet != nil && c.Spec.Firewall.IPSet.Name != nil {
+		return *c.Spec.Firewall.IPSet.Name
+	}
+	// IPSet names must not contain dots, which are valid in object names.
+	return "capmox-" + strings.ReplaceAll(c.Name, ".", "-")
+}
+
+// GetPoolName returns the name of the Proxmox resource pool of the cluster, or an empty
+// string if the cluster does not manage a pool.
+func (c *ProxmoxCluster) GetPoolName() string {
+	if c.Spec.Pool == nil {
+		return ""
+	}
+	if c.Spec.Pool.Name != nil {
+		return *c.Spec.Pool.Name
+	}
+	return "capmox-" + c.Name
+}
+
+// GetConfigDriftPolicy returns the configuration drift policy of the machines of the cluster.
+// If no policy is set, ConfigDriftPolicyReport is returned.
+func (c *ProxmoxCluster) GetConfigDriftPolicy() ConfigDriftPolicy {
+	if c.Spec.ConfigDriftPolicy != "" {
+		return c.Spec.ConfigDriftPolicy
+	}
+	return ConfigDriftPolicyReport
+}
+
+// GetOrphanedVMAction returns what happens to the orphaned virtual machines of the cluster.
+// If no policy is set, OrphanedVMActionReport is returned.
+func (c *ProxmoxCluster) GetOrphanedVMAction() OrphanedVMAction {
+	if c.Spec.OrphanedVMPolicy != nil && c.Spec.OrphanedVMPolicy.Action != "" {
+		return c.Spec.OrphanedVMPolicy.Action
+	}
+	return OrphanedVMActionReport
+}
+
+// GetOrphanedVMGracePeriod returns the time a virtual machine must have been orphaned for
+// before it is deleted.
+func (c *ProxmoxCluster) GetOrphanedVMGracePeriod() time.Duration {
+	seconds := int32(DefaultOrphanedVMGracePeriodSeconds)
+	if c.Spec.OrphanedVMPolicy != nil && c.Spec.OrphanedVMPolicy.GracePeriodSeconds != nil {
+		seconds = *c.Spec.OrphanedVMPolicy.GracePeriodSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
 // AddNodeLocation will add a node location to either the control plane or worker
 // node locations based on the isControlPlane parameter.
 func (c *ProxmoxCluster) AddNodeLocation(loc NodeLocation, isControlPlane bool) {
@@ -19,6 +19,7 @@ package v1alpha2
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -178,8 +179,72 @@ var _ = Describe("ProxmoxCluster Test", func() {
 			Expect(k8sClient.Create(context.Background(), dc)).Should(MatchError(ContainSubstring("should be less than or equal to 128")))
 		})
 	})
+
+	Context("ConfigDriftPolicy", func() {
+		It("Should not allow unknown policies", func() {
+			dc := defaultCluster()
+			dc.Spec.ConfigDriftPolicy = "ignore"
+
+			Expect(k8sClient.Create(context.Background(), dc)).Should(MatchError(ContainSubstring("spec.configDriftPolicy: Unsupported value")))
+		})
+
+		It("Should default to report", func() {
+			dc := defaultCluster()
+			Expect(dc.GetConfigDriftPolicy()).To(Equal(ConfigDriftPolicyReport))
+
+			dc.Spec.ConfigDriftPolicy = ConfigDriftPolicyEnforce
+			Expect(dc.GetConfigDriftPolicy()).To(Equal(ConfigDriftPolicyEnforce))
+		})
+	})
+
+	Context("TaskRetryPolicy", func() {
+		It("Should not allow unknown actions", func() {
+			dc := defaultCluster()
+			dc.Spec.TaskRetryPolicy = &TaskRetryPolicy{
+				TaskTypes: []TaskTypeRetryPolicy{{Type: "qmclone", Action: "reclone"}},
+			}
+
+			Expect(k8sClient.Create(context.Background(), dc)).Should(MatchError(ContainSubstring("spec.taskRetryPolicy.taskTypes[0].action: Unsupported value")))
+		})
+
+		It("Should not allow zero attempts", func() {
+			dc := defaultCluster()
+			dc.Spec.TaskRetryPolicy = &TaskRetryPolicy{MaxAttempts: new(int32(0))}
+
+			Expect(k8sClient.Create(context.Background(), dc)).Should(MatchError(ContainSubstring("spec.taskRetryPolicy.maxAttempts")))
+		})
+	})
 })
 
+func TestTaskRetryPolicy(t *testing.T) {
+	var policy *TaskRetryPolicy
+	require.Equal(t, TaskFailureActionRetry, policy.GetAction("qmclone"))
+	require.Equal(t, TaskFailureActionIgnore, policy.GetAction("qmstart"))
+	require.Equal(t, int32(DefaultTaskMaxAttempts), policy.GetMaxAttempts("qmclone"))
+	require.Equal(t, time.Minute, policy.GetBackoff(1))
+	require.Equal(t, 2*time.Minute, policy.GetBackoff(2))
+	require.Equal(t, 10*time.Minute, policy.GetBackoff(10))
+
+	policy = &TaskRetryPolicy{
+		MaxAttempts:           new(int32(5)),
+		InitialBackoffSeconds: new(int32(10)),
+		MaxBackoffSeconds:     new(int32(30)),
+		TaskTypes: []TaskTypeRetryPolicy{
+			{Type: "qmstart", Action: TaskFailureActionRetry},
+			{Type: "qmclone", MaxAttempts: new(int32(2))},
+			{Type: "qmconfig", Action: TaskFailureActionFail},
+		},
+	}
+	require.Equal(t, TaskFailureActionRetry, policy.GetAction("qmstart"))
+	require.Equal(t, TaskFailureActionRetry, policy.GetAction("qmclone"))
+	require.Equal(t, TaskFailureActionFail, policy.GetAction("qmconfig"))
+	require.Equal(t, int32(2), policy.GetMaxAttempts("qmclone"))
+	require.Equal(t, int32(5), policy.GetMaxAttempts("qmstart"))
+	require.Equal(t, 10*time.Second, policy.GetBackoff(1))
+	require.Equal(t, 20*time.Second, policy.GetBackoff(2))
+	require.Equal(t, 30*time.Second, policy.GetBackoff(3))
+}
+
 func TestRemoveNodeLocation(t *testing.T) {
 	cl := ProxmoxCluster{
 		Status: ProxmoxClusterStatus{NodeLocations: &NodeLocations{
@@ -239,3 +304,13 @@ func TestSetInClusterIPPoolRef(t *testing.T) {
 	cl.SetInClusterIPPoolRef(pool)
 	require.Equal(t, cl.Status.InClusterIPPoolRef[0].Name, pool.GetName())
 }
+
+func TestOrphanedVMPolicy(t *testing.T) {
+	cl := &ProxmoxCluster{}
+	require.Equal(t, OrphanedVMActionReport, cl.GetOrphanedVMAction())
+	require.Equal(t, time.Hour, cl.GetOrphanedVMGracePeriod())
+
+	cl.Spec.OrphanedVMPolicy = &OrphanedVMPolicy{Action: OrphanedVMActionDelete, GracePeriodSeconds: new(int32(0))}
+	require.Equal(t, OrphanedVMActionDelete, cl.GetOrphanedVMAction())
+	require.Zero(t, cl.GetOrphanedVMGracePeriod())
+}
@@ -38,6 +38,10 @@ const (
 	// DefaultReconcilerRequeue is the default value for the reconcile retry.
 	DefaultReconcilerRequeue = 10 * time.Second
 
+	// DHCPAddressRequeue is the interval in which the addresses of ready machines
+	// using DHCP are refreshed from the QEMU guest agent.
+	DHCPAddressRequeue = time.Minute
+
 	// DefaultNetworkDevice is the default network device name.
 	DefaultNetworkDevice = NetName("net0")
 
@@ -121,6 +125,11 @@ type ProxmoxMachineSpec struct {
 	// +optional
 	MetadataSettings *MetadataSettings `json:"metadataSettings,omitempty,omitzero"`
 
+	// bootstrapDelivery defines how the bootstrap data is delivered to this machine's VM.
+	// Defaults to a NoCloud ISO attached to the VM.
+	// +optional
+	BootstrapDelivery *BootstrapDelivery `json:"bootstrapDelivery,omitempty,omitzero"`
+
 	// allowedNodes specifies all Proxmox nodes which will be considered
 	// for operations. This implies that VMs can be cloned on different nodes from
 	// the node which holds the VM template.
@@ -139,6 +148,131 @@ type ProxmoxMachineSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:items:Pattern=`^(?i)[a-z0-9_][a-z0-9_\-\+\.]*$`
 	Tags []string `json:"tags,omitempty"`
+
+	// firewall defines the Proxmox VE firewall of the virtual machine. It is combined with
+	// the firewall of the ProxmoxCluster, see ClusterFirewallSpec.
+	// +optional
+	Firewall *FirewallSpec `json:"firewall,omitempty"`
+
+	// taskRetryPolicy defines how failed Proxmox tasks of this machine are handled.
+	// If set, it replaces the taskRetryPolicy of the ProxmoxCluster.
+	// +optional
+	TaskRetryPolicy *TaskRetryPolicy `json:"taskRetryPolicy,omitempty"`
+
+	// highAvailability registers the virtual machine as Proxmox HA resource once it is provisioned.
+	// If set, it replaces the highAvailability of the ProxmoxCluster. The HA resource is removed
+	// before the virtual machine is deleted.
+	// +optional
+	HighAvailability *HighAvailabilitySpec `json:"highAvailability,omitempty"`
+}
+
+// FirewallAction is the action of a firewall rule or policy.
+// +kubebuilder:validation:Enum=ACCEPT;DROP;REJECT
+type FirewallAction string
+
+const (
+	// FirewallActionAccept accepts the traffic.
+	FirewallActionAccept FirewallAction = "ACCEPT"
+
+	// FirewallActionDrop silently drops the traffic.
+	FirewallActionDrop FirewallAction = "DROP"
+
+	// FirewallActionReject rejects the traffic.
+	FirewallActionReject FirewallAction = "REJECT"
+)
+
+// FirewallDirection is the direction of traffic a firewall rule applies to.
+// +kubebuilder:validation:Enum=in;out
+type FirewallDirection string
+
+const (
+	// FirewallDirectionIn matches traffic to the virtual machine.
+	FirewallDirectionIn FirewallDirection = "in"
+
+	// FirewallDirectionOut matches traffic from the virtual machine.
+	FirewallDirectionOut FirewallDirection = "out"
+)
+
+// FirewallSpec defines the Proxmox VE firewall of a virtual machine.
+type FirewallSpec struct {
+	// enabled enables the firewall of the virtual machine and of its network devices.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// policyIn is the policy for incoming traffic which matches no rule.
+	// Defaults to the Proxmox default, which drops the traffic.
+	// +optional
+	PolicyIn *FirewallAction `json:"policyIn,omitempty"`
+
+	// policyOut is the policy for outgoing traffic which matches no rule.
+	// Defaults to the Proxmox default, which accepts the traffic.
+	// +optional
+	PolicyOut *FirewallAction `json:"policyOut,omitempty"`
+
+	// securityGroups are the names of the Proxmox security groups attached to the virtual machine.
+	// The groups must exist in the Proxmox datacenter firewall.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z][A-Za-z0-9_-]+$`
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+
+	// rules are the firewall rules of the virtual machine, in the order they are evaluated.
+	// +optional
+	// +listType=atomic
+	Rules []FirewallRule `json:"rules,omitempty"`
+}
+
+// FirewallRule defines a Proxmox VE firewall rule.
+type FirewallRule struct {
+	// direction is the direction of the traffic the rule applies to.
+	// +required
+	Direction FirewallDirection `json:"direction,omitempty"`
+
+	// action is applied to the traffic matched by the rule.
+	// +required
+	Action FirewallAction `json:"action,omitempty"`
+
+	// macro is a Proxmox firewall macro, e.g. SSH or HTTPS.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Macro *string `json:"macro,omitempty"`
+
+	// protocol is the IP protocol, e.g. tcp or udp.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Protocol *string `json:"protocol,omitempty"`
+
+	// source restricts the rule to a source address, CIDR, alias or IPSet,
+	// e.g. +capmox-my-cluster for the IPSet of the cluster.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Source *string `json:"source,omitempty"`
+
+	// destination restricts the rule to a destination address, CIDR, alias or IPSet.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Destination *string `json:"destination,omitempty"`
+
+	// sourcePort restricts the rule to source ports, e.g. 80,443 or 1024:65535.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	SourcePort *string `json:"sourcePort,omitempty"`
+
+	// destinationPort restricts the rule to destination ports, e.g. 6443 or 30000:32767.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	DestinationPort *string `json:"destinationPort,omitempty"`
+
+	// interface restricts the rule to a network device of the virtual machine.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^net[0-9]+$`
+	Interface *NetName `json:"interface,omitempty"`
+
+	// comment is a description of the rule.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Comment *string `json:"comment,omitempty"`
 }
 
 // Storage is the physical storage on the node.
@@ -292,6 +426,19 @@ type NetworkSpec struct {
 	// +optional
 	Zone Zone `json:"zone,omitempty"`
 
+	// renderer selects how the guest network configuration is rendered for the cloud-config
+	// bootstrap format.
+	//
+	// netplan (default) renders network-config v2 for systemd-networkd.
+	// networkConfigV1 renders network-config v1 and leaves rendering to the distribution's cloud-init.
+	// networkManager writes NetworkManager keyfiles and eni writes ifupdown interfaces files;
+	// both are delivered as an additional cloud-config part and disable cloud-init networking.
+	//
+	// +kubebuilder:validation:Enum=netplan;networkConfigV1;networkManager;eni
+	// +kubebuilder:default=netplan
+	// +optional
+	Renderer NetworkRenderer `json:"renderer,omitempty"`
+
 	// networkDevices is a list of network devices.
 	// +required
 	// +listType=map
@@ -304,17 +451,62 @@ type NetworkSpec struct {
 	VirtualNetworkDevices `json:",inline"`
 }
 
+// NetworkRenderer is the format the guest network configuration is rendered in.
+type NetworkRenderer string
+
+const (
+	// NetworkRendererNetplan renders network-config v2 with the networkd renderer.
+	NetworkRendererNetplan NetworkRenderer = "netplan"
+	// NetworkRendererNetworkConfigV1 renders network-config v1.
+	NetworkRendererNetworkConfigV1 NetworkRenderer = "networkConfigV1"
+	// NetworkRendererNetworkManager renders NetworkManager keyfiles.
+	NetworkRendererNetworkManager NetworkRenderer = "networkManager"
+	// NetworkRendererENI renders ifupdown interfaces files.
+	NetworkRendererENI NetworkRenderer = "eni"
+)
+
 // InterfaceConfig contains all configurables a network interface can have.
 type InterfaceConfig struct {
 	// ipPoolRef is a reference to an IPAM Pool resource, which exposes IPv4 addresses.
 	// The network device will use an available IP address from the referenced pool.
 	// This can be combined with `IPv6PoolRef` in order to enable dual stack.
 	// +optional
-	// +kubebuilder:validation:items:XValidation:rule="self.apiGroup == 'ipam.cluster.x-k8s.io'",message="ipPoolRef allows only IPAM apiGroup ipam.cluster.x-k8s.io"
-	// +kubebuilder:validation:items:XValidation:rule="self.kind == 'InClusterIPPool' || self.kind == 'GlobalInClusterIPPool'",message="ipPoolRef allows either InClusterIPPool or GlobalInClusterIPPool"
+	// +kubebuilder:validation:items:XValidation:rule="has(self.apiGroup) && self.apiGroup != ''",message="ipPoolRef requires an apiGroup"
 	// +listType=atomic
 	IPPoolRef []corev1.TypedLocalObjectReference `json:"ipPoolRef,omitempty"`
 
+	// dhcp4 configures the interface with DHCPv4. The leased addresses are discovered with the
+	// QEMU guest agent and published in the machine status. Mutually exclusive with defaultIPv4.
+	// +optional
+	DHCP4 *bool `json:"dhcp4,omitempty"`
+
+	// dhcp6 configures the interface with DHCPv6. The leased addresses are discovered with the
+	// QEMU guest agent and published in the machine status. Mutually exclusive with defaultIPv6.
+	// +optional
+	DHCP6 *bool `json:"dhcp6,omitempty"`
+
+	// ipv6Mode configures how the interface obtains its IPv6 addresses:
+	// static uses only the addresses allocated from IPAM, dhcpv6 is equal to dhcp6,
+	// slaac uses stateless address autoconfiguration from router advertisements,
+	// staticSLAAC combines the addresses allocated from IPAM with SLAAC, and disabled
+	// turns IPv6 off, including link-local addresses.
+	// If unset, router advertisements are handled by the defaults of the guest.
+	// +optional
+	// +kubebuilder:validation:Enum=static;dhcpv6;slaac;staticSLAAC;disabled
+	IPv6Mode IPv6Mode `json:"ipv6Mode,omitempty"`
+
+	// acceptRA configures if IPv6 router advertisements are accepted on the interface.
+	// Defaults to true for the ipv6Mode slaac and staticSLAAC, and to false for static and disabled.
+	// +optional
+	AcceptRA *bool `json:"acceptRA,omitempty"`
+
+	// ipv6Token is the static interface identifier SLAAC addresses are formed with,
+	// e.g. ::10, instead of an identifier derived from the hardware address.
+	// Requires the ipv6Mode slaac or staticSLAAC.
+	// +optional
+	// +kubebuilder:validation:MinLength=2
+	IPv6Token *string `json:"ipv6Token,omitempty"`
+
 	// dnsServers contains information about nameservers to be used for this interface.
 	// If this field is not set, it will use the default dns servers from the ProxmoxCluster.
 	// +optional
@@ -331,6 +523,42 @@ type InterfaceConfig struct {
 	LinkMTU MTU `json:"linkMtu,omitempty"`
 }
 
+// IPv6Mode defines how an interface obtains its IPv6 addresses.
+type IPv6Mode string
+
+const (
+	// IPv6ModeStatic uses only the IPv6 addresses allocated from IPAM and ignores router advertisements.
+	IPv6ModeStatic IPv6Mode = "static"
+
+	// IPv6ModeDHCPv6 leases the IPv6 addresses with DHCPv6.
+	IPv6ModeDHCPv6 IPv6Mode = "dhcpv6"
+
+	// IPv6ModeSLAAC forms the IPv6 addresses from router advertisements.
+	IPv6ModeSLAAC IPv6Mode = "slaac"
+
+	// IPv6ModeStaticSLAAC uses the IPv6 addresses allocated from IPAM and forms
+	// additional addresses from router advertisements.
+	IPv6ModeStaticSLAAC IPv6Mode = "staticSLAAC"
+
+	// IPv6ModeDisabled disables IPv6 on the interface.
+	IPv6ModeDisabled IPv6Mode = "disabled"
+)
+
+// GetIPv6Mode returns the IPv6 mode of the interface, dhcp6 implies the mode dhcpv6.
+func (c *InterfaceConfig) GetIPv6Mode() IPv6Mode {
+	if c.IPv6Mode == "" && ptr.Deref(c.DHCP6, false) {
+		return IPv6ModeDHCPv6
+	}
+	return c.IPv6Mode
+}
+
+// DynamicIPv6 returns true if the IPv6 addresses of the interface are assigned by
+// DHCPv6 or SLAAC only, and are therefore not allocated from IPAM.
+func (c *InterfaceConfig) DynamicIPv6() bool {
+	mode := c.GetIPv6Mode()
+	return mode == IPv6ModeDHCPv6 || mode == IPv6ModeSLAAC
+}
+
 // Routing is shared fields across devices and VRFs.
 type Routing struct {
 	// routes are the routes associated with this interface.
@@ -424,6 +652,133 @@ type VRFDevice struct {
 	Routing `json:",inline"`
 }
 
+// BondMode is the Linux bonding driver mode.
+type BondMode string
+
+const (
+	// BondModeBalanceRR transmits packets in sequential order over all members.
+	BondModeBalanceRR BondMode = "balance-rr"
+	// BondModeActiveBackup only uses one member at a time, the others are standby.
+	BondModeActiveBackup BondMode = "active-backup"
+	// BondModeBalanceXOR transmits based on the selected transmit hash policy.
+	BondModeBalanceXOR BondMode = "balance-xor"
+	// BondModeBroadcast transmits everything on all members.
+	BondModeBroadcast BondMode = "broadcast"
+	// BondMode8023AD is IEEE 802.3ad dynamic link aggregation (LACP).
+	BondMode8023AD BondMode = "802.3ad"
+	// BondModeBalanceTLB is adaptive transmit load balancing.
+	BondModeBalanceTLB BondMode = "balance-tlb"
+	// BondModeBalanceALB is adaptive load balancing.
+	BondModeBalanceALB BondMode = "balance-alb"
+)
+
+// BondDevice defines a Linux bond device aggregating proxmox network devices.
+type BondDevice struct {
+	// interfaces is the list of proxmox network devices enslaved by this bond.
+	// Enslaved devices must not carry any ip configuration of their own.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +required
+	Interfaces []NetName `json:"interfaces,omitempty"`
+
+	// name is the virtual network device name.
+	// Must be unique within the virtual machine.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// mode is the bonding mode.
+	// +kubebuilder:validation:Enum=balance-rr;active-backup;balance-xor;broadcast;802.3ad;balance-tlb;balance-alb
+	// +kubebuilder:default=active-backup
+	// +optional
+	Mode BondMode `json:"mode,omitempty"`
+
+	// miimon is the MII link monitoring interval in milliseconds.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MIIMon *int32 `json:"miimon,omitempty"`
+
+	// defaultIPv4 attaches the ipv4 host network to this device.
+	// +optional
+	DefaultIPv4 *bool `json:"defaultIPv4,omitempty"`
+
+	// defaultIPv6 attaches the ipv6 host network to this device.
+	// +optional
+	DefaultIPv6 *bool `json:"defaultIPv6,omitempty"`
+
+	// InterfaceConfig contains all configurables a network interface can have.
+	// +optional
+	InterfaceConfig `json:",inline"`
+}
+
+// VLANDevice defines an in-guest 802.1Q VLAN interface.
+type VLANDevice struct {
+	// name is the virtual network device name.
+	// Must be unique within the virtual machine.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// link is the device the VLAN is created on. This is either a proxmox
+	// network device (e.g. net1) or the name of a bond.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Link string `json:"link,omitempty"`
+
+	// id is the VLAN ID.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	// +required
+	ID int32 `json:"id,omitempty"`
+
+	// defaultIPv4 attaches the ipv4 host network to this device.
+	// +optional
+	DefaultIPv4 *bool `json:"defaultIPv4,omitempty"`
+
+	// defaultIPv6 attaches the ipv6 host network to this device.
+	// +optional
+	DefaultIPv6 *bool `json:"defaultIPv6,omitempty"`
+
+	// InterfaceConfig contains all configurables a network interface can have.
+	// +optional
+	InterfaceConfig `json:",inline"`
+}
+
+// BridgeDevice defines a Linux bridge inside the guest.
+type BridgeDevice struct {
+	// interfaces is the list of ports of this bridge. A port is either a proxmox
+	// network device (e.g. net1), or the name of a bond or VLAN.
+	// Ports must not carry any ip configuration of their own.
+	// +optional
+	// +listType=atomic
+	Interfaces []string `json:"interfaces,omitempty"`
+
+	// name is the virtual network device name.
+	// Must be unique within the virtual machine.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// stp enables the spanning tree protocol on the bridge.
+	// +optional
+	STP *bool `json:"stp,omitempty"`
+
+	// defaultIPv4 attaches the ipv4 host network to this device.
+	// +optional
+	DefaultIPv4 *bool `json:"defaultIPv4,omitempty"`
+
+	// defaultIPv6 attaches the ipv6 host network to this device.
+	// +optional
+	DefaultIPv6 *bool `json:"defaultIPv6,omitempty"`
+
+	// InterfaceConfig contains all configurables a network interface can have.
+	// +optional
+	InterfaceConfig `json:",inline"`
+}
+
 // VirtualNetworkDevices defines Linux software networking devices.
 type VirtualNetworkDevices struct {
 	// vrfs defines VRF Devices.
@@ -431,6 +786,24 @@ type VirtualNetworkDevices struct {
 	// +listType=map
 	// +listMapKey=name
 	VRFs []VRFDevice `json:"vrfs,omitempty"`
+
+	// bonds defines bond devices.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Bonds []BondDevice `json:"bonds,omitempty"`
+
+	// vlans defines VLAN devices.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	VLANs []VLANDevice `json:"vlans,omitempty"`
+
+	// bridges defines bridge devices.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Bridges []BridgeDevice `json:"bridges,omitempty"`
 }
 
 // NetworkDevice defines the required details of a virtual machine network device.
@@ -440,6 +813,13 @@ type NetworkDevice struct {
 	// +optional
 	Bridge *string `json:"bridge,omitempty"`
 
+	// vnet is the name of a Proxmox SDN VNet of the ProxmoxCluster to attach to the machine.
+	// The device is assigned addresses from the pools of the VNet's subnets instead of
+	// the cluster's default pools. Mutually exclusive with bridge.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	VNet *string `json:"vnet,omitempty"`
+
 	// defaultIPv4 attaches the ipv4 host network to this interface.
 	// +optional
 	DefaultIPv4 *bool `json:"defaultIPv4,omitempty"`
@@ -540,6 +920,48 @@ type ProxmoxMachineStatus struct {
 	// retryAfter tracks the time we can retry queueing a task.
 	// +optional
 	RetryAfter *metav1.Time `json:"retryAfter,omitempty"`
+
+	// lastFailedTask describes the most recent Proxmox task of this machine
+	// that failed, including the tail of its task log.
+	// +optional
+	LastFailedTask *FailedTask `json:"lastFailedTask,omitempty"`
+}
+
+// MaxFailedTaskLogLines is the maximum number of task log lines kept in FailedTask.
+const MaxFailedTaskLogLines = 100
+
+// FailedTask describes a failed Proxmox task.
+type FailedTask struct {
+	// upid is the unique ID of the task.
+	// +required
+	UPID string `json:"upid"`
+
+	// type is the Proxmox task type, e.g. qmclone.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// node is the Proxmox node which ran the task.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// exitStatus is the exit status reported by Proxmox.
+	// +optional
+	ExitStatus string `json:"exitStatus,omitempty"`
+
+	// log holds the last lines of the task log.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=100
+	Log []string `json:"log,omitempty"`
+
+	// attempts is the number of tasks of this type which failed in a row.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// provisioningReason is the VirtualMachineProvisioned reason of the machine when
+	// the task failed. Provisioning resumes from it when the task is retried.
+	// +optional
+	ProvisioningReason string `json:"provisioningReason,omitempty"`
 }
 
 // ProxmoxMachineInitializationStatus provides observations of the ProxmoxMachine initialization process.
@@ -553,9 +975,10 @@ type ProxmoxMachineInitializationStatus struct {
 
 // IPAddressesSpec stores the IP addresses of a network interface. Used for status.
 type IPAddressesSpec struct {
-	// net is the proxmox network name these ipaddresses are attached to.
-	// +kubebuilder:validation:MinLength=4
-	// +kubebuilder:validation:Pattern=`^(net[0-9]+|default)$`
+	// net is the proxmox network name, or the name of the bond, VLAN or bridge,
+	// these ipaddresses are attached to.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=15
 	// +required
 	NetName string `json:"net,omitempty"`
 
@@ -598,6 +1021,26 @@ type MetadataSettings struct {
 	ProviderIDInjection *bool `json:"providerIDInjection,omitempty"`
 }
 
+// BootstrapDeliveryMethod defines the mechanism used to hand bootstrap data to a VM.
+type BootstrapDeliveryMethod string
+
+const (
+	// BootstrapDeliveryMethodISO uploads a NoCloud ISO and attaches it as a cdrom.
+	BootstrapDeliveryMethodISO BootstrapDeliveryMethod = "iso"
+)
+
+// BootstrapDelivery defines how bootstrap data is delivered to the machine.
+type BootstrapDelivery struct {
+	// method is the delivery mechanism for the bootstrap data.
+	//
+	// iso (default) attaches a NoCloud ISO on ide0 which is unmounted after provisioning.
+	//
+	// +kubebuilder:validation:Enum=iso
+	// +kubebuilder:default=iso
+	// +optional
+	Method BootstrapDeliveryMethod `json:"method,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
@@ -674,6 +1117,38 @@ func (r *ProxmoxMachine) SetIPAddresses(ipSpec IPAddressesSpec) {
 	}
 }
 
+// UsesDHCP returns true if any network device, bond, VLAN or bridge of the machine is configured with DHCP
+// or SLAAC, i.e. has addresses which are not allocated from IPAM.
+func (r *ProxmoxMachine) UsesDHCP() bool {
+	if r.Spec.Network == nil {
+		return false
+	}
+	dhcp := func(ifconfig InterfaceConfig) bool {
+		return ptr.Deref(ifconfig.DHCP4, false) || ifconfig.DynamicIPv6()
+	}
+	for _, nic := range r.Spec.Network.NetworkDevices {
+		if dhcp(nic.InterfaceConfig) {
+			return true
+		}
+	}
+	for _, bond := range r.Spec.Network.Bonds {
+		if dhcp(bond.InterfaceConfig) {
+			return true
+		}
+	}
+	for _, vlan := range r.Spec.Network.VLANs {
+		if dhcp(vlan.InterfaceConfig) {
+			return true
+		}
+	}
+	for _, bridge := range r.Spec.Network.Bridges {
+		if dhcp(bridge.InterfaceConfig) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetConditions returns the observations of the operational state of the ProxmoxMachine resource.
 func (r *ProxmoxMachine) GetConditions() []metav1.Condition {
 	return r.Status.Conditions
@@ -722,6 +1197,24 @@ func (r *ProxmoxMachine) GetSourceNode() string {
 	return ptr.Deref(r.Spec.SourceNode, "")
 }
 
+// GetBootstrapDeliveryMethod returns the method used to deliver bootstrap data to the VM.
+// If no BootstrapDelivery or Method is set, BootstrapDeliveryMethodISO is returned.
+func (r *ProxmoxMachine) GetBootstrapDeliveryMethod() BootstrapDeliveryMethod {
+	if r.Spec.BootstrapDelivery != nil && r.Spec.BootstrapDelivery.Method != "" {
+		return r.Spec.BootstrapDelivery.Method
+	}
+	return BootstrapDeliveryMethodISO
+}
+
+// GetNetworkRenderer returns the format the guest network configuration is rendered in.
+// If no Network or Renderer is set, NetworkRendererNetplan is returned.
+func (r *ProxmoxMachine) GetNetworkRenderer() NetworkRenderer {
+	if r.Spec.Network != nil && r.Spec.Network.Renderer != "" {
+		return r.Spec.Network.Renderer
+	}
+	return NetworkRendererNetplan
+}
+
 // FormatSize returns the format required for the Proxmox API.
 func (d *DiskSize) FormatSize() string {
 	return fmt.Sprintf("%dG", d.SizeGB)
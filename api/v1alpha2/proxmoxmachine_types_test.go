@@ -247,7 +247,7 @@ var _ = Describe("ProxmoxMachine Test", func() {
 			Expect(k8sClient.Create(context.Background(), dm)).Should(MatchError(ContainSubstring("spec.network.networkDevices[1]: Duplicate value")))
 		})
 
-		It("Should only allow IPAM pool resources in IPPoolRef apiGroup", func() {
+		It("Should require an apiGroup in IPPoolRef", func() {
 			dm := defaultMachine()
 			dm.Spec.Network = &NetworkSpec{
 				NetworkDevices: []NetworkDevice{{
@@ -255,16 +255,16 @@ var _ = Describe("ProxmoxMachine Test", func() {
 					Name:   "net1",
 					InterfaceConfig: InterfaceConfig{
 						IPPoolRef: []corev1.TypedLocalObjectReference{{
-							APIGroup: new("apps"),
-							Name:     "some-app",
+							Kind: "ConfigMap",
+							Name: "some-app",
 						}},
 					},
 				}},
 			}
-			Expect(k8sClient.Create(context.Background(), dm)).Should(MatchError(ContainSubstring("ipPoolRef allows only IPAM apiGroup ipam.cluster.x-k8s.io")))
+			Expect(k8sClient.Create(context.Background(), dm)).Should(MatchError(ContainSubstring("ipPoolRef requires an apiGroup")))
 		})
 
-		It("Should only allow IPAM pool resources in IPPoolRef kind", func() {
+		It("Should allow pools of other IPAM providers in IPPoolRef", func() {
 			dm := defaultMachine()
 			dm.Spec.Network = &NetworkSpec{
 				NetworkDevices: []NetworkDevice{{
@@ -273,13 +273,27 @@ var _ = Describe("ProxmoxMachine Test", func() {
 					InterfaceConfig: InterfaceConfig{
 						IPPoolRef: []corev1.TypedLocalObjectReference{{
 							APIGroup: new("ipam.cluster.x-k8s.io"),
-							Kind:     "ConfigMap",
-							Name:     "some-app",
+							Kind:     "NetboxIPPool",
+							Name:     "some-pool",
 						}},
 					},
 				}},
 			}
-			Expect(k8sClient.Create(context.Background(), dm)).Should(MatchError(ContainSubstring("ipPoolRef allows either InClusterIPPool or GlobalInClusterIPPool")))
+			Expect(k8sClient.Create(context.Background(), dm)).To(Succeed())
+		})
+
+		It("Should not allow an unknown ipv6Mode", func() {
+			dm := defaultMachine()
+			dm.Spec.Network = &NetworkSpec{
+				NetworkDevices: []NetworkDevice{{
+					Bridge: new("vmbr0"),
+					InterfaceConfig: InterfaceConfig{
+						IPv6Mode: "stateless",
+					},
+				}},
+			}
+
+			Expect(k8sClient.Create(context.Background(), dm)).Should(MatchError(ContainSubstring("spec.network.networkDevices[0].ipv6Mode: Unsupported value")))
 		})
 
 		It("Should not allow machine with network device mtu less than 1", func() {
@@ -16,6 +16,8 @@ limitations under the License.
 
 package v1alpha2
 
+import "time"
+
 // VirtualMachineState describes the state of a VM.
 type VirtualMachineState string
 
@@ -50,6 +52,9 @@ const (
 
 	// ProxmoxDefaultGatewayAnnotation marks an IPAddress spec as containing a default gateway.
 	ProxmoxDefaultGatewayAnnotation string = "ipam.capmox.cluster.x-k8s.io/default-gateway"
+
+	// ProxmoxSDNOwnedAnnotation mirrors the SDN status of a ProxmoxCluster, which is not moved by clusterctl move.
+	ProxmoxSDNOwnedAnnotation string = "capmox.cluster.x-k8s.io/sdn-owned"
 )
 
 // VirtualMachine represents data about a Proxmox virtual machine object.
@@ -113,3 +118,187 @@ type NetName string
 // Zone is a formally verified Proxmox network zone name. Needs to adhere to Label rules.
 // +kubebuilder:validation:Pattern=`^[a-z0-9A-Z](?:[a-z0-9A-Z-_.]{0,61}[a-z0-9A-Z])?$`
 type Zone *string
+
+// TaskFailureAction defines how a failed Proxmox task is handled.
+type TaskFailureAction string
+
+const (
+	// TaskFailureActionRetry resumes provisioning after a backoff. A machine whose clone
+	// failed is deleted and cloned again.
+	TaskFailureActionRetry TaskFailureAction = "retry"
+
+	// TaskFailureActionIgnore resumes provisioning after a backoff without counting
+	// the failure as an attempt.
+	TaskFailureActionIgnore TaskFailureAction = "ignore"
+
+	// TaskFailureActionFail marks the machine as failed.
+	TaskFailureActionFail TaskFailureAction = "fail"
+)
+
+const (
+	// DefaultTaskMaxAttempts is the default number of attempts of a failing task type.
+	DefaultTaskMaxAttempts = 3
+
+	// DefaultTaskInitialBackoffSeconds is the default delay before a failed task is retried.
+	DefaultTaskInitialBackoffSeconds = 60
+
+	// DefaultTaskMaxBackoffSeconds is the default upper bound of the delay between retries.
+	DefaultTaskMaxBackoffSeconds = 600
+)
+
+// TaskRetryPolicy defines how failed Proxmox tasks of a machine are handled.
+type TaskRetryPolicy struct {
+	// maxAttempts is the number of times tasks of the same type may fail in a row
+	// before the machine is marked as failed. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// initialBackoffSeconds is the delay before the first retry of a failed task.
+	// The delay doubles with every further attempt. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	InitialBackoffSeconds *int32 `json:"initialBackoffSeconds,omitempty"`
+
+	// maxBackoffSeconds is the upper bound of the delay between retries. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBackoffSeconds *int32 `json:"maxBackoffSeconds,omitempty"`
+
+	// taskTypes overrides the policy for individual Proxmox task types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	TaskTypes []TaskTypeRetryPolicy `json:"taskTypes,omitempty"`
+}
+
+// TaskTypeRetryPolicy overrides the TaskRetryPolicy for one Proxmox task type.
+type TaskTypeRetryPolicy struct {
+	// type is the Proxmox task type, e.g. qmclone, qmconfig or qmstart.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Type string `json:"type"`
+
+	// action defines how a failed task of this type is handled.
+	// retry resumes provisioning after the backoff, a failed clone is deleted and cloned again.
+	// ignore resumes provisioning after the backoff without counting an attempt.
+	// fail marks the machine as failed.
+	// Defaults to ignore for qmstart and to retry for all other task types.
+	// +kubebuilder:validation:Enum=retry;ignore;fail
+	// +optional
+	Action TaskFailureAction `json:"action,omitempty"`
+
+	// maxAttempts overrides maxAttempts of the policy for this task type.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+}
+
+// taskType returns the override of the given task type, or nil.
+func (p *TaskRetryPolicy) taskType(taskType string) *TaskTypeRetryPolicy {
+	if p == nil {
+		return nil
+	}
+	for i := range p.TaskTypes {
+		if p.TaskTypes[i].Type == taskType {
+			return &p.TaskTypes[i]
+		}
+	}
+	return nil
+}
+
+// GetAction returns the action for a failed task of the given type.
+// qmstart can fail even though the VM was started, so its failures are ignored by default.
+func (p *TaskRetryPolicy) GetAction(taskType string) TaskFailureAction {
+	if t := p.taskType(taskType); t != nil && t.Action != "" {
+		return t.Action
+	}
+	if taskType == "qmstart" {
+		return TaskFailureActionIgnore
+	}
+	return TaskFailureActionRetry
+}
+
+// GetMaxAttempts returns the number of times a task of the given type may fail in a row.
+func (p *TaskRetryPolicy) GetMaxAttempts(taskType string) int32 {
+	if t := p.taskType(taskType); t != nil && t.MaxAttempts != nil {
+		return *t.MaxAttempts
+	}
+	if p != nil && p.MaxAttempts != nil {
+		return *p.MaxAttempts
+	}
+	return DefaultTaskMaxAttempts
+}
+
+// GetBackoff returns the delay before retrying a task which failed for the given attempt,
+// starting at 1. The delay grows exponentially up to maxBackoffSeconds.
+func (p *TaskRetryPolicy) GetBackoff(attempt int32) time.Duration {
+	initial, maxBackoff := int32(DefaultTaskInitialBackoffSeconds), int32(DefaultTaskMaxBackoffSeconds)
+	if p != nil && p.InitialBackoffSeconds != nil {
+		initial = *p.InitialBackoffSeconds
+	}
+	if p != nil && p.MaxBackoffSeconds != nil {
+		maxBackoff = *p.MaxBackoffSeconds
+	}
+
+	backoff := time.Duration(initial) * time.Second
+	for i := int32(1); i < attempt && backoff < time.Duration(maxBackoff)*time.Second; i++ {
+		backoff *= 2
+	}
+	return min(backoff, time.Duration(maxBackoff)*time.Second)
+}
+
+// HAState is the requested state of a Proxmox HA resource.
+type HAState string
+
+const (
+	// HAStateStarted makes the HA manager keep the virtual machine running.
+	HAStateStarted HAState = "started"
+
+	// HAStateStopped makes the HA manager keep the virtual machine stopped.
+	HAStateStopped HAState = "stopped"
+
+	// HAStateIgnored makes the HA manager leave the virtual machine alone.
+	HAStateIgnored HAState = "ignored"
+
+	// HAStateDisabled stops the virtual machine and keeps it stopped, without relocating it.
+	HAStateDisabled HAState = "disabled"
+)
+
+// HighAvailabilitySpec defines the Proxmox HA resource of a virtual machine.
+type HighAvailabilitySpec struct {
+	// group is the Proxmox HA group of the virtual machine. The HA manager only runs the
+	// virtual machine on the nodes of the group, which the provider also respects when
+	// it chooses the node to clone the virtual machine on.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Group *string `json:"group,omitempty"`
+
+	// state is the requested state of the HA resource. Defaults to started.
+	// +kubebuilder:validation:Enum=started;stopped;ignored;disabled
+	// +optional
+	State HAState `json:"state,omitempty"`
+
+	// maxRestart is the number of times the HA manager tries to restart the virtual machine
+	// on the same node after a failed start. Proxmox defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxRestart *int32 `json:"maxRestart,omitempty"`
+
+	// maxRelocate is the number of times the HA manager tries to relocate the virtual machine
+	// to another node after a failed start. Proxmox defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxRelocate *int32 `json:"maxRelocate,omitempty"`
+}
+
+// GetState returns the requested state of the HA resource.
+func (h *HighAvailabilitySpec) GetState() HAState {
+	if h == nil || h.State == "" {
+		return HAStateStarted
+	}
+	return h.State
+}
@@ -26,6 +26,187 @@ func (in *APIEndpoint) DeepCopy() *APIEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BondDevice) DeepCopyInto(out *BondDevice) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]NetName, len(*in))
+		copy(*out, *in)
+	}
+	if in.MIIMon != nil {
+		in, out := &in.MIIMon, &out.MIIMon
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultIPv4 != nil {
+		in, out := &in.DefaultIPv4, &out.DefaultIPv4
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultIPv6 != nil {
+		in, out := &in.DefaultIPv6, &out.DefaultIPv6
+		*out = new(bool)
+		**out = **in
+	}
+	in.InterfaceConfig.DeepCopyInto(&out.InterfaceConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BondDevice.
+func (in *BondDevice) DeepCopy() *BondDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(BondDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapDelivery) DeepCopyInto(out *BootstrapDelivery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapDelivery.
+func (in *BootstrapDelivery) DeepCopy() *BootstrapDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BridgeDevice) DeepCopyInto(out *BridgeDevice) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.STP != nil {
+		in, out := &in.STP, &out.STP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultIPv4 != nil {
+		in, out := &in.DefaultIPv4, &out.DefaultIPv4
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultIPv6 != nil {
+		in, out := &in.DefaultIPv6, &out.DefaultIPv6
+		*out = new(bool)
+		**out = **in
+	}
+	in.InterfaceConfig.DeepCopyInto(&out.InterfaceConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BridgeDevice.
+func (in *BridgeDevice) DeepCopy() *BridgeDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(BridgeDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFirewallSpec) DeepCopyInto(out *ClusterFirewallSpec) {
+	*out = *in
+	in.FirewallSpec.DeepCopyInto(&out.FirewallSpec)
+	if in.IPSet != nil {
+		in, out := &in.IPSet, &out.IPSet
+		*out = new(FirewallIPSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFirewallSpec.
+func (in *ClusterFirewallSpec) DeepCopy() *ClusterFirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHighAvailabilitySpec) DeepCopyInto(out *ClusterHighAvailabilitySpec) {
+	*out = *in
+	in.HighAvailabilitySpec.DeepCopyInto(&out.HighAvailabilitySpec)
+	if in.ControlPlaneOnly != nil {
+		in, out := &in.ControlPlaneOnly, &out.ControlPlaneOnly
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHighAvailabilitySpec.
+func (in *ClusterHighAvailabilitySpec) DeepCopy() *ClusterHighAvailabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHighAvailabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.ACLs != nil {
+		in, out := &in.ACLs, &out.ACLs
+		*out = make([]PoolACL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSpec.
+func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneEndpointIPAMSpec) DeepCopyInto(out *ControlPlaneEndpointIPAMSpec) {
+	*out = *in
+	if in.PoolRef != nil {
+		in, out := &in.PoolRef, &out.PoolRef
+		*out = new(v1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneEndpointIPAMSpec.
+func (in *ControlPlaneEndpointIPAMSpec) DeepCopy() *ControlPlaneEndpointIPAMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneEndpointIPAMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSize) DeepCopyInto(out *DiskSize) {
 	*out = *in
@@ -41,6 +222,178 @@ func (in *DiskSize) DeepCopy() *DiskSize {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedTask) DeepCopyInto(out *FailedTask) {
+	*out = *in
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedTask.
+func (in *FailedTask) DeepCopy() *FailedTask {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallIPSetSpec) DeepCopyInto(out *FirewallIPSetSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdditionalCIDRs != nil {
+		in, out := &in.AdditionalCIDRs, &out.AdditionalCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallIPSetSpec.
+func (in *FirewallIPSetSpec) DeepCopy() *FirewallIPSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallIPSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	if in.Macro != nil {
+		in, out := &in.Macro, &out.Macro
+		*out = new(string)
+		**out = **in
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(string)
+		**out = **in
+	}
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourcePort != nil {
+		in, out := &in.SourcePort, &out.SourcePort
+		*out = new(string)
+		**out = **in
+	}
+	if in.DestinationPort != nil {
+		in, out := &in.DestinationPort, &out.DestinationPort
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(NetName)
+		**out = **in
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallSpec) DeepCopyInto(out *FirewallSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PolicyIn != nil {
+		in, out := &in.PolicyIn, &out.PolicyIn
+		*out = new(FirewallAction)
+		**out = **in
+	}
+	if in.PolicyOut != nil {
+		in, out := &in.PolicyOut, &out.PolicyOut
+		*out = new(FirewallAction)
+		**out = **in
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallSpec.
+func (in *FirewallSpec) DeepCopy() *FirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilitySpec) DeepCopyInto(out *HighAvailabilitySpec) {
+	*out = *in
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxRestart != nil {
+		in, out := &in.MaxRestart, &out.MaxRestart
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRelocate != nil {
+		in, out := &in.MaxRelocate, &out.MaxRelocate
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilitySpec.
+func (in *HighAvailabilitySpec) DeepCopy() *HighAvailabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAddressesSpec) DeepCopyInto(out *IPAddressesSpec) {
 	*out = *in
@@ -131,6 +484,26 @@ func (in *InterfaceConfig) DeepCopyInto(out *InterfaceConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DHCP4 != nil {
+		in, out := &in.DHCP4, &out.DHCP4
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DHCP6 != nil {
+		in, out := &in.DHCP6, &out.DHCP6
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AcceptRA != nil {
+		in, out := &in.AcceptRA, &out.AcceptRA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IPv6Token != nil {
+		in, out := &in.IPv6Token, &out.IPv6Token
+		*out = new(string)
+		**out = **in
+	}
 	if in.DNSServers != nil {
 		in, out := &in.DNSServers, &out.DNSServers
 		*out = make([]string, len(*in))
@@ -144,12 +517,82 @@ func (in *InterfaceConfig) DeepCopyInto(out *InterfaceConfig) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceConfig.
-func (in *InterfaceConfig) DeepCopy() *InterfaceConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceConfig.
+func (in *InterfaceConfig) DeepCopy() *InterfaceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVIPBGPPeer) DeepCopyInto(out *KubeVIPBGPPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVIPBGPPeer.
+func (in *KubeVIPBGPPeer) DeepCopy() *KubeVIPBGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVIPBGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVIPBGPSpec) DeepCopyInto(out *KubeVIPBGPSpec) {
+	*out = *in
+	if in.RouterID != nil {
+		in, out := &in.RouterID, &out.RouterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]KubeVIPBGPPeer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVIPBGPSpec.
+func (in *KubeVIPBGPSpec) DeepCopy() *KubeVIPBGPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVIPBGPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVIPSpec) DeepCopyInto(out *KubeVIPSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(string)
+		**out = **in
+	}
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(KubeVIPBGPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVIPSpec.
+func (in *KubeVIPSpec) DeepCopy() *KubeVIPSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InterfaceConfig)
+	out := new(KubeVIPSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -182,6 +625,11 @@ func (in *NetworkDevice) DeepCopyInto(out *NetworkDevice) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.VNet != nil {
+		in, out := &in.VNet, &out.VNet
+		*out = new(string)
+		**out = **in
+	}
 	if in.DefaultIPv4 != nil {
 		in, out := &in.DefaultIPv4, &out.DefaultIPv4
 		*out = new(bool)
@@ -328,6 +776,72 @@ func (in *NodeLocations) DeepCopy() *NodeLocations {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedVM) DeepCopyInto(out *OrphanedVM) {
+	*out = *in
+	in.DetectedAt.DeepCopyInto(&out.DetectedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedVM.
+func (in *OrphanedVM) DeepCopy() *OrphanedVM {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedVM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedVMPolicy) DeepCopyInto(out *OrphanedVMPolicy) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedVMPolicy.
+func (in *OrphanedVMPolicy) DeepCopy() *OrphanedVMPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedVMPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolACL) DeepCopyInto(out *PoolACL) {
+	*out = *in
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(string)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolACL.
+func (in *PoolACL) DeepCopy() *PoolACL {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolACL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxmoxCluster) DeepCopyInto(out *ProxmoxCluster) {
 	*out = *in
@@ -427,6 +941,11 @@ func (in *ProxmoxClusterList) DeepCopyObject() runtime.Object {
 func (in *ProxmoxClusterSpec) DeepCopyInto(out *ProxmoxClusterSpec) {
 	*out = *in
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.ControlPlaneEndpointIPAM != nil {
+		in, out := &in.ControlPlaneEndpointIPAM, &out.ControlPlaneEndpointIPAM
+		*out = new(ControlPlaneEndpointIPAMSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExternalManagedControlPlane != nil {
 		in, out := &in.ExternalManagedControlPlane, &out.ExternalManagedControlPlane
 		*out = new(bool)
@@ -464,6 +983,41 @@ func (in *ProxmoxClusterSpec) DeepCopyInto(out *ProxmoxClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SDN != nil {
+		in, out := &in.SDN, &out.SDN
+		*out = new(SDNSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(ClusterFirewallSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeVIP != nil {
+		in, out := &in.KubeVIP, &out.KubeVIP
+		*out = new(KubeVIPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskRetryPolicy != nil {
+		in, out := &in.TaskRetryPolicy, &out.TaskRetryPolicy
+		*out = new(TaskRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedVMPolicy != nil {
+		in, out := &in.OrphanedVMPolicy, &out.OrphanedVMPolicy
+		*out = new(OrphanedVMPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(ClusterHighAvailabilitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pool != nil {
+		in, out := &in.Pool, &out.Pool
+		*out = new(ClusterPoolSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CredentialsRef != nil {
 		in, out := &in.CredentialsRef, &out.CredentialsRef
 		*out = new(v1.SecretReference)
@@ -509,6 +1063,18 @@ func (in *ProxmoxClusterStatus) DeepCopyInto(out *ProxmoxClusterStatus) {
 		*out = new(NodeLocations)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SDN != nil {
+		in, out := &in.SDN, &out.SDN
+		*out = new(SDNStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedVMs != nil {
+		in, out := &in.OrphanedVMs, &out.OrphanedVMs
+		*out = make([]OrphanedVM, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxmoxClusterStatus.
@@ -765,6 +1331,11 @@ func (in *ProxmoxMachineSpec) DeepCopyInto(out *ProxmoxMachineSpec) {
 		*out = new(MetadataSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BootstrapDelivery != nil {
+		in, out := &in.BootstrapDelivery, &out.BootstrapDelivery
+		*out = new(BootstrapDelivery)
+		**out = **in
+	}
 	if in.AllowedNodes != nil {
 		in, out := &in.AllowedNodes, &out.AllowedNodes
 		*out = make([]string, len(*in))
@@ -775,6 +1346,21 @@ func (in *ProxmoxMachineSpec) DeepCopyInto(out *ProxmoxMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(FirewallSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskRetryPolicy != nil {
+		in, out := &in.TaskRetryPolicy, &out.TaskRetryPolicy
+		*out = new(TaskRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(HighAvailabilitySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxmoxMachineSpec.
@@ -841,6 +1427,11 @@ func (in *ProxmoxMachineStatus) DeepCopyInto(out *ProxmoxMachineStatus) {
 		in, out := &in.RetryAfter, &out.RetryAfter
 		*out = (*in).DeepCopy()
 	}
+	if in.LastFailedTask != nil {
+		in, out := &in.LastFailedTask, &out.LastFailedTask
+		*out = new(FailedTask)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxmoxMachineStatus.
@@ -1053,6 +1644,141 @@ func (in *RoutingPolicySpec) DeepCopy() *RoutingPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SDNSpec) DeepCopyInto(out *SDNSpec) {
+	*out = *in
+	in.Zone.DeepCopyInto(&out.Zone)
+	if in.VNets != nil {
+		in, out := &in.VNets, &out.VNets
+		*out = make([]SDNVNetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SDNSpec.
+func (in *SDNSpec) DeepCopy() *SDNSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SDNSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SDNStatus) DeepCopyInto(out *SDNStatus) {
+	*out = *in
+	if in.VNets != nil {
+		in, out := &in.VNets, &out.VNets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SDNStatus.
+func (in *SDNStatus) DeepCopy() *SDNStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SDNStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SDNSubnetSpec) DeepCopyInto(out *SDNSubnetSpec) {
+	*out = *in
+	if in.SNAT != nil {
+		in, out := &in.SNAT, &out.SNAT
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SDNSubnetSpec.
+func (in *SDNSubnetSpec) DeepCopy() *SDNSubnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SDNSubnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SDNVNetSpec) DeepCopyInto(out *SDNVNetSpec) {
+	*out = *in
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]SDNSubnetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SDNVNetSpec.
+func (in *SDNVNetSpec) DeepCopy() *SDNVNetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SDNVNetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SDNZoneSpec) DeepCopyInto(out *SDNZoneSpec) {
+	*out = *in
+	if in.Bridge != nil {
+		in, out := &in.Bridge, &out.Bridge
+		*out = new(string)
+		**out = **in
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SDNZoneSpec.
+func (in *SDNZoneSpec) DeepCopy() *SDNZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SDNZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulerHints) DeepCopyInto(out *SchedulerHints) {
 	*out = *in
@@ -1093,6 +1819,63 @@ func (in *Storage) DeepCopy() *Storage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRetryPolicy) DeepCopyInto(out *TaskRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialBackoffSeconds != nil {
+		in, out := &in.InitialBackoffSeconds, &out.InitialBackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackoffSeconds != nil {
+		in, out := &in.MaxBackoffSeconds, &out.MaxBackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TaskTypes != nil {
+		in, out := &in.TaskTypes, &out.TaskTypes
+		*out = make([]TaskTypeRetryPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRetryPolicy.
+func (in *TaskRetryPolicy) DeepCopy() *TaskRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTypeRetryPolicy) DeepCopyInto(out *TaskTypeRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTypeRetryPolicy.
+func (in *TaskTypeRetryPolicy) DeepCopy() *TaskTypeRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTypeRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplateSelector) DeepCopyInto(out *TemplateSelector) {
 	*out = *in
@@ -1143,6 +1926,32 @@ func (in *TemplateSource) DeepCopy() *TemplateSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLANDevice) DeepCopyInto(out *VLANDevice) {
+	*out = *in
+	if in.DefaultIPv4 != nil {
+		in, out := &in.DefaultIPv4, &out.DefaultIPv4
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultIPv6 != nil {
+		in, out := &in.DefaultIPv6, &out.DefaultIPv6
+		*out = new(bool)
+		**out = **in
+	}
+	in.InterfaceConfig.DeepCopyInto(&out.InterfaceConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLANDevice.
+func (in *VLANDevice) DeepCopy() *VLANDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMIDRange) DeepCopyInto(out *VMIDRange) {
 	*out = *in
@@ -1257,6 +2066,27 @@ func (in *VirtualNetworkDevices) DeepCopyInto(out *VirtualNetworkDevices) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Bonds != nil {
+		in, out := &in.Bonds, &out.Bonds
+		*out = make([]BondDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VLANs != nil {
+		in, out := &in.VLANs, &out.VLANs
+		*out = make([]VLANDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bridges != nil {
+		in, out := &in.Bridges, &out.Bridges
+		*out = make([]BridgeDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualNetworkDevices.
@@ -0,0 +1,265 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/convert"
+)
+
+const (
+	// clusterNameLabel is the label Cluster API sets on the objects of a cluster.
+	clusterNameLabel = "cluster.x-k8s.io/cluster-name"
+
+	// lastAppliedAnnotation holds the manifest last applied with kubectl apply.
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// liveKinds are the kinds read from a live cluster, in the API versions converted by convert.Convert.
+var liveKinds = []schema.GroupVersionKind{
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Kind: convert.KindProxmoxCluster},
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Kind: convert.KindProxmoxClusterTemplate},
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Kind: convert.KindProxmoxMachine},
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Kind: convert.KindProxmoxMachineTemplate},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineDeployment"},
+	{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Kind: "KubeadmControlPlane"},
+	{Group: "bootstrap.cluster.x-k8s.io", Version: "v1beta1", Kind: "KubeadmConfigTemplate"},
+}
+
+// liveOptions configures the conversion of the objects of a live cluster.
+type liveOptions struct {
+	kubeconfig string
+	context    string
+	namespace  string
+	cluster    string
+	apply      bool
+}
+
+func newLiveClient(opts liveOptions) (client.Client, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = opts.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: opts.context}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	c, err := client.New(config, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("creating client: %w", err)
+	}
+	return c, nil
+}
+
+// runLive converts the objects of a live cluster. For every object which is converted, the
+// diff between the object and the server-side dry-run of the update is written to out.
+// The update is only applied if requested.
+func runLive(ctx context.Context, c client.Client, opts liveOptions, out io.Writer) error {
+	for _, gvk := range liveKinds {
+		objects, err := listLive(ctx, c, gvk, opts)
+		if err != nil {
+			return err
+		}
+		for i := range objects {
+			if err := convertLive(ctx, c, &objects[i], opts.apply, out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// listLive lists the objects of a kind, filtered by namespace and cluster.
+// Kinds whose API version is not served are skipped.
+func listLive(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, opts liveOptions) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	var listOpts []client.ListOption
+	if opts.namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(opts.namespace))
+	}
+	if err := c.List(ctx, list, listOpts...); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			fmt.Fprintf(os.Stderr, "skipping %s %s: not served\n", gvk.Kind, gvk.GroupVersion())
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing %s: %w", gvk.Kind, err)
+	}
+
+	if opts.cluster == "" {
+		return list.Items, nil
+	}
+	return slices.DeleteFunc(list.Items, func(obj unstructured.Unstructured) bool {
+		return !inCluster(&obj, opts.cluster)
+	}), nil
+}
+
+// inCluster reports whether an object is the Cluster or carries its cluster name label.
+func inCluster(obj *unstructured.Unstructured, cluster string) bool {
+	if obj.GetKind() == "Cluster" {
+		return obj.GetName() == cluster
+	}
+	return obj.GetLabels()[clusterNameLabel] == cluster
+}
+
+func convertLive(ctx context.Context, c client.Client, obj *unstructured.Unstructured, apply bool, out io.Writer) error {
+	name := fmt.Sprintf("%s %s", obj.GetKind(), client.ObjectKeyFromObject(obj))
+
+	converted, err := convertObject(obj, name)
+	if err != nil || converted == nil {
+		return err
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(converted.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return fmt.Errorf("getting %s: %w", name, err)
+	}
+
+	dryRun := converted.DeepCopy()
+	if err := c.Update(ctx, dryRun, client.DryRunAll); err != nil {
+		return fmt.Errorf("dry-run updating %s: %w", name, err)
+	}
+
+	diff, err := diffObjects(name, current, dryRun)
+	if err != nil || diff == "" {
+		return err
+	}
+	if _, err := io.WriteString(out, diff); err != nil {
+		return err
+	}
+
+	if !apply {
+		return nil
+	}
+	if err := c.Update(ctx, converted); err != nil {
+		return fmt.Errorf("updating %s: %w", name, err)
+	}
+	fmt.Fprintf(os.Stderr, "%s updated\n", name)
+	return nil
+}
+
+// convertObject runs a live object through convert.Convert. It returns nil if the object is not
+// converted. Status and managed fields are dropped, as an update does not write them. The last
+// configuration applied with kubectl apply is converted as well, so the next apply is compared
+// against the new API version. Other annotations are kept as they are.
+func convertObject(obj *unstructured.Unstructured, name string) (*unstructured.Unstructured, error) {
+	prepared := obj.DeepCopy()
+	unstructured.RemoveNestedField(prepared.Object, "status")
+	prepared.SetManagedFields(nil)
+
+	annotations := prepared.GetAnnotations()
+	if lastApplied, ok := annotations[lastAppliedAnnotation]; ok {
+		converted, err := convertManifest([]byte(lastApplied), name+" "+lastAppliedAnnotation)
+		if err != nil {
+			return nil, err
+		}
+		annotations[lastAppliedAnnotation] = string(converted)
+		prepared.SetAnnotations(annotations)
+	}
+
+	data, err := prepared.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", name, err)
+	}
+	data, err = convertManifest(data, name)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := &unstructured.Unstructured{}
+	if err := converted.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("decoding converted %s: %w", name, err)
+	}
+	if converted.GetAPIVersion() == obj.GetAPIVersion() {
+		return nil, nil
+	}
+	return converted, nil
+}
+
+// convertManifest converts a JSON manifest and returns the result as JSON.
+func convertManifest(manifest []byte, name string) ([]byte, error) {
+	data, err := yaml.JSONToYAML(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", name, err)
+	}
+
+	out, err := convert.Convert(data, convert.Options{
+		Filename: name,
+		Warn:     stderrWarn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %w", name, err)
+	}
+
+	data, err = yaml.YAMLToJSON(out)
+	if err != nil {
+		return nil, fmt.Errorf("decoding converted %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// diffObjects returns the unified diff between the YAML of two objects, or an empty
+// string if they are equal. Managed fields are left out.
+func diffObjects(name string, from, to *unstructured.Unstructured) (string, error) {
+	a, err := objectYAML(from)
+	if err != nil {
+		return "", err
+	}
+	b, err := objectYAML(to)
+	if err != nil {
+		return "", err
+	}
+	if a == b {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: name + " (live)",
+		ToFile:   name + " (converted)",
+		Context:  3,
+	})
+}
+
+func objectYAML(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("encoding %s: %w", obj.GetName(), err)
+	}
+	return string(data), nil
+}
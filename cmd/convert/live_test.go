@@ -0,0 +1,174 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const liveProxmoxClusterYAML = `apiVersion: infrastructure.cluster.x-k8s.io/v1alpha1
+kind: ProxmoxCluster
+metadata:
+  name: test
+  namespace: default
+  resourceVersion: "42"
+  labels:
+    cluster.x-k8s.io/cluster-name: test
+  annotations:
+    example.com/owner: team-a
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"infrastructure.cluster.x-k8s.io/v1alpha1","kind":"ProxmoxCluster","metadata":{"name":"test","namespace":"default"},"spec":{"controlPlaneEndpoint":{"host":"10.0.0.1","port":6443}}}'
+  managedFields:
+  - manager: kubectl
+    operation: Update
+spec:
+  controlPlaneEndpoint:
+    host: 10.0.0.1
+    port: 6443
+status:
+  ready: true
+`
+
+func liveObject(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(manifest), &obj.Object); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func TestConvertObject(t *testing.T) {
+	converted, err := convertObject(liveObject(t, liveProxmoxClusterYAML), "ProxmoxCluster default/test")
+	if err != nil {
+		t.Fatalf("convertObject: %v", err)
+	}
+	if converted == nil {
+		t.Fatal("object should be converted")
+	}
+
+	if got := converted.GetAPIVersion(); got != "infrastructure.cluster.x-k8s.io/v1alpha2" {
+		t.Errorf("unexpected apiVersion: %s", got)
+	}
+	if _, found := converted.Object["status"]; found {
+		t.Error("status should be dropped")
+	}
+	if len(converted.GetManagedFields()) > 0 {
+		t.Error("managed fields should be dropped")
+	}
+	if converted.GetResourceVersion() != "42" {
+		t.Errorf("resourceVersion should be kept, got %q", converted.GetResourceVersion())
+	}
+
+	annotations := converted.GetAnnotations()
+	if annotations["example.com/owner"] != "team-a" {
+		t.Error("annotations should be kept")
+	}
+	lastApplied := annotations[lastAppliedAnnotation]
+	if !strings.Contains(lastApplied, `"apiVersion":"infrastructure.cluster.x-k8s.io/v1alpha2"`) {
+		t.Errorf("last applied configuration should be converted, got %s", lastApplied)
+	}
+}
+
+func TestConvertObject_AlreadyConverted(t *testing.T) {
+	obj := liveObject(t, configMapYAML)
+
+	converted, err := convertObject(obj, "ConfigMap default/test")
+	if err != nil {
+		t.Fatalf("convertObject: %v", err)
+	}
+	if converted != nil {
+		t.Error("unrecognized objects should not be converted")
+	}
+}
+
+func TestInCluster(t *testing.T) {
+	obj := liveObject(t, liveProxmoxClusterYAML)
+	if !inCluster(obj, "test") {
+		t.Error("object with the cluster name label should be in the cluster")
+	}
+	if inCluster(obj, "other") {
+		t.Error("object should not be in another cluster")
+	}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetKind("Cluster")
+	cluster.SetName("test")
+	if !inCluster(cluster, "test") {
+		t.Error("the Cluster should be in its own cluster")
+	}
+}
+
+func TestDiffObjects(t *testing.T) {
+	from := liveObject(t, liveProxmoxClusterYAML)
+	to := from.DeepCopy()
+
+	diff, err := diffObjects("ProxmoxCluster default/test", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("equal objects should have no diff, got %s", diff)
+	}
+
+	to.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1alpha2")
+	to.SetManagedFields(nil)
+	diff, err = diffObjects("ProxmoxCluster default/test", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "+apiVersion: infrastructure.cluster.x-k8s.io/v1alpha2") {
+		t.Errorf("unexpected diff: %s", diff)
+	}
+	if strings.Contains(diff, "managedFields") {
+		t.Errorf("managed fields should not be diffed: %s", diff)
+	}
+}
+
+func TestRunLive_NoObjects(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	var out bytes.Buffer
+	if err := runLive(context.Background(), c, liveOptions{namespace: "default"}, &out); err != nil {
+		t.Fatalf("runLive: %v", err)
+	}
+	if out.Len() > 0 {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+}
+
+func TestNewCommand_LiveFlags(t *testing.T) {
+	cmd := newCommand()
+	cmd.SetArgs([]string{"--apply"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--apply requires --live") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	path := writeFixture(t, t.TempDir(), testfile, configMapYAML)
+	cmd = newCommand()
+	cmd.SetArgs([]string{"--live", "-f", path})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--live cannot be combined") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
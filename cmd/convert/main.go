@@ -42,6 +42,8 @@ func newCommand() *cobra.Command {
 	var filenames []string
 	var inPlace string
 	var inPlaceSet bool
+	var live bool
+	var liveOpts liveOptions
 
 	cmd := &cobra.Command{
 		Use:   "convert",
@@ -51,16 +53,41 @@ and templates from v1alpha1 to v1alpha2, and CAPI resources from v1beta1 to v1be
 
 It preserves envsubst variables (${VAR}) and YAML comments where possible.
 
-Resources that are not recognized are passed through unchanged with a warning.`,
+Resources that are not recognized are passed through unchanged with a warning.
+
+With --live, the resources are read from a management cluster instead, optionally
+filtered by namespace and cluster. The diff between every resource and the
+server-side dry-run of its conversion is printed, --apply updates the resources.`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			inPlaceSet = cmd.Flags().Changed("in-place")
+			if live {
+				if len(filenames) > 0 || inPlaceSet {
+					return fmt.Errorf("--live cannot be combined with --filename or --in-place")
+				}
+				c, err := newLiveClient(liveOpts)
+				if err != nil {
+					return err
+				}
+				return runLive(cmd.Context(), c, liveOpts, os.Stdout)
+			}
+			for _, flag := range []string{"kubeconfig", "context", "namespace", "cluster", "apply"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s requires --live", flag)
+				}
+			}
 			return run(filenames, inPlace, inPlaceSet)
 		},
 	}
 
 	cmd.Flags().StringArrayVarP(&filenames, "filename", "f", nil, "input file(s) to convert (reads stdin if omitted)")
 	cmd.Flags().StringVarP(&inPlace, "in-place", "i", "", "edit file(s) in-place; optional suffix for backup (e.g. -i.bak)")
+	cmd.Flags().BoolVar(&live, "live", false, "convert the resources of a live cluster")
+	cmd.Flags().StringVar(&liveOpts.kubeconfig, "kubeconfig", "", "path to the kubeconfig of the live cluster (defaults to $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&liveOpts.context, "context", "", "kubeconfig context of the live cluster (defaults to the current context)")
+	cmd.Flags().StringVarP(&liveOpts.namespace, "namespace", "n", "", "only convert resources in this namespace")
+	cmd.Flags().StringVar(&liveOpts.cluster, "cluster", "", "only convert the resources of this cluster")
+	cmd.Flags().BoolVar(&liveOpts.apply, "apply", false, "update the converted resources instead of only printing the diff")
 
 	return cmd
 }
@@ -36,6 +36,9 @@ import (
 	ipamicv1 "sigs.k8s.io/cluster-api-ipam-provider-in-cluster/api/v1alpha2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeserver "sigs.k8s.io/cluster-api/exp/runtime/server"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/flags"
 	"sigs.k8s.io/cluster-api/util/record"
@@ -51,6 +54,7 @@ import (
 	infrav1alpha1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha1"
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/controller"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/extension"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/tlshelper"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/webhook"
 	capmox "github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox"
@@ -68,6 +72,9 @@ var (
 	leaderElectionRetryPeriod   time.Duration
 	enableWebhooks              bool
 	probeAddr                   string
+	machineHealthCheckInterval  time.Duration
+	runtimeExtensionPort        int
+	runtimeExtensionCertDir     string
 	managerOptions              = flags.ManagerOptions{}
 
 	// ProxmoxURL env variable that defines the Proxmox host.
@@ -171,6 +178,12 @@ func main() {
 			os.Exit(1)
 		}
 	}
+	if runtimeExtensionPort != 0 {
+		if err := setupRuntimeExtension(mgr); err != nil {
+			setupLog.Error(err, "unable to setup runtime extension")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -203,6 +216,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, proxmoxClient capmo
 		Scheme:        mgr.GetScheme(),
 		Recorder:      mgr.GetEventRecorderFor("proxmoxmachine-controller"),
 		ProxmoxClient: proxmoxClient,
+
+		HealthCheckInterval: machineHealthCheckInterval,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("setting up ProxmoxMachine controller: %w", err)
 	}
@@ -253,6 +268,42 @@ func setupWebhooks(mgr ctrl.Manager) error {
 	return nil
 }
 
+func setupRuntimeExtension(mgr ctrl.Manager) error {
+	catalog := runtimecatalog.New()
+	if err := runtimehooksv1.AddToCatalog(catalog); err != nil {
+		return fmt.Errorf("adding runtime hooks to catalog: %w", err)
+	}
+
+	server, err := runtimeserver.New(runtimeserver.Options{
+		Port:    runtimeExtensionPort,
+		CertDir: runtimeExtensionCertDir,
+		Catalog: catalog,
+	})
+	if err != nil {
+		return fmt.Errorf("creating runtime extension server: %w", err)
+	}
+
+	for _, handler := range []runtimeserver.ExtensionHandler{{
+		Hook:        runtimehooksv1.DiscoverVariables,
+		Name:        "discover-variables",
+		HandlerFunc: extension.DiscoverVariables,
+	}, {
+		Hook:        runtimehooksv1.GeneratePatches,
+		Name:        "generate-patches",
+		HandlerFunc: extension.GeneratePatches,
+	}, {
+		Hook:        runtimehooksv1.ValidateTopology,
+		Name:        "validate-topology",
+		HandlerFunc: extension.ValidateTopology,
+	}} {
+		if err := server.AddExtensionHandler(handler); err != nil {
+			return fmt.Errorf("adding %s handler: %w", handler.Name, err)
+		}
+	}
+
+	return mgr.Add(server)
+}
+
 func initFlagsAndEnv(fs *pflag.FlagSet) {
 	klog.InitFlags(nil)
 
@@ -278,6 +329,12 @@ func initFlagsAndEnv(fs *pflag.FlagSet) {
 		"Duration the LeaderElector clients should wait between tries of actions (duration string)")
 	fs.BoolVar(&enableWebhooks, "enable-webhooks", true,
 		"If true, run webhook server alongside manager")
+	fs.DurationVar(&machineHealthCheckInterval, "machine-health-check-interval", time.Minute,
+		"Interval at which the runtime health of ready machines is observed, 0 disables it (duration string)")
+	fs.IntVar(&runtimeExtensionPort, "runtime-extension-port", 0,
+		"Port the Runtime SDK extension for ClusterClass patches is served on, 0 disables it")
+	fs.StringVar(&runtimeExtensionCertDir, "runtime-extension-cert-dir", "/tmp/runtime-extension/serving-certs",
+		"Directory with the serving certificate tls.crt and key tls.key of the Runtime SDK extension")
 
 	flags.AddManagerOptions(fs, &managerOptions)
 
@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	utilrecord "sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +46,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/service/firewallservice"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/service/orphanservice"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/service/poolservice"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/service/preflightservice"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/service/sdnservice"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/consts"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/kubernetes/ipam"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox"
@@ -72,14 +78,42 @@ func (r *ProxmoxClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 		Watches(&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(clusterutil.ClusterToInfrastructureMapFunc(ctx, infrav1.GroupVersion.WithKind(infrav1.ProxmoxClusterKind), mgr.GetClient(), &infrav1.ProxmoxCluster{})),
 			builder.WithPredicates(predicates.ClusterUnpaused(r.Scheme, ctrl.LoggerFrom(ctx)))).
+		// the firewall ipset of a cluster contains the addresses of its machines.
+		Watches(&infrav1.ProxmoxMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.proxmoxMachineToFirewallCluster)).
 		WithEventFilter(predicates.ResourceIsNotExternallyManaged(r.Scheme, ctrl.LoggerFrom(ctx))).
 		Complete(r)
 }
 
+// proxmoxMachineToFirewallCluster maps a ProxmoxMachine to its ProxmoxCluster if the cluster defines a firewall.
+func (r *ProxmoxClusterReconciler) proxmoxMachineToFirewallCluster(ctx context.Context, o client.Object) []ctrl.Request {
+	clusterName, ok := o.GetLabels()[clusterv1.ClusterNameLabel]
+	if !ok {
+		return nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: o.GetNamespace(), Name: clusterName}, cluster); err != nil {
+		return nil
+	}
+	if cluster.Spec.InfrastructureRef.Kind != infrav1.ProxmoxClusterKind {
+		return nil
+	}
+
+	key := client.ObjectKey{Namespace: o.GetNamespace(), Name: cluster.Spec.InfrastructureRef.Name}
+	proxmoxCluster := &infrav1.ProxmoxCluster{}
+	if err := r.Get(ctx, key, proxmoxCluster); err != nil || proxmoxCluster.Spec.Firewall == nil {
+		return nil
+	}
+
+	return []ctrl.Request{{NamespacedName: key}}
+}
+
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=proxmoxclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=proxmoxclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=proxmoxclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=proxmoxmachinetemplates,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch;patch
 
@@ -176,11 +210,30 @@ func (r *ProxmoxClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		return ctrl.Result{RequeueAfter: infrav1.DefaultReconcilerRequeue}, nil
 	}
 
+	if err := sdnservice.ReconcileSDNDelete(ctx, clusterScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to delete sdn")
+	}
+
+	if err := firewallservice.ReconcileFirewallDelete(ctx, clusterScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to delete firewall ipset")
+	}
+
+	if err := poolservice.ReconcilePoolDelete(ctx, clusterScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to delete pool")
+	}
+
+	if err := clusterScope.IPAMHelper.ReleaseControlPlaneEndpoint(ctx); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to release control plane endpoint")
+	}
+
 	if err := r.reconcileDeleteCredentialsSecret(ctx, clusterScope); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	orphanservice.ReconcileOrphanedVMsDelete(clusterScope)
+
 	clusterScope.Info("cluster deleted successfully")
+	utilrecord.Event(clusterScope.ProxmoxCluster, "Deleted", "Released the cluster's Proxmox resources")
 	ctrlutil.RemoveFinalizer(clusterScope.ProxmoxCluster, infrav1.ClusterFinalizer)
 	return ctrl.Result{}, nil
 }
@@ -215,6 +268,15 @@ func (r *ProxmoxClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return res, nil
 	}
 
+	res, err = r.reconcileControlPlaneEndpoint(ctx, clusterScope)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !res.IsZero() {
+		return res, nil
+	}
+
 	if err := r.reconcileNormalCredentialsSecret(ctx, clusterScope); err != nil {
 		reason := infrav1.ProxmoxClusterProxmoxAvailableProxmoxUnreachableReason
 		if apierrors.IsNotFound(err) {
@@ -235,9 +297,31 @@ func (r *ProxmoxClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		Reason: clusterv1.ProvisionedReason,
 	})
 
+	if err := sdnservice.ReconcileSDN(ctx, clusterScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to reconcile sdn")
+	}
+
+	if err := firewallservice.ReconcileFirewall(ctx, clusterScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to reconcile firewall")
+	}
+
+	if err := poolservice.ReconcilePool(ctx, clusterScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to reconcile pool")
+	}
+
+	// The pre-flight checks only report problems of the Proxmox environment, they do not block the cluster.
+	if err := preflightservice.ReconcilePreflight(ctx, clusterScope); err != nil {
+		clusterScope.Error(err, "unable to run pre-flight checks")
+	}
+
+	// Orphaned virtual machines do not affect the cluster, they are checked again after the ScanInterval.
+	if err := orphanservice.ReconcileOrphanedVMs(ctx, clusterScope); err != nil {
+		clusterScope.Error(err, "unable to reconcile orphaned virtual machines")
+	}
+
 	clusterScope.SetReady()
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: orphanservice.ScanInterval}, nil
 }
 
 func (r *ProxmoxClusterReconciler) reconcileIPAM(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
@@ -285,6 +369,41 @@ func (r *ProxmoxClusterReconciler) reconcileIPAM(ctx context.Context, clusterSco
 	return reconcile.Result{}, nil
 }
 
+// reconcileControlPlaneEndpoint claims the host of the ControlPlaneEndpoint from IPAM and writes it back to the spec,
+// if the ProxmoxCluster has a ControlPlaneEndpointIPAM but no ControlPlaneEndpoint.
+func (r *ProxmoxClusterReconciler) reconcileControlPlaneEndpoint(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+	if proxmoxCluster.Spec.ControlPlaneEndpointIPAM == nil || !proxmoxCluster.Spec.ControlPlaneEndpoint.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	address, err := clusterScope.IPAMHelper.ClaimControlPlaneEndpoint(ctx)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to claim control plane endpoint")
+	}
+
+	if address == nil {
+		clusterScope.Logger.Info("ProxmoxCluster is not ready, waiting for the ControlPlaneEndpoint to be claimed")
+
+		conditions.Set(proxmoxCluster, metav1.Condition{
+			Type:    infrav1.ProxmoxClusterProxmoxAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.ProxmoxClusterProxmoxAvailableMissingControlPlaneEndpointReason,
+			Message: "The ProxmoxCluster is waiting for the ControlPlaneEndpoint to be claimed",
+		})
+
+		return ctrl.Result{RequeueAfter: infrav1.DefaultReconcilerRequeue}, nil
+	}
+
+	proxmoxCluster.Spec.ControlPlaneEndpoint = infrav1.APIEndpoint{
+		Host: address.Spec.Address,
+		Port: ptr.Deref(proxmoxCluster.Spec.ControlPlaneEndpointIPAM.Port, ControlPlaneEndpointPort),
+	}
+	clusterScope.Info("claimed control plane endpoint", "endpoint", proxmoxCluster.Spec.ControlPlaneEndpoint.String())
+
+	return reconcile.Result{}, nil
+}
+
 func (r *ProxmoxClusterReconciler) reconcileNormalCredentialsSecret(ctx context.Context, clusterScope *scope.ClusterScope) error {
 	proxmoxCluster := clusterScope.ProxmoxCluster
 	if !hasCredentialsRef(proxmoxCluster) {
@@ -215,6 +215,50 @@ var _ = Describe("Controller Test", func() {
 				WithPolling(time.Second).
 				Should(Succeed())
 		})
+		It("Should claim the ControlPlaneEndpoint from IPAM", func() {
+			cl := buildProxmoxCluster(clusterName)
+			cl.Spec.ControlPlaneEndpoint = infrav1.APIEndpoint{}
+			cl.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMSpec{}
+
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cl)).NotTo(HaveOccurred())
+
+			var claim ipamv1.IPAddressClaim
+			claimKey := client.ObjectKey{Name: ipam.ControlPlaneEndpointClaimName(&cl), Namespace: testNS}
+
+			// the claim is pending, as there is no IPAM provider running.
+			g.Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(testEnv.GetContext(), claimKey, &claim)).To(Succeed())
+				g.Expect(claim.Spec.PoolRef.Name).To(Equal(ipam.InClusterPoolFormat(&cl, nil, infrav1.IPv4Format)))
+
+				var res infrav1.ProxmoxCluster
+				g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cl), &res)).To(Succeed())
+				g.Expect(res.Spec.ControlPlaneEndpoint.IsZero()).To(BeTrue())
+				g.Expect(conditions.GetReason(&res, infrav1.ProxmoxClusterProxmoxAvailableCondition)).
+					To(Equal(infrav1.ProxmoxClusterProxmoxAvailableMissingControlPlaneEndpointReason))
+			}).WithTimeout(time.Second * 10).
+				WithPolling(time.Second).
+				Should(Succeed())
+
+			// fulfill the claim.
+			g.Expect(k8sClient.Create(testEnv.GetContext(), dummyIPAddress(k8sClient, &claim, claim.Spec.PoolRef.Name))).To(Succeed())
+			claim.Status.AddressRef.Name = claim.GetName()
+			g.Expect(k8sClient.Status().Update(testEnv.GetContext(), &claim)).To(Succeed())
+
+			g.Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cl), &cl)).To(Succeed())
+				g.Expect(cl.Spec.ControlPlaneEndpoint.Host).To(Equal("10.10.10.11"))
+				g.Expect(cl.Spec.ControlPlaneEndpoint.Port).To(BeEquivalentTo(ControlPlaneEndpointPort))
+			}).WithTimeout(time.Second * 10).
+				WithPolling(time.Second).
+				Should(Succeed())
+
+			assertClusterIsReady(testEnv.GetContext(), g, clusterName)
+
+			// the claim is released on deletion.
+			cleanupResources(testEnv.GetContext(), g, cl)
+			g.Expect(apierrors.IsNotFound(k8sClient.Get(testEnv.GetContext(), claimKey, &claim))).To(BeTrue())
+			g.Expect(k8sClient.DeleteAllOf(testEnv.GetContext(), &ipamv1.IPAddress{}, client.InNamespace(testNS))).To(Succeed())
+		})
 	})
 })
 
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -29,9 +30,12 @@ import (
 	"k8s.io/utils/ptr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	utilrecord "sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -53,6 +57,10 @@ type ProxmoxMachineReconciler struct {
 	Scheme        *runtime.Scheme
 	Recorder      record.EventRecorder
 	ProxmoxClient proxmox.Client
+
+	// HealthCheckInterval is the interval in which the runtime health of ready
+	// machines is observed. Zero disables the periodic observation.
+	HealthCheckInterval time.Duration
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -113,9 +121,12 @@ func (r *ProxmoxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
-	if annotations.IsPaused(cluster, proxmoxMachine) {
+	// A paused machine is not reconciled, except for settling its in-flight task. clusterctl move
+	// pauses the cluster and waits for the block-move annotation to be removed before moving it.
+	paused := annotations.IsPaused(cluster, proxmoxMachine)
+	if paused && proxmoxMachine.Status.TaskRef == nil {
 		logger.Info("ProxmoxMachine or linked Cluster is marked as paused, not reconciling")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.releaseBlockMove(ctx, proxmoxMachine)
 	}
 
 	logger = logger.WithValues("cluster", klog.KObj(cluster))
@@ -146,11 +157,16 @@ func (r *ProxmoxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Always close the scope when exiting this function, so we can persist any ProxmoxMachine changes.
 	defer func() {
+		taskservice.ReconcileBlockMove(proxmoxMachine)
 		if err := machineScope.Close(); err != nil && reterr == nil {
 			reterr = err
 		}
 	}()
 
+	if paused {
+		return r.reconcilePaused(ctx, machineScope)
+	}
+
 	if !proxmoxMachine.ObjectMeta.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, machineScope)
 	}
@@ -158,8 +174,41 @@ func (r *ProxmoxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return r.reconcileNormal(ctx, machineScope, infraCluster)
 }
 
+// reconcilePaused settles the in-flight task of a paused machine, as the task is tracked in the
+// status, which is not restored by clusterctl move.
+func (r *ProxmoxMachineReconciler) reconcilePaused(ctx context.Context, machineScope *scope.MachineScope) (ctrl.Result, error) {
+	machineScope.Info("ProxmoxMachine or linked Cluster is marked as paused, settling in-flight task")
+	settled, err := taskservice.SettleInFlightTask(ctx, machineScope)
+	if err != nil {
+		if requeueErr := new(taskservice.RequeueError); errors.As(err, &requeueErr) {
+			return ctrl.Result{RequeueAfter: requeueErr.RequeueAfter()}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "failed to settle in-flight task")
+	}
+	if !settled {
+		return ctrl.Result{RequeueAfter: infrav1.DefaultReconcilerRequeue}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// releaseBlockMove removes a block-move annotation left over from a settled task.
+func (r *ProxmoxMachineReconciler) releaseBlockMove(ctx context.Context, proxmoxMachine *infrav1.ProxmoxMachine) error {
+	if _, ok := proxmoxMachine.GetAnnotations()[clusterctlv1.BlockMoveAnnotation]; !ok {
+		return nil
+	}
+	helper, err := patch.NewHelper(proxmoxMachine, r.Client)
+	if err != nil {
+		return err
+	}
+	taskservice.ReconcileBlockMove(proxmoxMachine)
+	return helper.Patch(ctx, proxmoxMachine)
+}
+
 func (r *ProxmoxMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope) (ctrl.Result, error) {
 	machineScope.Logger.Info("Handling deleted ProxmoxMachine")
+	if conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition) != clusterv1.DeletingReason {
+		utilrecord.Event(machineScope.ProxmoxMachine, "Deleting", "Deleting ProxmoxMachine")
+	}
 	conditions.Set(machineScope.ProxmoxMachine, metav1.Condition{
 		Type:   infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
 		Status: metav1.ConditionFalse,
@@ -249,6 +298,15 @@ func (r *ProxmoxMachineReconciler) reconcileNormal(ctx context.Context, machineS
 	})
 	machineScope.Logger.Info("ProxmoxMachine is ready")
 
+	// Keep observing the runtime health. DHCP leases may change at any time,
+	// so machines using DHCP are polled even if health checks are disabled.
+	if r.HealthCheckInterval > 0 {
+		return reconcile.Result{RequeueAfter: r.HealthCheckInterval}, nil
+	}
+	if machineScope.ProxmoxMachine.UsesDHCP() {
+		return reconcile.Result{RequeueAfter: infrav1.DHCPAddressRequeue}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -21,7 +21,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-
+	"github.com/stretchr/testify/mock"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,6 +58,11 @@ func TestAPIs(t *testing.T) {
 
 var _ = BeforeSuite(func() {
 	proxmoxClient = proxmoxtest.NewMockClient(GinkgoT())
+	// every reconcile of a ProxmoxCluster looks for orphaned virtual machines and runs the pre-flight checks.
+	proxmoxClient.EXPECT().FindVMResourcesByTag(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	proxmoxClient.EXPECT().GetNodes(mock.Anything).Return(nil, nil).Maybe()
+	proxmoxClient.EXPECT().GetVMResources(mock.Anything).Return(nil, nil).Maybe()
+	proxmoxClient.EXPECT().GetPermissions(mock.Anything).Return(nil, nil).Maybe()
 	testEnv = helpers.NewTestEnvironment(managerCtx, false, proxmoxClient)
 	// TODO: do I need this?
 	cache := testEnv.GetCache()
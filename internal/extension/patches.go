@@ -0,0 +1,238 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+)
+
+// GeneratePatches patches the ProxmoxClusterTemplates and ProxmoxMachineTemplates of a
+// cluster topology with the values of the variables. Other templates are left untouched.
+func GeneratePatches(ctx context.Context, req *runtimehooksv1.GeneratePatchesRequest, resp *runtimehooksv1.GeneratePatchesResponse) {
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, item := range req.Items {
+		vars := mergeVariables(req.Variables, item.Variables)
+		patch, err := generatePatch(item.Object.Raw, vars, isControlPlane(item.HolderReference))
+		if err != nil {
+			log.Error(err, "generating patch", "holder", item.HolderReference)
+			resp.Status = runtimehooksv1.ResponseStatusFailure
+			resp.Message = fmt.Sprintf("%s %s: %s", item.HolderReference.Kind, item.HolderReference.Name, err)
+			return
+		}
+		if patch == nil {
+			continue
+		}
+		resp.Items = append(resp.Items, runtimehooksv1.GeneratePatchesResponseItem{
+			UID:       item.UID,
+			PatchType: runtimehooksv1.JSONPatchType,
+			Patch:     patch,
+		})
+	}
+
+	resp.Status = runtimehooksv1.ResponseStatusSuccess
+}
+
+// isControlPlane reports whether a template belongs to the control plane. The other
+// templates are referenced by MachineDeployments and MachinePools.
+func isControlPlane(holder runtimehooksv1.HolderReference) bool {
+	return holder.Kind != "MachineDeployment" && holder.Kind != "MachinePool"
+}
+
+// generatePatch returns the JSON patch of a template, or nil if the template is not
+// patched by the extension.
+func generatePatch(raw []byte, vars variables, controlPlane bool) ([]byte, error) {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, errors.Wrap(err, "decoding template")
+	}
+	if typeMeta.APIVersion != infrav1.GroupVersion.String() {
+		return nil, nil
+	}
+
+	switch typeMeta.Kind {
+	case "ProxmoxClusterTemplate":
+		return patchTemplate(raw, func(template *infrav1.ProxmoxClusterTemplate) error {
+			return patchClusterTemplate(template, vars)
+		})
+	case "ProxmoxMachineTemplate":
+		return patchTemplate(raw, func(template *infrav1.ProxmoxMachineTemplate) error {
+			return patchMachineTemplate(template, vars, controlPlane)
+		})
+	default:
+		return nil, nil
+	}
+}
+
+// patchTemplate decodes a template, applies mutate to it and returns the JSON patch of
+// the changes. The original is encoded again, so fields unknown to the API do not show
+// up as removed in the patch.
+func patchTemplate[T any](raw []byte, mutate func(*T) error) ([]byte, error) {
+	var template T
+	if err := json.Unmarshal(raw, &template); err != nil {
+		return nil, errors.Wrap(err, "decoding template")
+	}
+	original, err := json.Marshal(&template)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding template")
+	}
+
+	if err := mutate(&template); err != nil {
+		return nil, err
+	}
+	modified, err := json.Marshal(&template)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding template")
+	}
+
+	operations, err := jsonpatch.CreatePatch(original, modified)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating patch")
+	}
+	if len(operations) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(operations)
+}
+
+// patchClusterTemplate applies the site and the IPv4 pool of the network to a ProxmoxClusterTemplate.
+func patchClusterTemplate(template *infrav1.ProxmoxClusterTemplate, vars variables) error {
+	spec := &template.Spec.Template.Spec
+
+	site, err := get[Site](vars, SiteVariable)
+	if err != nil {
+		return err
+	}
+	if site != nil {
+		if len(site.AllowedNodes) > 0 {
+			spec.AllowedNodes = slices.Clone(site.AllowedNodes)
+		}
+		if len(site.DNSServers) > 0 {
+			spec.DNSServers = slices.Clone(site.DNSServers)
+		}
+	}
+
+	network, err := get[Network](vars, NetworkVariable)
+	if err != nil {
+		return err
+	}
+	if network != nil && network.IPv4 != nil {
+		if spec.IPv4Config == nil {
+			spec.IPv4Config = &infrav1.IPConfigSpec{}
+		}
+		spec.IPv4Config.Addresses = slices.Clone(network.IPv4.Addresses)
+		spec.IPv4Config.Prefix = network.IPv4.Prefix
+		spec.IPv4Config.Gateway = network.IPv4.Gateway
+	}
+
+	return nil
+}
+
+// patchMachineTemplate applies the site, the size class and the network to a ProxmoxMachineTemplate.
+func patchMachineTemplate(template *infrav1.ProxmoxMachineTemplate, vars variables, controlPlane bool) error {
+	spec := &template.Spec.Template.Spec
+
+	site, err := get[Site](vars, SiteVariable)
+	if err != nil {
+		return err
+	}
+	if site != nil {
+		if len(site.AllowedNodes) > 0 {
+			spec.AllowedNodes = slices.Clone(site.AllowedNodes)
+		}
+		if len(site.TemplateTags) > 0 {
+			// a template selector excludes a template ID and its source node.
+			spec.TemplateSelector = &infrav1.TemplateSelector{
+				MatchTags:   slices.Clone(site.TemplateTags),
+				MatchPolicy: ptr.Deref(spec.TemplateSelector, infrav1.TemplateSelector{}).MatchPolicy,
+			}
+			spec.TemplateID = nil
+			spec.SourceNode = nil
+		}
+		if site.Storage != "" {
+			spec.Storage = new(site.Storage)
+		}
+	}
+
+	size, err := nodeSize(vars, controlPlane)
+	if err != nil {
+		return err
+	}
+	if size != nil {
+		spec.NumSockets = new(max(size.NumSockets, 1))
+		spec.NumCores = new(size.NumCores)
+		spec.MemoryMiB = new(size.MemoryMiB)
+		if size.DiskSizeGB > 0 {
+			if spec.Disks == nil {
+				spec.Disks = &infrav1.Storage{}
+			}
+			if spec.Disks.BootVolume == nil {
+				spec.Disks.BootVolume = &infrav1.DiskSize{Disk: defaultBootDisk}
+			}
+			spec.Disks.BootVolume.SizeGB = size.DiskSizeGB
+		}
+	}
+
+	network, err := get[Network](vars, NetworkVariable)
+	if err != nil {
+		return err
+	}
+	if network != nil {
+		patchDefaultNetworkDevice(spec, network)
+	}
+
+	return nil
+}
+
+// patchDefaultNetworkDevice attaches the default network device of a machine to the bridge of the network.
+func patchDefaultNetworkDevice(spec *infrav1.ProxmoxMachineSpec, network *Network) {
+	if spec.Network == nil {
+		spec.Network = &infrav1.NetworkSpec{}
+	}
+	devices := spec.Network.NetworkDevices
+	i := slices.IndexFunc(devices, func(device infrav1.NetworkDevice) bool {
+		return device.Name == infrav1.DefaultNetworkDevice
+	})
+	if i == -1 {
+		devices = append(devices, infrav1.NetworkDevice{Name: infrav1.DefaultNetworkDevice})
+		i = len(devices) - 1
+	}
+
+	device := &devices[i]
+	device.Bridge = new(network.Bridge)
+	device.VNet = nil
+	device.VLAN = network.VLAN
+	if network.Model != "" {
+		device.Model = new(network.Model)
+	}
+	if network.MTU != nil {
+		device.MTU = network.MTU
+	}
+	spec.Network.NetworkDevices = devices
+}
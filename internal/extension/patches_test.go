@@ -0,0 +1,228 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+)
+
+func variable(t *testing.T, name string, value any) runtimehooksv1.Variable {
+	t.Helper()
+	raw, err := json.Marshal(value)
+	require.NoError(t, err)
+	return runtimehooksv1.Variable{Name: name, Value: apiextensionsv1.JSON{Raw: raw}}
+}
+
+func rawObject(t *testing.T, obj any) runtime.RawExtension {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return runtime.RawExtension{Raw: raw}
+}
+
+func machineTemplate() *infrav1.ProxmoxMachineTemplate {
+	return &infrav1.ProxmoxMachineTemplate{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: "ProxmoxMachineTemplate"},
+		ObjectMeta: metav1.ObjectMeta{Name: "workers"},
+		Spec: infrav1.ProxmoxMachineTemplateSpec{Template: infrav1.ProxmoxMachineTemplateResource{
+			Spec: infrav1.ProxmoxMachineSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					TemplateSource: infrav1.TemplateSource{SourceNode: new("pve1"), TemplateID: new(int32(100))},
+				},
+				Network: &infrav1.NetworkSpec{NetworkDevices: []infrav1.NetworkDevice{{
+					Name:   infrav1.DefaultNetworkDevice,
+					Bridge: new("vmbr0"),
+					Model:  new("virtio"),
+				}}},
+			},
+		}},
+	}
+}
+
+// applyPatch applies the patch of the response item to the template.
+func applyPatch[T any](t *testing.T, template *T, item runtimehooksv1.GeneratePatchesResponseItem) *T {
+	t.Helper()
+	require.Equal(t, runtimehooksv1.JSONPatchType, item.PatchType)
+
+	patch, err := jsonpatch.DecodePatch(item.Patch)
+	require.NoError(t, err)
+	original, err := json.Marshal(template)
+	require.NoError(t, err)
+	patched, err := patch.Apply(original)
+	require.NoError(t, err)
+
+	var result T
+	require.NoError(t, json.Unmarshal(patched, &result))
+	return &result
+}
+
+func TestGeneratePatches_MachineTemplate(t *testing.T) {
+	template := machineTemplate()
+	req := &runtimehooksv1.GeneratePatchesRequest{
+		Variables: []runtimehooksv1.Variable{
+			variable(t, SiteVariable, Site{TemplateTags: []string{"ubuntu", "v1.34"}, Storage: "ceph", AllowedNodes: []string{"pve1", "pve2"}}),
+			variable(t, NetworkVariable, Network{Bridge: "vmbr1", VLAN: new(int32(42)), MTU: new(int32(9000))}),
+			variable(t, NodeSizeVariable, "small"),
+			variable(t, "builtin", map[string]any{"cluster": map[string]any{"name": "test"}}),
+		},
+		Items: []runtimehooksv1.GeneratePatchesRequestItem{{
+			UID:             "1",
+			HolderReference: runtimehooksv1.HolderReference{Kind: "MachineDeployment", Name: "md-0"},
+			Object:          rawObject(t, template),
+			Variables:       []runtimehooksv1.Variable{variable(t, NodeSizeVariable, "large")},
+		}},
+	}
+	resp := &runtimehooksv1.GeneratePatchesResponse{}
+
+	GeneratePatches(context.Background(), req, resp)
+	require.Equal(t, runtimehooksv1.ResponseStatusSuccess, resp.Status, resp.Message)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, req.Items[0].UID, resp.Items[0].UID)
+
+	patched := applyPatch(t, template, resp.Items[0]).Spec.Template.Spec
+	require.Nil(t, patched.TemplateID)
+	require.Nil(t, patched.SourceNode)
+	require.Equal(t, &infrav1.TemplateSelector{MatchTags: []string{"ubuntu", "v1.34"}}, patched.TemplateSelector)
+	require.Equal(t, new("ceph"), patched.Storage)
+	require.Equal(t, []string{"pve1", "pve2"}, patched.AllowedNodes)
+
+	// the size class of the MachineDeployment overrides the one of the cluster.
+	require.Equal(t, new(int32(2)), patched.NumSockets)
+	require.Equal(t, new(int32(4)), patched.NumCores)
+	require.Equal(t, new(int32(16384)), patched.MemoryMiB)
+	require.Equal(t, &infrav1.DiskSize{Disk: "scsi0", SizeGB: 100}, patched.Disks.BootVolume)
+
+	require.Len(t, patched.Network.NetworkDevices, 1)
+	device := patched.Network.NetworkDevices[0]
+	require.Equal(t, new("vmbr1"), device.Bridge)
+	require.Equal(t, new(int32(42)), device.VLAN)
+	require.Equal(t, infrav1.MTU(new(int32(9000))), device.MTU)
+	require.Equal(t, new("virtio"), device.Model)
+}
+
+func TestGeneratePatches_ControlPlaneNodeSize(t *testing.T) {
+	template := machineTemplate()
+	req := &runtimehooksv1.GeneratePatchesRequest{
+		Variables: []runtimehooksv1.Variable{
+			variable(t, NodeSizeVariable, "small"),
+			variable(t, ControlPlaneNodeSizeVariable, "etcd"),
+			variable(t, NodeSizesVariable, map[string]NodeSize{"etcd": {NumCores: 6, MemoryMiB: 12288}}),
+		},
+		Items: []runtimehooksv1.GeneratePatchesRequestItem{{
+			UID:             "1",
+			HolderReference: runtimehooksv1.HolderReference{Kind: "KubeadmControlPlane", Name: "test"},
+			Object:          rawObject(t, template),
+		}},
+	}
+	resp := &runtimehooksv1.GeneratePatchesResponse{}
+
+	GeneratePatches(context.Background(), req, resp)
+	require.Equal(t, runtimehooksv1.ResponseStatusSuccess, resp.Status, resp.Message)
+	require.Len(t, resp.Items, 1)
+
+	patched := applyPatch(t, template, resp.Items[0]).Spec.Template.Spec
+	require.Equal(t, new(int32(1)), patched.NumSockets)
+	require.Equal(t, new(int32(6)), patched.NumCores)
+	require.Equal(t, new(int32(12288)), patched.MemoryMiB)
+	// the custom size class has no disk size, the boot volume of the template is kept.
+	require.Nil(t, patched.Disks)
+}
+
+func TestGeneratePatches_ClusterTemplate(t *testing.T) {
+	template := &infrav1.ProxmoxClusterTemplate{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: "ProxmoxClusterTemplate"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.ProxmoxClusterTemplateSpec{Template: infrav1.ProxmoxClusterTemplateResource{
+			Spec: infrav1.ProxmoxClusterSpec{
+				IPv4Config: &infrav1.IPConfigSpec{Addresses: []string{"10.0.0.10-10.0.0.20"}, Prefix: 24, Gateway: "10.0.0.1", Metric: new(int32(100))},
+			},
+		}},
+	}
+	req := &runtimehooksv1.GeneratePatchesRequest{
+		Variables: []runtimehooksv1.Variable{
+			variable(t, SiteVariable, Site{AllowedNodes: []string{"pve1"}, DNSServers: []string{"8.8.8.8"}}),
+			variable(t, NetworkVariable, Network{Bridge: "vmbr0", IPv4: &IPv4Config{
+				Addresses: []string{"192.168.1.10-192.168.1.50"}, Prefix: 24, Gateway: "192.168.1.1",
+			}}),
+		},
+		Items: []runtimehooksv1.GeneratePatchesRequestItem{{
+			UID:             "1",
+			HolderReference: runtimehooksv1.HolderReference{Kind: "Cluster", Name: "test"},
+			Object:          rawObject(t, template),
+		}},
+	}
+	resp := &runtimehooksv1.GeneratePatchesResponse{}
+
+	GeneratePatches(context.Background(), req, resp)
+	require.Equal(t, runtimehooksv1.ResponseStatusSuccess, resp.Status, resp.Message)
+	require.Len(t, resp.Items, 1)
+
+	patched := applyPatch(t, template, resp.Items[0]).Spec.Template.Spec
+	require.Equal(t, []string{"pve1"}, patched.AllowedNodes)
+	require.Equal(t, []string{"8.8.8.8"}, patched.DNSServers)
+	require.Equal(t, &infrav1.IPConfigSpec{
+		Addresses: []string{"192.168.1.10-192.168.1.50"}, Prefix: 24, Gateway: "192.168.1.1", Metric: new(int32(100)),
+	}, patched.IPv4Config)
+}
+
+func TestGeneratePatches_OtherTemplates(t *testing.T) {
+	req := &runtimehooksv1.GeneratePatchesRequest{
+		Items: []runtimehooksv1.GeneratePatchesRequestItem{{
+			UID: "1",
+			Object: rawObject(t, map[string]any{
+				"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta2",
+				"kind":       "KubeadmConfigTemplate",
+			}),
+		}, {
+			// a template without variables is not changed.
+			UID:    "2",
+			Object: rawObject(t, machineTemplate()),
+		}},
+	}
+	resp := &runtimehooksv1.GeneratePatchesResponse{}
+
+	GeneratePatches(context.Background(), req, resp)
+	require.Equal(t, runtimehooksv1.ResponseStatusSuccess, resp.Status, resp.Message)
+	require.Empty(t, resp.Items)
+}
+
+func TestGeneratePatches_UnknownNodeSize(t *testing.T) {
+	req := &runtimehooksv1.GeneratePatchesRequest{
+		Variables: []runtimehooksv1.Variable{variable(t, NodeSizeVariable, "huge")},
+		Items: []runtimehooksv1.GeneratePatchesRequestItem{{
+			UID:             "1",
+			HolderReference: runtimehooksv1.HolderReference{Kind: "MachineDeployment", Name: "md-0"},
+			Object:          rawObject(t, machineTemplate()),
+		}},
+	}
+	resp := &runtimehooksv1.GeneratePatchesResponse{}
+
+	GeneratePatches(context.Background(), req, resp)
+	require.Equal(t, runtimehooksv1.ResponseStatusFailure, resp.Status)
+	require.Equal(t, `MachineDeployment md-0: unknown node size "huge"`, resp.Message)
+}
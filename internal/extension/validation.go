@@ -0,0 +1,97 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/pkg/errors"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+)
+
+// ValidateTopology validates the variables of a cluster topology beyond their schema:
+// the size classes must exist and the addresses of the IPv4 pool must be in the subnet of its gateway.
+func ValidateTopology(_ context.Context, req *runtimehooksv1.ValidateTopologyRequest, resp *runtimehooksv1.ValidateTopologyResponse) {
+	var problems []string
+	if err := validateVariables(mergeVariables(req.Variables, nil), true); err != nil {
+		problems = append(problems, err.Error())
+	}
+	for _, item := range req.Items {
+		if err := validateVariables(mergeVariables(req.Variables, item.Variables), isControlPlane(item.HolderReference)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s %s: %s", item.HolderReference.Kind, item.HolderReference.Name, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = strings.Join(problems, "; ")
+		return
+	}
+	resp.Status = runtimehooksv1.ResponseStatusSuccess
+}
+
+func validateVariables(vars variables, controlPlane bool) error {
+	if _, err := get[Site](vars, SiteVariable); err != nil {
+		return err
+	}
+	if _, err := nodeSize(vars, controlPlane); err != nil {
+		return err
+	}
+	// the size class of the workers is validated even if the control plane overrides it.
+	if _, err := nodeSize(vars, false); err != nil {
+		return err
+	}
+
+	network, err := get[Network](vars, NetworkVariable)
+	if err != nil {
+		return err
+	}
+	if network != nil && network.IPv4 != nil {
+		return validateIPv4(network.IPv4)
+	}
+
+	return nil
+}
+
+// validateIPv4 checks that the addresses of the IPv4 pool are in the subnet of its gateway.
+// The addresses are single IPs, ranges or CIDRs, ranges are checked by their first IP.
+func validateIPv4(config *IPv4Config) error {
+	gateway, err := netip.ParseAddr(config.Gateway)
+	if err != nil || !gateway.Is4() {
+		return errors.Errorf("invalid IPv4 gateway %q", config.Gateway)
+	}
+	subnet, err := gateway.Prefix(int(config.Prefix))
+	if err != nil {
+		return errors.Errorf("invalid IPv4 prefix %d", config.Prefix)
+	}
+
+	for _, address := range config.Addresses {
+		first, _, _ := strings.Cut(address, "-")
+		first, _, _ = strings.Cut(first, "/")
+		ip, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil || !ip.Is4() {
+			return errors.Errorf("invalid IPv4 address %q", address)
+		}
+		if !subnet.Contains(ip) {
+			return errors.Errorf("IPv4 address %q is not in the subnet %s of the gateway", address, subnet)
+		}
+	}
+	return nil
+}
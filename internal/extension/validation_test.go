@@ -0,0 +1,97 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+)
+
+func TestValidateTopology(t *testing.T) {
+	ipv4 := &IPv4Config{Addresses: []string{"10.0.0.10-10.0.0.20", "10.0.0.128/25"}, Prefix: 24, Gateway: "10.0.0.1"}
+
+	tests := []struct {
+		name      string
+		variables []runtimehooksv1.Variable
+		overrides []runtimehooksv1.Variable
+		message   string
+	}{{
+		name: "valid",
+		variables: []runtimehooksv1.Variable{
+			variable(t, NodeSizeVariable, "medium"),
+			variable(t, ControlPlaneNodeSizeVariable, "etcd"),
+			variable(t, NodeSizesVariable, map[string]NodeSize{"etcd": {NumCores: 4, MemoryMiB: 8192}}),
+			variable(t, NetworkVariable, Network{Bridge: "vmbr0", IPv4: ipv4}),
+		},
+		overrides: []runtimehooksv1.Variable{variable(t, NodeSizeVariable, "large")},
+	}, {
+		name:      "unknown node size of a machine deployment",
+		variables: []runtimehooksv1.Variable{variable(t, NodeSizeVariable, "medium")},
+		overrides: []runtimehooksv1.Variable{variable(t, NodeSizeVariable, "huge")},
+		message:   `MachineDeployment md-0: unknown node size "huge"`,
+	}, {
+		name:      "unknown control plane node size",
+		variables: []runtimehooksv1.Variable{variable(t, ControlPlaneNodeSizeVariable, "etcd")},
+		message:   `unknown node size "etcd"; KubeadmControlPlane test: unknown node size "etcd"`,
+	}, {
+		name: "address outside of the subnet of the gateway",
+		variables: []runtimehooksv1.Variable{variable(t, NetworkVariable, Network{Bridge: "vmbr0", IPv4: &IPv4Config{
+			Addresses: []string{"10.0.1.10-10.0.1.20"}, Prefix: 24, Gateway: "10.0.0.1",
+		}})},
+		message: `IPv4 address "10.0.1.10-10.0.1.20" is not in the subnet 10.0.0.0/24 of the gateway; ` +
+			`KubeadmControlPlane test: IPv4 address "10.0.1.10-10.0.1.20" is not in the subnet 10.0.0.0/24 of the gateway; ` +
+			`MachineDeployment md-0: IPv4 address "10.0.1.10-10.0.1.20" is not in the subnet 10.0.0.0/24 of the gateway`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &runtimehooksv1.ValidateTopologyRequest{
+				Variables: test.variables,
+				Items: []*runtimehooksv1.ValidateTopologyRequestItem{{
+					HolderReference: runtimehooksv1.HolderReference{Kind: "KubeadmControlPlane", Name: "test"},
+				}, {
+					HolderReference: runtimehooksv1.HolderReference{Kind: "MachineDeployment", Name: "md-0"},
+					Variables:       test.overrides,
+				}},
+			}
+			resp := &runtimehooksv1.ValidateTopologyResponse{}
+
+			ValidateTopology(context.Background(), req, resp)
+			if test.message == "" {
+				require.Equal(t, runtimehooksv1.ResponseStatusSuccess, resp.Status, resp.Message)
+				return
+			}
+			require.Equal(t, runtimehooksv1.ResponseStatusFailure, resp.Status)
+			require.Equal(t, test.message, resp.Message)
+		})
+	}
+}
+
+func TestDiscoverVariables(t *testing.T) {
+	resp := &runtimehooksv1.DiscoverVariablesResponse{}
+	DiscoverVariables(context.Background(), &runtimehooksv1.DiscoverVariablesRequest{}, resp)
+
+	require.Equal(t, runtimehooksv1.ResponseStatusSuccess, resp.Status)
+	names := make([]string, 0, len(resp.Variables))
+	for _, v := range resp.Variables {
+		names = append(names, v.Name)
+	}
+	require.Equal(t, []string{SiteVariable, NetworkVariable, NodeSizeVariable, ControlPlaneNodeSizeVariable, NodeSizesVariable}, names)
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package extension implements a Cluster API Runtime SDK extension, which turns high-level
+// cluster topology variables into patches of ProxmoxClusterTemplates and ProxmoxMachineTemplates.
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+)
+
+const (
+	// SiteVariable is the variable describing the Proxmox nodes, templates and storage of a cluster.
+	SiteVariable = "proxmoxSite"
+
+	// NetworkVariable is the variable describing the network of the machines.
+	NetworkVariable = "proxmoxNetwork"
+
+	// NodeSizeVariable is the variable selecting the size class of the machines.
+	// It can be overridden per MachineDeployment or MachinePool.
+	NodeSizeVariable = "proxmoxNodeSize"
+
+	// ControlPlaneNodeSizeVariable is the variable selecting the size class of the control plane machines.
+	// The size class of NodeSizeVariable is used if it is not set.
+	ControlPlaneNodeSizeVariable = "proxmoxControlPlaneNodeSize"
+
+	// NodeSizesVariable is the variable defining custom size classes in addition to the built-in ones.
+	NodeSizesVariable = "proxmoxNodeSizes"
+
+	// defaultNodeSize is the size class of machines which do not select one.
+	defaultNodeSize = "medium"
+
+	// defaultBootDisk is the boot disk resized to the disk size of a size class.
+	defaultBootDisk = "scsi0"
+)
+
+// Site describes the Proxmox nodes, templates and storage the machines of a cluster use.
+type Site struct {
+	AllowedNodes []string `json:"allowedNodes,omitempty"`
+	TemplateTags []string `json:"templateTags,omitempty"`
+	Storage      string   `json:"storage,omitempty"`
+	DNSServers   []string `json:"dnsServers,omitempty"`
+}
+
+// Network describes the bridge the default network device of the machines is attached to,
+// and the IPv4 pool its addresses are allocated from.
+type Network struct {
+	Bridge string      `json:"bridge"`
+	VLAN   *int32      `json:"vlan,omitempty"`
+	Model  string      `json:"model,omitempty"`
+	MTU    *int32      `json:"mtu,omitempty"`
+	IPv4   *IPv4Config `json:"ipv4,omitempty"`
+}
+
+// IPv4Config is the IPv4 pool of the cluster.
+type IPv4Config struct {
+	Addresses []string `json:"addresses"`
+	Prefix    int32    `json:"prefix"`
+	Gateway   string   `json:"gateway"`
+}
+
+// NodeSize is a size class of machines.
+type NodeSize struct {
+	NumSockets int32 `json:"numSockets,omitempty"`
+	NumCores   int32 `json:"numCores"`
+	MemoryMiB  int32 `json:"memoryMiB"`
+	DiskSizeGB int32 `json:"diskSizeGb,omitempty"`
+}
+
+// builtinNodeSizes are the size classes available without defining them in NodeSizesVariable.
+var builtinNodeSizes = map[string]NodeSize{
+	"small":  {NumSockets: 1, NumCores: 2, MemoryMiB: 4096, DiskSizeGB: 40},
+	"medium": {NumSockets: 1, NumCores: 4, MemoryMiB: 8192, DiskSizeGB: 60},
+	"large":  {NumSockets: 2, NumCores: 4, MemoryMiB: 16384, DiskSizeGB: 100},
+}
+
+// DiscoverVariables returns the definitions of the variables the extension supports.
+func DiscoverVariables(_ context.Context, _ *runtimehooksv1.DiscoverVariablesRequest, resp *runtimehooksv1.DiscoverVariablesResponse) {
+	resp.Variables = []clusterv1.ClusterClassVariable{{
+		Name: SiteVariable,
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+			Type:        "object",
+			Description: "Proxmox nodes, VM templates and storage used by the machines of the cluster.",
+			Properties: map[string]clusterv1.JSONSchemaProps{
+				"allowedNodes": stringList("Proxmox nodes the machines are scheduled on."),
+				"templateTags": stringList("Tags of the VM templates the machines are cloned from."),
+				"storage": {
+					Type:        "string",
+					Description: "Storage the disks of the machines are cloned to.",
+				},
+				"dnsServers": stringList("DNS servers of the machines."),
+			},
+		}},
+	}, {
+		Name: NetworkVariable,
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+			Type:        "object",
+			Description: "Network of the default network device of the machines.",
+			Required:    []string{"bridge"},
+			Properties: map[string]clusterv1.JSONSchemaProps{
+				"bridge": {
+					Type:        "string",
+					Description: "Bridge the default network device is attached to.",
+					MinLength:   ptr.To[int64](1),
+				},
+				"vlan": {
+					Type:        "integer",
+					Description: "VLAN tag of the default network device.",
+					Minimum:     ptr.To[int64](1),
+					Maximum:     ptr.To[int64](4094),
+				},
+				"model": {
+					Type:        "string",
+					Description: "Model of the default network device.",
+					Enum:        jsonStrings("e1000", "virtio", "rtl8139", "vmxnet3"),
+				},
+				"mtu": {
+					Type:        "integer",
+					Description: "MTU of the default network device, 1 inherits the MTU of the bridge.",
+					Minimum:     ptr.To[int64](1),
+					Maximum:     ptr.To[int64](65520),
+				},
+				"ipv4": {
+					Type:        "object",
+					Description: "IPv4 pool the addresses of the machines are allocated from.",
+					Required:    []string{"addresses", "prefix", "gateway"},
+					Properties: map[string]clusterv1.JSONSchemaProps{
+						"addresses": stringList("IPv4 addresses, ranges or CIDRs of the pool."),
+						"prefix": {
+							Type:    "integer",
+							Minimum: ptr.To[int64](0),
+							Maximum: ptr.To[int64](32),
+						},
+						"gateway": {
+							Type:   "string",
+							Format: "ipv4",
+						},
+					},
+				},
+			},
+		}},
+	}, {
+		Name: NodeSizeVariable,
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: nodeSizeName(
+			"Size class of the machines, one of small, medium, large or a class defined in "+NodeSizesVariable+".",
+			ptr.To(apiextensionsv1.JSON{Raw: []byte(`"` + defaultNodeSize + `"`)}),
+		)},
+	}, {
+		Name: ControlPlaneNodeSizeVariable,
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: nodeSizeName(
+			"Size class of the control plane machines, defaults to the class of "+NodeSizeVariable+".",
+			nil,
+		)},
+	}, {
+		Name: NodeSizesVariable,
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+			Type:        "object",
+			Description: "Custom size classes by name.",
+			AdditionalProperties: &clusterv1.JSONSchemaProps{
+				Type:     "object",
+				Required: []string{"numCores", "memoryMiB"},
+				Properties: map[string]clusterv1.JSONSchemaProps{
+					"numSockets": {Type: "integer", Minimum: ptr.To[int64](1)},
+					"numCores":   {Type: "integer", Minimum: ptr.To[int64](1)},
+					"memoryMiB":  {Type: "integer", Minimum: ptr.To[int64](1)},
+					"diskSizeGb": {Type: "integer", Minimum: ptr.To[int64](5)},
+				},
+			},
+		}},
+	}}
+	resp.Status = runtimehooksv1.ResponseStatusSuccess
+}
+
+func stringList(description string) clusterv1.JSONSchemaProps {
+	return clusterv1.JSONSchemaProps{
+		Type:        "array",
+		Description: description,
+		Items:       &clusterv1.JSONSchemaProps{Type: "string"},
+	}
+}
+
+func nodeSizeName(description string, defaultValue *apiextensionsv1.JSON) clusterv1.JSONSchemaProps {
+	return clusterv1.JSONSchemaProps{
+		Type:        "string",
+		Description: description,
+		MinLength:   ptr.To[int64](1),
+		Default:     defaultValue,
+	}
+}
+
+func jsonStrings(values ...string) []apiextensionsv1.JSON {
+	result := make([]apiextensionsv1.JSON, 0, len(values))
+	for _, value := range values {
+		raw, _ := json.Marshal(value)
+		result = append(result, apiextensionsv1.JSON{Raw: raw})
+	}
+	return result
+}
+
+// variables are the values of the variables of a template, by name.
+type variables map[string]apiextensionsv1.JSON
+
+// mergeVariables returns the variables of the cluster, overridden by the ones of a template.
+func mergeVariables(global, overrides []runtimehooksv1.Variable) variables {
+	merged := make(variables, len(global)+len(overrides))
+	for _, variable := range slices.Concat(global, overrides) {
+		merged[variable.Name] = variable.Value
+	}
+	return merged
+}
+
+// get decodes the value of a variable. It returns nil if the variable is not set.
+func get[T any](vars variables, name string) (*T, error) {
+	value, ok := vars[name]
+	if !ok {
+		return nil, nil
+	}
+	var v T
+	if err := json.Unmarshal(value.Raw, &v); err != nil {
+		return nil, errors.Wrapf(err, "decoding variable %s", name)
+	}
+	return &v, nil
+}
+
+// nodeSize returns the size class selected for a machine, or nil if none is selected.
+func nodeSize(vars variables, controlPlane bool) (*NodeSize, error) {
+	name, err := get[string](vars, NodeSizeVariable)
+	if err != nil {
+		return nil, err
+	}
+	if controlPlane {
+		cpName, err := get[string](vars, ControlPlaneNodeSizeVariable)
+		if err != nil {
+			return nil, err
+		}
+		if cpName != nil {
+			name = cpName
+		}
+	}
+	if name == nil {
+		return nil, nil
+	}
+
+	custom, err := get[map[string]NodeSize](vars, NodeSizesVariable)
+	if err != nil {
+		return nil, err
+	}
+	if custom != nil {
+		if size, ok := (*custom)[*name]; ok {
+			return &size, nil
+		}
+	}
+	if size, ok := builtinNodeSizes[*name]; ok {
+		return &size, nil
+	}
+	return nil, errors.Errorf("unknown node size %q", *name)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/cloudinit"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/ignition"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/talos"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -42,6 +43,8 @@ type ISOInjector struct {
 	NetworkRenderer cloudinit.Renderer
 
 	IgnitionEnricher *ignition.Enricher
+
+	TalosPatcher *talos.Patcher
 }
 
 // Inject injects cloudinit userdata, metadata and network-config into a Proxmox VirtualMachine.
@@ -51,6 +54,8 @@ func (i *ISOInjector) Inject(ctx context.Context, format BootstrapDataFormat) er
 		return i.injectIgnition(ctx)
 	case CloudConfigFormat:
 		return i.injectCloudInit(ctx)
+	case TalosFormat:
+		return i.injectTalos(ctx)
 	default:
 		return errors.New("unsupported format")
 	}
@@ -73,8 +78,13 @@ func (i *ISOInjector) injectCloudInit(ctx context.Context) error {
 
 	logger.V(4).Info("CloudInit:", "network-config", string(network))
 
+	userdata, err := renderUserData(i.BootstrapData, i.NetworkRenderer)
+	if err != nil {
+		return err
+	}
+
 	// Inject an ISO with userdata, metadata and network-config into the VirtualMachine.
-	err = i.VirtualMachine.CloudInit(ctx, CloudInitISODevice, string(i.BootstrapData), string(metadata), "", string(network))
+	err = i.VirtualMachine.CloudInit(ctx, CloudInitISODevice, string(userdata), string(metadata), "", string(network))
 	if err != nil {
 		return errors.Wrap(err, "unable to inject CloudInit ISO")
 	}
@@ -118,3 +128,56 @@ func (i *ISOInjector) injectIgnition(ctx context.Context) error {
 
 	return nil
 }
+
+func (i *ISOInjector) injectTalos(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if i.TalosPatcher == nil {
+		return errors.New("talos patcher is not defined")
+	}
+
+	if i.TalosPatcher.BootstrapData == nil {
+		i.TalosPatcher.BootstrapData = i.BootstrapData
+	}
+
+	if i.MetaRenderer == nil {
+		return errors.New("metadata renderer is not defined")
+	}
+
+	// Render metadata.
+	metadata, err := i.MetaRenderer.Render()
+	if err != nil {
+		return errors.Wrap(err, "unable to render metadata")
+	}
+
+	bootstrapData, err := i.TalosPatcher.Patch()
+	if err != nil {
+		return errors.Wrap(err, "unable to patch talos machine config")
+	}
+
+	logger.V(4).Info("Talos", "bootstrapData", string(bootstrapData))
+
+	// Inject a NoCloud ISO with the patched machine config, metadata and an empty network-config v1.
+	// The network is configured by the machine config, Talos must not apply a platform network on top.
+	err = i.VirtualMachine.CloudInit(ctx, CloudInitISODevice, string(bootstrapData), string(metadata), "", string(cloudinit.EmptyNetworkV1))
+	if err != nil {
+		return errors.Wrap(err, "unable to inject talos userdata iso")
+	}
+
+	return nil
+}
+
+// renderUserData adds the network configuration to the bootstrap data if the network
+// renderer configures the network through user-data.
+func renderUserData(bootstrapData []byte, network cloudinit.Renderer) ([]byte, error) {
+	r, ok := network.(cloudinit.UserDataNetworkRenderer)
+	if !ok {
+		return bootstrapData, nil
+	}
+
+	userdata, err := r.RenderUserData(bootstrapData)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to render network user-data")
+	}
+	return userdata, nil
+}
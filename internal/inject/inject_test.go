@@ -32,6 +32,7 @@ import (
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/ignition"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/talos"
 )
 
 const (
@@ -79,8 +80,29 @@ const (
     ]
   }
 }`
+	talosBootstrapData = `version: v1alpha1
+machine:
+  type: worker
+  token: abc.def
+cluster:
+  clusterName: test
+`
 )
 
+var talosNetwork = []network.ConfigData{
+	{
+		Type:       network.TypeEthernet,
+		Name:       "eth0",
+		MacAddress: "aa:bb:cc:dd:ee:ff",
+		IPConfigs:  []network.IPConfig{{IPAddress: netip.MustParsePrefix("10.1.1.6/24")}},
+		DNSServers: []string{"8.8.8.8", "8.8.4.4"},
+		Routes: []network.RoutingData{{
+			To:  netip.MustParsePrefix("0.0.0.0/0"),
+			Via: netip.MustParseAddr("10.1.1.1"),
+		}},
+	},
+}
+
 func TestISOInjectorInjectCloudInit(t *testing.T) {
 	client := newTestClient(t)
 
@@ -315,6 +337,104 @@ func TestISOInjectorInjectIgnition_Errors(t *testing.T) {
 	require.Error(t, err, "unable to enrich ignition")
 }
 
+func TestISOInjectorInjectTalos(t *testing.T) {
+	client := newTestClient(t)
+
+	vm := &proxmox.VirtualMachine{
+		Node: "pve",
+		VMID: proxmox.StringOrUint64(100),
+		VirtualMachineConfig: &proxmox.VirtualMachineConfig{
+			Agent:     "1",
+			TagsSlice: []string{"talos"},
+			Tags:      "talos",
+		},
+	}
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf(`=~/nodes/%s/status`, "pve"),
+		newJSONResponder(200, proxmox.Node{Name: "pve"}, 2))
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf(`=~/nodes/%s/qemu/%d/status/current`, "pve", 100),
+		newJSONResponder(200, vm, 1))
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf(`=~/nodes/%s/qemu/%d/config`, "pve", 100),
+		newJSONResponder(200, vm.VirtualMachineConfig, 1))
+
+	vm, err := client.GetVM(context.Background(), "pve", 100)
+	require.NoError(t, err)
+
+	injector := &ISOInjector{
+		VirtualMachine: vm,
+		BootstrapData:  []byte(talosBootstrapData),
+		MetaRenderer:   cloudinit.NewMetadata("xxx-xxxx", "my-custom-vm", "1.2.3", false),
+		TalosPatcher: &talos.Patcher{
+			Hostname: "my-custom-vm",
+			Network:  talosNetwork,
+		},
+	}
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf(`=~/nodes/%s/storage`, "pve"),
+		newJSONResponder(200, &proxmox.Storages{{Name: "iso", Content: "iso", Enabled: 1}}, 1))
+
+	ptask := &proxmox.Task{
+		UPID:      "UPID:pve:003B4235:1DF4ABCA:667C1C45:vncproxy:103:root@pam:",
+		Type:      "upload",
+		User:      "foo",
+		Status:    "completed",
+		Node:      "pve",
+		IsRunning: false,
+	}
+
+	httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf(`=~/nodes/%s/storage/iso/upload`, "pve"),
+		newJSONResponder(200, ptask.UPID, 1))
+
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf(`=~/nodes/%s/tasks/%s/status`, "pve", string(ptask.UPID)),
+		newJSONResponder(200, ptask, 4))
+
+	httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf(`=~/nodes/%s/qemu/%d/config`, "pve", 100),
+		newJSONResponder(200, ptask.UPID, 2))
+
+	err = injector.Inject(context.Background(), "talos")
+	require.NoError(t, err)
+	require.Equal(t, []byte(talosBootstrapData), injector.TalosPatcher.BootstrapData)
+}
+
+func TestISOInjectorInjectTalos_Errors(t *testing.T) {
+	vm := &proxmox.VirtualMachine{
+		Node: "pve",
+		VMID: proxmox.StringOrUint64(100),
+	}
+	p := &talos.Patcher{
+		BootstrapData: []byte(talosBootstrapData),
+		Hostname:      "my-custom-vm",
+		Network:       talosNetwork,
+	}
+	injector := &ISOInjector{
+		VirtualMachine: vm,
+		MetaRenderer:   nil,
+		TalosPatcher:   p,
+	}
+
+	// missing metadata renderer
+	err := injector.Inject(context.Background(), "talos")
+	require.ErrorContains(t, err, "metadata renderer is not defined")
+
+	// missing hostname
+	injector.MetaRenderer = cloudinit.NewMetadata("xxxx-xxxxx", "", "1.2.3", false)
+	err = injector.Inject(context.Background(), "talos")
+	require.ErrorContains(t, err, "unable to render metadata")
+
+	// patch failed - no machine config
+	injector.MetaRenderer = cloudinit.NewMetadata("xxxx-xxxxx", "my-custom-vm", "1.2.3", true)
+	p.BootstrapData = []byte("kind: ExtensionServiceConfig")
+	err = injector.Inject(context.Background(), "talos")
+	require.ErrorIs(t, err, talos.ErrMissingMachineConfig)
+
+	// no patcher
+	injector.TalosPatcher = nil
+	err = injector.Inject(context.Background(), "talos")
+	require.ErrorContains(t, err, "talos patcher is not defined")
+}
+
 func TestISOInjectorInject_Unsupported(t *testing.T) {
 	vm := &proxmox.VirtualMachine{
 		Node: "pve",
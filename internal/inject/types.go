@@ -19,6 +19,7 @@ package inject
 import (
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/cloudinit"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/ignition"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/talos"
 )
 
 // BootstrapDataFormat represents the format of the bootstrap data.
@@ -29,4 +30,6 @@ const (
 	CloudConfigFormat BootstrapDataFormat = cloudinit.FormatCloudConfig
 	// IgnitionFormat represents the Ignition format.
 	IgnitionFormat BootstrapDataFormat = ignition.FormatIgnition
+	// TalosFormat represents the Talos machine config format.
+	TalosFormat BootstrapDataFormat = talos.FormatTalos
 )
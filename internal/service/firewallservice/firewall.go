@@ -0,0 +1,201 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package firewallservice implements the management of the Proxmox firewall IPSet owned by a ProxmoxCluster.
+package firewallservice
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// ErrIPSetConflict is returned when the IPSet of the cluster already exists in Proxmox
+// but was not created by the cluster.
+var ErrIPSetConflict = errors.New("ipset exists but is not owned by the cluster")
+
+// ReconcileFirewall makes sure the IPSet of the cluster exists in Proxmox and contains
+// the addresses of all machines of the cluster and the additional CIDRs.
+func ReconcileFirewall(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+	if proxmoxCluster.Spec.Firewall == nil {
+		return nil
+	}
+
+	if err := reconcileIPSet(ctx, clusterScope); err != nil {
+		reason := infrav1.ProxmoxClusterFirewallReadyFailedReason
+		if errors.Is(err, ErrIPSetConflict) {
+			reason = infrav1.ProxmoxClusterFirewallReadyConflictReason
+		}
+		conditions.Set(proxmoxCluster, metav1.Condition{
+			Type:    infrav1.ProxmoxClusterFirewallReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		})
+		return err
+	}
+
+	conditions.Set(proxmoxCluster, metav1.Condition{
+		Type:   infrav1.ProxmoxClusterFirewallReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ProvisionedReason,
+	})
+
+	return nil
+}
+
+func reconcileIPSet(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	client := clusterScope.ProxmoxClient
+	name := clusterScope.ProxmoxCluster.GetFirewallIPSetName()
+
+	ipsets, err := client.GetFirewallIPSets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list firewall ipsets")
+	}
+	index := slices.IndexFunc(ipsets, func(s *proxmox.FirewallIPSet) bool { return s.Name == name })
+	switch {
+	case index == -1:
+		clusterScope.Logger.Info("creating firewall ipset", "ipset", name)
+		if err := client.CreateFirewallIPSet(ctx, name, ipSetComment(clusterScope)); err != nil {
+			return err
+		}
+	case ipsets[index].Comment != ipSetComment(clusterScope):
+		return errors.Wrapf(ErrIPSetConflict, "ipset %s", name)
+	}
+
+	desired, err := desiredEntries(ctx, clusterScope)
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.GetFirewallIPSetEntries(ctx, name)
+	if err != nil {
+		return errors.Wrapf(err, "unable to list entries of firewall ipset %s", name)
+	}
+	current := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		cidr := normalizeCIDR(entry.CIDR)
+		if !slices.Contains(desired, cidr) {
+			clusterScope.Logger.Info("removing address from firewall ipset", "ipset", name, "cidr", entry.CIDR)
+			if err := client.DeleteFirewallIPSetEntry(ctx, name, entry.CIDR); err != nil {
+				return err
+			}
+			continue
+		}
+		current = append(current, cidr)
+	}
+
+	for _, cidr := range desired {
+		if slices.Contains(current, cidr) {
+			continue
+		}
+		clusterScope.Logger.Info("adding address to firewall ipset", "ipset", name, "cidr", cidr)
+		if err := client.AddFirewallIPSetEntry(ctx, name, cidr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// desiredEntries returns the addresses of all machines of the cluster and the additional
+// CIDRs of the IPSet, normalized and without duplicates.
+func desiredEntries(ctx context.Context, clusterScope *scope.ClusterScope) ([]string, error) {
+	machines, err := clusterScope.ListProxmoxMachinesForCluster(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list proxmox machines")
+	}
+
+	var desired []string
+	add := func(cidr string) {
+		cidr = normalizeCIDR(cidr)
+		if !slices.Contains(desired, cidr) {
+			desired = append(desired, cidr)
+		}
+	}
+
+	for _, machine := range machines {
+		for _, addresses := range machine.Status.IPAddresses {
+			for _, address := range addresses.IPv4 {
+				add(address)
+			}
+			for _, address := range addresses.IPv6 {
+				add(address)
+			}
+		}
+	}
+
+	if ipSet := clusterScope.ProxmoxCluster.Spec.Firewall.IPSet; ipSet != nil {
+		for _, cidr := range ipSet.AdditionalCIDRs {
+			add(cidr)
+		}
+	}
+
+	return desired, nil
+}
+
+// ReconcileFirewallDelete removes the IPSet of the cluster from Proxmox if it is owned by the cluster.
+func ReconcileFirewallDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	if clusterScope.ProxmoxCluster.Spec.Firewall == nil {
+		return nil
+	}
+	client := clusterScope.ProxmoxClient
+	name := clusterScope.ProxmoxCluster.GetFirewallIPSetName()
+
+	ipsets, err := client.GetFirewallIPSets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list firewall ipsets")
+	}
+	if !slices.ContainsFunc(ipsets, func(s *proxmox.FirewallIPSet) bool {
+		return s.Name == name && s.Comment == ipSetComment(clusterScope)
+	}) {
+		return nil
+	}
+
+	clusterScope.Logger.Info("deleting firewall ipset", "ipset", name)
+	return client.DeleteFirewallIPSet(ctx, name)
+}
+
+// ipSetComment marks the IPSet as owned by the cluster.
+func ipSetComment(clusterScope *scope.ClusterScope) string {
+	return fmt.Sprintf("managed by cluster-api-provider-proxmox for %s/%s", clusterScope.Namespace(), clusterScope.InfraClusterName())
+}
+
+// normalizeCIDR returns the notation Proxmox uses for an IPSet entry: single addresses
+// without a prefix length and networks in their canonical form.
+func normalizeCIDR(cidr string) string {
+	if prefix, err := netip.ParsePrefix(cidr); err == nil {
+		if prefix.IsSingleIP() {
+			return prefix.Addr().String()
+		}
+		return prefix.Masked().String()
+	}
+	if addr, err := netip.ParseAddr(cidr); err == nil {
+		return addr.String()
+	}
+	return cidr
+}
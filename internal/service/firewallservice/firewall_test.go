@@ -0,0 +1,150 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewallservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/proxmoxtest"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/test/helpers"
+)
+
+const testComment = "managed by cluster-api-provider-proxmox for default/test"
+
+func setupFirewallTest(t *testing.T) (*scope.ClusterScope, *proxmoxtest.MockClient) {
+	t.Helper()
+
+	infraCluster := &infrav1.ProxmoxCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: infrav1.ProxmoxClusterSpec{
+			Firewall: &infrav1.ClusterFirewallSpec{
+				IPSet: &infrav1.FirewallIPSetSpec{
+					AdditionalCIDRs: []string{"192.168.1.10/32"},
+				},
+			},
+		},
+	}
+
+	machine := &infrav1.ProxmoxMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-machine",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test"},
+		},
+		Status: infrav1.ProxmoxMachineStatus{
+			IPAddresses: []infrav1.IPAddressesSpec{
+				{NetName: "net0", IPv4: []string{"10.0.0.10"}, IPv6: []string{"2001:db8::10"}},
+				{NetName: "net1", IPv4: []string{"10.1.0.10"}},
+			},
+		},
+	}
+
+	return helpers.NewClusterScope(t, infraCluster, machine)
+}
+
+func TestReconcileFirewall_NoSpec(t *testing.T) {
+	clusterScope, _ := setupFirewallTest(t)
+	clusterScope.ProxmoxCluster.Spec.Firewall = nil
+
+	require.NoError(t, ReconcileFirewall(context.Background(), clusterScope))
+	require.Nil(t, conditions.Get(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterFirewallReadyCondition))
+}
+
+func TestReconcileFirewall_Create(t *testing.T) {
+	clusterScope, mockClient := setupFirewallTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetFirewallIPSets(ctx).Return(nil, nil).Once()
+	mockClient.EXPECT().CreateFirewallIPSet(ctx, "capmox-test", testComment).Return(nil).Once()
+	mockClient.EXPECT().GetFirewallIPSetEntries(ctx, "capmox-test").Return(nil, nil).Once()
+	mockClient.EXPECT().AddFirewallIPSetEntry(ctx, "capmox-test", "10.0.0.10").Return(nil).Once()
+	mockClient.EXPECT().AddFirewallIPSetEntry(ctx, "capmox-test", "2001:db8::10").Return(nil).Once()
+	mockClient.EXPECT().AddFirewallIPSetEntry(ctx, "capmox-test", "10.1.0.10").Return(nil).Once()
+	mockClient.EXPECT().AddFirewallIPSetEntry(ctx, "capmox-test", "192.168.1.10").Return(nil).Once()
+
+	require.NoError(t, ReconcileFirewall(ctx, clusterScope))
+	require.True(t, conditions.IsTrue(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterFirewallReadyCondition))
+}
+
+func TestReconcileFirewall_Update(t *testing.T) {
+	clusterScope, mockClient := setupFirewallTest(t)
+	ctx := context.Background()
+	clusterScope.ProxmoxCluster.Spec.Firewall.IPSet.Name = new("k8s-members")
+
+	mockClient.EXPECT().GetFirewallIPSets(ctx).Return([]*proxmox.FirewallIPSet{{Name: "k8s-members", Comment: testComment}}, nil).Once()
+	mockClient.EXPECT().GetFirewallIPSetEntries(ctx, "k8s-members").Return([]*proxmox.FirewallIPSetEntry{
+		{CIDR: "10.0.0.10"},
+		{CIDR: "2001:db8:0::10/128"},
+		{CIDR: "10.1.0.10"},
+		{CIDR: "192.168.1.10"},
+		{CIDR: "10.0.0.11"},
+	}, nil).Once()
+	mockClient.EXPECT().DeleteFirewallIPSetEntry(ctx, "k8s-members", "10.0.0.11").Return(nil).Once()
+
+	require.NoError(t, ReconcileFirewall(ctx, clusterScope))
+	require.True(t, conditions.IsTrue(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterFirewallReadyCondition))
+}
+
+func TestReconcileFirewall_Conflict(t *testing.T) {
+	clusterScope, mockClient := setupFirewallTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetFirewallIPSets(ctx).Return([]*proxmox.FirewallIPSet{{Name: "capmox-test"}}, nil).Once()
+
+	require.ErrorIs(t, ReconcileFirewall(ctx, clusterScope), ErrIPSetConflict)
+	require.Equal(t, infrav1.ProxmoxClusterFirewallReadyConflictReason,
+		conditions.GetReason(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterFirewallReadyCondition))
+}
+
+func TestReconcileFirewallDelete(t *testing.T) {
+	clusterScope, mockClient := setupFirewallTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetFirewallIPSets(ctx).Return([]*proxmox.FirewallIPSet{{Name: "capmox-test", Comment: testComment}}, nil).Once()
+	mockClient.EXPECT().DeleteFirewallIPSet(ctx, "capmox-test").Return(nil).Once()
+
+	require.NoError(t, ReconcileFirewallDelete(ctx, clusterScope))
+}
+
+func TestReconcileFirewallDelete_NotOwned(t *testing.T) {
+	clusterScope, mockClient := setupFirewallTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetFirewallIPSets(ctx).Return([]*proxmox.FirewallIPSet{{Name: "capmox-test"}}, nil).Once()
+
+	require.NoError(t, ReconcileFirewallDelete(ctx, clusterScope))
+}
+
+func TestNormalizeCIDR(t *testing.T) {
+	require.Equal(t, "10.0.0.1", normalizeCIDR("10.0.0.1"))
+	require.Equal(t, "10.0.0.1", normalizeCIDR("10.0.0.1/32"))
+	require.Equal(t, "10.0.0.0/24", normalizeCIDR("10.0.0.5/24"))
+	require.Equal(t, "2001:db8::1", normalizeCIDR("2001:DB8:0::1"))
+	require.Equal(t, "invalid", normalizeCIDR("invalid"))
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanservice
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	orphanedVMs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capmox_orphaned_virtual_machines",
+		Help: "Number of virtual machines which carry the ownership tag of a ProxmoxCluster but belong to none of its ProxmoxMachines.",
+	}, []string{"namespace", "proxmoxcluster"})
+
+	deletedOrphanedVMs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capmox_orphaned_virtual_machines_deleted_total",
+		Help: "Number of orphaned virtual machines deleted for a ProxmoxCluster.",
+	}, []string{"namespace", "proxmoxcluster"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(orphanedVMs, deletedOrphanedVMs)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orphanservice implements the detection and removal of virtual machines which carry
+// the ownership tag of a ProxmoxCluster, but belong to none of its ProxmoxMachines.
+package orphanservice
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/service/vmservice"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// ScanInterval is the interval in which the virtual machines of a cluster are checked for orphans.
+const ScanInterval = 5 * time.Minute
+
+// ReconcileOrphanedVMs compares the virtual machines which carry the ownership tag of the cluster
+// with its ProxmoxMachines and records the orphans in the status of the ProxmoxCluster.
+// If the policy of the cluster says so, orphans are deleted once the grace period passed.
+func ReconcileOrphanedVMs(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+
+	vms, err := clusterScope.ProxmoxClient.FindVMResourcesByTag(ctx, clusterScope.VMOwnerTag())
+	if err != nil {
+		return errors.Wrap(err, "unable to list virtual machines")
+	}
+
+	machines, err := clusterScope.ListProxmoxMachinesForCluster(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list proxmox machines")
+	}
+	owned := make(map[int64]bool, len(machines))
+	for _, machine := range machines {
+		if machine.Spec.VirtualMachineID != nil {
+			owned[*machine.Spec.VirtualMachineID] = true
+		}
+	}
+
+	now := metav1.Now()
+	var orphans []infrav1.OrphanedVM
+	for _, vm := range vms {
+		vmID := int64(vm.VMID)
+		if owned[vmID] {
+			continue
+		}
+
+		orphan := infrav1.OrphanedVM{VMID: vmID, Name: vm.Name, Node: vm.Node, DetectedAt: now}
+		if i := slices.IndexFunc(proxmoxCluster.Status.OrphanedVMs, func(o infrav1.OrphanedVM) bool { return o.VMID == vmID }); i >= 0 {
+			orphan.DetectedAt = proxmoxCluster.Status.OrphanedVMs[i].DetectedAt
+		} else {
+			clusterScope.Info("found orphaned virtual machine", "vmID", vmID, "name", vm.Name, "node", vm.Node)
+			record.Warnf(proxmoxCluster, "OrphanedVirtualMachine",
+				"Virtual machine %d (%s) on node %s carries the tag %s but belongs to no ProxmoxMachine",
+				vmID, vm.Name, vm.Node, clusterScope.VMOwnerTag())
+		}
+		orphans = append(orphans, orphan)
+	}
+	slices.SortFunc(orphans, func(a, b infrav1.OrphanedVM) int { return cmp.Compare(a.VMID, b.VMID) })
+	proxmoxCluster.Status.OrphanedVMs = orphans
+	orphanedVMs.WithLabelValues(clusterScope.Namespace(), clusterScope.InfraClusterName()).Set(float64(len(orphans)))
+
+	if proxmoxCluster.GetOrphanedVMAction() != infrav1.OrphanedVMActionDelete {
+		return nil
+	}
+
+	var failed []string
+	gracePeriod := proxmoxCluster.GetOrphanedVMGracePeriod()
+	for _, orphan := range orphans {
+		if now.Sub(orphan.DetectedAt.Time) < gracePeriod {
+			continue
+		}
+		if err := deleteOrphanedVM(ctx, clusterScope, orphan); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("unable to delete orphaned virtual machines: %s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+func deleteOrphanedVM(ctx context.Context, clusterScope *scope.ClusterScope, orphan infrav1.OrphanedVM) error {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+
+	// the tag is easily copied, only VMs with the ownership markers of the cluster are deleted.
+	vm, err := clusterScope.ProxmoxClient.GetVM(ctx, orphan.Node, orphan.VMID)
+	if err != nil {
+		if vmservice.VMNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "virtual machine %d", orphan.VMID)
+	}
+	if !vmservice.IsOwnedByCluster(vm.VirtualMachineConfig.Description, clusterScope) {
+		clusterScope.Info("not deleting orphaned virtual machine without ownership markers", "vmID", orphan.VMID, "name", orphan.Name, "node", orphan.Node)
+		return nil
+	}
+	if err := vmservice.DeregisterHAResource(ctx, clusterScope, vm); err != nil {
+		return errors.Wrapf(err, "virtual machine %d", orphan.VMID)
+	}
+
+	if _, err := clusterScope.ProxmoxClient.DeleteVM(ctx, orphan.Node, orphan.VMID); err != nil {
+		if errors.Is(err, goproxmox.ErrVMIDFree) {
+			return nil
+		}
+		record.Warnf(proxmoxCluster, "OrphanedVirtualMachineDeletionFailed",
+			"Failed to delete orphaned virtual machine %d on node %s: %s", orphan.VMID, orphan.Node, err)
+		return errors.Wrapf(err, "virtual machine %d", orphan.VMID)
+	}
+
+	clusterScope.Info("deleting orphaned virtual machine", "vmID", orphan.VMID, "name", orphan.Name, "node", orphan.Node)
+	record.Eventf(proxmoxCluster, "DeletingOrphanedVirtualMachine",
+		"Deleting virtual machine %d (%s) on node %s, orphaned since %s",
+		orphan.VMID, orphan.Name, orphan.Node, orphan.DetectedAt.UTC().Format(time.RFC3339))
+	deletedOrphanedVMs.WithLabelValues(clusterScope.Namespace(), clusterScope.InfraClusterName()).Inc()
+
+	return nil
+}
+
+// ReconcileOrphanedVMsDelete removes the metrics of a deleted cluster.
+func ReconcileOrphanedVMsDelete(clusterScope *scope.ClusterScope) {
+	orphanedVMs.DeleteLabelValues(clusterScope.Namespace(), clusterScope.InfraClusterName())
+	deletedOrphanedVMs.DeleteLabelValues(clusterScope.Namespace(), clusterScope.InfraClusterName())
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/proxmoxtest"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/test/helpers"
+)
+
+const testTag = "capmox-cluster.default.test"
+
+func setupOrphanTest(t *testing.T) (*scope.ClusterScope, *proxmoxtest.MockClient) {
+	t.Helper()
+
+	infraCluster := &infrav1.ProxmoxCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	machine := &infrav1.ProxmoxMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-machine",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test"},
+		},
+		Spec: infrav1.ProxmoxMachineSpec{
+			VirtualMachineID: new(int64(100)),
+		},
+	}
+
+	return helpers.NewClusterScope(t, infraCluster, machine)
+}
+
+// ownedVM returns a VM which carries the ownership markers of the test cluster.
+func ownedVM() *proxmox.VirtualMachine {
+	return &proxmox.VirtualMachine{
+		VirtualMachineConfig: &proxmox.VirtualMachineConfig{
+			Description: "[capmox]\ncluster = default/test\nproxmoxmachine = test-orphan\nuid = orphan-uid",
+		},
+	}
+}
+
+func taggedVMs() []*proxmox.ClusterResource {
+	return []*proxmox.ClusterResource{
+		{VMID: 100, Name: "test-machine", Node: "node1", Tags: testTag},
+		{VMID: 101, Name: "test-orphan", Node: "node2", Tags: testTag},
+	}
+}
+
+func TestReconcileOrphanedVMs_Report(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	require.Equal(t, testTag, clusterScope.VMOwnerTag())
+
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(taggedVMs(), nil).Once()
+
+	require.NoError(t, ReconcileOrphanedVMs(context.Background(), clusterScope))
+
+	orphans := clusterScope.ProxmoxCluster.Status.OrphanedVMs
+	require.Len(t, orphans, 1)
+	require.Equal(t, int64(101), orphans[0].VMID)
+	require.Equal(t, "test-orphan", orphans[0].Name)
+	require.Equal(t, "node2", orphans[0].Node)
+	require.False(t, orphans[0].DetectedAt.IsZero())
+	require.InDelta(t, 1, testutil.ToFloat64(orphanedVMs.WithLabelValues("default", "test")), 0)
+}
+
+func TestReconcileOrphanedVMs_ReportKeepsDetectedAt(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	detectedAt := metav1.NewTime(time.Now().Add(-48 * time.Hour).Truncate(time.Second))
+	clusterScope.ProxmoxCluster.Status.OrphanedVMs = []infrav1.OrphanedVM{
+		{VMID: 101, Name: "test-orphan", Node: "node2", DetectedAt: detectedAt},
+		{VMID: 102, Name: "gone", Node: "node2", DetectedAt: detectedAt},
+	}
+
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(taggedVMs(), nil).Once()
+
+	require.NoError(t, ReconcileOrphanedVMs(context.Background(), clusterScope))
+
+	orphans := clusterScope.ProxmoxCluster.Status.OrphanedVMs
+	require.Len(t, orphans, 1)
+	require.Equal(t, int64(101), orphans[0].VMID)
+	require.Equal(t, detectedAt, orphans[0].DetectedAt)
+}
+
+func TestReconcileOrphanedVMs_Delete(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	clusterScope.ProxmoxCluster.Spec.OrphanedVMPolicy = &infrav1.OrphanedVMPolicy{
+		Action:             infrav1.OrphanedVMActionDelete,
+		GracePeriodSeconds: new(int32(600)),
+	}
+	clusterScope.ProxmoxCluster.Status.OrphanedVMs = []infrav1.OrphanedVM{
+		{VMID: 101, Name: "test-orphan", Node: "node2", DetectedAt: metav1.NewTime(time.Now().Add(-time.Hour))},
+	}
+
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(taggedVMs(), nil).Once()
+	mockClient.EXPECT().GetVM(context.Background(), "node2", int64(101)).Return(ownedVM(), nil).Once()
+	mockClient.EXPECT().DeleteVM(context.Background(), "node2", int64(101)).Return(&proxmox.Task{}, nil).Once()
+
+	require.NoError(t, ReconcileOrphanedVMs(context.Background(), clusterScope))
+	// the orphan is reported until it is gone.
+	require.Len(t, clusterScope.ProxmoxCluster.Status.OrphanedVMs, 1)
+}
+
+func TestReconcileOrphanedVMs_DeleteDeregistersHAResource(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	clusterScope.ProxmoxCluster.Spec.OrphanedVMPolicy = &infrav1.OrphanedVMPolicy{
+		Action:             infrav1.OrphanedVMActionDelete,
+		GracePeriodSeconds: new(int32(0)),
+	}
+	vm := ownedVM()
+	vm.VMID = 101
+	vm.HA.Managed = 1
+
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(taggedVMs(), nil).Once()
+	mockClient.EXPECT().GetVM(context.Background(), "node2", int64(101)).Return(vm, nil).Once()
+	deregister := mockClient.EXPECT().DeleteHAResource(context.Background(), "vm:101").Return(nil).Once()
+	mockClient.EXPECT().DeleteVM(context.Background(), "node2", int64(101)).Return(&proxmox.Task{}, nil).Once().NotBefore(deregister)
+
+	require.NoError(t, ReconcileOrphanedVMs(context.Background(), clusterScope))
+}
+
+func TestReconcileOrphanedVMs_DeleteRequiresOwnershipMarkers(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	clusterScope.ProxmoxCluster.Spec.OrphanedVMPolicy = &infrav1.OrphanedVMPolicy{
+		Action:             infrav1.OrphanedVMActionDelete,
+		GracePeriodSeconds: new(int32(0)),
+	}
+	vm := ownedVM()
+	vm.VirtualMachineConfig.Description = "tagged by hand"
+
+	// the VM carries the tag, but not the markers of the cluster, so no DeleteVM call is expected.
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(taggedVMs(), nil).Once()
+	mockClient.EXPECT().GetVM(context.Background(), "node2", int64(101)).Return(vm, nil).Once()
+
+	require.NoError(t, ReconcileOrphanedVMs(context.Background(), clusterScope))
+	require.Len(t, clusterScope.ProxmoxCluster.Status.OrphanedVMs, 1)
+}
+
+func TestReconcileOrphanedVMs_DeleteWaitsForGracePeriod(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	clusterScope.ProxmoxCluster.Spec.OrphanedVMPolicy = &infrav1.OrphanedVMPolicy{
+		Action: infrav1.OrphanedVMActionDelete,
+	}
+
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(taggedVMs(), nil).Once()
+
+	require.NoError(t, ReconcileOrphanedVMs(context.Background(), clusterScope))
+	require.Len(t, clusterScope.ProxmoxCluster.Status.OrphanedVMs, 1)
+}
+
+func TestReconcileOrphanedVMs_DeleteFailed(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	clusterScope.ProxmoxCluster.Spec.OrphanedVMPolicy = &infrav1.OrphanedVMPolicy{
+		Action:             infrav1.OrphanedVMActionDelete,
+		GracePeriodSeconds: new(int32(0)),
+	}
+	vms := append(taggedVMs(), &proxmox.ClusterResource{VMID: 102, Name: "gone", Node: "node1", Tags: testTag})
+
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(vms, nil).Once()
+	mockClient.EXPECT().GetVM(context.Background(), "node2", int64(101)).Return(ownedVM(), nil).Once()
+	mockClient.EXPECT().DeleteVM(context.Background(), "node2", int64(101)).Return(nil, errors.New("permission denied")).Once()
+	mockClient.EXPECT().GetVM(context.Background(), "node1", int64(102)).Return(ownedVM(), nil).Once()
+	mockClient.EXPECT().DeleteVM(context.Background(), "node1", int64(102)).Return(nil, goproxmox.ErrVMIDFree).Once()
+
+	err := ReconcileOrphanedVMs(context.Background(), clusterScope)
+	require.EqualError(t, err, "unable to delete orphaned virtual machines: virtual machine 101: permission denied")
+	require.Len(t, clusterScope.ProxmoxCluster.Status.OrphanedVMs, 2)
+}
+
+func TestReconcileOrphanedVMs_ListFailed(t *testing.T) {
+	clusterScope, mockClient := setupOrphanTest(t)
+	clusterScope.ProxmoxCluster.Status.OrphanedVMs = []infrav1.OrphanedVM{{VMID: 101, DetectedAt: metav1.Now()}}
+
+	mockClient.EXPECT().FindVMResourcesByTag(context.Background(), testTag).Return(nil, errors.New("unauthorized")).Once()
+
+	require.Error(t, ReconcileOrphanedVMs(context.Background(), clusterScope))
+	// the last known orphans are kept.
+	require.Len(t, clusterScope.ProxmoxCluster.Status.OrphanedVMs, 1)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package poolservice implements the management of the Proxmox resource pool owned by a ProxmoxCluster.
+package poolservice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// ErrPoolConflict is returned when the pool of the cluster already exists in Proxmox
+// but was not created by the cluster.
+var ErrPoolConflict = errors.New("pool exists but is not owned by the cluster")
+
+// ReconcilePool makes sure the pool of the cluster exists in Proxmox, contains the
+// virtual machines of the cluster and grants exactly the ACLs of the spec.
+func ReconcilePool(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+	if proxmoxCluster.Spec.Pool == nil {
+		return nil
+	}
+
+	if err := reconcilePool(ctx, clusterScope); err != nil {
+		reason := infrav1.ProxmoxClusterPoolReadyFailedReason
+		if errors.Is(err, ErrPoolConflict) {
+			reason = infrav1.ProxmoxClusterPoolReadyConflictReason
+		}
+		conditions.Set(proxmoxCluster, metav1.Condition{
+			Type:    infrav1.ProxmoxClusterPoolReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		})
+		return err
+	}
+
+	conditions.Set(proxmoxCluster, metav1.Condition{
+		Type:   infrav1.ProxmoxClusterPoolReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ProvisionedReason,
+	})
+
+	return nil
+}
+
+func reconcilePool(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	client := clusterScope.ProxmoxClient
+	name := clusterScope.ProxmoxCluster.GetPoolName()
+
+	pools, err := client.GetPools(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list pools")
+	}
+	index := slices.IndexFunc(pools, func(p *proxmox.Pool) bool { return p.PoolID == name })
+	switch {
+	case index == -1:
+		clusterScope.Logger.Info("creating pool", "pool", name)
+		if err := client.CreatePool(ctx, name, poolComment(clusterScope)); err != nil {
+			return err
+		}
+	case pools[index].Comment != poolComment(clusterScope):
+		return errors.Wrapf(ErrPoolConflict, "pool %s", name)
+	}
+
+	if err := reconcileMembers(ctx, clusterScope, name); err != nil {
+		return err
+	}
+
+	return reconcileACLs(ctx, clusterScope, name)
+}
+
+// reconcileMembers adds the virtual machines of the cluster to the pool. New virtual machines are
+// cloned into the pool, this picks up the ones which were created before the pool was configured.
+func reconcileMembers(ctx context.Context, clusterScope *scope.ClusterScope, name string) error {
+	client := clusterScope.ProxmoxClient
+
+	machines, err := clusterScope.ListProxmoxMachinesForCluster(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list proxmox machines")
+	}
+
+	pool, err := client.GetPool(ctx, name)
+	if err != nil {
+		return err
+	}
+	members := make(map[int64]bool, len(pool.Members))
+	for _, member := range pool.Members {
+		if member.Type == "qemu" {
+			members[int64(member.VMID)] = true
+		}
+	}
+
+	var missing []int64
+	for _, machine := range machines {
+		// machines with a pool of their own and machines which are still being provisioned are skipped.
+		if machine.Spec.Pool != nil || machine.Spec.VirtualMachineID == nil ||
+			!ptr.Deref(machine.Status.Initialization.Provisioned, false) {
+			continue
+		}
+		if vmID := *machine.Spec.VirtualMachineID; !members[vmID] {
+			missing = append(missing, vmID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	clusterScope.Logger.Info("adding virtual machines to pool", "pool", name, "vmIDs", missing)
+	return client.AddPoolVMs(ctx, name, missing)
+}
+
+// reconcileACLs grants the ACLs of the spec on the pool and revokes all others.
+func reconcileACLs(ctx context.Context, clusterScope *scope.ClusterScope, name string) error {
+	client := clusterScope.ProxmoxClient
+	desired := desiredACLs(clusterScope.ProxmoxCluster.Spec.Pool)
+
+	current, err := client.GetPoolACLs(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	for _, acl := range current {
+		if slices.ContainsFunc(desired, func(d *proxmox.ACL) bool { return sameACL(d, acl) }) {
+			continue
+		}
+		clusterScope.Logger.Info("revoking pool acl", "pool", name, "ugid", acl.UGID, "role", acl.RoleID)
+		if err := client.UpdatePoolACL(ctx, name, acl, true); err != nil {
+			return err
+		}
+	}
+
+	for _, acl := range desired {
+		if slices.ContainsFunc(current, func(c *proxmox.ACL) bool { return sameACL(c, acl) }) {
+			continue
+		}
+		clusterScope.Logger.Info("granting pool acl", "pool", name, "ugid", acl.UGID, "role", acl.RoleID)
+		if err := client.UpdatePoolACL(ctx, name, acl, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func desiredACLs(spec *infrav1.ClusterPoolSpec) []*proxmox.ACL {
+	acls := make([]*proxmox.ACL, 0, len(spec.ACLs))
+	for _, acl := range spec.ACLs {
+		entry := &proxmox.ACL{RoleID: acl.GetRole()}
+		if acl.Group != nil {
+			entry.Type = "group"
+			entry.UGID = *acl.Group
+		} else {
+			entry.Type = "user"
+			entry.UGID = ptr.Deref(acl.User, "")
+		}
+		acls = append(acls, entry)
+	}
+	return acls
+}
+
+func sameACL(a, b *proxmox.ACL) bool {
+	return a.Type == b.Type && a.UGID == b.UGID && a.RoleID == b.RoleID
+}
+
+// ReconcilePoolDelete removes the pool of the cluster from Proxmox if it is owned by the cluster.
+// A pool which still has members, e.g. virtual machines added by hand, is kept.
+func ReconcilePoolDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	if clusterScope.ProxmoxCluster.Spec.Pool == nil {
+		return nil
+	}
+	client := clusterScope.ProxmoxClient
+	name := clusterScope.ProxmoxCluster.GetPoolName()
+
+	pools, err := client.GetPools(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list pools")
+	}
+	if !slices.ContainsFunc(pools, func(p *proxmox.Pool) bool {
+		return p.PoolID == name && p.Comment == poolComment(clusterScope)
+	}) {
+		return nil
+	}
+
+	pool, err := client.GetPool(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(pool.Members) > 0 {
+		clusterScope.Logger.Info("not deleting pool with members", "pool", name, "members", len(pool.Members))
+		record.Warnf(clusterScope.ProxmoxCluster, "PoolNotEmpty", "Not deleting pool %s, it still has %d members", name, len(pool.Members))
+		return nil
+	}
+
+	// Proxmox removes the ACLs of the pool together with the pool.
+	clusterScope.Logger.Info("deleting pool", "pool", name)
+	return client.DeletePool(ctx, name)
+}
+
+// poolComment marks the pool as owned by the cluster.
+func poolComment(clusterScope *scope.ClusterScope) string {
+	return fmt.Sprintf("managed by cluster-api-provider-proxmox for %s/%s", clusterScope.Namespace(), clusterScope.InfraClusterName())
+}
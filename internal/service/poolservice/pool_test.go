@@ -0,0 +1,194 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/proxmoxtest"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/test/helpers"
+)
+
+const testComment = "managed by cluster-api-provider-proxmox for default/test"
+
+func setupPoolTest(t *testing.T) (*scope.ClusterScope, *proxmoxtest.MockClient) {
+	t.Helper()
+
+	infraCluster := &infrav1.ProxmoxCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: infrav1.ProxmoxClusterSpec{
+			Pool: &infrav1.ClusterPoolSpec{
+				ACLs: []infrav1.PoolACL{{User: new("tenant@pve")}},
+			},
+		},
+	}
+
+	provisioned := infrav1.ProxmoxMachineStatus{
+		Initialization: infrav1.ProxmoxMachineInitializationStatus{Provisioned: new(true)},
+	}
+	machines := []*infrav1.ProxmoxMachine{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-machine",
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "test"},
+			},
+			Spec:   infrav1.ProxmoxMachineSpec{VirtualMachineID: new(int64(100))},
+			Status: provisioned,
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-own-pool",
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "test"},
+			},
+			Spec: infrav1.ProxmoxMachineSpec{
+				VirtualMachineID:        new(int64(101)),
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{Pool: new("other")},
+			},
+			Status: provisioned,
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cloning",
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "test"},
+			},
+			Spec: infrav1.ProxmoxMachineSpec{VirtualMachineID: new(int64(102))},
+		},
+	}
+
+	return helpers.NewClusterScope(t, infraCluster, machines[0], machines[1], machines[2])
+}
+
+func TestReconcilePool_NoSpec(t *testing.T) {
+	clusterScope, _ := setupPoolTest(t)
+	clusterScope.ProxmoxCluster.Spec.Pool = nil
+
+	require.NoError(t, ReconcilePool(context.Background(), clusterScope))
+	require.Nil(t, conditions.Get(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterPoolReadyCondition))
+}
+
+func TestReconcilePool_Create(t *testing.T) {
+	clusterScope, mockClient := setupPoolTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetPools(ctx).Return(nil, nil).Once()
+	mockClient.EXPECT().CreatePool(ctx, "capmox-test", testComment).Return(nil).Once()
+	mockClient.EXPECT().GetPool(ctx, "capmox-test").Return(&proxmox.Pool{PoolID: "capmox-test"}, nil).Once()
+	// only the provisioned machine without a pool of its own is added.
+	mockClient.EXPECT().AddPoolVMs(ctx, "capmox-test", []int64{100}).Return(nil).Once()
+	mockClient.EXPECT().GetPoolACLs(ctx, "capmox-test").Return(nil, nil).Once()
+	mockClient.EXPECT().UpdatePoolACL(ctx, "capmox-test",
+		&proxmox.ACL{Type: "user", UGID: "tenant@pve", RoleID: "PVEAuditor"}, false).Return(nil).Once()
+
+	require.NoError(t, ReconcilePool(ctx, clusterScope))
+	require.True(t, conditions.IsTrue(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterPoolReadyCondition))
+}
+
+func TestReconcilePool_UpToDate(t *testing.T) {
+	clusterScope, mockClient := setupPoolTest(t)
+	ctx := context.Background()
+	acl := &proxmox.ACL{Path: "/pool/capmox-test", Type: "user", UGID: "tenant@pve", RoleID: "PVEAuditor", Propagate: true}
+
+	mockClient.EXPECT().GetPools(ctx).Return([]*proxmox.Pool{{PoolID: "capmox-test", Comment: testComment}}, nil).Once()
+	mockClient.EXPECT().GetPool(ctx, "capmox-test").Return(&proxmox.Pool{
+		PoolID:  "capmox-test",
+		Members: []proxmox.ClusterResource{{Type: "qemu", VMID: 100}},
+	}, nil).Once()
+	mockClient.EXPECT().GetPoolACLs(ctx, "capmox-test").Return([]*proxmox.ACL{acl}, nil).Once()
+
+	require.NoError(t, ReconcilePool(ctx, clusterScope))
+}
+
+func TestReconcilePool_RevokesACLs(t *testing.T) {
+	clusterScope, mockClient := setupPoolTest(t)
+	ctx := context.Background()
+	clusterScope.ProxmoxCluster.Spec.Pool = &infrav1.ClusterPoolSpec{
+		Name: new("tenant"),
+		ACLs: []infrav1.PoolACL{{Group: new("tenants"), Role: new("PVEVMUser")}},
+	}
+	stale := &proxmox.ACL{Path: "/pool/tenant", Type: "user", UGID: "tenant@pve", RoleID: "PVEAuditor"}
+
+	mockClient.EXPECT().GetPools(ctx).Return([]*proxmox.Pool{{PoolID: "tenant", Comment: testComment}}, nil).Once()
+	mockClient.EXPECT().GetPool(ctx, "tenant").Return(&proxmox.Pool{
+		PoolID:  "tenant",
+		Members: []proxmox.ClusterResource{{Type: "qemu", VMID: 100}},
+	}, nil).Once()
+	mockClient.EXPECT().GetPoolACLs(ctx, "tenant").Return([]*proxmox.ACL{stale}, nil).Once()
+	revoke := mockClient.EXPECT().UpdatePoolACL(ctx, "tenant", stale, true).Return(nil).Once()
+	mockClient.EXPECT().UpdatePoolACL(ctx, "tenant",
+		&proxmox.ACL{Type: "group", UGID: "tenants", RoleID: "PVEVMUser"}, false).Return(nil).Once().NotBefore(revoke)
+
+	require.NoError(t, ReconcilePool(ctx, clusterScope))
+}
+
+func TestReconcilePool_Conflict(t *testing.T) {
+	clusterScope, mockClient := setupPoolTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetPools(ctx).Return([]*proxmox.Pool{{PoolID: "capmox-test", Comment: "by hand"}}, nil).Once()
+
+	require.ErrorIs(t, ReconcilePool(ctx, clusterScope), ErrPoolConflict)
+	require.Equal(t, infrav1.ProxmoxClusterPoolReadyConflictReason,
+		conditions.GetReason(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterPoolReadyCondition))
+}
+
+func TestReconcilePoolDelete(t *testing.T) {
+	clusterScope, mockClient := setupPoolTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetPools(ctx).Return([]*proxmox.Pool{{PoolID: "capmox-test", Comment: testComment}}, nil).Once()
+	mockClient.EXPECT().GetPool(ctx, "capmox-test").Return(&proxmox.Pool{PoolID: "capmox-test"}, nil).Once()
+	mockClient.EXPECT().DeletePool(ctx, "capmox-test").Return(nil).Once()
+
+	require.NoError(t, ReconcilePoolDelete(ctx, clusterScope))
+}
+
+func TestReconcilePoolDelete_KeepsPoolWithMembers(t *testing.T) {
+	clusterScope, mockClient := setupPoolTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetPools(ctx).Return([]*proxmox.Pool{{PoolID: "capmox-test", Comment: testComment}}, nil).Once()
+	mockClient.EXPECT().GetPool(ctx, "capmox-test").Return(&proxmox.Pool{
+		PoolID:  "capmox-test",
+		Members: []proxmox.ClusterResource{{Type: "storage", Storage: "local"}},
+	}, nil).Once()
+
+	require.NoError(t, ReconcilePoolDelete(ctx, clusterScope))
+}
+
+func TestReconcilePoolDelete_NotOwned(t *testing.T) {
+	clusterScope, mockClient := setupPoolTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetPools(ctx).Return([]*proxmox.Pool{{PoolID: "capmox-test", Comment: "by hand"}}, nil).Once()
+
+	require.NoError(t, ReconcilePoolDelete(ctx, clusterScope))
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflightservice validates the Proxmox environment against the specs of a ProxmoxCluster
+// and its machines, before virtual machines are cloned.
+package preflightservice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// errNodesUnknown is reported by the checks which depend on the nodes if they could not be listed.
+var errNodesUnknown = errors.New("unable to determine the status of the nodes")
+
+// machineSpec is a machine spec which is checked, together with the object it was taken from.
+type machineSpec struct {
+	source  string
+	machine *infrav1.ProxmoxMachine
+}
+
+// preflight holds the state of one run of the pre-flight checks.
+// The Proxmox API is queried only once per node and run.
+type preflight struct {
+	clusterScope *scope.ClusterScope
+	specs        []machineSpec
+
+	nodes       map[string]*proxmox.NodeStatus
+	storages    map[string][]*proxmox.Storage
+	networks    map[string][]*proxmox.NodeNetwork
+	vmResources []*proxmox.ClusterResource
+}
+
+// ReconcilePreflight checks that the nodes, storages, bridges, templates and VMID ranges used by
+// the cluster exist in Proxmox and that the token has the privileges to use them, and reports
+// the results in conditions of the ProxmoxCluster.
+// Problems are only reported, they do not prevent the cluster from becoming ready.
+func ReconcilePreflight(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	specs, err := machineSpecs(ctx, clusterScope)
+	if err != nil {
+		return err
+	}
+
+	p := &preflight{
+		clusterScope: clusterScope,
+		specs:        specs,
+		storages:     map[string][]*proxmox.Storage{},
+		networks:     map[string][]*proxmox.NodeNetwork{},
+	}
+
+	problems, err := p.checkNodes(ctx)
+	setCondition(clusterScope, infrav1.ProxmoxClusterNodesAvailableCondition, problems, err)
+	problems, err = p.checkStorages(ctx)
+	setCondition(clusterScope, infrav1.ProxmoxClusterStoragesAvailableCondition, problems, err)
+	problems, err = p.checkBridges(ctx)
+	setCondition(clusterScope, infrav1.ProxmoxClusterBridgesAvailableCondition, problems, err)
+	problems, err = p.checkTemplates(ctx)
+	setCondition(clusterScope, infrav1.ProxmoxClusterTemplatesAvailableCondition, problems, err)
+	problems, err = p.checkVMIDs(ctx)
+	setCondition(clusterScope, infrav1.ProxmoxClusterVMIDsAvailableCondition, problems, err)
+	problems, err = p.checkPrivileges(ctx)
+	setCondition(clusterScope, infrav1.ProxmoxClusterPrivilegesAvailableCondition, problems, err)
+
+	return nil
+}
+
+// machineSpecs returns the specs of the ProxmoxMachineTemplates of the cluster and of the
+// ProxmoxMachines which are not provisioned yet. Provisioned machines are not checked again.
+func machineSpecs(ctx context.Context, clusterScope *scope.ClusterScope) ([]machineSpec, error) {
+	templates, err := clusterScope.ListProxmoxMachineTemplatesForCluster(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list proxmox machine templates")
+	}
+	machines, err := clusterScope.ListProxmoxMachinesForCluster(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list proxmox machines")
+	}
+
+	specs := make([]machineSpec, 0, len(templates)+len(machines))
+	for _, template := range templates {
+		specs = append(specs, machineSpec{
+			source:  "ProxmoxMachineTemplate " + template.Name,
+			machine: &infrav1.ProxmoxMachine{Spec: template.Spec.Template.Spec},
+		})
+	}
+	for _, machine := range machines {
+		if ptr.Deref(machine.Status.Initialization.Provisioned, false) || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		specs = append(specs, machineSpec{
+			source:  "ProxmoxMachine " + machine.Name,
+			machine: &machine,
+		})
+	}
+	return specs, nil
+}
+
+// nodesFor returns the nodes a machine can be cloned to. Without allowed nodes the
+// machine is cloned to the node of its template.
+func (p *preflight) nodesFor(machine *infrav1.ProxmoxMachine) []string {
+	if len(machine.Spec.AllowedNodes) > 0 {
+		return machine.Spec.AllowedNodes
+	}
+	if allowed := p.clusterScope.ProxmoxCluster.Spec.AllowedNodes; len(allowed) > 0 {
+		return allowed
+	}
+	if node := machine.GetSourceNode(); node != "" {
+		return []string{node}
+	}
+	return nil
+}
+
+// onlineNodesFor returns the nodes of a machine which are online. The other nodes
+// are reported by checkNodes and skipped by the checks of the node resources.
+func (p *preflight) onlineNodesFor(machine *infrav1.ProxmoxMachine) []string {
+	var nodes []string
+	for _, node := range p.nodesFor(machine) {
+		if status, ok := p.nodes[node]; ok && status.Status == "online" {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func (p *preflight) checkNodes(ctx context.Context) ([]string, error) {
+	nodes, err := p.clusterScope.ProxmoxClient.GetNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.nodes = make(map[string]*proxmox.NodeStatus, len(nodes))
+	for _, node := range nodes {
+		p.nodes[node.Node] = node
+	}
+
+	var problems []string
+	check := func(source, node string) {
+		status, ok := p.nodes[node]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: node %s does not exist", source, node))
+		case status.Status != "online":
+			problems = append(problems, fmt.Sprintf("%s: node %s is %s", source, node, status.Status))
+		}
+	}
+	for _, node := range p.clusterScope.ProxmoxCluster.Spec.AllowedNodes {
+		check("ProxmoxCluster "+p.clusterScope.InfraClusterName(), node)
+	}
+	for _, spec := range p.specs {
+		for _, node := range spec.machine.Spec.AllowedNodes {
+			check(spec.source, node)
+		}
+		if node := spec.machine.GetSourceNode(); node != "" {
+			check(spec.source, node)
+		}
+	}
+	return problems, nil
+}
+
+func (p *preflight) checkStorages(ctx context.Context) ([]string, error) {
+	if p.nodes == nil {
+		return nil, errNodesUnknown
+	}
+
+	var problems []string
+	for _, spec := range p.specs {
+		storage := ptr.Deref(spec.machine.Spec.Storage, "")
+		if storage == "" {
+			continue
+		}
+
+		for _, node := range p.onlineNodesFor(spec.machine) {
+			storages, err := p.nodeStorages(ctx, node)
+			if err != nil {
+				return nil, err
+			}
+			if problem := storageProblem(storages, storage); problem != "" {
+				problems = append(problems, fmt.Sprintf("%s: storage %s %s on node %s", spec.source, storage, problem, node))
+			}
+		}
+	}
+	return problems, nil
+}
+
+// storageProblem describes why a storage can not be used, or returns an empty string if it can.
+func storageProblem(storages []*proxmox.Storage, name string) string {
+	index := slices.IndexFunc(storages, func(s *proxmox.Storage) bool { return s.Name == name })
+	switch {
+	case index == -1:
+		return "does not exist"
+	case storages[index].Enabled == 0:
+		return "is disabled"
+	case storages[index].Active == 0:
+		return "is not active"
+	}
+	return ""
+}
+
+func (p *preflight) checkBridges(ctx context.Context) ([]string, error) {
+	if p.nodes == nil {
+		return nil, errNodesUnknown
+	}
+
+	var problems []string
+	for _, spec := range p.specs {
+		network := spec.machine.Spec.Network
+		if network == nil {
+			continue
+		}
+
+		for _, node := range p.onlineNodesFor(spec.machine) {
+			networks, err := p.nodeNetworks(ctx, node)
+			if err != nil {
+				return nil, err
+			}
+			for _, device := range network.NetworkDevices {
+				// VNets are managed by the SDN of the cluster.
+				if device.Bridge == nil || device.VNet != nil {
+					continue
+				}
+				index := slices.IndexFunc(networks, func(n *proxmox.NodeNetwork) bool {
+					return n.Iface == *device.Bridge && (n.Type == "bridge" || n.Type == "OVSBridge")
+				})
+				switch {
+				case index == -1:
+					problems = append(problems, fmt.Sprintf("%s: bridge %s does not exist on node %s", spec.source, *device.Bridge, node))
+				case device.VLAN != nil && networks[index].Type == "bridge" && networks[index].BridgeVLANAware == 0:
+					// Open vSwitch bridges always support VLAN tags.
+					problems = append(problems, fmt.Sprintf("%s: bridge %s is not VLAN aware on node %s", spec.source, *device.Bridge, node))
+				}
+			}
+		}
+	}
+	return problems, nil
+}
+
+func (p *preflight) checkTemplates(ctx context.Context) ([]string, error) {
+	var problems []string
+	for _, spec := range p.specs {
+		machine := spec.machine
+
+		if templateID := machine.GetTemplateID(); templateID != -1 {
+			resources, err := p.getVMResources(ctx)
+			if err != nil {
+				return nil, err
+			}
+			index := slices.IndexFunc(resources, func(r *proxmox.ClusterResource) bool { return r.VMID == uint64(templateID) })
+			switch {
+			case index == -1:
+				problems = append(problems, fmt.Sprintf("%s: template %d does not exist", spec.source, templateID))
+			case resources[index].Template == 0:
+				problems = append(problems, fmt.Sprintf("%s: %d is not a template", spec.source, templateID))
+			case machine.GetSourceNode() != "" && resources[index].Node != machine.GetSourceNode():
+				problems = append(problems, fmt.Sprintf("%s: template %d is on node %s, not on %s",
+					spec.source, templateID, resources[index].Node, machine.GetSourceNode()))
+			}
+			continue
+		}
+
+		tags := machine.GetTemplateSelectorTags()
+		if len(tags) == 0 {
+			continue
+		}
+		// FindVMTemplateByTags normalizes the tags in place, which must not change the spec.
+		_, _, err := p.clusterScope.ProxmoxClient.FindVMTemplateByTags(ctx, slices.Clone(tags), string(machine.GetTemplateMatchPolicy()))
+		if errors.Is(err, goproxmox.ErrTemplateNotFound) {
+			problems = append(problems, fmt.Sprintf("%s: %s", spec.source, err))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+func (p *preflight) checkVMIDs(ctx context.Context) ([]string, error) {
+	var problems []string
+	for _, spec := range p.specs {
+		vmIDRange := spec.machine.Spec.VMIDRange
+		if vmIDRange == nil {
+			continue
+		}
+
+		resources, err := p.getVMResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		used := int64(0)
+		for _, resource := range resources {
+			if vmID := int64(resource.VMID); vmID >= vmIDRange.Start && vmID <= vmIDRange.End {
+				used++
+			}
+		}
+		if used > vmIDRange.End-vmIDRange.Start {
+			problems = append(problems, fmt.Sprintf("%s: no free VMID left in range %d-%d",
+				spec.source, vmIDRange.Start, vmIDRange.End))
+		}
+	}
+	return problems, nil
+}
+
+func (p *preflight) nodeStorages(ctx context.Context, node string) ([]*proxmox.Storage, error) {
+	if storages, ok := p.storages[node]; ok {
+		return storages, nil
+	}
+	storages, err := p.clusterScope.ProxmoxClient.GetNodeStorages(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	p.storages[node] = storages
+	return storages, nil
+}
+
+func (p *preflight) nodeNetworks(ctx context.Context, node string) ([]*proxmox.NodeNetwork, error) {
+	if networks, ok := p.networks[node]; ok {
+		return networks, nil
+	}
+	networks, err := p.clusterScope.ProxmoxClient.GetNodeNetworks(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	p.networks[node] = networks
+	return networks, nil
+}
+
+func (p *preflight) getVMResources(ctx context.Context) ([]*proxmox.ClusterResource, error) {
+	if p.vmResources != nil {
+		return p.vmResources, nil
+	}
+	resources, err := p.clusterScope.ProxmoxClient.GetVMResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.vmResources = resources
+	return resources, nil
+}
+
+// setCondition reports the result of a check. A warning event is only emitted
+// when the problems change, not on every reconcile.
+func setCondition(clusterScope *scope.ClusterScope, conditionType string, problems []string, err error) {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+	condition := metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.ProxmoxClusterPreflightPassedReason,
+	}
+
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = infrav1.ProxmoxClusterPreflightErrorReason
+		condition.Message = err.Error()
+	case len(problems) > 0:
+		slices.Sort(problems)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = infrav1.ProxmoxClusterPreflightFailedReason
+		condition.Message = strings.Join(slices.Compact(problems), "; ")
+		if previous := conditions.Get(proxmoxCluster, conditionType); previous == nil || previous.Message != condition.Message {
+			clusterScope.Logger.Info("pre-flight check failed", "condition", conditionType, "problems", condition.Message)
+			record.Warnf(proxmoxCluster, "PreflightCheckFailed", "Pre-flight check %s failed: %s", conditionType, condition.Message)
+		}
+	}
+
+	conditions.Set(proxmoxCluster, condition)
+}
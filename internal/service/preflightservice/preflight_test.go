@@ -0,0 +1,292 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflightservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/proxmoxtest"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/test/helpers"
+)
+
+func setupPreflightTest(t *testing.T) (*scope.ClusterScope, *proxmoxtest.MockClient) {
+	t.Helper()
+
+	infraCluster := &infrav1.ProxmoxCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: infrav1.ProxmoxClusterSpec{
+			AllowedNodes: []string{"pve1"},
+		},
+	}
+
+	template := &infrav1.ProxmoxMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-md-0",
+			Namespace: metav1.NamespaceDefault,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       "test",
+				UID:        "uid",
+			}},
+		},
+		Spec: infrav1.ProxmoxMachineTemplateSpec{
+			Template: infrav1.ProxmoxMachineTemplateResource{
+				Spec: infrav1.ProxmoxMachineSpec{
+					VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+						TemplateSource: infrav1.TemplateSource{
+							SourceNode: new("pve1"),
+							TemplateID: new(int32(9000)),
+						},
+						Storage: new("local-lvm"),
+					},
+					Network: &infrav1.NetworkSpec{
+						NetworkDevices: []infrav1.NetworkDevice{{Bridge: new("vmbr0"), VLAN: new(int32(10))}},
+					},
+					VMIDRange: &infrav1.VMIDRange{Start: 100, End: 101},
+				},
+			},
+		},
+	}
+
+	// templates of other clusters are not checked.
+	foreign := &infrav1.ProxmoxMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-md-0",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "other"},
+		},
+		Spec: infrav1.ProxmoxMachineTemplateSpec{
+			Template: infrav1.ProxmoxMachineTemplateResource{
+				Spec: infrav1.ProxmoxMachineSpec{
+					VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+						TemplateSource: infrav1.TemplateSource{TemplateID: new(int32(1))},
+					},
+				},
+			},
+		},
+	}
+
+	// provisioned machines are not checked.
+	provisioned := &infrav1.ProxmoxMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-provisioned",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test"},
+		},
+		Spec: infrav1.ProxmoxMachineSpec{
+			VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+				TemplateSource: infrav1.TemplateSource{TemplateID: new(int32(1))},
+			},
+		},
+		Status: infrav1.ProxmoxMachineStatus{
+			Initialization: infrav1.ProxmoxMachineInitializationStatus{Provisioned: new(true)},
+		},
+	}
+
+	return helpers.NewClusterScope(t, infraCluster, template, foreign, provisioned)
+}
+
+// allPrivileges grants the privileges needed by the test cluster on all paths.
+func allPrivileges() proxmox.Permissions {
+	privileges := proxmox.Permission{
+		"Sys.Audit":               true,
+		"VM.Clone":                true,
+		"VM.Monitor":              true,
+		"Datastore.AllocateSpace": true,
+		"SDN.Use":                 true,
+	}
+	for _, privilege := range vmPrivileges {
+		privileges[privilege] = true
+	}
+	return proxmox.Permissions{"/": privileges}
+}
+
+func requirePreflightCondition(t *testing.T, clusterScope *scope.ClusterScope, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	t.Helper()
+	condition := conditions.Get(clusterScope.ProxmoxCluster, conditionType)
+	require.NotNil(t, condition, conditionType)
+	require.Equal(t, status, condition.Status, conditionType)
+	require.Equal(t, reason, condition.Reason, conditionType)
+	require.Equal(t, message, condition.Message, conditionType)
+}
+
+func TestReconcilePreflight_Passed(t *testing.T) {
+	clusterScope, mockClient := setupPreflightTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetNodes(ctx).Return([]*proxmox.NodeStatus{{Node: "pve1", Status: "online"}}, nil).Once()
+	mockClient.EXPECT().GetNodeStorages(ctx, "pve1").Return([]*proxmox.Storage{
+		{Name: "local-lvm", Enabled: 1, Active: 1, Content: "images,rootdir"},
+	}, nil).Once()
+	mockClient.EXPECT().GetNodeNetworks(ctx, "pve1").Return([]*proxmox.NodeNetwork{
+		{Iface: "vmbr0", Type: "bridge", BridgeVLANAware: 1},
+	}, nil).Once()
+	mockClient.EXPECT().GetVMResources(ctx).Return([]*proxmox.ClusterResource{
+		{VMID: 100, Node: "pve1"},
+		{VMID: 9000, Node: "pve1", Template: 1},
+	}, nil).Once()
+	mockClient.EXPECT().GetPermissions(ctx).Return(allPrivileges(), nil).Once()
+
+	require.NoError(t, ReconcilePreflight(ctx, clusterScope))
+
+	for _, conditionType := range []string{
+		infrav1.ProxmoxClusterNodesAvailableCondition,
+		infrav1.ProxmoxClusterStoragesAvailableCondition,
+		infrav1.ProxmoxClusterBridgesAvailableCondition,
+		infrav1.ProxmoxClusterTemplatesAvailableCondition,
+		infrav1.ProxmoxClusterVMIDsAvailableCondition,
+		infrav1.ProxmoxClusterPrivilegesAvailableCondition,
+	} {
+		requirePreflightCondition(t, clusterScope, conditionType, metav1.ConditionTrue, infrav1.ProxmoxClusterPreflightPassedReason, "")
+	}
+}
+
+func TestReconcilePreflight_Failed(t *testing.T) {
+	clusterScope, mockClient := setupPreflightTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetNodes(ctx).Return([]*proxmox.NodeStatus{{Node: "pve1", Status: "online"}}, nil).Once()
+	mockClient.EXPECT().GetNodeStorages(ctx, "pve1").Return([]*proxmox.Storage{
+		{Name: "local-lvm", Enabled: 1, Active: 0},
+	}, nil).Once()
+	mockClient.EXPECT().GetNodeNetworks(ctx, "pve1").Return([]*proxmox.NodeNetwork{
+		{Iface: "vmbr0", Type: "bridge"},
+	}, nil).Once()
+	mockClient.EXPECT().GetVMResources(ctx).Return([]*proxmox.ClusterResource{
+		{VMID: 100, Node: "pve1"},
+		{VMID: 101, Node: "pve1"},
+		{VMID: 9000, Node: "pve2", Template: 1},
+	}, nil).Once()
+	permissions := allPrivileges()
+	permissions["/storage/local-lvm"] = proxmox.Permission{"Datastore.Audit": true}
+	permissions["/vms"] = proxmox.Permission{"VM.Clone": true, "VM.Audit": true}
+	mockClient.EXPECT().GetPermissions(ctx).Return(permissions, nil).Once()
+
+	require.NoError(t, ReconcilePreflight(ctx, clusterScope))
+
+	failed := infrav1.ProxmoxClusterPreflightFailedReason
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterNodesAvailableCondition,
+		metav1.ConditionTrue, infrav1.ProxmoxClusterPreflightPassedReason, "")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterStoragesAvailableCondition, metav1.ConditionFalse, failed,
+		"ProxmoxMachineTemplate test-md-0: storage local-lvm is not active on node pve1")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterBridgesAvailableCondition, metav1.ConditionFalse, failed,
+		"ProxmoxMachineTemplate test-md-0: bridge vmbr0 is not VLAN aware on node pve1")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterTemplatesAvailableCondition, metav1.ConditionFalse, failed,
+		"ProxmoxMachineTemplate test-md-0: template 9000 is on node pve2, not on pve1")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterVMIDsAvailableCondition, metav1.ConditionFalse, failed,
+		"ProxmoxMachineTemplate test-md-0: no free VMID left in range 100-101")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterPrivilegesAvailableCondition, metav1.ConditionFalse, failed,
+		"/storage/local-lvm: missing Datastore.AllocateSpace; "+
+			"/vms: missing VM.Allocate, VM.Config.CDROM, VM.Config.CPU, VM.Config.Cloudinit, VM.Config.Disk, VM.Config.Memory, "+
+			"VM.Config.Network, VM.Config.Options, VM.GuestAgent.Audit or VM.Monitor, VM.GuestAgent.Unrestricted or VM.Monitor, VM.PowerMgmt")
+}
+
+func TestReconcilePreflight_NodesUnavailable(t *testing.T) {
+	clusterScope, mockClient := setupPreflightTest(t)
+	ctx := context.Background()
+	clusterScope.ProxmoxCluster.Spec.AllowedNodes = []string{"pve1", "pve2"}
+
+	// no storages or networks are listed, as none of the nodes is online.
+	mockClient.EXPECT().GetNodes(ctx).Return([]*proxmox.NodeStatus{{Node: "pve1", Status: "offline"}}, nil).Once()
+	mockClient.EXPECT().GetVMResources(ctx).Return([]*proxmox.ClusterResource{
+		{VMID: 9000, Node: "pve1", Template: 1},
+	}, nil).Once()
+	mockClient.EXPECT().GetPermissions(ctx).Return(allPrivileges(), nil).Once()
+
+	require.NoError(t, ReconcilePreflight(ctx, clusterScope))
+
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterNodesAvailableCondition,
+		metav1.ConditionFalse, infrav1.ProxmoxClusterPreflightFailedReason,
+		"ProxmoxCluster test: node pve1 is offline; ProxmoxCluster test: node pve2 does not exist; ProxmoxMachineTemplate test-md-0: node pve1 is offline")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterStoragesAvailableCondition,
+		metav1.ConditionTrue, infrav1.ProxmoxClusterPreflightPassedReason, "")
+}
+
+func TestReconcilePreflight_Error(t *testing.T) {
+	clusterScope, mockClient := setupPreflightTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetNodes(ctx).Return(nil, fmt.Errorf("unreachable")).Once()
+	// the templates and VMID checks both try to list the virtual machines.
+	mockClient.EXPECT().GetVMResources(ctx).Return(nil, fmt.Errorf("unreachable")).Twice()
+	mockClient.EXPECT().GetPermissions(ctx).Return(nil, fmt.Errorf("unreachable")).Once()
+
+	require.NoError(t, ReconcilePreflight(ctx, clusterScope))
+
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterNodesAvailableCondition,
+		metav1.ConditionUnknown, infrav1.ProxmoxClusterPreflightErrorReason, "unreachable")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterBridgesAvailableCondition,
+		metav1.ConditionUnknown, infrav1.ProxmoxClusterPreflightErrorReason, errNodesUnknown.Error())
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterTemplatesAvailableCondition,
+		metav1.ConditionUnknown, infrav1.ProxmoxClusterPreflightErrorReason, "unreachable")
+	requirePreflightCondition(t, clusterScope, infrav1.ProxmoxClusterPrivilegesAvailableCondition,
+		metav1.ConditionUnknown, infrav1.ProxmoxClusterPreflightErrorReason, "unreachable")
+}
+
+func TestCheckTemplates_Selector(t *testing.T) {
+	clusterScope, mockClient := setupPreflightTest(t)
+	ctx := context.Background()
+
+	tags := []string{"Ubuntu", "k8s"}
+	p := &preflight{clusterScope: clusterScope, specs: []machineSpec{{
+		source: "ProxmoxMachine test-0",
+		machine: &infrav1.ProxmoxMachine{Spec: infrav1.ProxmoxMachineSpec{
+			VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+				TemplateSource: infrav1.TemplateSource{
+					TemplateSelector: &infrav1.TemplateSelector{MatchTags: tags},
+				},
+			},
+		}},
+	}}}
+
+	notFound := fmt.Errorf("%w: found 0 VM templates with tags \"k8s;ubuntu\"", goproxmox.ErrTemplateNotFound)
+	mockClient.EXPECT().FindVMTemplateByTags(ctx, tags, string(infrav1.TemplateMatchPolicyExact)).Return("", -1, notFound).Once()
+
+	problems, err := p.checkTemplates(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ProxmoxMachine test-0: " + notFound.Error()}, problems)
+	// the selector of the spec is left as it is.
+	require.Equal(t, []string{"Ubuntu", "k8s"}, tags)
+}
+
+func TestStorageProblem(t *testing.T) {
+	storages := []*proxmox.Storage{
+		{Name: "local", Enabled: 1, Active: 1, Content: "iso,vztmpl,snippets"},
+		{Name: "local-lvm", Enabled: 1, Active: 1, Content: "images,rootdir"},
+		{Name: "nfs", Enabled: 0},
+	}
+
+	require.Empty(t, storageProblem(storages, "local"))
+	require.Empty(t, storageProblem(storages, "local-lvm"))
+	require.Equal(t, "is disabled", storageProblem(storages, "nfs"))
+	require.Equal(t, "does not exist", storageProblem(storages, "ceph"))
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflightservice
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"k8s.io/utils/ptr"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+)
+
+// vmPrivileges are needed on every virtual machine to clone, configure, start and delete it.
+var vmPrivileges = []string{
+	"VM.Allocate",
+	"VM.Audit",
+	"VM.Config.CDROM",
+	"VM.Config.CPU",
+	"VM.Config.Cloudinit",
+	"VM.Config.Disk",
+	"VM.Config.Memory",
+	"VM.Config.Network",
+	"VM.Config.Options",
+	"VM.PowerMgmt",
+}
+
+// requirement is a privilege the token needs on a path. Privileges which were renamed
+// between Proxmox releases are listed with their alternatives, any of them is sufficient.
+type requirement struct {
+	path       string
+	privileges []string
+}
+
+// requirements collects the privileges needed by the cluster and its machines.
+type requirements []requirement
+
+// need adds the privileges, each of which is required on the path.
+func (r *requirements) need(path string, privileges ...string) {
+	for _, privilege := range privileges {
+		*r = append(*r, requirement{path: path, privileges: []string{privilege}})
+	}
+}
+
+// needAny adds a requirement which is met by any of the privileges.
+func (r *requirements) needAny(path string, privileges ...string) {
+	*r = append(*r, requirement{path: path, privileges: privileges})
+}
+
+// checkPrivileges compares the privileges of the token with the privileges needed by the
+// features the cluster and its machines use, and reports the missing ones per path.
+func (p *preflight) checkPrivileges(ctx context.Context) ([]string, error) {
+	permissions, err := p.clusterScope.ProxmoxClient.GetPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := map[string][]string{}
+	for _, req := range p.requirements() {
+		if !slices.ContainsFunc(req.privileges, func(privilege string) bool { return granted(permissions, req.path, privilege) }) {
+			privilege := strings.Join(req.privileges, " or ")
+			if !slices.Contains(missing[req.path], privilege) {
+				missing[req.path] = append(missing[req.path], privilege)
+			}
+		}
+	}
+
+	problems := make([]string, 0, len(missing))
+	for _, aclPath := range slices.Sorted(maps.Keys(missing)) {
+		slices.Sort(missing[aclPath])
+		problems = append(problems, fmt.Sprintf("%s: missing %s", aclPath, strings.Join(missing[aclPath], ", ")))
+	}
+	return problems, nil
+}
+
+// granted reports whether a privilege is granted on a path. Proxmox returns the effective
+// privileges for the paths with ACLs, other paths inherit them from their closest parent.
+func granted(permissions proxmox.Permissions, p, privilege string) bool {
+	for {
+		if privileges, ok := permissions[p]; ok {
+			return bool(privileges[privilege])
+		}
+		if p == "/" {
+			return false
+		}
+		p = path.Dir(p)
+	}
+}
+
+// requirements returns the privileges needed by the cluster and its machines.
+func (p *preflight) requirements() requirements {
+	proxmoxCluster := p.clusterScope.ProxmoxCluster
+	var reqs requirements
+
+	for _, node := range proxmoxCluster.Spec.AllowedNodes {
+		reqs.need("/nodes/"+node, "Sys.Audit")
+	}
+
+	if pool := proxmoxCluster.GetPoolName(); pool != "" {
+		reqs.need("/pool/"+pool, "Pool.Allocate")
+		if len(proxmoxCluster.Spec.Pool.ACLs) > 0 {
+			reqs.need("/pool/"+pool, "Permissions.Modify")
+		}
+	}
+
+	if proxmoxCluster.Spec.Firewall != nil {
+		// the IPSet of the cluster is part of the datacenter firewall.
+		reqs.need("/", "Sys.Modify")
+	}
+
+	if proxmoxCluster.Spec.SDN != nil {
+		reqs.need("/sdn/zones", "SDN.Allocate")
+		reqs.need("/sdn", "SDN.Allocate")
+	}
+
+	if proxmoxCluster.Spec.HighAvailability != nil {
+		reqs.need("/", "Sys.Console")
+	}
+
+	for _, spec := range p.specs {
+		reqs = append(reqs, p.machineRequirements(spec.machine)...)
+	}
+
+	return reqs
+}
+
+// machineRequirements returns the privileges needed to provision a machine.
+func (p *preflight) machineRequirements(machine *infrav1.ProxmoxMachine) requirements {
+	proxmoxCluster := p.clusterScope.ProxmoxCluster
+	var reqs requirements
+
+	for _, node := range machine.Spec.AllowedNodes {
+		reqs.need("/nodes/"+node, "Sys.Audit")
+	}
+	if node := machine.GetSourceNode(); node != "" {
+		reqs.need("/nodes/"+node, "Sys.Audit")
+	}
+
+	// templates found by their tags are only known once they are resolved.
+	if templateID := machine.GetTemplateID(); templateID != -1 {
+		reqs.need(fmt.Sprintf("/vms/%d", templateID), "VM.Clone")
+	}
+
+	// new virtual machines are created in the pool of the machine or of the cluster,
+	// their privileges are inherited from the pool.
+	vmPath := "/vms"
+	if pool := ptr.Deref(machine.Spec.Pool, proxmoxCluster.GetPoolName()); pool != "" {
+		vmPath = "/pool/" + pool
+	}
+	reqs.need(vmPath, vmPrivileges...)
+
+	checks := ptr.Deref(machine.Spec.Checks, infrav1.ProxmoxMachineChecks{})
+	if !ptr.Deref(checks.SkipQemuGuestAgent, false) {
+		// Proxmox VE 9 replaced VM.Monitor with the VM.GuestAgent privileges.
+		reqs.needAny(vmPath, "VM.GuestAgent.Audit", "VM.Monitor")
+		if !ptr.Deref(checks.SkipCloudInitStatus, false) {
+			reqs.needAny(vmPath, "VM.GuestAgent.Unrestricted", "VM.Monitor")
+		}
+	}
+
+	if storage := ptr.Deref(machine.Spec.Storage, ""); storage != "" {
+		reqs.need("/storage/"+storage, "Datastore.AllocateSpace")
+	}
+
+	if network := machine.Spec.Network; network != nil {
+		for _, device := range network.NetworkDevices {
+			switch {
+			case device.VNet != nil:
+				// only the zone of the VNets of the cluster SDN is known.
+				if sdn := proxmoxCluster.Spec.SDN; sdn != nil {
+					reqs.need(fmt.Sprintf("/sdn/zones/%s/%s", sdn.Zone.Name, *device.VNet), "SDN.Use")
+				}
+			case device.Bridge != nil:
+				bridgePath := "/sdn/zones/localnetwork/" + *device.Bridge
+				if device.VLAN != nil {
+					bridgePath = fmt.Sprintf("%s/%d", bridgePath, *device.VLAN)
+				}
+				reqs.need(bridgePath, "SDN.Use")
+			}
+		}
+	}
+
+	if machine.Spec.HighAvailability != nil {
+		reqs.need("/", "Sys.Console")
+	}
+
+	return reqs
+}
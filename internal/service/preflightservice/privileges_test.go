@@ -0,0 +1,79 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflightservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+)
+
+func TestGranted(t *testing.T) {
+	permissions := proxmox.Permissions{
+		"/":          {"Sys.Audit": true},
+		"/vms":       {"VM.Audit": true},
+		"/vms/100":   {"VM.Clone": true},
+		"/pool/capi": {"VM.Allocate": true},
+	}
+
+	require.True(t, granted(permissions, "/nodes/pve1", "Sys.Audit"))
+	require.True(t, granted(permissions, "/vms/101", "VM.Audit"))
+	require.True(t, granted(permissions, "/vms/100", "VM.Clone"))
+	// the privileges of a path with an ACL replace the ones of its parents.
+	require.False(t, granted(permissions, "/vms/100", "VM.Audit"))
+	require.False(t, granted(permissions, "/vms", "VM.Allocate"))
+	require.False(t, granted(proxmox.Permissions{}, "/", "Sys.Audit"))
+}
+
+func TestCheckPrivileges_ClusterFeatures(t *testing.T) {
+	clusterScope, mockClient := setupPreflightTest(t)
+	ctx := context.Background()
+	spec := &clusterScope.ProxmoxCluster.Spec
+	spec.Pool = &infrav1.ClusterPoolSpec{ACLs: []infrav1.PoolACL{{User: new("tenant@pve")}}}
+	spec.Firewall = &infrav1.ClusterFirewallSpec{}
+	spec.SDN = &infrav1.SDNSpec{Zone: infrav1.SDNZoneSpec{Name: "capmox"}}
+	spec.HighAvailability = &infrav1.ClusterHighAvailabilitySpec{}
+
+	p := &preflight{clusterScope: clusterScope, specs: []machineSpec{{
+		source: "ProxmoxMachine test-0",
+		machine: &infrav1.ProxmoxMachine{Spec: infrav1.ProxmoxMachineSpec{
+			Checks: &infrav1.ProxmoxMachineChecks{SkipQemuGuestAgent: new(true)},
+			Network: &infrav1.NetworkSpec{
+				NetworkDevices: []infrav1.NetworkDevice{{VNet: new("workers")}},
+			},
+		}},
+	}}}
+
+	// the machine is created in the pool of the cluster, so privileges on /vms are not sufficient.
+	permissions := allPrivileges()
+	permissions["/pool/capmox-test"] = proxmox.Permission{"Pool.Allocate": true}
+	mockClient.EXPECT().GetPermissions(ctx).Return(permissions, nil).Once()
+
+	problems, err := p.checkPrivileges(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/: missing Sys.Console, Sys.Modify",
+		"/pool/capmox-test: missing Permissions.Modify, VM.Allocate, VM.Audit, VM.Config.CDROM, VM.Config.CPU, " +
+			"VM.Config.Cloudinit, VM.Config.Disk, VM.Config.Memory, VM.Config.Network, VM.Config.Options, VM.PowerMgmt",
+		"/sdn: missing SDN.Allocate",
+		"/sdn/zones: missing SDN.Allocate",
+	}, problems)
+}
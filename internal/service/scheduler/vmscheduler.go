@@ -21,7 +21,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"k8s.io/utils/ptr"
@@ -61,9 +63,37 @@ func ScheduleVM(ctx context.Context, machineScope *scope.MachineScope) (string,
 		allowedNodes = machineScope.ProxmoxMachine.Spec.AllowedNodes
 	}
 
+	// The HA manager only runs the VM on the nodes of its HA group.
+	if ha := machineScope.HighAvailability(); ha != nil && ptr.Deref(ha.Group, "") != "" {
+		group, err := client.GetHAGroup(ctx, *ha.Group)
+		if err != nil {
+			return "", err
+		}
+		allowedNodes = haGroupNodes(allowedNodes, group.Nodes)
+		if len(allowedNodes) == 0 {
+			return "", fmt.Errorf("none of the allowed nodes is a member of HA group %s", *ha.Group)
+		}
+	}
+
 	return selectNode(ctx, client, machineScope.ProxmoxMachine, locations, allowedNodes, schedulerHints)
 }
 
+// haGroupNodes returns the allowed nodes which are members of the HA group, or all members
+// if no nodes are allowed explicitly. Members are listed as "node[:priority],...".
+func haGroupNodes(allowedNodes []string, members string) []string {
+	var nodes []string
+	for member := range strings.SplitSeq(members, ",") {
+		node, _, _ := strings.Cut(strings.TrimSpace(member), ":")
+		if node == "" {
+			continue
+		}
+		if len(allowedNodes) == 0 || slices.Contains(allowedNodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
 func selectNode(
 	ctx context.Context,
 	client resourceClient,
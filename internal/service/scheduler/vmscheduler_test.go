@@ -249,6 +249,12 @@ func TestScheduleVM(t *testing.T) {
 	require.Equal(t, "pve2", node)
 }
 
+func TestHAGroupNodes(t *testing.T) {
+	require.Equal(t, []string{"pve1", "pve3"}, haGroupNodes(nil, "pve1:2,pve3"))
+	require.Equal(t, []string{"pve3"}, haGroupNodes([]string{"pve2", "pve3"}, "pve1:2, pve3"))
+	require.Empty(t, haGroupNodes([]string{"pve2"}, "pve1,pve3"))
+}
+
 func TestInsufficientMemoryError_Error(t *testing.T) {
 	err := InsufficientMemoryError{
 		node:      "pve1",
@@ -0,0 +1,389 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdnservice implements the management of the Proxmox SDN objects owned by a ProxmoxCluster.
+package sdnservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	capmox "github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// ErrSDNConflict is returned when a SDN object of the cluster already exists in Proxmox
+// but was not created by the cluster.
+var ErrSDNConflict = errors.New("sdn object exists but is not owned by the cluster")
+
+// ReconcileSDN creates the SDN zone, VNets and subnets of the cluster which do not exist
+// in Proxmox yet and applies the SDN configuration. Objects created by the cluster are
+// recorded in its status and the ProxmoxSDNOwnedAnnotation. Existing VNets are only adopted
+// if they carry the alias of the cluster, an existing zone only if it holds such VNets and no others.
+func ReconcileSDN(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+	sdn := proxmoxCluster.Spec.SDN
+	if sdn == nil {
+		return nil
+	}
+	if err := restoreSDNStatus(ctx, clusterScope); err != nil {
+		return err
+	}
+
+	// the status is only written once the cluster owns a SDN object.
+	status := ptr.Deref(proxmoxCluster.Status.SDN, infrav1.SDNStatus{})
+	changed, err := reconcileSDNObjects(ctx, clusterScope, &status)
+	if status.Zone != "" || len(status.VNets) > 0 {
+		proxmoxCluster.Status.SDN = &status
+		mirrorSDNStatus(proxmoxCluster)
+	}
+	if err != nil {
+		reason := infrav1.ProxmoxClusterSDNReadyFailedReason
+		if errors.Is(err, ErrSDNConflict) {
+			reason = infrav1.ProxmoxClusterSDNReadyConflictReason
+		}
+		conditions.Set(proxmoxCluster, metav1.Condition{
+			Type:    infrav1.ProxmoxClusterSDNReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		})
+		return err
+	}
+
+	if changed || !conditions.IsTrue(proxmoxCluster, infrav1.ProxmoxClusterSDNReadyCondition) {
+		clusterScope.Logger.Info("applying sdn configuration", "zone", sdn.Zone.Name)
+		if err := clusterScope.ProxmoxClient.ApplySDN(ctx); err != nil {
+			conditions.Set(proxmoxCluster, metav1.Condition{
+				Type:    infrav1.ProxmoxClusterSDNReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.ProxmoxClusterSDNReadyFailedReason,
+				Message: err.Error(),
+			})
+			return errors.Wrap(err, "unable to apply sdn configuration")
+		}
+	}
+
+	conditions.Set(proxmoxCluster, metav1.Condition{
+		Type:   infrav1.ProxmoxClusterSDNReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ProvisionedReason,
+	})
+
+	return nil
+}
+
+// reconcileSDNObjects creates the missing zone, VNets and subnets. It returns true if
+// anything was created.
+func reconcileSDNObjects(ctx context.Context, clusterScope *scope.ClusterScope, status *infrav1.SDNStatus) (bool, error) {
+	client := clusterScope.ProxmoxClient
+	sdn := clusterScope.ProxmoxCluster.Spec.SDN
+	alias := vnetAlias(clusterScope)
+	changed := false
+
+	zones, err := client.GetSDNZones(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to list sdn zones")
+	}
+	vnets, err := client.GetSDNVNets(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to list sdn vnets")
+	}
+
+	index := slices.IndexFunc(zones, func(z *capmox.SDNZone) bool { return z.Name == sdn.Zone.Name })
+	switch {
+	case index == -1:
+		clusterScope.Logger.Info("creating sdn zone", "zone", sdn.Zone.Name)
+		if err := client.CreateSDNZone(ctx, zoneOptions(sdn.Zone)); err != nil {
+			return false, err
+		}
+		status.Zone = sdn.Zone.Name
+		changed = true
+	case status.Zone == sdn.Zone.Name:
+		// the zone is owned by the cluster.
+	case adoptableZone(zones[index], sdn.Zone, vnets, alias):
+		// the zone holds the vnets of the cluster, but the status could not be written.
+		clusterScope.Logger.Info("adopting sdn zone", "zone", sdn.Zone.Name)
+		status.Zone = sdn.Zone.Name
+	default:
+		return false, errors.Wrapf(ErrSDNConflict, "zone %s", sdn.Zone.Name)
+	}
+
+	for _, vnet := range sdn.VNets {
+		index := slices.IndexFunc(vnets, func(v *proxmox.VNet) bool { return v.Name == vnet.Name })
+		switch {
+		case index == -1:
+			clusterScope.Logger.Info("creating sdn vnet", "vnet", vnet.Name)
+			if err := client.CreateSDNVNet(ctx, &proxmox.VNetOptions{
+				Name:  vnet.Name,
+				Zone:  sdn.Zone.Name,
+				Alias: alias,
+				Tag:   uint32(ptr.Deref(vnet.Tag, 0)),
+				Type:  "vnet",
+			}); err != nil {
+				return changed, err
+			}
+			if !slices.Contains(status.VNets, vnet.Name) {
+				status.VNets = append(status.VNets, vnet.Name)
+			}
+			changed = true
+		case vnets[index].Zone != sdn.Zone.Name:
+			return changed, errors.Wrapf(ErrSDNConflict, "vnet %s", vnet.Name)
+		case slices.Contains(status.VNets, vnet.Name):
+			// the vnet is owned by the cluster.
+		case vnets[index].Alias == alias && vnets[index].Tag == uint32(ptr.Deref(vnet.Tag, 0)):
+			// the vnet carries the alias of the cluster, but the status could not be written.
+			clusterScope.Logger.Info("adopting sdn vnet", "vnet", vnet.Name)
+			status.VNets = append(status.VNets, vnet.Name)
+		default:
+			return changed, errors.Wrapf(ErrSDNConflict, "vnet %s", vnet.Name)
+		}
+
+		created, err := reconcileSubnets(ctx, clusterScope, vnet)
+		if err != nil {
+			return changed, err
+		}
+		changed = changed || created
+	}
+
+	return changed, nil
+}
+
+// vnetAlias returns the alias of the VNets created by the cluster, which marks them as owned by it.
+func vnetAlias(clusterScope *scope.ClusterScope) string {
+	return "capmox " + clusterScope.Namespace() + "/" + clusterScope.Name()
+}
+
+// adoptableZone reports whether an existing zone which is not recorded in the status was created by
+// the cluster. Zones can not be marked, so the zone must match the spec and hold VNets, which all carry
+// the alias of the cluster. An empty zone is never adopted, as nothing proves it belongs to the cluster.
+func adoptableZone(zone *capmox.SDNZone, spec infrav1.SDNZoneSpec, vnets []*proxmox.VNet, alias string) bool {
+	options := zoneOptions(spec)
+	if zone.Type != options.Type || zone.Bridge != options.Bridge || zone.MTU != options.MTU ||
+		!sameMembers(zone.Nodes, spec.Nodes) || !sameMembers(zone.Peers, spec.Peers) {
+		return false
+	}
+	return ownsZoneVNets(zone.Name, vnets, alias)
+}
+
+// ownsZoneVNets reports whether the zone holds at least one VNet and all of its VNets carry the alias.
+func ownsZoneVNets(zone string, vnets []*proxmox.VNet, alias string) bool {
+	owned := false
+	for _, vnet := range vnets {
+		if vnet.Zone != zone {
+			continue
+		}
+		if vnet.Alias != alias {
+			return false
+		}
+		owned = true
+	}
+	return owned
+}
+
+// sameMembers compares two lists independent of their order.
+func sameMembers(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func reconcileSubnets(ctx context.Context, clusterScope *scope.ClusterScope, vnet infrav1.SDNVNetSpec) (bool, error) {
+	client := clusterScope.ProxmoxClient
+
+	subnets, err := client.GetSDNSubnets(ctx, vnet.Name)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to list subnets of sdn vnet %s", vnet.Name)
+	}
+
+	changed := false
+	for _, subnet := range vnet.Subnets {
+		if slices.ContainsFunc(subnets, func(s *proxmox.VNetSubnet) bool { return sameCIDR(s.CIDR, subnet.CIDR) }) {
+			continue
+		}
+
+		clusterScope.Logger.Info("creating sdn subnet", "vnet", vnet.Name, "cidr", subnet.CIDR)
+		if err := client.CreateSDNSubnet(ctx, vnet.Name, &proxmox.SDNSubnetOptions{
+			Subnet:  subnet.CIDR,
+			Gateway: subnet.Gateway,
+			SNAT:    proxmox.IntOrBool(ptr.Deref(subnet.SNAT, false)),
+		}); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// ReconcileSDNDelete removes the SDN zone, VNets and subnets recorded in the status of the
+// cluster from Proxmox and applies the SDN configuration.
+func ReconcileSDNDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	if err := restoreSDNStatus(ctx, clusterScope); err != nil {
+		return err
+	}
+	status := clusterScope.ProxmoxCluster.Status.SDN
+	if status == nil {
+		return nil
+	}
+	client := clusterScope.ProxmoxClient
+
+	vnets, err := client.GetSDNVNets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list sdn vnets")
+	}
+	for _, name := range slices.Clone(status.VNets) {
+		if slices.ContainsFunc(vnets, func(v *proxmox.VNet) bool { return v.Name == name }) {
+			subnets, err := client.GetSDNSubnets(ctx, name)
+			if err != nil {
+				return errors.Wrapf(err, "unable to list subnets of sdn vnet %s", name)
+			}
+			for _, subnet := range subnets {
+				if err := client.DeleteSDNSubnet(ctx, name, subnet); err != nil {
+					return err
+				}
+			}
+
+			clusterScope.Logger.Info("deleting sdn vnet", "vnet", name)
+			if err := client.DeleteSDNVNet(ctx, name); err != nil {
+				return err
+			}
+		}
+		status.VNets = slices.DeleteFunc(status.VNets, func(v string) bool { return v == name })
+	}
+
+	if status.Zone != "" {
+		zones, err := client.GetSDNZones(ctx)
+		if err != nil {
+			return errors.Wrap(err, "unable to list sdn zones")
+		}
+		if slices.ContainsFunc(zones, func(z *capmox.SDNZone) bool { return z.Name == status.Zone }) {
+			clusterScope.Logger.Info("deleting sdn zone", "zone", status.Zone)
+			if err := client.DeleteSDNZone(ctx, status.Zone); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := client.ApplySDN(ctx); err != nil {
+		return errors.Wrap(err, "unable to apply sdn configuration")
+	}
+
+	// the zone is kept in the status until the deletion was applied.
+	clusterScope.ProxmoxCluster.Status.SDN = nil
+	mirrorSDNStatus(clusterScope.ProxmoxCluster)
+	return nil
+}
+
+// restoreSDNStatus restores the SDN status of a cluster from the annotation mirroring it,
+// if the status was lost by clusterctl move. Unlike the status, the annotation can be written
+// by anyone allowed to edit the cluster, so only entries which Proxmox proves to be owned by
+// the cluster are restored: VNets carrying its alias and a zone holding only such VNets.
+func restoreSDNStatus(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	proxmoxCluster := clusterScope.ProxmoxCluster
+	value, ok := proxmoxCluster.GetAnnotations()[infrav1.ProxmoxSDNOwnedAnnotation]
+	if proxmoxCluster.Status.SDN != nil || !ok {
+		return nil
+	}
+
+	recorded := infrav1.SDNStatus{}
+	if err := json.Unmarshal([]byte(value), &recorded); err != nil {
+		return errors.Wrapf(err, "invalid annotation %s", infrav1.ProxmoxSDNOwnedAnnotation)
+	}
+
+	vnets, err := clusterScope.ProxmoxClient.GetSDNVNets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to list sdn vnets")
+	}
+	alias := vnetAlias(clusterScope)
+
+	status := infrav1.SDNStatus{}
+	if recorded.Zone != "" {
+		if ownsZoneVNets(recorded.Zone, vnets, alias) {
+			status.Zone = recorded.Zone
+		} else {
+			clusterScope.Logger.Info("not restoring sdn zone which is not owned by the cluster", "zone", recorded.Zone)
+		}
+	}
+	for _, name := range recorded.VNets {
+		if slices.ContainsFunc(vnets, func(v *proxmox.VNet) bool { return v.Name == name && v.Alias == alias }) {
+			status.VNets = append(status.VNets, name)
+		} else {
+			clusterScope.Logger.Info("not restoring sdn vnet which is not owned by the cluster", "vnet", name)
+		}
+	}
+
+	if status.Zone != "" || len(status.VNets) > 0 {
+		proxmoxCluster.Status.SDN = &status
+	}
+	mirrorSDNStatus(proxmoxCluster)
+	return nil
+}
+
+// mirrorSDNStatus records the SDN status of a cluster in an annotation, which is moved by clusterctl move.
+func mirrorSDNStatus(proxmoxCluster *infrav1.ProxmoxCluster) {
+	annotations := proxmoxCluster.GetAnnotations()
+	if proxmoxCluster.Status.SDN == nil {
+		delete(annotations, infrav1.ProxmoxSDNOwnedAnnotation)
+		proxmoxCluster.SetAnnotations(annotations)
+		return
+	}
+
+	value, _ := json.Marshal(proxmoxCluster.Status.SDN)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[infrav1.ProxmoxSDNOwnedAnnotation] = string(value)
+	proxmoxCluster.SetAnnotations(annotations)
+}
+
+func zoneOptions(zone infrav1.SDNZoneSpec) *proxmox.SDNZoneOptions {
+	zoneType := zone.Type
+	if zoneType == "" {
+		zoneType = infrav1.SDNZoneTypeSimple
+	}
+
+	return &proxmox.SDNZoneOptions{
+		Name:   zone.Name,
+		Type:   string(zoneType),
+		Bridge: ptr.Deref(zone.Bridge, ""),
+		Peers:  strings.Join(zone.Peers, ","),
+		MTU:    int(ptr.Deref(zone.MTU, 0)),
+		Nodes:  strings.Join(zone.Nodes, ","),
+	}
+}
+
+// sameCIDR compares two networks independent of their notation.
+func sameCIDR(a, b string) bool {
+	pa, errA := netip.ParsePrefix(a)
+	pb, errB := netip.ParsePrefix(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return pa.Masked() == pb.Masked()
+}
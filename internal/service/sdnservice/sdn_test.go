@@ -0,0 +1,286 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdnservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	capmox "github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/proxmoxtest"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/test/helpers"
+)
+
+func setupSDNTest(t *testing.T) (*scope.ClusterScope, *proxmoxtest.MockClient) {
+	t.Helper()
+
+	infraCluster := &infrav1.ProxmoxCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: infrav1.ProxmoxClusterSpec{
+			SDN: &infrav1.SDNSpec{
+				Zone: infrav1.SDNZoneSpec{
+					Name: "capmox",
+					Type: infrav1.SDNZoneTypeSimple,
+				},
+				VNets: []infrav1.SDNVNetSpec{{
+					Name: "vnet0",
+					Subnets: []infrav1.SDNSubnetSpec{{
+						CIDR:    "10.10.0.0/24",
+						Gateway: "10.10.0.1",
+						SNAT:    new(true),
+					}},
+				}},
+			},
+		},
+	}
+
+	return helpers.NewClusterScope(t, infraCluster)
+}
+
+func TestReconcileSDN_NoSpec(t *testing.T) {
+	clusterScope, _ := setupSDNTest(t)
+	clusterScope.ProxmoxCluster.Spec.SDN = nil
+
+	require.NoError(t, ReconcileSDN(context.Background(), clusterScope))
+	require.Nil(t, clusterScope.ProxmoxCluster.Status.SDN)
+	require.Nil(t, conditions.Get(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterSDNReadyCondition))
+}
+
+func TestReconcileSDN_Create(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetSDNZones(ctx).Return(nil, nil).Once()
+	mockClient.EXPECT().CreateSDNZone(ctx, &proxmox.SDNZoneOptions{Name: "capmox", Type: "simple"}).Return(nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return(nil, nil).Once()
+	mockClient.EXPECT().CreateSDNVNet(ctx, &proxmox.VNetOptions{Name: "vnet0", Zone: "capmox", Alias: "capmox default/test", Type: "vnet"}).Return(nil).Once()
+	mockClient.EXPECT().GetSDNSubnets(ctx, "vnet0").Return(nil, nil).Once()
+	mockClient.EXPECT().CreateSDNSubnet(ctx, "vnet0", &proxmox.SDNSubnetOptions{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1", SNAT: true}).Return(nil).Once()
+	mockClient.EXPECT().ApplySDN(ctx).Return(nil).Once()
+
+	require.NoError(t, ReconcileSDN(ctx, clusterScope))
+	require.Equal(t, &infrav1.SDNStatus{Zone: "capmox", VNets: []string{"vnet0"}}, clusterScope.ProxmoxCluster.Status.SDN)
+	require.Equal(t, `{"zone":"capmox","vnets":["vnet0"]}`, clusterScope.ProxmoxCluster.GetAnnotations()[infrav1.ProxmoxSDNOwnedAnnotation])
+	require.True(t, conditions.IsTrue(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterSDNReadyCondition))
+}
+
+func TestReconcileSDN_Moved(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+	// clusterctl move does not move the status, the annotation is kept.
+	clusterScope.ProxmoxCluster.SetAnnotations(map[string]string{
+		infrav1.ProxmoxSDNOwnedAnnotation: `{"zone":"capmox","vnets":["vnet0"]}`,
+	})
+
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{simpleZone("capmox")}, nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "vnet0", Zone: "capmox", Alias: "capmox default/test"}}, nil).Twice()
+	mockClient.EXPECT().GetSDNSubnets(ctx, "vnet0").Return([]*proxmox.VNetSubnet{{CIDR: "10.10.0.0/24"}}, nil).Once()
+	mockClient.EXPECT().ApplySDN(ctx).Return(nil).Once()
+
+	require.NoError(t, ReconcileSDN(ctx, clusterScope))
+	require.Equal(t, &infrav1.SDNStatus{Zone: "capmox", VNets: []string{"vnet0"}}, clusterScope.ProxmoxCluster.Status.SDN)
+	require.True(t, conditions.IsTrue(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterSDNReadyCondition))
+}
+
+func TestRestoreSDNStatus_NotOwned(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+	// the annotation names a zone and vnets of someone else.
+	clusterScope.ProxmoxCluster.SetAnnotations(map[string]string{
+		infrav1.ProxmoxSDNOwnedAnnotation: `{"zone":"foreign","vnets":["vnet0","vnet1","missing"]}`,
+	})
+
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{
+		{Name: "vnet0", Zone: "foreign", Alias: "capmox default/test"},
+		{Name: "vnet1", Zone: "foreign", Alias: "production"},
+	}, nil).Once()
+
+	require.NoError(t, restoreSDNStatus(ctx, clusterScope))
+	require.Equal(t, &infrav1.SDNStatus{VNets: []string{"vnet0"}}, clusterScope.ProxmoxCluster.Status.SDN)
+	require.Equal(t, `{"vnets":["vnet0"]}`, clusterScope.ProxmoxCluster.GetAnnotations()[infrav1.ProxmoxSDNOwnedAnnotation])
+}
+
+func TestReconcileSDNDelete_ForgedAnnotation(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+	clusterScope.ProxmoxCluster.SetAnnotations(map[string]string{
+		infrav1.ProxmoxSDNOwnedAnnotation: `{"zone":"foreign","vnets":["vnet0"]}`,
+	})
+
+	// nothing is deleted, as neither the zone nor the vnet carry the alias of the cluster.
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "vnet0", Zone: "foreign"}}, nil).Once()
+
+	require.NoError(t, ReconcileSDNDelete(ctx, clusterScope))
+	require.Nil(t, clusterScope.ProxmoxCluster.Status.SDN)
+	require.NotContains(t, clusterScope.ProxmoxCluster.GetAnnotations(), infrav1.ProxmoxSDNOwnedAnnotation)
+}
+
+func TestReconcileSDN_UpToDate(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+	clusterScope.ProxmoxCluster.Status.SDN = &infrav1.SDNStatus{Zone: "capmox", VNets: []string{"vnet0"}}
+	conditions.Set(clusterScope.ProxmoxCluster, metav1.Condition{
+		Type:   infrav1.ProxmoxClusterSDNReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ProvisionedReason,
+	})
+
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{simpleZone("capmox")}, nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "vnet0", Zone: "capmox"}}, nil).Once()
+	mockClient.EXPECT().GetSDNSubnets(ctx, "vnet0").Return([]*proxmox.VNetSubnet{{CIDR: "10.10.0.0/24"}}, nil).Once()
+
+	require.NoError(t, ReconcileSDN(ctx, clusterScope))
+	require.True(t, conditions.IsTrue(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterSDNReadyCondition))
+}
+
+func TestReconcileSDN_Conflict(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().GetSDNZones(ctx).Return(nil, nil).Once()
+	mockClient.EXPECT().CreateSDNZone(ctx, mock.Anything).Return(nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "vnet0", Zone: "other"}}, nil).Once()
+
+	err := ReconcileSDN(ctx, clusterScope)
+	require.ErrorIs(t, err, ErrSDNConflict)
+	require.Equal(t, &infrav1.SDNStatus{Zone: "capmox"}, clusterScope.ProxmoxCluster.Status.SDN)
+	require.Equal(t, infrav1.ProxmoxClusterSDNReadyConflictReason,
+		conditions.GetReason(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterSDNReadyCondition))
+}
+
+func TestReconcileSDN_ZoneNotOwned(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+
+	// a zone of the same name which does not match the spec.
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{{SDNZone: proxmox.SDNZone{Name: "capmox", Type: "vlan"}, Bridge: "vmbr0"}}, nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return(nil, nil).Once()
+
+	require.ErrorIs(t, ReconcileSDN(ctx, clusterScope), ErrSDNConflict)
+	require.Nil(t, clusterScope.ProxmoxCluster.Status.SDN)
+}
+
+func TestReconcileSDN_ZoneWithForeignVNets(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+
+	// the zone matches the spec, but holds a vnet of someone else.
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{simpleZone("capmox")}, nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "foreign", Zone: "capmox"}}, nil).Once()
+
+	require.ErrorIs(t, ReconcileSDN(ctx, clusterScope), ErrSDNConflict)
+	require.Nil(t, clusterScope.ProxmoxCluster.Status.SDN)
+}
+
+func TestReconcileSDN_EmptyZoneNotOwned(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+
+	// an empty zone which matches the spec, but was created by someone else.
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{simpleZone("capmox")}, nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return(nil, nil).Once()
+
+	require.ErrorIs(t, ReconcileSDN(ctx, clusterScope), ErrSDNConflict)
+	require.Nil(t, clusterScope.ProxmoxCluster.Status.SDN)
+
+	// the zone is not deleted with the cluster.
+	require.NoError(t, ReconcileSDNDelete(ctx, clusterScope))
+}
+
+func TestReconcileSDN_Adopt(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+
+	// the zone and vnet were created, but the status was never written.
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{simpleZone("capmox")}, nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "vnet0", Zone: "capmox", Alias: "capmox default/test"}}, nil).Once()
+	mockClient.EXPECT().GetSDNSubnets(ctx, "vnet0").Return([]*proxmox.VNetSubnet{{CIDR: "10.10.0.0/24"}}, nil).Once()
+	mockClient.EXPECT().ApplySDN(ctx).Return(nil).Once()
+
+	require.NoError(t, ReconcileSDN(ctx, clusterScope))
+	require.Equal(t, &infrav1.SDNStatus{Zone: "capmox", VNets: []string{"vnet0"}}, clusterScope.ProxmoxCluster.Status.SDN)
+	require.True(t, conditions.IsTrue(clusterScope.ProxmoxCluster, infrav1.ProxmoxClusterSDNReadyCondition))
+}
+
+func TestReconcileSDN_VNetNotOwned(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+	clusterScope.ProxmoxCluster.Status.SDN = &infrav1.SDNStatus{Zone: "capmox"}
+
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{simpleZone("capmox")}, nil).Once()
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "vnet0", Zone: "capmox", Alias: "capmox default/other"}}, nil).Once()
+
+	require.ErrorIs(t, ReconcileSDN(ctx, clusterScope), ErrSDNConflict)
+	require.Equal(t, &infrav1.SDNStatus{Zone: "capmox"}, clusterScope.ProxmoxCluster.Status.SDN)
+}
+
+func TestAdoptableZone(t *testing.T) {
+	spec := infrav1.SDNZoneSpec{Name: "zone0", Type: infrav1.SDNZoneTypeVLAN, Bridge: new("vmbr0"), Nodes: []string{"pve1", "pve2"}}
+	zone := &capmox.SDNZone{SDNZone: proxmox.SDNZone{Name: "zone0", Type: "vlan", Nodes: proxmox.CSV{"pve2", "pve1"}}, Bridge: "vmbr0"}
+	alias := "capmox default/test"
+
+	owned := []*proxmox.VNet{{Name: "vnet0", Zone: "zone0", Alias: alias}, {Name: "other", Zone: "other"}}
+
+	require.True(t, adoptableZone(zone, spec, owned, alias))
+	require.False(t, adoptableZone(zone, spec, nil, alias))
+	require.False(t, adoptableZone(zone, spec, []*proxmox.VNet{{Name: "other", Zone: "other", Alias: alias}}, alias))
+	require.False(t, adoptableZone(zone, spec, append(owned, &proxmox.VNet{Name: "vnet1", Zone: "zone0"}), alias))
+
+	spec.Bridge = new("vmbr1")
+	require.False(t, adoptableZone(zone, spec, owned, alias))
+}
+
+func TestReconcileSDNDelete(t *testing.T) {
+	clusterScope, mockClient := setupSDNTest(t)
+	ctx := context.Background()
+	clusterScope.ProxmoxCluster.Status.SDN = &infrav1.SDNStatus{Zone: "capmox", VNets: []string{"vnet0", "vnet1"}}
+
+	subnet := &proxmox.VNetSubnet{ID: "capmox-10.10.0.0-24", CIDR: "10.10.0.0/24"}
+	mockClient.EXPECT().GetSDNVNets(ctx).Return([]*proxmox.VNet{{Name: "vnet0", Zone: "capmox"}, {Name: "foreign"}}, nil).Once()
+	mockClient.EXPECT().GetSDNSubnets(ctx, "vnet0").Return([]*proxmox.VNetSubnet{subnet}, nil).Once()
+	mockClient.EXPECT().DeleteSDNSubnet(ctx, "vnet0", subnet).Return(nil).Once()
+	mockClient.EXPECT().DeleteSDNVNet(ctx, "vnet0").Return(nil).Once()
+	mockClient.EXPECT().GetSDNZones(ctx).Return([]*capmox.SDNZone{{SDNZone: proxmox.SDNZone{Name: "capmox"}}}, nil).Once()
+	mockClient.EXPECT().DeleteSDNZone(ctx, "capmox").Return(nil).Once()
+	mockClient.EXPECT().ApplySDN(ctx).Return(nil).Once()
+
+	require.NoError(t, ReconcileSDNDelete(ctx, clusterScope))
+	require.Nil(t, clusterScope.ProxmoxCluster.Status.SDN)
+	require.NotContains(t, clusterScope.ProxmoxCluster.GetAnnotations(), infrav1.ProxmoxSDNOwnedAnnotation)
+}
+
+func TestReconcileSDNDelete_NotOwned(t *testing.T) {
+	clusterScope, _ := setupSDNTest(t)
+
+	require.NoError(t, ReconcileSDNDelete(context.Background(), clusterScope))
+}
+
+func simpleZone(name string) *capmox.SDNZone {
+	return &capmox.SDNZone{SDNZone: proxmox.SDNZone{Name: name, Type: "simple"}}
+}
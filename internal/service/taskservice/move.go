@@ -0,0 +1,62 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskservice
+
+import (
+	"context"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// ReconcileBlockMove annotates a ProxmoxMachine with the clusterctl block-move annotation while
+// a Proxmox task is in flight. The task is tracked in the status, which clusterctl move does not
+// restore, so the move has to wait until the task is settled.
+func ReconcileBlockMove(proxmoxMachine *infrav1.ProxmoxMachine) {
+	annotations := proxmoxMachine.GetAnnotations()
+	if proxmoxMachine.Status.TaskRef == nil {
+		if _, ok := annotations[clusterctlv1.BlockMoveAnnotation]; ok {
+			delete(annotations, clusterctlv1.BlockMoveAnnotation)
+			proxmoxMachine.SetAnnotations(annotations)
+		}
+		return
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[clusterctlv1.BlockMoveAnnotation] = "true"
+	proxmoxMachine.SetAnnotations(annotations)
+}
+
+// SettleInFlightTask follows the in-flight task of a paused machine until it is finished, without
+// starting new tasks. It returns true once the machine has no in-flight task left.
+func SettleInFlightTask(ctx context.Context, machineScope *scope.MachineScope) (bool, error) {
+	if machineScope.ProxmoxMachine.Status.TaskRef == nil {
+		return true, nil
+	}
+
+	machineScope.Info("settling in-flight task", "task", *machineScope.ProxmoxMachine.Status.TaskRef)
+	if _, err := ReconcileInFlightTask(ctx, machineScope); err != nil {
+		return false, err
+	}
+
+	// a failed task is only released once its backoff expired.
+	return machineScope.ProxmoxMachine.Status.TaskRef == nil, nil
+}
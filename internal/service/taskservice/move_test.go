@@ -0,0 +1,67 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+func TestReconcileBlockMove(t *testing.T) {
+	machineScope, _ := setupTaskTest(t)
+	proxmoxMachine := machineScope.ProxmoxMachine
+
+	// nothing to do without a task.
+	ReconcileBlockMove(proxmoxMachine)
+	require.Empty(t, proxmoxMachine.GetAnnotations())
+
+	proxmoxMachine.Status.TaskRef = new("UPID:node1:001")
+	ReconcileBlockMove(proxmoxMachine)
+	require.Contains(t, proxmoxMachine.GetAnnotations(), clusterctlv1.BlockMoveAnnotation)
+
+	proxmoxMachine.Status.TaskRef = nil
+	ReconcileBlockMove(proxmoxMachine)
+	require.NotContains(t, proxmoxMachine.GetAnnotations(), clusterctlv1.BlockMoveAnnotation)
+}
+
+func TestSettleInFlightTask(t *testing.T) {
+	machineScope, mockClient := setupTaskTest(t)
+
+	settled, err := SettleInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, settled)
+
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:001")
+	running := &proxmox.Task{UPID: "UPID:node1:001", IsRunning: true, Status: "running", Type: "qmconfig"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(running, nil).Once()
+
+	settled, err = SettleInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, settled)
+
+	finished := &proxmox.Task{UPID: "UPID:node1:001", IsCompleted: true, IsSuccessful: true, Status: "stopped", ExitStatus: "OK", Type: "qmconfig"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(finished, nil).Once()
+
+	settled, err = SettleInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, settled)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.TaskRef)
+}
@@ -20,12 +20,16 @@ package taskservice
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/luthermonson/go-proxmox"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
@@ -49,6 +53,13 @@ var (
 	ErrTaskNotFound = errors.New("task not found")
 )
 
+// taskLogMessageLines is the number of task log lines added to condition messages and events.
+const taskLogMessageLines = 3
+
+// taskLogProgress matches progress lines of disk transfers, e.g.
+// "transferred 1.0 GiB of 10.0 GiB (10.00%)".
+var taskLogProgress = regexp.MustCompile(`\(\d+(\.\d+)?%\)$`)
+
 // GetTask returns the task relative to the current action.
 func GetTask(ctx context.Context, machineScope *scope.MachineScope) (*proxmox.Task, error) {
 	if machineScope.ProxmoxMachine.Status.TaskRef == nil {
@@ -77,12 +88,12 @@ func ReconcileInFlightTask(ctx context.Context, machineScope *scope.MachineScope
 	}
 	machineScope.Logger.V(4).Info("reconciling task", "task", t)
 
-	return checkAndRetryTask(machineScope, t)
+	return checkAndRetryTask(ctx, machineScope, t)
 }
 
 // checkAndRetryTask verifies whether the task exists and if the task should be reconciled.
 // This is determined by the task state retryAfter value set.
-func checkAndRetryTask(scope *scope.MachineScope, task *proxmox.Task) (bool, error) {
+func checkAndRetryTask(ctx context.Context, scope *scope.MachineScope, task *proxmox.Task) (bool, error) {
 	// Make sure to requeue if no task was found.
 	if task == nil {
 		scope.Logger.V(4).Info("task is nil, requeueing")
@@ -106,46 +117,159 @@ func checkAndRetryTask(scope *scope.MachineScope, task *proxmox.Task) (bool, err
 	case task.IsSuccessful && task.IsCompleted:
 		logger.Info("task is a success", "description", task.Type)
 		scope.ProxmoxMachine.Status.TaskRef = nil
+		if failedTask := scope.ProxmoxMachine.Status.LastFailedTask; failedTask != nil && failedTask.Type == task.Type {
+			failedTask.Attempts = 0
+		}
+		if task.Type == "qmclone" {
+			record.Eventf(scope.ProxmoxMachine, "CloneFinished", "Finished cloning virtual machine %d on node %s",
+				scope.ProxmoxMachine.GetVirtualMachineID(), task.Node)
+		}
 		return false, nil
 	case task.IsFailed:
-		// Failing tasks are actually red herrings. Some tasks fail, other
-		// tasks can fail successfully (like qmstart).
-		// We save the condition so the ReconcileVM statemachine keeps on working.
-		conditionReason := conditions.GetReason(scope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
-
-		// qmstart can fail and yet actually start the VM. We can not handle qmstart properly.
-		// In fact qmstart can find a machine already started, because proxmox's api is
-		// eventually consistent here.
-		// For all other jobs we do set the condition to failed.
-		if task.Type != "qmstart" {
-			logger.Info("task failed", "description", task.Type)
-			// We notify the user that intervention is required. This should stop the state machine.
-			conditionReason = infrav1.ProxmoxMachineVirtualMachineProvisionedTaskFailedReason
-		}
+		logger.Info("task failed", "description", task.Type, "exitStatus", task.ExitStatus)
+		handleFailedTask(ctx, scope, task)
+		return true, nil
+	default:
+		return false, NewRequeueError(fmt.Sprintf("unknown task state %q for %q", task.ExitStatus, scope.ProxmoxMachine.Name), infrav1.DefaultReconcilerRequeue)
+	}
+}
+
+// handleFailedTask applies the TaskRetryPolicy of the machine to a failed task.
+// When the failure is first observed, the task log is recorded and provisioning waits
+// for the backoff, unless the attempts are exhausted and the machine is marked as failed.
+// Once the backoff expired, provisioning resumes from where the task failed.
+func handleFailedTask(ctx context.Context, scope *scope.MachineScope, task *proxmox.Task) {
+	pm := scope.ProxmoxMachine
+	policy := scope.TaskRetryPolicy()
+	action := policy.GetAction(task.Type)
+	maxAttempts := policy.GetMaxAttempts(task.Type)
 
-		errorMessage := fmt.Sprintf("%s: %s", task.Type, task.ExitStatus)
-		if task.ExitStatus == "OK" {
-			// If you end up here, file a bug with go-proxmox.
-			errorMessage = fmt.Sprintf("task %s failed but its exit status is OK; this should not happen", task.UPID)
+	failedTask := pm.Status.LastFailedTask
+	if failedTask == nil || failedTask.UPID != string(task.UPID) {
+		var attempts int32
+		// qmstart can fail and yet actually start the VM, because proxmox's api is
+		// eventually consistent here. Ignored failures do not count as attempts.
+		if action != infrav1.TaskFailureActionIgnore {
+			attempts = 1
+			if failedTask != nil && failedTask.Type == task.Type {
+				attempts = failedTask.Attempts + 1
+			}
 		}
+		failedTask = &infrav1.FailedTask{
+			UPID:               string(task.UPID),
+			Type:               task.Type,
+			Node:               task.Node,
+			ExitStatus:         task.ExitStatus,
+			Log:                fetchTaskLog(ctx, scope, task),
+			Attempts:           attempts,
+			ProvisioningReason: conditions.GetReason(pm, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition),
+		}
+		pm.Status.LastFailedTask = failedTask
+	}
+	details := strings.Join(relevantTaskLogLines(failedTask.Log), "; ")
+
+	errorMessage := fmt.Sprintf("%s: %s", task.Type, task.ExitStatus)
+	if task.ExitStatus == "OK" {
+		// If you end up here, file a bug with go-proxmox.
+		errorMessage = fmt.Sprintf("task %s failed but its exit status is OK; this should not happen", task.UPID)
+	}
+	if details != "" {
+		errorMessage = fmt.Sprintf("%s; task log: %s", errorMessage, details)
+	}
 
-		conditions.Set(scope.ProxmoxMachine, metav1.Condition{
+	resumeReason := failedTask.ProvisioningReason
+	if resumeReason == "" {
+		resumeReason = infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason
+	}
+
+	// The backoff expired, resume provisioning.
+	if !pm.Status.RetryAfter.IsZero() {
+		pm.Status.TaskRef = nil
+		pm.Status.RetryAfter = nil
+		if action != infrav1.TaskFailureActionIgnore {
+			resumeReason = retryReason(task.Type, resumeReason)
+			record.Eventf(pm, "RetryingTask", "Retrying after failed Proxmox task %s (attempt %d of %d)",
+				task.Type, failedTask.Attempts+1, maxAttempts)
+		}
+		conditions.Set(pm, metav1.Condition{
 			Type:    infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
 			Status:  metav1.ConditionFalse,
-			Reason:  conditionReason,
+			Reason:  resumeReason,
 			Message: errorMessage,
 		})
+		return
+	}
+
+	record.Warnf(pm, "TaskFailed", "Proxmox task %s on node %s failed with exit status %q; task log: %s",
+		task.Type, task.Node, task.ExitStatus, details)
+
+	reason := infrav1.ProxmoxMachineVirtualMachineProvisionedTaskFailedReason
+	switch {
+	case action == infrav1.TaskFailureActionIgnore:
+		reason = resumeReason
+	case action == infrav1.TaskFailureActionFail || failedTask.Attempts >= maxAttempts:
+		// We notify the user that intervention is required. This stops the state machine.
+		pm.Status.TaskRef = nil
+		conditions.Set(pm, metav1.Condition{
+			Type:    infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason,
+			Message: fmt.Sprintf("%s; giving up after %d failed attempts", errorMessage, failedTask.Attempts),
+		})
+		return
+	}
 
-		// Instead of directly requeuing the failed task, wait for the RetryAfter duration to pass
-		// before resetting the taskRef from the ProxmoxMachine status.
-		if scope.ProxmoxMachine.Status.RetryAfter.IsZero() {
-			scope.ProxmoxMachine.Status.RetryAfter = &metav1.Time{Time: time.Now().Add(1 * time.Minute)}
-		} else {
-			scope.ProxmoxMachine.Status.TaskRef = nil
-			scope.ProxmoxMachine.Status.RetryAfter = nil
+	conditions.Set(pm, metav1.Condition{
+		Type:    infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: errorMessage,
+	})
+
+	// Instead of directly requeuing the failed task, wait for the backoff to pass
+	// before resetting the taskRef from the ProxmoxMachine status.
+	pm.Status.RetryAfter = &metav1.Time{Time: time.Now().Add(policy.GetBackoff(max(failedTask.Attempts, 1)))}
+}
+
+// retryReason returns the VirtualMachineProvisioned reason from which provisioning
+// resumes after a failed task of the given type.
+func retryReason(taskType, provisioningReason string) string {
+	switch {
+	case taskType == "qmclone":
+		// The partially cloned VM is deleted before cloning again.
+		return infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason
+	case taskType == "qmconfig" && provisioningReason == infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForDiskReconciliationReason:
+		// The VM configuration is applied while Cloning.
+		return infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason
+	}
+	return provisioningReason
+}
+
+// fetchTaskLog returns the last MaxFailedTaskLogLines lines of the task log.
+// Errors are only logged, as the log is purely diagnostic.
+func fetchTaskLog(ctx context.Context, scope *scope.MachineScope, task *proxmox.Task) []string {
+	lines, err := scope.InfraCluster.ProxmoxClient.GetTaskLog(ctx, string(task.UPID))
+	if err != nil {
+		scope.Logger.Info("unable to fetch task log", "upid", task.UPID, "error", err.Error())
+		return nil
+	}
+	if len(lines) > infrav1.MaxFailedTaskLogLines {
+		lines = lines[len(lines)-infrav1.MaxFailedTaskLogLines:]
+	}
+	return lines
+}
+
+// relevantTaskLogLines returns the last few task log lines which are not
+// blank or transfer progress, as these usually explain why a task failed.
+func relevantTaskLogLines(log []string) []string {
+	var relevant []string
+	for i := len(log) - 1; i >= 0 && len(relevant) < taskLogMessageLines; i-- {
+		line := strings.TrimSpace(log[i])
+		if line == "" || taskLogProgress.MatchString(line) {
+			continue
 		}
-		return true, nil
-	default:
-		return false, NewRequeueError(fmt.Sprintf("unknown task state %q for %q", task.ExitStatus, scope.ProxmoxMachine.Name), infrav1.DefaultReconcilerRequeue)
+		relevant = append(relevant, line)
 	}
+	slices.Reverse(relevant)
+	return relevant
 }
@@ -19,6 +19,7 @@ package taskservice
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -28,8 +29,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	utilrecord "sigs.k8s.io/cluster-api/util/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
@@ -38,8 +41,32 @@ import (
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
 )
 
+// events receives the events recorded by the services. record.InitFromRecorder only takes
+// effect once, so all tests share the recorder and the test setup drains it.
+var events = initEventRecorder()
+
+func initEventRecorder() chan string {
+	recorder := record.NewFakeRecorder(1000)
+	utilrecord.InitFromRecorder(recorder)
+	return recorder.Events
+}
+
+// recordedEvents returns the events recorded since the last call, formatted as "<type> <reason> <message>".
+func recordedEvents() []string {
+	var recorded []string
+	for {
+		select {
+		case event := <-events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
 func setupTaskTest(t *testing.T) (*scope.MachineScope, *proxmoxtest.MockClient) {
 	t.Helper()
+	recordedEvents()
 
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -195,14 +222,16 @@ func TestReconcileInFlightTask_TaskRunning(t *testing.T) {
 func TestReconcileInFlightTask_TaskSuccessful(t *testing.T) {
 	machineScope, mockClient := setupTaskTest(t)
 	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:001")
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(100))
 
-	task := &proxmox.Task{UPID: "UPID:node1:001", IsCompleted: true, IsSuccessful: true, Status: "stopped", ExitStatus: "OK", Type: "qmclone"}
+	task := &proxmox.Task{UPID: "UPID:node1:001", IsCompleted: true, IsSuccessful: true, Status: "stopped", ExitStatus: "OK", Type: "qmclone", Node: "node1"}
 	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
 
 	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
 	require.NoError(t, err)
 	require.False(t, requeue)
 	require.Nil(t, machineScope.ProxmoxMachine.Status.TaskRef)
+	require.Equal(t, []string{"Normal CloneFinished Finished cloning virtual machine 100 on node node1"}, recordedEvents())
 }
 
 // Test ReconcileInflightTask on task failure switch case if not qmstart.
@@ -217,8 +246,15 @@ func TestReconcileInFlightTask_CloneTaskFailed(t *testing.T) {
 		Reason: "SomeOtherReason",
 	})
 
-	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: clone failed", Type: "qmclone"}
+	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: clone failed", Type: "qmclone", Node: "node1"}
 	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:001").Return([]string{
+		"create full clone of drive scsi0 (local-lvm:base-100-disk-0)",
+		"transferred 1.0 GiB of 10.0 GiB (10.00%)",
+		"can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout",
+		"",
+		"TASK ERROR: clone failed: can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout",
+	}, nil).Once()
 
 	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
 	require.NoError(t, err)
@@ -233,6 +269,68 @@ func TestReconcileInFlightTask_CloneTaskFailed(t *testing.T) {
 	require.NotNil(t, cond)
 	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedTaskFailedReason, cond.Reason)
 	require.Contains(t, cond.Message, "ERROR: clone failed")
+	require.Equal(t, "qmclone: ERROR: clone failed; task log: "+
+		"create full clone of drive scsi0 (local-lvm:base-100-disk-0); "+
+		"can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout; "+
+		"TASK ERROR: clone failed: can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout",
+		cond.Message)
+
+	// The task log is kept in the status.
+	failedTask := machineScope.ProxmoxMachine.Status.LastFailedTask
+	require.NotNil(t, failedTask)
+	require.Equal(t, "UPID:node1:001", failedTask.UPID)
+	require.Equal(t, "qmclone", failedTask.Type)
+	require.Equal(t, "node1", failedTask.Node)
+	require.Equal(t, "ERROR: clone failed", failedTask.ExitStatus)
+	require.Len(t, failedTask.Log, 5)
+
+	require.Equal(t, []string{`Warning TaskFailed Proxmox task qmclone on node node1 failed with exit status "ERROR: clone failed"; task log: ` +
+		"create full clone of drive scsi0 (local-lvm:base-100-disk-0); " +
+		"can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout; " +
+		"TASK ERROR: clone failed: can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout"}, recordedEvents())
+}
+
+// Test ReconcileInflightTask keeps the task failure when its log can not be fetched.
+func TestReconcileInFlightTask_TaskFailed_LogUnavailable(t *testing.T) {
+	machineScope, mockClient := setupTaskTest(t)
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:001")
+
+	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "command failed", Type: "qmclone"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:001").Return(nil, errors.New("forbidden")).Once()
+
+	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+
+	cond := conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, "qmclone: command failed", cond.Message)
+	require.NotNil(t, machineScope.ProxmoxMachine.Status.LastFailedTask)
+	require.Empty(t, machineScope.ProxmoxMachine.Status.LastFailedTask.Log)
+}
+
+// Test ReconcileInflightTask only keeps the tail of long task logs.
+func TestReconcileInFlightTask_TaskFailed_LogBounded(t *testing.T) {
+	machineScope, mockClient := setupTaskTest(t)
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:001")
+
+	log := make([]string, infrav1.MaxFailedTaskLogLines+50)
+	for i := range log {
+		log[i] = fmt.Sprintf("line %d", i)
+	}
+
+	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "command failed", Type: "qmclone"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:001").Return(log, nil).Once()
+
+	_, err := ReconcileInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+
+	failedTask := machineScope.ProxmoxMachine.Status.LastFailedTask
+	require.Len(t, failedTask.Log, infrav1.MaxFailedTaskLogLines)
+	require.Equal(t, "line 50", failedTask.Log[0])
+	require.Equal(t, log[len(log)-1], failedTask.Log[infrav1.MaxFailedTaskLogLines-1])
 }
 
 // Test ReconcileInflightTask on task failure switch case if qmstart (special case failure).
@@ -249,6 +347,7 @@ func TestReconcileInFlightTask_TaskFailed_QMStart(t *testing.T) {
 
 	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: VM already running", Type: "qmstart"}
 	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:001").Return(nil, nil).Once()
 
 	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
 	require.NoError(t, err)
@@ -267,6 +366,11 @@ func TestReconcileInFlightTask_TaskFailed_SecondPass_ClearsTaskRef(t *testing.T)
 
 	// Simulate second reconciliation pass: RetryAfter is already set and expired.
 	machineScope.ProxmoxMachine.Status.RetryAfter = &metav1.Time{Time: time.Now().Add(-1 * time.Minute)}
+	// The task log was fetched on the first pass.
+	machineScope.ProxmoxMachine.Status.LastFailedTask = &infrav1.FailedTask{
+		UPID: "UPID:node1:001",
+		Log:  []string{"TASK ERROR: clone failed: storage 'local-lvm' does not exist"},
+	}
 
 	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: clone failed", Type: "qmclone"}
 	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
@@ -278,6 +382,115 @@ func TestReconcileInFlightTask_TaskFailed_SecondPass_ClearsTaskRef(t *testing.T)
 	// Second failure pass: both TaskRef and RetryAfter should be cleared.
 	require.Nil(t, machineScope.ProxmoxMachine.Status.TaskRef)
 	require.Nil(t, machineScope.ProxmoxMachine.Status.RetryAfter)
+
+	cond := conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+	require.NotNil(t, cond)
+	require.Contains(t, cond.Message, "storage 'local-lvm' does not exist")
+}
+
+// Test ReconcileInflightTask sends a failed clone to Recloning once the backoff expired.
+func TestReconcileInFlightTask_CloneTaskFailed_Recloning(t *testing.T) {
+	machineScope, mockClient := setupTaskTest(t)
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:001")
+	machineScope.ProxmoxMachine.Status.RetryAfter = &metav1.Time{Time: time.Now().Add(-1 * time.Minute)}
+	machineScope.ProxmoxMachine.Status.LastFailedTask = &infrav1.FailedTask{
+		UPID:               "UPID:node1:001",
+		Type:               "qmclone",
+		Attempts:           1,
+		ProvisioningReason: infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason,
+	}
+
+	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: clone failed", Type: "qmclone"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
+
+	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.TaskRef)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.RetryAfter)
+
+	cond := conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason, cond.Reason)
+}
+
+// Test ReconcileInflightTask gives up once the task failed maxAttempts times.
+func TestReconcileInFlightTask_TaskFailed_AttemptsExhausted(t *testing.T) {
+	machineScope, mockClient := setupTaskTest(t)
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:003")
+	machineScope.ProxmoxMachine.Status.LastFailedTask = &infrav1.FailedTask{
+		UPID:     "UPID:node1:002",
+		Type:     "qmclone",
+		Attempts: infrav1.DefaultTaskMaxAttempts - 1,
+	}
+
+	task := &proxmox.Task{UPID: "UPID:node1:003", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: clone failed", Type: "qmclone"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:003").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:003").Return(nil, nil).Once()
+
+	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.TaskRef)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.RetryAfter)
+	require.Equal(t, int32(infrav1.DefaultTaskMaxAttempts), machineScope.ProxmoxMachine.Status.LastFailedTask.Attempts)
+
+	cond := conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason, cond.Reason)
+	require.Equal(t, "qmclone: ERROR: clone failed; giving up after 3 failed attempts", cond.Message)
+	require.True(t, machineScope.HasFailed())
+}
+
+// Test ReconcileInflightTask honours the task retry policy of the machine.
+func TestReconcileInFlightTask_TaskFailed_RetryPolicy(t *testing.T) {
+	machineScope, mockClient := setupTaskTest(t)
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:001")
+	machineScope.ProxmoxMachine.Spec.TaskRetryPolicy = &infrav1.TaskRetryPolicy{
+		InitialBackoffSeconds: new(int32(300)),
+		TaskTypes: []infrav1.TaskTypeRetryPolicy{
+			{Type: "qmconfig", Action: infrav1.TaskFailureActionFail},
+		},
+	}
+
+	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: clone failed", Type: "qmclone"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:001").Return(nil, nil).Once()
+
+	_, err := ReconcileInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.NotNil(t, machineScope.ProxmoxMachine.Status.RetryAfter)
+	require.WithinDuration(t, time.Now().Add(5*time.Minute), machineScope.ProxmoxMachine.Status.RetryAfter.Time, 10*time.Second)
+
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:002")
+	machineScope.ProxmoxMachine.Status.RetryAfter = nil
+	task = &proxmox.Task{UPID: "UPID:node1:002", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "ERROR: storage full", Type: "qmconfig"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:002").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:002").Return(nil, nil).Once()
+
+	_, err = ReconcileInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.TaskRef)
+	require.Equal(t, int32(1), machineScope.ProxmoxMachine.Status.LastFailedTask.Attempts)
+
+	cond := conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason, cond.Reason)
+}
+
+// Test ReconcileInflightTask resets the attempts once the task type succeeds.
+func TestReconcileInFlightTask_TaskSuccessful_ResetsAttempts(t *testing.T) {
+	machineScope, mockClient := setupTaskTest(t)
+	machineScope.ProxmoxMachine.Status.TaskRef = new("UPID:node1:002")
+	machineScope.ProxmoxMachine.Status.LastFailedTask = &infrav1.FailedTask{UPID: "UPID:node1:001", Type: "qmclone", Attempts: 2}
+
+	task := &proxmox.Task{UPID: "UPID:node1:002", IsCompleted: true, IsSuccessful: true, Status: "stopped", ExitStatus: "OK", Type: "qmclone"}
+	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:002").Return(task, nil).Once()
+
+	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Zero(t, machineScope.ProxmoxMachine.Status.LastFailedTask.Attempts)
 }
 
 // Test ReconcileInflightTask on invalid task state in go-proxmox.
@@ -287,6 +500,7 @@ func TestReconcileInFlightTask_TaskFailed_ExitStatusOK(t *testing.T) {
 
 	task := &proxmox.Task{UPID: "UPID:node1:001", IsFailed: true, IsCompleted: true, Status: "stopped", ExitStatus: "OK", Type: "qmclone"}
 	mockClient.EXPECT().GetTask(context.Background(), "UPID:node1:001").Return(task, nil).Once()
+	mockClient.EXPECT().GetTaskLog(context.Background(), "UPID:node1:001").Return(nil, nil).Once()
 
 	requeue, err := ReconcileInFlightTask(context.Background(), machineScope)
 	require.NoError(t, err)
@@ -329,3 +543,20 @@ func TestReconcileInFlightTask_UnknownState(t *testing.T) {
 	var requeueErr *RequeueError
 	require.ErrorAs(t, err, &requeueErr)
 }
+
+func TestRetryReason(t *testing.T) {
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason,
+		retryReason("qmclone", infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason))
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason,
+		retryReason("qmconfig", infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForDiskReconciliationReason))
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForVMPowerUpReason,
+		retryReason("qmstart", infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForVMPowerUpReason))
+}
+
+func TestRelevantTaskLogLines(t *testing.T) {
+	require.Empty(t, relevantTaskLogLines(nil))
+	require.Equal(t,
+		[]string{"b", "c", "TASK ERROR: d"},
+		relevantTaskLogLines([]string{"a", "b", "", "c", "drive-scsi0: transferred 5.0 GiB of 10.0 GiB (50.00%)", "TASK ERROR: d", "  "}),
+	)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package vmservice
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -30,16 +31,21 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/utils/ptr"
 	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/inject"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/cloudinit"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/ignition"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/kubevip"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/talos"
 )
 
 func reconcileBootstrapData(ctx context.Context, machineScope *scope.MachineScope) (requeue bool, err error) {
@@ -87,10 +93,13 @@ func reconcileBootstrapData(ctx context.Context, machineScope *scope.MachineScop
 
 	machineScope.Logger.V(4).Info("nicData", "json", func() string { ret, _ := json.Marshal(nicData); return string(ret) }())
 	// Inject userdata based on the format
-	if ptr.Deref(format, "") == ignition.FormatIgnition {
+	switch ptr.Deref(format, "") {
+	case ignition.FormatIgnition:
 		err = injectIgnition(ctx, machineScope, bootstrapData, biosUUID, nicData, kubernetesVersion)
-	} else if ptr.Deref(format, "") == cloudinit.FormatCloudConfig {
+	case cloudinit.FormatCloudConfig:
 		err = injectCloudInit(ctx, machineScope, bootstrapData, biosUUID, nicData, kubernetesVersion)
+	case talos.FormatTalos:
+		err = injectTalos(ctx, machineScope, bootstrapData, biosUUID, nicData, kubernetesVersion)
 	}
 	if err != nil {
 		// Todo: test this (colliding default gateways for example)
@@ -101,8 +110,11 @@ func reconcileBootstrapData(ctx context.Context, machineScope *scope.MachineScop
 			Message: err.Error(),
 		})
 		machineScope.Logger.V(0).Error(err, "nicData", "json", func() string { ret, _ := json.Marshal(nicData); return string(ret) }())
+		record.Warnf(machineScope.ProxmoxMachine, "BootstrapInjectionFailed", "Failed to inject bootstrap data: %s", err)
 		return false, errors.Wrap(err, "failed to inject bootstrap data")
 	}
+	record.Eventf(machineScope.ProxmoxMachine, "BootstrapDataInjected", "Injected %s bootstrap data via %s",
+		ptr.Deref(format, ""), machineScope.ProxmoxMachine.GetBootstrapDeliveryMethod())
 
 	// Todo: This status field is now superfluous
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
@@ -117,11 +129,29 @@ func reconcileBootstrapData(ctx context.Context, machineScope *scope.MachineScop
 
 func injectCloudInit(ctx context.Context, machineScope *scope.MachineScope, bootstrapData []byte, biosUUID string, nicData []network.ConfigData, kubernetesVersion string) error {
 	// create network renderer
-	network := cloudinit.NewNetworkConfig(nicData)
+	network := newNetworkRenderer(machineScope.ProxmoxMachine.GetNetworkRenderer(), nicData)
 
 	// create metadata renderer
 	metadata := cloudinit.NewMetadata(biosUUID, machineScope.Name(), kubernetesVersion, *ptr.Deref(machineScope.ProxmoxMachine.Spec.MetadataSettings, infrav1.MetadataSettings{ProviderIDInjection: new(false)}).ProviderIDInjection)
 
+	// add the kube-vip static pod to control plane machines
+	if kubeVIP := kubeVIPManifest(machineScope); kubeVIP != nil {
+		manifest, err := kubeVIP.Render(nicData)
+		if err != nil {
+			return errors.Wrap(err, "unable to render kube-vip manifest")
+		}
+		bootstrapData, err = cloudinit.AppendFile(bootstrapData, kubevip.ManifestPath, "0644", manifest)
+		if err != nil {
+			return errors.Wrap(err, "unable to add kube-vip manifest")
+		}
+		if kubeVIP.SuperAdminKubeconfig {
+			bootstrapData, err = cloudinit.AppendService(bootstrapData, kubevip.AdminKubeconfigScriptPath, kubevip.AdminKubeconfigUnit, kubevip.AdminKubeconfigScript())
+			if err != nil {
+				return errors.Wrap(err, "unable to add kube-vip kubeconfig switch")
+			}
+		}
+	}
+
 	injector := getISOInjector(machineScope.VirtualMachine, bootstrapData, metadata, network)
 	return injector.Inject(ctx, inject.CloudConfigFormat)
 }
@@ -137,12 +167,71 @@ func injectIgnition(ctx context.Context, machineScope *scope.MachineScope, boots
 		InstanceID:    biosUUID,
 		ProviderID:    fmt.Sprintf("proxmox://%s", biosUUID),
 		Network:       nicData,
+		KubeVIP:       kubeVIPManifest(machineScope),
 	}
 
 	injector := getIgnitionISOInjector(machineScope.VirtualMachine, metadata, enricher)
 	return injector.Inject(ctx, inject.IgnitionFormat)
 }
 
+func injectTalos(ctx context.Context, machineScope *scope.MachineScope, bootstrapData []byte, biosUUID string, nicData []network.ConfigData, kubernetesVersion string) error {
+	// create metadata renderer
+	metadata := cloudinit.NewMetadata(biosUUID, machineScope.Name(), kubernetesVersion, *ptr.Deref(machineScope.ProxmoxMachine.Spec.MetadataSettings, infrav1.MetadataSettings{ProviderIDInjection: new(false)}).ProviderIDInjection)
+
+	// create a patcher for the machine network
+	patcher := &talos.Patcher{
+		BootstrapData: bootstrapData,
+		Hostname:      machineScope.Name(),
+		Network:       nicData,
+	}
+
+	injector := getTalosISOInjector(machineScope.VirtualMachine, metadata, patcher)
+	return injector.Inject(ctx, inject.TalosFormat)
+}
+
+// kubeVIPManifest returns the kube-vip static pod of a machine, or nil if the machine
+// is no control plane machine or the cluster does not use kube-vip.
+func kubeVIPManifest(machineScope *scope.MachineScope) *kubevip.Manifest {
+	proxmoxCluster := machineScope.InfraCluster.ProxmoxCluster
+	if proxmoxCluster.Spec.KubeVIP == nil || !util.IsControlPlaneMachine(machineScope.Machine) {
+		return nil
+	}
+
+	// The first control plane machine runs kubeadm init, no other machine is created
+	// before the control plane is initialized.
+	kubeadmInit := !ptr.Deref(machineScope.Cluster.Status.Initialization.ControlPlaneInitialized, false)
+
+	return &kubevip.Manifest{
+		Spec:                 *proxmoxCluster.Spec.KubeVIP,
+		Endpoint:             proxmoxCluster.Spec.ControlPlaneEndpoint,
+		SuperAdminKubeconfig: kubeadmInit && hasSuperAdminKubeconfig(machineScope.Machine.Spec.Version),
+	}
+}
+
+// hasSuperAdminKubeconfig returns true if kubeadm init of the given Kubernetes version
+// creates a super-admin kubeconfig, which it does since v1.29.
+func hasSuperAdminKubeconfig(kubernetesVersion string) bool {
+	v, err := version.ParseGeneric(kubernetesVersion)
+	if err != nil {
+		return false
+	}
+	return v.AtLeast(version.MajorMinor(1, 29))
+}
+
+// newNetworkRenderer returns the cloud-init network renderer selected for a machine.
+func newNetworkRenderer(renderer infrav1.NetworkRenderer, nicData []network.ConfigData) cloudinit.Renderer {
+	switch renderer {
+	case infrav1.NetworkRendererNetworkConfigV1:
+		return cloudinit.NewNetworkConfigV1(nicData)
+	case infrav1.NetworkRendererNetworkManager:
+		return cloudinit.NewNetworkManagerConfig(nicData)
+	case infrav1.NetworkRendererENI:
+		return cloudinit.NewENIConfig(nicData)
+	default:
+		return cloudinit.NewNetworkConfig(nicData)
+	}
+}
+
 type isoInjector interface {
 	Inject(ctx context.Context, format inject.BootstrapDataFormat) error
 }
@@ -164,9 +253,18 @@ func defaultIgnitionISOInjector(vm *proxmox.VirtualMachine, metadata cloudinit.R
 	}
 }
 
+func defaultTalosISOInjector(vm *proxmox.VirtualMachine, metadata cloudinit.Renderer, patcher *talos.Patcher) isoInjector {
+	return &inject.ISOInjector{
+		VirtualMachine: vm,
+		TalosPatcher:   patcher,
+		MetaRenderer:   metadata,
+	}
+}
+
 var (
 	getISOInjector         = defaultISOInjector
 	getIgnitionISOInjector = defaultIgnitionISOInjector
+	getTalosISOInjector    = defaultTalosISOInjector
 )
 
 // getBootstrapData obtains a machine's bootstrap data from the relevant K8s secret and returns the data.
@@ -199,14 +297,28 @@ func getBootstrapData(ctx context.Context, scope *scope.MachineScope) ([]byte, *
 func getNetworkConfigData(ctx context.Context, machineScope *scope.MachineScope) ([]network.ConfigData, error) {
 	// provide a default in case network is not defined
 	networkSpec := ptr.Deref(machineScope.ProxmoxMachine.Spec.Network, infrav1.NetworkSpec{})
-	networkConfigData := make([]network.ConfigData, 0, len(networkSpec.NetworkDevices)+len(networkSpec.VRFs))
+	networkConfigData := make([]network.ConfigData, 0, len(networkSpec.NetworkDevices)+len(networkSpec.VRFs)+
+		len(networkSpec.Bonds)+len(networkSpec.VLANs)+len(networkSpec.Bridges))
+	ipAddressMap := make(map[infrav1.NetName]map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress)
 
-	networkConfig, err := getNetworkDevices(ctx, machineScope, networkSpec)
+	requeue, err := handleDevices(ctx, machineScope, ipAddressMap)
+	if requeue || err != nil {
+		// invalid state. Machine should've had all IPs assigned
+		return nil, errors.Wrapf(err, "unable to get IPs for network config data")
+	}
+
+	networkConfig, err := getNetworkDevices(ctx, machineScope, networkSpec, ipAddressMap)
 	if err != nil {
 		return nil, err
 	}
 	networkConfigData = append(networkConfigData, networkConfig...)
 
+	linkConfig, err := getLinkDevices(ctx, machineScope, networkSpec, ipAddressMap, networkConfigData)
+	if err != nil {
+		return nil, err
+	}
+	networkConfigData = append(networkConfigData, linkConfig...)
+
 	virtualConfig, err := getVirtualNetworkDevices(ctx, machineScope, networkSpec, networkConfigData)
 	if err != nil {
 		return nil, err
@@ -237,6 +349,15 @@ func getNetworkConfigDataForDevice(ctx context.Context, machineScope *scope.Mach
 		DNSServers: dns,
 	}
 
+	if err := getIPConfigData(ctx, machineScope, cloudinitNetworkConfigData, ipPoolRefs); err != nil {
+		return nil, err
+	}
+
+	return cloudinitNetworkConfigData, nil
+}
+
+// getIPConfigData adds the IPAM addresses of a device and their gateways to its network config data.
+func getIPConfigData(ctx context.Context, machineScope *scope.MachineScope, cloudinitNetworkConfigData *network.ConfigData, ipPoolRefs map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress) error {
 	// Keys need to be sorted as golang doesn't guarantee stable map iteration
 	ipAddresses := slices.Concat(slices.Collect(maps.Values(ipPoolRefs))...)
 	slices.SortFunc(ipAddresses, func(a, b ipamv1.IPAddress) int {
@@ -247,7 +368,7 @@ func getNetworkConfigDataForDevice(ctx context.Context, machineScope *scope.Mach
 		ipConfig := network.IPConfig{}
 		ip, err := netip.ParsePrefix(fmt.Sprintf("%s/%d", ipAddr.Spec.Address, ptr.Deref(ipAddr.Spec.Prefix, 0)))
 		if err != nil {
-			return nil, errors.Wrapf(err, "error converting ip address spec to netip prefix: %+v", ipAddr.Spec)
+			return errors.Wrapf(err, "error converting ip address spec to netip prefix: %+v", ipAddr.Spec)
 		}
 		ipConfig.IPAddress = ip
 
@@ -265,7 +386,7 @@ func getNetworkConfigDataForDevice(ctx context.Context, machineScope *scope.Mach
 
 		via, err := netip.ParseAddr(gateway)
 		if err != nil {
-			return nil, errors.Wrapf(err, "invalid gateway %q for ip %s", gateway, ipAddr.Name)
+			return errors.Wrapf(err, "invalid gateway %q for ip %s", gateway, ipAddr.Name)
 		}
 
 		defaultPrefix := netip.PrefixFrom(netip.IPv4Unspecified(), 0)
@@ -275,7 +396,7 @@ func getNetworkConfigDataForDevice(ctx context.Context, machineScope *scope.Mach
 
 		metric, err := findIPAddressGatewayMetric(ctx, machineScope, &ipAddr)
 		if err != nil {
-			return nil, errors.Wrapf(err, "error converting metric annotation, kind=%s, name=%s", ipAddr.Spec.PoolRef.Kind, ipAddr.Spec.PoolRef.Name)
+			return errors.Wrapf(err, "error converting metric annotation, kind=%s, name=%s", ipAddr.Spec.PoolRef.Kind, ipAddr.Spec.PoolRef.Name)
 		}
 
 		cloudinitNetworkConfigData.Routes = append(cloudinitNetworkConfigData.Routes, network.RoutingData{
@@ -285,7 +406,7 @@ func getNetworkConfigDataForDevice(ctx context.Context, machineScope *scope.Mach
 		})
 	}
 
-	return cloudinitNetworkConfigData, nil
+	return nil
 }
 
 // getCommonInterfaceConfig sets data which is common to all types of network interfaces.
@@ -304,18 +425,16 @@ func getCommonInterfaceConfig(_ context.Context, _ *scope.MachineScope, ciconfig
 	ciconfig.Routes = append(ciconfig.Routes, routes...)
 	ciconfig.FIBRules = rules
 	ciconfig.LinkMTU = ifconfig.LinkMTU
+	ciconfig.DHCP4 = ptr.Deref(ifconfig.DHCP4, false)
+	ciconfig.DHCP6 = ifconfig.GetIPv6Mode() == infrav1.IPv6ModeDHCPv6
+	ciconfig.IPv6Mode = ifconfig.GetIPv6Mode()
+	ciconfig.AcceptRA = ifconfig.AcceptRA
+	ciconfig.IPv6Token = ptr.Deref(ifconfig.IPv6Token, "")
 	return nil
 }
 
-func getNetworkDevices(ctx context.Context, machineScope *scope.MachineScope, networkSpec infrav1.NetworkSpec) ([]network.ConfigData, error) {
+func getNetworkDevices(ctx context.Context, machineScope *scope.MachineScope, networkSpec infrav1.NetworkSpec, ipAddressMap map[infrav1.NetName]map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress) ([]network.ConfigData, error) {
 	networkConfigData := make([]network.ConfigData, 0, len(networkSpec.NetworkDevices))
-	ipAddressMap := make(map[infrav1.NetName]map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress)
-
-	requeue, err := handleDevices(ctx, machineScope, ipAddressMap)
-	if requeue || err != nil {
-		// invalid state. Machine should've had all IPs assigned
-		return nil, errors.Wrapf(err, "unable to get IPs for network config data")
-	}
 
 	// network devices.
 	for i, nic := range networkSpec.NetworkDevices {
@@ -347,6 +466,84 @@ func getNetworkDevices(ctx context.Context, machineScope *scope.MachineScope, ne
 	return networkConfigData, nil
 }
 
+// getLinkDevices returns the network config data of the bonds, VLANs and bridges.
+// Members are resolved against data, which holds the network devices, and the
+// devices returned so far, so bonds precede VLANs which precede bridges.
+func getLinkDevices(ctx context.Context, machineScope *scope.MachineScope, networkSpec infrav1.NetworkSpec, ipAddressMap map[infrav1.NetName]map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress, data []network.ConfigData) ([]network.ConfigData, error) {
+	networkConfigData := make([]network.ConfigData, 0, len(networkSpec.Bonds)+len(networkSpec.VLANs)+len(networkSpec.Bridges))
+
+	newConfig := func(deviceType, name string, ifconfig infrav1.InterfaceConfig) (*network.ConfigData, error) {
+		config := &network.ConfigData{
+			Type:       deviceType,
+			Name:       name,
+			DNSServers: machineScope.InfraCluster.ProxmoxCluster.Spec.DNSServers,
+		}
+		if err := getIPConfigData(ctx, machineScope, config, ipAddressMap[infrav1.NetName(name)]); err != nil {
+			return nil, errors.Wrapf(err, "unable to get network config data for %s=%s", deviceType, name)
+		}
+		if err := getCommonInterfaceConfig(ctx, machineScope, config, ifconfig); err != nil {
+			return nil, errors.Wrapf(err, "unable to convert routing config for %s=%s", deviceType, name)
+		}
+		return config, nil
+	}
+
+	resolve := func(deviceType, name, member string) (string, error) {
+		for _, net := range slices.Concat(data, networkConfigData) {
+			if net.Name == member || string(net.ProxName) == member {
+				return net.Name, nil
+			}
+		}
+		return "", errors.Errorf("unable to find %s interface=%s child interface %s", deviceType, name, member)
+	}
+
+	for _, bond := range networkSpec.Bonds {
+		config, err := newConfig(network.TypeBond, bond.Name, bond.InterfaceConfig)
+		if err != nil {
+			return nil, err
+		}
+		config.BondMode = string(cmp.Or(bond.Mode, infrav1.BondModeActiveBackup))
+		config.BondMIIMon = bond.MIIMon
+		for _, member := range bond.Interfaces {
+			child, err := resolve(network.TypeBond, bond.Name, string(member))
+			if err != nil {
+				return nil, err
+			}
+			config.Children = append(config.Children, child)
+		}
+		networkConfigData = append(networkConfigData, *config)
+	}
+
+	for _, vlan := range networkSpec.VLANs {
+		config, err := newConfig(network.TypeVLAN, vlan.Name, vlan.InterfaceConfig)
+		if err != nil {
+			return nil, err
+		}
+		config.VLANID = new(vlan.ID)
+		if config.Link, err = resolve(network.TypeVLAN, vlan.Name, vlan.Link); err != nil {
+			return nil, err
+		}
+		networkConfigData = append(networkConfigData, *config)
+	}
+
+	for _, bridge := range networkSpec.Bridges {
+		config, err := newConfig(network.TypeBridge, bridge.Name, bridge.InterfaceConfig)
+		if err != nil {
+			return nil, err
+		}
+		config.STP = bridge.STP
+		for _, port := range bridge.Interfaces {
+			child, err := resolve(network.TypeBridge, bridge.Name, port)
+			if err != nil {
+				return nil, err
+			}
+			config.Children = append(config.Children, child)
+		}
+		networkConfigData = append(networkConfigData, *config)
+	}
+
+	return networkConfigData, nil
+}
+
 func getVirtualNetworkDevices(_ context.Context, _ *scope.MachineScope, networkSpec infrav1.NetworkSpec, data []network.ConfigData) ([]network.ConfigData, error) {
 	networkConfigData := make([]network.ConfigData, 0, len(networkSpec.VRFs))
 
@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
 
 	ipamicv1 "sigs.k8s.io/cluster-api-ipam-provider-in-cluster/api/v1alpha2"
@@ -38,8 +39,10 @@ import (
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/cloudinit"
 	. "github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/consts"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/ignition"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/kubevip"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/talos"
 )
 
 const (
@@ -416,6 +419,31 @@ func TestGetCommonInterfaceConfig(t *testing.T) {
 	require.Equal(t, "172.24.16.0/24", cfg.FIBRules[1].From.String())
 }
 
+func TestGetCommonInterfaceConfig_IPv6Mode(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
+
+	cfg := &network.ConfigData{Name: "net1"}
+	require.NoError(t, getCommonInterfaceConfig(context.Background(), machineScope, cfg, infrav1.InterfaceConfig{
+		IPv6Mode:  infrav1.IPv6ModeSLAAC,
+		IPv6Token: new("::10"),
+	}))
+	require.False(t, cfg.DHCP6)
+	require.Equal(t, infrav1.IPv6ModeSLAAC, cfg.IPv6Mode)
+	require.Equal(t, "::10", cfg.IPv6Token)
+	require.True(t, *cfg.IPv6AcceptRA())
+
+	// dhcp6 implies the ipv6 mode dhcpv6 and vice versa.
+	cfg = &network.ConfigData{Name: "net1"}
+	require.NoError(t, getCommonInterfaceConfig(context.Background(), machineScope, cfg, infrav1.InterfaceConfig{DHCP6: new(true)}))
+	require.True(t, cfg.DHCP6)
+	require.Equal(t, infrav1.IPv6ModeDHCPv6, cfg.IPv6Mode)
+
+	cfg = &network.ConfigData{Name: "net1"}
+	require.NoError(t, getCommonInterfaceConfig(context.Background(), machineScope, cfg, infrav1.InterfaceConfig{IPv6Mode: infrav1.IPv6ModeDHCPv6}))
+	require.True(t, cfg.DHCP6)
+	require.Nil(t, cfg.IPv6AcceptRA())
+}
+
 func TestGetVirtualNetworkDevices_VRFDevice_MissingInterface(t *testing.T) {
 	machineScope, _, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
 	machineScope.SetVirtualMachine(newStoppedVM())
@@ -437,6 +465,26 @@ func TestGetVirtualNetworkDevices_VRFDevice_MissingInterface(t *testing.T) {
 	require.Nil(t, cfg)
 }
 
+func TestGetLinkDevices_BondDevice_MissingInterface(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
+	machineScope.SetVirtualMachine(newStoppedVM())
+
+	networkSpec := infrav1.NetworkSpec{
+		VirtualNetworkDevices: infrav1.VirtualNetworkDevices{
+			Bonds: []infrav1.BondDevice{{
+				Name:       "bond0",
+				Interfaces: []infrav1.NetName{"net1"},
+			}},
+		},
+	}
+	networkConfigData := []network.ConfigData{{}}
+
+	cfg, err := getLinkDevices(context.Background(), machineScope, networkSpec, nil, networkConfigData)
+	require.Error(t, err)
+	require.Equal(t, "unable to find bond interface=bond0 child interface net1", err.Error())
+	require.Nil(t, cfg)
+}
+
 func TestReconcileBootstrapData_DualStack(t *testing.T) {
 	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
 	setupVMWithMetadata(machineScope, "virtio=A6:23:64:4D:84:CB,bridge=vmbr0", "virtio=AA:23:64:4D:84:CD,bridge=vmbr1")
@@ -667,6 +715,64 @@ func TestReconcileBootstrapData_VirtualDevices_VRF(t *testing.T) {
 	require.Equal(t, int32(500), *networkConfigData[2].Table)
 }
 
+func TestReconcileBootstrapData_VirtualDevices_BondVLAN(t *testing.T) {
+	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
+	setupVMWithMetadata(machineScope, "virtio=A6:23:64:4D:84:CB,bridge=vmbr0", "virtio=AA:23:64:4D:84:CD,bridge=vmbr1")
+	networkDataPtr := setupFakeIsoInjector(t)
+	createBootstrapSecret(t, kubeClient, machineScope, cloudinit.FormatCloudConfig)
+
+	machineScope.ProxmoxMachine.Spec.Network = &infrav1.NetworkSpec{
+		NetworkDevices: []infrav1.NetworkDevice{{
+			Name:   "net0",
+			Bridge: new("vmbr0"),
+			Model:  new("virtio"),
+		}, {
+			Name:   "net1",
+			Bridge: new("vmbr1"),
+			Model:  new("virtio"),
+		}},
+		VirtualNetworkDevices: infrav1.VirtualNetworkDevices{
+			Bonds: []infrav1.BondDevice{{
+				Name:       "bond0",
+				Interfaces: []infrav1.NetName{"net1"},
+				MIIMon:     new(int32(100)),
+			}},
+			VLANs: []infrav1.VLANDevice{{
+				Name: "bond0.42",
+				Link: "bond0",
+				ID:   42,
+			}},
+		},
+	}
+
+	// NetworkSetup for default pools
+	addDefaultIPPool(machineScope)
+
+	createNetworkSpecForMachine(t, kubeClient, machineScope, "10.10.10.10")
+
+	requeue, err := reconcileBootstrapData(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.True(t, *machineScope.ProxmoxMachine.Status.BootstrapDataProvided)
+
+	// Test if generated data is equal.
+	networkConfigData := getNetworkConfigDataFromVM(t, *networkDataPtr)
+	require.Equal(t, 4, len(networkConfigData))
+	require.Equal(t, "10.10.10.10/24", networkConfigData[0].IPConfigs[0].IPAddress.String())
+	require.Equal(t, 0, len(networkConfigData[1].IPConfigs))
+	// Bond data
+	require.Equal(t, "bond", networkConfigData[2].Type)
+	require.Equal(t, "bond0", networkConfigData[2].Name)
+	require.Equal(t, "active-backup", networkConfigData[2].BondMode)
+	require.Equal(t, int32(100), *networkConfigData[2].BondMIIMon)
+	require.Equal(t, []string{"eth1"}, networkConfigData[2].Children)
+	// VLAN data
+	require.Equal(t, "vlan", networkConfigData[3].Type)
+	require.Equal(t, "bond0.42", networkConfigData[3].Name)
+	require.Equal(t, "bond0", networkConfigData[3].Link)
+	require.Equal(t, int32(42), *networkConfigData[3].VLANID)
+}
+
 func TestVMHasMacAddress(t *testing.T) {
 	machineScope := &scope.MachineScope{VirtualMachine: newRunningVM()}
 	require.False(t, vmHasMacAddresses(machineScope))
@@ -724,6 +830,93 @@ func TestReconcileBootstrapData_Format_CloudConfig(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestReconcileBootstrapData_NetworkRenderer(t *testing.T) {
+	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
+	machineScope.ProxmoxMachine.Spec.Network = &infrav1.NetworkSpec{Renderer: infrav1.NetworkRendererNetworkManager}
+	setupVMWithMetadata(machineScope, "virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	createBootstrapSecret(t, kubeClient, machineScope, cloudinit.FormatCloudConfig)
+	createIPAddress(t, kubeClient, machineScope, infrav1.DefaultNetworkDevice, "10.10.10.10", 0)
+
+	var renderer cloudinit.Renderer
+	getISOInjector = func(_ *proxmox.VirtualMachine, _ []byte, _, network cloudinit.Renderer) isoInjector {
+		renderer = network
+		return FakeIgnitionISOInjector{}
+	}
+	t.Cleanup(func() { getISOInjector = defaultISOInjector })
+
+	requeue, err := reconcileBootstrapData(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.IsType(t, &cloudinit.NetworkManagerConfig{}, renderer)
+}
+
+func TestReconcileBootstrapData_KubeVIP(t *testing.T) {
+	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
+	machineScope.Machine.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	machineScope.InfraCluster.ProxmoxCluster.Spec.ControlPlaneEndpoint = infrav1.APIEndpoint{Host: "10.10.10.100", Port: 6443}
+	machineScope.InfraCluster.ProxmoxCluster.Spec.KubeVIP = &infrav1.KubeVIPSpec{Mode: infrav1.KubeVIPModeARP}
+	machineScope.Machine.Spec.Version = "v1.30.2"
+	setupVMWithMetadata(machineScope, "virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	createBootstrapSecret(t, kubeClient, machineScope, cloudinit.FormatCloudConfig)
+	createIPAddress(t, kubeClient, machineScope, infrav1.DefaultNetworkDevice, "10.10.10.10", 0)
+
+	var userData []byte
+	getISOInjector = func(_ *proxmox.VirtualMachine, bootstrapData []byte, _, _ cloudinit.Renderer) isoInjector {
+		userData = bootstrapData
+		return FakeIgnitionISOInjector{}
+	}
+	t.Cleanup(func() { getISOInjector = defaultISOInjector })
+
+	requeue, err := reconcileBootstrapData(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Contains(t, string(userData), "path: "+kubevip.ManifestPath)
+	require.Contains(t, string(userData), "value: 10.10.10.100")
+	// kubeadm init switches kube-vip back to admin.conf once it succeeded.
+	require.Contains(t, string(userData), "path: "+kubevip.AdminKubeconfigScriptPath)
+	require.Contains(t, string(userData), "systemd-run --unit "+kubevip.AdminKubeconfigUnit)
+}
+
+func TestKubeVIPManifest(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	machineScope.InfraCluster.ProxmoxCluster.Spec.ControlPlaneEndpoint = infrav1.APIEndpoint{Host: "10.10.10.100", Port: 6443}
+	require.Nil(t, kubeVIPManifest(machineScope))
+
+	// workers do not run kube-vip
+	machineScope.InfraCluster.ProxmoxCluster.Spec.KubeVIP = &infrav1.KubeVIPSpec{Mode: infrav1.KubeVIPModeARP}
+	require.Nil(t, kubeVIPManifest(machineScope))
+
+	machineScope.Machine.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	machineScope.Machine.Spec.Version = "v1.30.2"
+	manifest := kubeVIPManifest(machineScope)
+	require.NotNil(t, manifest)
+	require.Equal(t, "10.10.10.100", manifest.Endpoint.Host)
+	require.True(t, manifest.SuperAdminKubeconfig)
+
+	machineScope.Cluster.Status.Initialization.ControlPlaneInitialized = new(true)
+	require.False(t, kubeVIPManifest(machineScope).SuperAdminKubeconfig)
+
+	machineScope.Cluster.Status.Initialization.ControlPlaneInitialized = nil
+	machineScope.Machine.Spec.Version = "v1.28.9"
+	require.False(t, kubeVIPManifest(machineScope).SuperAdminKubeconfig)
+}
+
+func TestNewNetworkRenderer(t *testing.T) {
+	tests := map[infrav1.NetworkRenderer]cloudinit.Renderer{
+		"":                                     &cloudinit.NetworkConfig{},
+		infrav1.NetworkRendererNetplan:         &cloudinit.NetworkConfig{},
+		infrav1.NetworkRendererNetworkConfigV1: &cloudinit.NetworkConfigV1{},
+		infrav1.NetworkRendererNetworkManager:  &cloudinit.NetworkManagerConfig{},
+		infrav1.NetworkRendererENI:             &cloudinit.ENIConfig{},
+	}
+
+	for renderer, want := range tests {
+		t.Run(string(renderer), func(t *testing.T) {
+			require.IsType(t, want, newNetworkRenderer(renderer, nil))
+		})
+	}
+}
+
 func TestReconcileBootstrapData_Format_Ignition(t *testing.T) {
 	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
 	setupVMWithMetadata(machineScope, "virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
@@ -747,6 +940,37 @@ func TestReconcileBootstrapData_Format_Ignition(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestReconcileBootstrapData_Format_Talos(t *testing.T) {
+	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
+	setupVMWithMetadata(machineScope, "virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	createBootstrapSecret(t, kubeClient, machineScope, talos.FormatTalos)
+
+	defaultPool := addDefaultIPPool(machineScope)
+	createIPAddress(t, kubeClient, machineScope, infrav1.DefaultNetworkDevice, "10.10.10.10/24", 0, &defaultPool)
+
+	var patcher *talos.Patcher
+	getTalosISOInjector = func(_ *proxmox.VirtualMachine, _ cloudinit.Renderer, p *talos.Patcher) isoInjector {
+		patcher = p
+		return FakeIgnitionISOInjector{}
+	}
+	t.Cleanup(func() { getTalosISOInjector = defaultTalosISOInjector })
+
+	requeue, err := reconcileBootstrapData(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.True(t, *machineScope.ProxmoxMachine.Status.BootstrapDataProvided)
+
+	require.NotNil(t, patcher)
+	require.Equal(t, machineScope.Name(), patcher.Hostname)
+	require.Len(t, patcher.Network, 1)
+	require.Equal(t, "10.10.10.10/24", patcher.Network[0].IPConfigs[0].IPAddress.String())
+
+	data, err := patcher.Patch()
+	require.NoError(t, err)
+	require.Contains(t, string(data), "hardwareAddr: A6:23:64:4D:84:CB")
+	require.Contains(t, string(data), "- 10.10.10.10/24")
+}
+
 func TestDefaultISOInjector(t *testing.T) {
 	injector := defaultISOInjector(newRunningVM(), []byte("data"), cloudinit.NewMetadata(biosUUID, "test", "1.2.3", true), cloudinit.NewNetworkConfig(nil))
 
@@ -765,6 +989,17 @@ func TestIgnitionISOInjector(t *testing.T) {
 	require.Equal(t, []byte("data"), injector.(*inject.ISOInjector).IgnitionEnricher.BootstrapData)
 }
 
+func TestTalosISOInjector(t *testing.T) {
+	injector := defaultTalosISOInjector(newRunningVM(), cloudinit.NewMetadata(biosUUID, "test", "1.2.3", true), &talos.Patcher{
+		BootstrapData: []byte("data"),
+		Hostname:      "test",
+	})
+
+	require.NotEmpty(t, injector)
+	require.NotNil(t, injector.(*inject.ISOInjector).TalosPatcher)
+	require.Equal(t, []byte("data"), injector.(*inject.ISOInjector).TalosPatcher.BootstrapData)
+}
+
 func TestReconcileBootstrapData_DefaultDeviceIPPoolRef(t *testing.T) {
 	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapDataReconciliationReason)
 	setupVMWithMetadata(machineScope, "virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
@@ -32,29 +33,63 @@ import (
 )
 
 // DeleteVM implements the logic of destroying a VM.
+// A VM which is not owned by the ProxmoxMachine, e.g. because its VMID was reused, is left alone.
 func DeleteVM(ctx context.Context, machineScope *scope.MachineScope) error {
 	vmID := machineScope.ProxmoxMachine.GetVirtualMachineID()
 	node := machineScope.LocateProxmoxNode()
+	if vmID < 0 {
+		// the VM was never cloned.
+		return forgetVM(machineScope)
+	}
+
+	vm, err := machineScope.InfraCluster.ProxmoxClient.GetVM(ctx, node, vmID)
+	if err != nil {
+		if VMNotFound(err) {
+			return vmDeleted(machineScope, node, vmID)
+		}
+		return errors.Wrapf(err, "unable to get vm %d", vmID)
+	}
+	if err := verifyVMOwnership(machineScope, vm); err != nil {
+		record.Warnf(machineScope.ProxmoxMachine, "VirtualMachineNotOwned", "Not deleting virtual machine %d on node %s: %s", vmID, node, err)
+		return forgetVM(machineScope)
+	}
+
+	// the HA manager would start the VM again while it is being deleted.
+	if err := DeregisterHAResource(ctx, machineScope.InfraCluster, vm); err != nil {
+		record.Warnf(machineScope.ProxmoxMachine, "DeletionFailed", "Failed to delete virtual machine %d on node %s: %s", vmID, node, err)
+		return err
+	}
 
 	if _, err := machineScope.InfraCluster.ProxmoxClient.DeleteVM(ctx, node, vmID); err != nil {
 		if VMNotFound(err) || errors.Is(err, goproxmox.ErrVMIDFree) {
-			// remove machine from cluster status
-			machineScope.InfraCluster.ProxmoxCluster.RemoveNodeLocation(machineScope.Name(), util.IsControlPlaneMachine(machineScope.Machine))
-			// The VM is deleted so remove the finalizer.
-			ctrlutil.RemoveFinalizer(machineScope.ProxmoxMachine, infrav1.MachineFinalizer)
-			return machineScope.InfraCluster.PatchObject()
+			return vmDeleted(machineScope, node, vmID)
 		}
 		conditions.Set(machineScope.ProxmoxMachine, metav1.Condition{
 			Type:   infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
 			Status: metav1.ConditionFalse,
 			Reason: infrav1.ProxmoxMachineVirtualMachineProvisionedDeletionFailedReason,
 		})
+		record.Warnf(machineScope.ProxmoxMachine, "DeletionFailed", "Failed to delete virtual machine %d on node %s: %s", vmID, node, err)
 		return err
 	}
+	record.Eventf(machineScope.ProxmoxMachine, "DeletingVirtualMachine", "Deleting virtual machine %d on node %s", vmID, node)
 
 	return nil
 }
 
+// vmDeleted cleans up after the VM of the ProxmoxMachine is gone.
+func vmDeleted(machineScope *scope.MachineScope, node string, vmID int64) error {
+	record.Eventf(machineScope.ProxmoxMachine, "VirtualMachineDeleted", "Virtual machine %d is gone from node %s", vmID, node)
+	return forgetVM(machineScope)
+}
+
+// forgetVM removes the machine from the cluster status and removes the finalizer.
+func forgetVM(machineScope *scope.MachineScope) error {
+	machineScope.InfraCluster.ProxmoxCluster.RemoveNodeLocation(machineScope.Name(), util.IsControlPlaneMachine(machineScope.Machine))
+	ctrlutil.RemoveFinalizer(machineScope.ProxmoxMachine, infrav1.MachineFinalizer)
+	return machineScope.InfraCluster.PatchObject()
+}
+
 // VMNotFound checks if the given err is related to that the VM is not found in Proxmox.
 func VMNotFound(err error) bool {
 	return strings.Contains(err.Error(), "does not exist")
@@ -36,9 +36,66 @@ func TestDeleteVM_SuccessNotFound(t *testing.T) {
 		Node:    "node1",
 	}, false)
 
+	proxmoxClient.EXPECT().GetVM(context.TODO(), "node1", int64(123)).Return(vm, nil).Once()
 	proxmoxClient.EXPECT().DeleteVM(context.TODO(), "node1", int64(123)).Return(nil, errors.New("vm does not exist: some reason")).Once()
 
 	require.NoError(t, DeleteVM(context.TODO(), machineScope))
 	require.Empty(t, machineScope.ProxmoxMachine.Finalizers)
 	require.Empty(t, machineScope.InfraCluster.ProxmoxCluster.GetNode(machineScope.Name(), false))
 }
+
+func TestDeleteVM_Deleting(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(vm.VMID))
+
+	proxmoxClient.EXPECT().GetVM(context.TODO(), "node1", int64(123)).Return(vm, nil).Once()
+	proxmoxClient.EXPECT().DeleteVM(context.TODO(), "node1", int64(123)).Return(newTask(), nil).Once()
+
+	require.NoError(t, DeleteVM(context.TODO(), machineScope))
+	require.NotEmpty(t, machineScope.ProxmoxMachine.Finalizers)
+	require.Equal(t, []string{"Normal DeletingVirtualMachine Deleting virtual machine 123 on node node1"}, recordedEvents())
+}
+
+func TestDeleteVM_DeregistersHAResource(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
+	vm.HA.Managed = 1
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(vm.VMID))
+
+	proxmoxClient.EXPECT().GetVM(context.TODO(), "node1", int64(123)).Return(vm, nil).Once()
+	deregister := proxmoxClient.EXPECT().DeleteHAResource(context.TODO(), "vm:123").Return(nil).Once()
+	proxmoxClient.EXPECT().DeleteVM(context.TODO(), "node1", int64(123)).Return(newTask(), nil).Once().NotBefore(deregister)
+
+	require.NoError(t, DeleteVM(context.TODO(), machineScope))
+}
+
+func TestDeleteVM_NotOwned(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = "[capmox]\ncluster = other/test\nproxmoxmachine = test\nuid = other-uid"
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(vm.VMID))
+	machineScope.InfraCluster.ProxmoxCluster.AddNodeLocation(infrav1.NodeLocation{
+		Machine: corev1.LocalObjectReference{Name: machineScope.Name()},
+		Node:    "node1",
+	}, false)
+
+	// the VM is left alone, no DeleteVM call is expected.
+	proxmoxClient.EXPECT().GetVM(context.TODO(), "node1", int64(123)).Return(vm, nil).Once()
+
+	require.NoError(t, DeleteVM(context.TODO(), machineScope))
+	require.Empty(t, machineScope.ProxmoxMachine.Finalizers)
+	require.Empty(t, machineScope.InfraCluster.ProxmoxCluster.GetNode(machineScope.Name(), false))
+}
+
+func TestDeleteVM_GetVMFailed(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(123))
+
+	proxmoxClient.EXPECT().GetVM(context.TODO(), "node1", int64(123)).Return(nil, errors.New("connection refused")).Once()
+
+	require.Error(t, DeleteVM(context.TODO(), machineScope))
+	require.NotEmpty(t, machineScope.ProxmoxMachine.Finalizers)
+}
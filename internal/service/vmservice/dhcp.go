@@ -0,0 +1,194 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// dhcpDevice is a guest network device which is configured with DHCP, or with
+// SLAAC for IPv6.
+type dhcpDevice struct {
+	name infrav1.NetName
+	// mac is the hardware address of a proxmox network device. Bonds, VLANs
+	// and bridges share the hardware address of a member and are looked up by
+	// their name instead.
+	mac   string
+	dhcp4 bool
+	dhcp6 bool
+}
+
+// dhcpDevices returns the devices of a machine which are configured with DHCP,
+// and whether any device is attached to the default IPv4/IPv6 network.
+func dhcpDevices(machineScope *scope.MachineScope) (devices []dhcpDevice, defaultIPv4, defaultIPv6 bool) {
+	networkSpec := ptr.Deref(machineScope.ProxmoxMachine.Spec.Network, infrav1.NetworkSpec{})
+	nets := machineScope.VirtualMachine.VirtualMachineConfig.Nets
+
+	add := func(name infrav1.NetName, mac string, ifconfig infrav1.InterfaceConfig, v4, v6 *bool) {
+		defaultIPv4 = defaultIPv4 || ptr.Deref(v4, false)
+		defaultIPv6 = defaultIPv6 || ptr.Deref(v6, false)
+		d := dhcpDevice{name, mac, ptr.Deref(ifconfig.DHCP4, false), ifconfig.DynamicIPv6()}
+		if d.dhcp4 || d.dhcp6 {
+			devices = append(devices, d)
+		}
+	}
+	for _, nic := range networkSpec.NetworkDevices {
+		add(nic.Name, extractMACAddress(nets[string(nic.Name)]), nic.InterfaceConfig, nic.DefaultIPv4, nic.DefaultIPv6)
+	}
+	for _, bond := range networkSpec.Bonds {
+		add(infrav1.NetName(bond.Name), "", bond.InterfaceConfig, bond.DefaultIPv4, bond.DefaultIPv6)
+	}
+	for _, vlan := range networkSpec.VLANs {
+		add(infrav1.NetName(vlan.Name), "", vlan.InterfaceConfig, vlan.DefaultIPv4, vlan.DefaultIPv6)
+	}
+	for _, bridge := range networkSpec.Bridges {
+		add(infrav1.NetName(bridge.Name), "", bridge.InterfaceConfig, bridge.DefaultIPv4, bridge.DefaultIPv6)
+	}
+	return devices, defaultIPv4, defaultIPv6
+}
+
+// guestLinkNames returns the guest names of the virtual devices of a machine.
+func guestLinkNames(networkSpec infrav1.NetworkSpec) []string {
+	var names []string
+	for _, bond := range networkSpec.Bonds {
+		names = append(names, bond.Name)
+	}
+	for _, vlan := range networkSpec.VLANs {
+		names = append(names, vlan.Name)
+	}
+	for _, bridge := range networkSpec.Bridges {
+		names = append(names, bridge.Name)
+	}
+	for _, vrf := range networkSpec.VRFs {
+		names = append(names, vrf.Name)
+	}
+	return names
+}
+
+// findGuestInterface returns the guest interface of a device or nil. Proxmox
+// network devices are matched by their hardware address, skipping the virtual
+// devices which share it, all other devices by their name.
+func findGuestInterface(ifaces []*proxmox.AgentNetworkIface, name, mac string, linkNames []string) *proxmox.AgentNetworkIface {
+	index := slices.IndexFunc(ifaces, func(iface *proxmox.AgentNetworkIface) bool {
+		if mac == "" {
+			return iface.Name == name
+		}
+		return strings.EqualFold(iface.HardwareAddress, mac) && !slices.Contains(linkNames, iface.Name)
+	})
+	if index < 0 {
+		return nil
+	}
+	return ifaces[index]
+}
+
+// guestAddresses returns the global unicast addresses of a guest interface.
+func guestAddresses(iface *proxmox.AgentNetworkIface) (ipv4, ipv6 []string) {
+	for _, address := range iface.IPAddresses {
+		addr, err := netip.ParseAddr(address.IPAddress)
+		if err != nil || !addr.IsGlobalUnicast() {
+			continue
+		}
+		if addr.Is4() {
+			ipv4 = append(ipv4, addr.String())
+		} else {
+			ipv6 = append(ipv6, addr.WithZone("").String())
+		}
+	}
+	return ipv4, ipv6
+}
+
+// errGuestAgentNotRunning is returned by reconcileDHCPAddresses while the QEMU
+// guest agent is not up, which is expected until the guest has booted.
+var errGuestAgentNotRunning = errors.New("guest agent is not running")
+
+// reconcileDHCPAddresses reads the addresses of the devices configured with
+// DHCP from the QEMU guest agent and publishes them in status.ipAddresses.
+func reconcileDHCPAddresses(ctx context.Context, machineScope *scope.MachineScope) error {
+	ifaces, err := machineScope.InfraCluster.ProxmoxClient.QemuAgentNetworkInterfaces(ctx, machineScope.VirtualMachine)
+	if errors.Is(err, goproxmox.ErrAgentNotRunning) {
+		return errGuestAgentNotRunning
+	}
+	if err != nil {
+		return errors.Wrap(err, "unable to discover DHCP addresses")
+	}
+
+	setDHCPAddresses(machineScope, ifaces)
+	return nil
+}
+
+// setDHCPAddresses publishes the addresses the guest reports for the devices
+// configured with DHCP in status.ipAddresses. The guest agent is authoritative
+// for the address families a device uses DHCP for, so renewed leases replace
+// the addresses previously recorded. Devices the guest does not report are left
+// untouched. If no device is attached to the default network of a family, the
+// first device with DHCP for that family provides the default addresses.
+func setDHCPAddresses(machineScope *scope.MachineScope, ifaces []*proxmox.AgentNetworkIface) {
+	pm := machineScope.ProxmoxMachine
+
+	devices, defaultIPv4, defaultIPv6 := dhcpDevices(machineScope)
+	if len(devices) == 0 {
+		return
+	}
+
+	linkNames := guestLinkNames(ptr.Deref(pm.Spec.Network, infrav1.NetworkSpec{}))
+	defaultSpec := ptr.Deref(pm.GetIPAddressesNet("default"), infrav1.IPAddressesSpec{NetName: "default"})
+	dhcpDefault4, dhcpDefault6 := !defaultIPv4, !defaultIPv6
+
+	for _, device := range devices {
+		iface := findGuestInterface(ifaces, string(device.name), device.mac, linkNames)
+		if iface == nil {
+			machineScope.Logger.V(4).Info("guest agent does not report device", "device", device.name)
+			continue
+		}
+		ipv4, ipv6 := guestAddresses(iface)
+
+		ipSpec := ptr.Deref(pm.GetIPAddressesNet(device.name), infrav1.IPAddressesSpec{NetName: string(device.name)})
+		if device.dhcp4 {
+			ipSpec.IPv4 = ipv4
+			if dhcpDefault4 && len(ipv4) > 0 {
+				defaultSpec.IPv4, dhcpDefault4 = ipv4, false
+			}
+		}
+		if device.dhcp6 {
+			ipSpec.IPv6 = ipv6
+			if dhcpDefault6 && len(ipv6) > 0 {
+				defaultSpec.IPv6, dhcpDefault6 = ipv6, false
+			}
+		}
+		pm.SetIPAddresses(ipSpec)
+	}
+	pm.SetIPAddresses(defaultSpec)
+}
+
+// hasDefaultAddresses returns true if the default network of a machine has an address.
+func hasDefaultAddresses(pm *infrav1.ProxmoxMachine) bool {
+	defaultSpec := pm.GetIPAddressesNet("default")
+	return defaultSpec != nil && slices.ContainsFunc(slices.Concat(defaultSpec.IPv4, defaultSpec.IPv6), func(s string) bool {
+		return s != ""
+	})
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+)
+
+func guestInterface(name, mac string, addresses ...string) *proxmox.AgentNetworkIface {
+	iface := &proxmox.AgentNetworkIface{Name: name, HardwareAddress: mac}
+	for _, address := range addresses {
+		iface.IPAddresses = append(iface.IPAddresses, &proxmox.AgentNetworkIPAddress{IPAddress: address})
+	}
+	return iface
+}
+
+func TestReconcileMachineAddresses_DHCP(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForClusterAPIMachineAddressesReason)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
+		Name:            infrav1.DefaultNetworkDevice,
+		InterfaceConfig: infrav1.InterfaceConfig{DHCP4: new(true)},
+	}}
+
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return([]*proxmox.AgentNetworkIface{
+		guestInterface("eth0", "a6:23:64:4d:84:cb", "192.168.1.50", "fe80::a423:64ff:fe4d:84cb"),
+	}, nil).Once()
+
+	requeue, err := reconcileMachineAddresses(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Equal(t, []string{"192.168.1.50"}, machineScope.ProxmoxMachine.GetIPAddressesNet("default").IPv4)
+	require.Equal(t, []string{"192.168.1.50"}, machineScope.ProxmoxMachine.GetIPAddressesNet(infrav1.DefaultNetworkDevice).IPv4)
+	require.Equal(t, []clusterv1.MachineAddress{
+		{Type: clusterv1.MachineHostName, Address: machineScope.ProxmoxMachine.GetName()},
+		{Type: clusterv1.MachineInternalIP, Address: "192.168.1.50"},
+	}, machineScope.ProxmoxMachine.Status.Addresses)
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForCloudInitReason,
+		conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition))
+}
+
+func TestReconcileMachineAddresses_DHCPWaitingForLease(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForClusterAPIMachineAddressesReason)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
+		Name:            infrav1.DefaultNetworkDevice,
+		InterfaceConfig: infrav1.InterfaceConfig{DHCP4: new(true)},
+	}}
+
+	// the guest agent is not up yet.
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return(nil, goproxmox.ErrAgentNotRunning).Once()
+	requeue, err := reconcileMachineAddresses(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+
+	// the guest has not received a lease yet.
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return([]*proxmox.AgentNetworkIface{
+		guestInterface("eth0", "A6:23:64:4D:84:CB", "fe80::a423:64ff:fe4d:84cb"),
+	}, nil).Once()
+	requeue, err = reconcileMachineAddresses(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+
+	require.Empty(t, machineScope.ProxmoxMachine.Status.Addresses)
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForClusterAPIMachineAddressesReason,
+		conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition))
+}
+
+func TestReconcileMachineAddresses_DHCPError(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForClusterAPIMachineAddressesReason)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
+		Name:            infrav1.DefaultNetworkDevice,
+		InterfaceConfig: infrav1.InterfaceConfig{DHCP4: new(true)},
+	}}
+
+	// errors other than a guest agent which is not up yet are returned.
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return(nil, errors.New("403 Permission check failed")).Once()
+	requeue, err := reconcileMachineAddresses(context.Background(), machineScope)
+	require.ErrorContains(t, err, "unable to discover DHCP addresses: 403 Permission check failed")
+	require.False(t, requeue)
+}
+
+func TestReconcileDHCPAddresses_SLAAC(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0", "virtio=A6:23:64:4D:84:CC,bridge=vmbr1")
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
+		Name:        infrav1.DefaultNetworkDevice,
+		DefaultIPv4: new(true),
+	}, {
+		Name:            "net1",
+		InterfaceConfig: infrav1.InterfaceConfig{IPv6Mode: infrav1.IPv6ModeSLAAC},
+	}}
+	machineScope.ProxmoxMachine.Status.IPAddresses = []infrav1.IPAddressesSpec{
+		{NetName: "default", IPv4: []string{"10.0.0.10"}},
+	}
+
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return([]*proxmox.AgentNetworkIface{
+		guestInterface("eth0", "A6:23:64:4D:84:CB", "10.0.0.10"),
+		guestInterface("eth1", "A6:23:64:4D:84:CC", "2001:db8:1::10", "fe80::a423:64ff:fe4d:84cc"),
+	}, nil).Once()
+
+	require.NoError(t, reconcileDHCPAddresses(context.Background(), machineScope))
+
+	require.Equal(t, infrav1.IPAddressesSpec{NetName: "net1", IPv6: []string{"2001:db8:1::10"}},
+		*machineScope.ProxmoxMachine.GetIPAddressesNet("net1"))
+}
+
+func TestReconcileDHCPAddresses_LinkDevices(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0", "virtio=A6:23:64:4D:84:CC,bridge=vmbr1")
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
+		Name: infrav1.DefaultNetworkDevice,
+	}, {
+		Name:            "net1",
+		InterfaceConfig: infrav1.InterfaceConfig{DHCP6: new(true)},
+	}}
+	machineScope.ProxmoxMachine.Spec.Network.Bonds = []infrav1.BondDevice{{
+		Name:            "bond0",
+		Interfaces:      []infrav1.NetName{infrav1.DefaultNetworkDevice},
+		InterfaceConfig: infrav1.InterfaceConfig{DHCP4: new(true)},
+		DefaultIPv6:     new(true),
+	}}
+	machineScope.ProxmoxMachine.Spec.Network.VLANs = []infrav1.VLANDevice{{
+		Name: "eth1.42",
+		Link: "net1",
+		ID:   42,
+	}}
+	machineScope.ProxmoxMachine.Status.IPAddresses = []infrav1.IPAddressesSpec{
+		{NetName: "default", IPv6: []string{"2001:db8::2"}},
+		{NetName: "bond0", IPv6: []string{"2001:db8::2"}},
+	}
+
+	// bond0 and the VLAN share the hardware address of their member.
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return([]*proxmox.AgentNetworkIface{
+		guestInterface("eth0", "A6:23:64:4D:84:CB"),
+		guestInterface("eth1", "A6:23:64:4D:84:CC", "2001:db8:1::10", "fe80::a423:64ff:fe4d:84cc"),
+		guestInterface("bond0", "A6:23:64:4D:84:CB", "10.0.0.50", "2001:db8::2"),
+		guestInterface("eth1.42", "A6:23:64:4D:84:CC", "2001:db8:42::10"),
+	}, nil).Once()
+
+	require.NoError(t, reconcileDHCPAddresses(context.Background(), machineScope))
+
+	require.Equal(t, infrav1.IPAddressesSpec{NetName: "default", IPv4: []string{"10.0.0.50"}, IPv6: []string{"2001:db8::2"}},
+		*machineScope.ProxmoxMachine.GetIPAddressesNet("default"))
+	require.Equal(t, infrav1.IPAddressesSpec{NetName: "bond0", IPv4: []string{"10.0.0.50"}, IPv6: []string{"2001:db8::2"}},
+		*machineScope.ProxmoxMachine.GetIPAddressesNet("bond0"))
+	require.Equal(t, infrav1.IPAddressesSpec{NetName: "net1", IPv6: []string{"2001:db8:1::10"}},
+		*machineScope.ProxmoxMachine.GetIPAddressesNet("net1"))
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// reconcileConfigDrift compares the configuration of a provisioned VM with the spec of
+// its ProxmoxMachine and reports the differences in the ConfigDrift condition and in
+// events. If the cluster enforces its configuration, the differences are reverted.
+func reconcileConfigDrift(ctx context.Context, machineScope *scope.MachineScope) (requeue bool, err error) {
+	pm := machineScope.ProxmoxMachine
+	if !ptr.Deref(pm.Status.Initialization.Provisioned, false) {
+		// drift is only observed once the machine is provisioned.
+		return false, nil
+	}
+
+	drift, vmOptions := configDrift(machineScope)
+	if len(drift) == 0 {
+		conditions.Set(pm, metav1.Condition{
+			Type:   infrav1.ProxmoxMachineConfigDriftCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.ProxmoxMachineConfigDriftNoDriftReason,
+		})
+		return false, nil
+	}
+	message := strings.Join(drift, "; ")
+
+	if machineScope.InfraCluster.ProxmoxCluster.GetConfigDriftPolicy() == infrav1.ConfigDriftPolicyEnforce && len(vmOptions) > 0 {
+		machineScope.Logger.Info("reverting virtual machine config drift", "drift", message)
+
+		task, err := machineScope.InfraCluster.ProxmoxClient.ConfigureVM(ctx, machineScope.VirtualMachine, vmOptions...)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to revert config drift of VM %s", machineScope.Name())
+		}
+		machineScope.ProxmoxMachine.Status.TaskRef = new(string(task.UPID))
+
+		record.Eventf(pm, "CorrectingConfigDrift", "Reverting virtual machine config drift: %s", message)
+		conditions.Set(pm, metav1.Condition{
+			Type:    infrav1.ProxmoxMachineConfigDriftCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  infrav1.ProxmoxMachineConfigDriftCorrectingReason,
+			Message: message,
+		})
+		return true, nil
+	}
+
+	// only report drift once, and again whenever it changes.
+	if cond := conditions.Get(pm, infrav1.ProxmoxMachineConfigDriftCondition); cond == nil ||
+		cond.Reason != infrav1.ProxmoxMachineConfigDriftDetectedReason || cond.Message != message {
+		record.Warnf(pm, "ConfigDriftDetected", "Virtual machine config drifted from spec: %s", message)
+	}
+	conditions.Set(pm, metav1.Condition{
+		Type:    infrav1.ProxmoxMachineConfigDriftCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  infrav1.ProxmoxMachineConfigDriftDetectedReason,
+		Message: message,
+	})
+	return false, nil
+}
+
+// configDrift returns the differences between the configuration of the VM and the spec
+// of the ProxmoxMachine, and the options which revert them. A VM converted into a template
+// or moved to a node which is not allowed is reported, but not reverted.
+func configDrift(machineScope *scope.MachineScope) (drift []string, vmOptions []proxmox.VirtualMachineOption) {
+	pm := machineScope.ProxmoxMachine
+	vm := machineScope.VirtualMachine
+	vmConfig := vm.VirtualMachineConfig
+
+	if vm.Template {
+		drift = append(drift, "virtual machine was converted to a template")
+	}
+
+	allowedNodes := machineScope.InfraCluster.ProxmoxCluster.Spec.AllowedNodes
+	if len(pm.Spec.AllowedNodes) > 0 {
+		allowedNodes = pm.Spec.AllowedNodes
+	}
+	if len(allowedNodes) > 0 && !slices.Contains(allowedNodes, vm.Node) {
+		drift = append(drift, fmt.Sprintf("node %s is not an allowed node", vm.Node))
+	}
+
+	// CPU & Memory
+	sockets := ptr.Deref(pm.Spec.NumSockets, 0)
+	cores := ptr.Deref(pm.Spec.NumCores, 0)
+	memory := ptr.Deref(pm.Spec.MemoryMiB, 0)
+	if sockets > 0 && ptr.Deref(vmConfig.Sockets, 0) != int(sockets) {
+		drift = append(drift, fmt.Sprintf("sockets is %d, expected %d", ptr.Deref(vmConfig.Sockets, 0), sockets))
+		vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionSockets, Value: sockets})
+	}
+	if cores > 0 && ptr.Deref(vmConfig.Cores, 0) != int(cores) {
+		drift = append(drift, fmt.Sprintf("cores is %d, expected %d", ptr.Deref(vmConfig.Cores, 0), cores))
+		vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionCores, Value: cores})
+	}
+	if memory > 0 && int(vmConfig.Memory) != int(memory) {
+		drift = append(drift, fmt.Sprintf("memory is %d MiB, expected %d MiB", int(vmConfig.Memory), memory))
+		vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionMemory, Value: memory})
+	}
+
+	// Description
+	if _, description, _ := ParseOwnerMarkers(vmConfig.Description); pm.Spec.Description != nil && description != *pm.Spec.Description {
+		drift = append(drift, "description differs")
+		vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionDescription, Value: vmDescription(machineScope)})
+	}
+
+	// Network devices
+	if pm.Spec.Network != nil {
+		firewall := machineFirewall(machineScope)
+		for _, device := range pm.Spec.Network.NetworkDevices {
+			name := device.Name
+			if len(name) == 0 {
+				name = infrav1.DefaultNetworkDevice
+			}
+			net := vmConfig.Nets[string(name)]
+			deviceDrift := networkDeviceDrift(device, net, firewall)
+			if len(deviceDrift) == 0 {
+				continue
+			}
+			drift = append(drift, fmt.Sprintf("%s: %s", name, strings.Join(deviceDrift, ", ")))
+
+			// keep the hardware address the guest network configuration is bound to.
+			model := ptr.Deref(device.Model, "virtio")
+			if mac := extractMACAddress(net); mac != "" {
+				model = fmt.Sprintf("%s=%s", model, mac)
+			}
+			vmOptions = append(vmOptions, proxmox.VirtualMachineOption{
+				Name:  string(name),
+				Value: formatNetworkDevice(model, networkDeviceBridge(device), device.MTU, device.VLAN, device.Queues, firewallEnabled(firewall)),
+			})
+		}
+	}
+
+	// custom tags, tags added to the VM are kept.
+	if pm.Spec.Tags != nil {
+		var missing []string
+		for _, tag := range pm.Spec.Tags {
+			if !vm.HasTag(tag) {
+				missing = append(missing, tag)
+			}
+		}
+		if len(missing) > 0 {
+			drift = append(drift, fmt.Sprintf("tags %s are missing", strings.Join(missing, ", ")))
+			tags := slices.DeleteFunc(strings.Split(vmConfig.Tags, proxmox.TagSeperator), func(tag string) bool {
+				return tag == ""
+			})
+			vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionTags, Value: strings.Join(slices.Concat(tags, missing), ";")})
+		}
+	}
+
+	return drift, vmOptions
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+func requireConfigDriftCondition(t *testing.T, pm *infrav1.ProxmoxMachine, status metav1.ConditionStatus, reason, message string) {
+	t.Helper()
+	cond := conditions.Get(pm, infrav1.ProxmoxMachineConfigDriftCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, status, cond.Status)
+	require.Equal(t, reason, cond.Reason)
+	require.Equal(t, message, cond.Message)
+}
+
+func setupDriftTest(t *testing.T) (*scope.MachineScope, *proxmox.VirtualMachine) {
+	t.Helper()
+	machineScope, _, _ := setupReconcilerTest(t)
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+	machineScope.ProxmoxMachine.Spec.NumCores = new(int32(4))
+	machineScope.ProxmoxMachine.Spec.MemoryMiB = new(int32(4096))
+	machineScope.ProxmoxMachine.Spec.Tags = []string{"capmox"}
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{
+		{Name: "net0", Bridge: new("vmbr0"), Model: new("virtio")},
+		{Name: "net1", Bridge: new("vmbr1"), Model: new("virtio"), VLAN: new(int32(42))},
+	}
+
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0", "virtio=A6:23:64:4D:84:CC,bridge=vmbr1,tag=42")
+	vm.VirtualMachineConfig.Cores = new(4)
+	vm.VirtualMachineConfig.Memory = 4096
+	vm.VirtualMachineConfig.Tags = "capmox"
+	machineScope.SetVirtualMachine(vm)
+	return machineScope, vm
+}
+
+func TestReconcileConfigDrift_NoDrift(t *testing.T) {
+	machineScope, _ := setupDriftTest(t)
+
+	requeue, err := reconcileConfigDrift(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	requireConfigDriftCondition(t, machineScope.ProxmoxMachine, metav1.ConditionFalse, infrav1.ProxmoxMachineConfigDriftNoDriftReason, "")
+}
+
+func TestReconcileConfigDrift_NotProvisioned(t *testing.T) {
+	machineScope, vm := setupDriftTest(t)
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = nil
+	vm.VirtualMachineConfig.Cores = new(8)
+
+	requeue, err := reconcileConfigDrift(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.False(t, conditions.Has(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineConfigDriftCondition))
+}
+
+func TestReconcileConfigDrift_Report(t *testing.T) {
+	machineScope, vm := setupDriftTest(t)
+	machineScope.InfraCluster.ProxmoxCluster.Spec.AllowedNodes = []string{"node1", "node2"}
+	vm.Node = "node3"
+	vm.Template = true
+	vm.VirtualMachineConfig.Cores = new(8)
+	vm.VirtualMachineConfig.Memory = 8192
+	vm.VirtualMachineConfig.Nets["net1"] = "virtio=A6:23:64:4D:84:CC,bridge=vmbr2,tag=43"
+	vm.VirtualMachineConfig.Tags = "manual"
+
+	// no ConfigureVM call is expected in report mode.
+	requeue, err := reconcileConfigDrift(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.TaskRef)
+	requireConfigDriftCondition(t, machineScope.ProxmoxMachine, metav1.ConditionTrue, infrav1.ProxmoxMachineConfigDriftDetectedReason,
+		"virtual machine was converted to a template; node node3 is not an allowed node; cores is 8, expected 4; "+
+			"memory is 8192 MiB, expected 4096 MiB; net1: bridge is vmbr2, expected vmbr1, vlan is 43, expected 42; tags capmox are missing")
+}
+
+func TestReconcileConfigDrift_Enforce(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	machineScope.InfraCluster.ProxmoxCluster.Spec.ConfigDriftPolicy = infrav1.ConfigDriftPolicyEnforce
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+	machineScope.ProxmoxMachine.Spec.NumCores = new(int32(4))
+	machineScope.ProxmoxMachine.Spec.Tags = []string{"capmox"}
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{
+		{Name: "net0", Bridge: new("vmbr0"), Model: new("virtio"), VLAN: new(int32(42))},
+	}
+
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr1")
+	vm.VirtualMachineConfig.Cores = new(8)
+	vm.VirtualMachineConfig.Tags = "manual"
+	machineScope.SetVirtualMachine(vm)
+
+	expectedOptions := []any{
+		proxmox.VirtualMachineOption{Name: optionCores, Value: int32(4)},
+		// the hardware address of the device is kept.
+		proxmox.VirtualMachineOption{Name: "net0", Value: "virtio=A6:23:64:4D:84:CB,bridge=vmbr0,tag=42"},
+		proxmox.VirtualMachineOption{Name: optionTags, Value: "manual;capmox"},
+	}
+	proxmoxClient.EXPECT().ConfigureVM(context.Background(), vm, expectedOptions...).Return(newTask(), nil).Once()
+
+	requeue, err := reconcileConfigDrift(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+	require.NotNil(t, machineScope.ProxmoxMachine.Status.TaskRef)
+	requireConfigDriftCondition(t, machineScope.ProxmoxMachine, metav1.ConditionTrue, infrav1.ProxmoxMachineConfigDriftCorrectingReason,
+		"cores is 8, expected 4; net0: bridge is vmbr1, expected vmbr0, vlan is 0, expected 42; tags capmox are missing")
+}
+
+func TestReconcileConfigDrift_EnforceTemplate(t *testing.T) {
+	machineScope, vm := setupDriftTest(t)
+	machineScope.InfraCluster.ProxmoxCluster.Spec.ConfigDriftPolicy = infrav1.ConfigDriftPolicyEnforce
+	vm.Template = true
+
+	// a template can not be reverted and is only reported.
+	requeue, err := reconcileConfigDrift(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	requireConfigDriftCondition(t, machineScope.ProxmoxMachine, metav1.ConditionTrue, infrav1.ProxmoxMachineConfigDriftDetectedReason,
+		"virtual machine was converted to a template")
+}
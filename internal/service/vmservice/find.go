@@ -23,8 +23,10 @@ import (
 	"github.com/luthermonson/go-proxmox"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
@@ -58,6 +60,10 @@ func FindVM(ctx context.Context, scope *scope.MachineScope) (*proxmox.VirtualMac
 			scope.Error(err, "vm is not initialized yet")
 			return nil, ErrVMNotInitialized
 		}
+		if err := verifyVMOwnership(scope, vm); err != nil {
+			setVMNotOwned(scope, err)
+			return nil, err
+		}
 		return vm, nil
 	}
 
@@ -115,12 +121,29 @@ func updateVMLocation(ctx context.Context, s *scope.MachineScope) error {
 		return err
 	}
 
+	// A VM with the right name might still belong to another cluster.
+	if err := verifyVMOwnership(s, vm); err != nil {
+		setVMNotOwned(s, err)
+		return err
+	}
+
+	// A VM which is moved after provisioning is followed to its new node.
+	if node := s.ProxmoxMachine.Status.ProxmoxNode; node != nil && *node != vm.Node &&
+		ptr.Deref(s.ProxmoxMachine.Status.Initialization.Provisioned, false) {
+		record.Warnf(s.ProxmoxMachine, "VirtualMachineMoved", "Virtual machine was moved from node %s to %s", *node, vm.Node)
+	}
+
+	return recordVMLocation(s, vm)
+}
+
+// recordVMLocation records the node of the VM in the machine and cluster status.
+func recordVMLocation(s *scope.MachineScope, vm *proxmox.VirtualMachine) error {
 	// Update the Proxmox node in the status.
 	s.ProxmoxMachine.Status.ProxmoxNode = new(vm.Node)
 
 	// Attempt to update the cluster status
 	updated := s.InfraCluster.ProxmoxCluster.UpdateNodeLocation(
-		machineName,
+		s.ProxmoxMachine.GetName(),
 		vm.Node,
 		util.IsControlPlaneMachine(s.Machine),
 	)
@@ -131,3 +154,14 @@ func updateVMLocation(ctx context.Context, s *scope.MachineScope) error {
 
 	return nil
 }
+
+// setVMNotOwned stops the provisioning of a machine whose VMID is used by a VM it does not own.
+func setVMNotOwned(s *scope.MachineScope, err error) {
+	conditions.Set(s.ProxmoxMachine, metav1.Condition{
+		Type:    infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason,
+		Message: err.Error(),
+	})
+	record.Warn(s.ProxmoxMachine, "VirtualMachineNotOwned", err.Error())
+}
@@ -89,6 +89,33 @@ func TestFindVM_NotInitialized(t *testing.T) {
 	require.ErrorIs(t, err, ErrVMNotInitialized)
 }
 
+func TestFindVM_OwnedByMarkers(t *testing.T) {
+	ctx := context.TODO()
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(vm.VMID))
+
+	proxmoxClient.EXPECT().GetVM(ctx, "node1", int64(123)).Return(vm, nil).Once()
+
+	_, err := FindVM(ctx, machineScope)
+	require.NoError(t, err)
+}
+
+func TestFindVM_NotOwned(t *testing.T) {
+	ctx := context.TODO()
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = "[capmox]\ncluster = other/test\nproxmoxmachine = test\nuid = other-uid"
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(vm.VMID))
+
+	proxmoxClient.EXPECT().GetVM(ctx, "node1", int64(123)).Return(vm, nil).Once()
+
+	_, err := FindVM(ctx, machineScope)
+	require.ErrorIs(t, err, ErrVMNotOwned)
+	require.True(t, machineScope.HasFailed())
+}
+
 func TestUpdateVMLocation_MissingName(t *testing.T) {
 	ctx := context.TODO()
 	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
@@ -142,6 +169,22 @@ func TestUpdateVMLocation_UpdateNode(t *testing.T) {
 	require.Equal(t, vmr.Node, machineScope.InfraCluster.ProxmoxCluster.GetNode(machineScope.Name(), false))
 }
 
+func TestUpdateVMLocation_NotOwned(t *testing.T) {
+	ctx := context.TODO()
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = "[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = other-uid"
+	vmr := newVMResource()
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = new(int64(vm.VMID))
+
+	proxmoxClient.EXPECT().FindVMResource(ctx, uint64(123)).Return(vmr, nil).Once()
+	proxmoxClient.EXPECT().GetVM(ctx, "node1", int64(123)).Return(vm, nil).Once()
+
+	require.ErrorIs(t, updateVMLocation(ctx, machineScope), ErrVMNotOwned)
+	require.True(t, machineScope.HasFailed())
+	require.Nil(t, machineScope.ProxmoxMachine.Status.ProxmoxNode)
+}
+
 func TestUpdateVMLocation_WithTask(t *testing.T) {
 	machineScope, _, _ := setupReconcilerTest(t)
 	vm := newRunningVM()
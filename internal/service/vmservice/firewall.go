@@ -0,0 +1,177 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// firewallRuleComment marks the firewall rules of a VM which are managed by the provider.
+// Rules without it were added by hand and are left untouched.
+const firewallRuleComment = "capmox"
+
+// machineFirewall returns the firewall of the machine combined with the firewall of
+// its cluster, or nil if neither defines one.
+func machineFirewall(machineScope *scope.MachineScope) *infrav1.FirewallSpec {
+	var specs []*infrav1.FirewallSpec
+	if cluster := machineScope.InfraCluster.ProxmoxCluster.Spec.Firewall; cluster != nil {
+		specs = append(specs, &cluster.FirewallSpec)
+	}
+	if machine := machineScope.ProxmoxMachine.Spec.Firewall; machine != nil {
+		specs = append(specs, machine)
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+
+	firewall := &infrav1.FirewallSpec{}
+	for _, spec := range specs {
+		if spec.Enabled != nil {
+			firewall.Enabled = spec.Enabled
+		}
+		if spec.PolicyIn != nil {
+			firewall.PolicyIn = spec.PolicyIn
+		}
+		if spec.PolicyOut != nil {
+			firewall.PolicyOut = spec.PolicyOut
+		}
+		for _, group := range spec.SecurityGroups {
+			if !slices.Contains(firewall.SecurityGroups, group) {
+				firewall.SecurityGroups = append(firewall.SecurityGroups, group)
+			}
+		}
+		firewall.Rules = append(firewall.Rules, spec.Rules...)
+	}
+
+	return firewall
+}
+
+// firewallEnabled returns true if the firewall of the VM and its network devices is enabled.
+func firewallEnabled(firewall *infrav1.FirewallSpec) bool {
+	return firewall != nil && ptr.Deref(firewall.Enabled, true)
+}
+
+// reconcileFirewall applies the firewall options, security groups and rules to the VM.
+// Security groups and rules are managed as one block on top of the rules added by hand.
+func reconcileFirewall(ctx context.Context, machineScope *scope.MachineScope) error {
+	firewall := machineFirewall(machineScope)
+	if firewall == nil {
+		return nil
+	}
+	client := machineScope.InfraCluster.ProxmoxClient
+	vm := machineScope.VirtualMachine
+
+	options, err := client.GetVMFirewallOptions(ctx, vm)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get firewall options of vm %s", machineScope.Name())
+	}
+	desiredOptions := &proxmox.FirewallVirtualMachineOption{
+		Enable:    proxmox.IntOrBool(firewallEnabled(firewall)),
+		PolicyIn:  string(ptr.Deref(firewall.PolicyIn, "")),
+		PolicyOut: string(ptr.Deref(firewall.PolicyOut, "")),
+	}
+	if options.Enable != desiredOptions.Enable ||
+		(desiredOptions.PolicyIn != "" && options.PolicyIn != desiredOptions.PolicyIn) ||
+		(desiredOptions.PolicyOut != "" && options.PolicyOut != desiredOptions.PolicyOut) {
+		machineScope.Info("updating firewall options", "enable", desiredOptions.Enable)
+		if err := client.UpdateVMFirewallOptions(ctx, vm, desiredOptions); err != nil {
+			return errors.Wrapf(err, "unable to update firewall options of vm %s", machineScope.Name())
+		}
+	}
+
+	rules, err := client.GetVMFirewallRules(ctx, vm)
+	if err != nil {
+		return errors.Wrapf(err, "unable to list firewall rules of vm %s", machineScope.Name())
+	}
+	managed := slices.DeleteFunc(rules, func(r *proxmox.FirewallRule) bool { return !isManagedFirewallRule(r) })
+	desired := firewallRules(firewall)
+	if slices.EqualFunc(managed, desired, sameFirewallRule) {
+		return nil
+	}
+
+	machineScope.Info("updating firewall rules", "rules", len(desired))
+	// delete from the bottom so the positions of the remaining rules do not change.
+	slices.SortFunc(managed, func(a, b *proxmox.FirewallRule) int { return b.Pos - a.Pos })
+	for _, rule := range managed {
+		if err := client.DeleteVMFirewallRule(ctx, vm, rule.Pos); err != nil {
+			return errors.Wrapf(err, "unable to delete firewall rule of vm %s", machineScope.Name())
+		}
+	}
+	// Proxmox inserts new rules at the top, so they are created in reverse order.
+	for _, rule := range slices.Backward(desired) {
+		if err := client.CreateVMFirewallRule(ctx, vm, rule); err != nil {
+			return errors.Wrapf(err, "unable to create firewall rule of vm %s", machineScope.Name())
+		}
+	}
+
+	return nil
+}
+
+// firewallRules returns the Proxmox firewall rules for the security groups and rules of a firewall.
+func firewallRules(firewall *infrav1.FirewallSpec) []*proxmox.FirewallRule {
+	rules := make([]*proxmox.FirewallRule, 0, len(firewall.SecurityGroups)+len(firewall.Rules))
+	for _, group := range firewall.SecurityGroups {
+		rules = append(rules, &proxmox.FirewallRule{
+			Type:    "group",
+			Action:  group,
+			Enable:  1,
+			Comment: firewallRuleComment,
+		})
+	}
+
+	for _, rule := range firewall.Rules {
+		comment := firewallRuleComment
+		if rule.Comment != nil {
+			comment += ": " + *rule.Comment
+		}
+		rules = append(rules, &proxmox.FirewallRule{
+			Type:    string(rule.Direction),
+			Action:  string(rule.Action),
+			Enable:  1,
+			Comment: comment,
+			Macro:   ptr.Deref(rule.Macro, ""),
+			Proto:   ptr.Deref(rule.Protocol, ""),
+			Source:  ptr.Deref(rule.Source, ""),
+			Dest:    ptr.Deref(rule.Destination, ""),
+			Sport:   ptr.Deref(rule.SourcePort, ""),
+			Dport:   ptr.Deref(rule.DestinationPort, ""),
+			Iface:   string(ptr.Deref(rule.Interface, "")),
+		})
+	}
+
+	return rules
+}
+
+func isManagedFirewallRule(rule *proxmox.FirewallRule) bool {
+	return rule.Comment == firewallRuleComment || strings.HasPrefix(rule.Comment, firewallRuleComment+": ")
+}
+
+// sameFirewallRule compares the fields of two rules which are managed by the provider.
+func sameFirewallRule(a, b *proxmox.FirewallRule) bool {
+	return a.Type == b.Type && a.Action == b.Action && a.Enable == b.Enable && a.Comment == b.Comment &&
+		a.Macro == b.Macro && a.Proto == b.Proto && a.Source == b.Source && a.Dest == b.Dest &&
+		a.Sport == b.Sport && a.Dport == b.Dport && a.Iface == b.Iface
+}
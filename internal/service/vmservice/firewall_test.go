@@ -0,0 +1,136 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+)
+
+func TestMachineFirewall(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	require.Nil(t, machineFirewall(machineScope))
+
+	machineScope.InfraCluster.ProxmoxCluster.Spec.Firewall = &infrav1.ClusterFirewallSpec{
+		FirewallSpec: infrav1.FirewallSpec{
+			PolicyIn:       new(infrav1.FirewallActionDrop),
+			SecurityGroups: []string{"base"},
+			Rules: []infrav1.FirewallRule{{
+				Direction: infrav1.FirewallDirectionIn,
+				Action:    infrav1.FirewallActionAccept,
+				Macro:     new("SSH"),
+			}},
+		},
+	}
+	machineScope.ProxmoxMachine.Spec.Firewall = &infrav1.FirewallSpec{
+		PolicyIn:       new(infrav1.FirewallActionReject),
+		SecurityGroups: []string{"base", "web"},
+		Rules: []infrav1.FirewallRule{{
+			Direction:       infrav1.FirewallDirectionIn,
+			Action:          infrav1.FirewallActionAccept,
+			Protocol:        new("tcp"),
+			DestinationPort: new("443"),
+		}},
+	}
+
+	firewall := machineFirewall(machineScope)
+	require.True(t, firewallEnabled(firewall))
+	require.Equal(t, infrav1.FirewallActionReject, *firewall.PolicyIn)
+	require.Nil(t, firewall.PolicyOut)
+	require.Equal(t, []string{"base", "web"}, firewall.SecurityGroups)
+	require.Len(t, firewall.Rules, 2)
+	require.Equal(t, "SSH", *firewall.Rules[0].Macro)
+}
+
+func TestReconcileFirewall_NoFirewall(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+
+	require.NoError(t, reconcileFirewall(context.Background(), machineScope))
+}
+
+func TestReconcileFirewall_Create(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	vm := newStoppedVM()
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Firewall = &infrav1.FirewallSpec{
+		PolicyIn:       new(infrav1.FirewallActionDrop),
+		SecurityGroups: []string{"k8s"},
+		Rules: []infrav1.FirewallRule{{
+			Direction:       infrav1.FirewallDirectionIn,
+			Action:          infrav1.FirewallActionAccept,
+			Protocol:        new("tcp"),
+			Source:          new("+capmox-test"),
+			DestinationPort: new("6443"),
+			Comment:         new("api server"),
+		}},
+	}
+
+	manual := &proxmox.FirewallRule{Pos: 0, Type: "in", Action: "ACCEPT", Macro: "Ping", Enable: 1}
+	stale := &proxmox.FirewallRule{Pos: 1, Type: "in", Action: "ACCEPT", Dport: "22", Enable: 1, Comment: "capmox"}
+
+	proxmoxClient.EXPECT().GetVMFirewallOptions(ctx, vm).Return(&proxmox.FirewallVirtualMachineOption{}, nil).Once()
+	proxmoxClient.EXPECT().UpdateVMFirewallOptions(ctx, vm, &proxmox.FirewallVirtualMachineOption{Enable: true, PolicyIn: "DROP"}).Return(nil).Once()
+	proxmoxClient.EXPECT().GetVMFirewallRules(ctx, vm).Return([]*proxmox.FirewallRule{manual, stale}, nil).Once()
+	proxmoxClient.EXPECT().DeleteVMFirewallRule(ctx, vm, 1).Return(nil).Once()
+	create := proxmoxClient.EXPECT().CreateVMFirewallRule(ctx, vm, &proxmox.FirewallRule{
+		Type: "in", Action: "ACCEPT", Enable: 1, Comment: "capmox: api server",
+		Proto: "tcp", Source: "+capmox-test", Dport: "6443",
+	}).Return(nil).Once()
+	proxmoxClient.EXPECT().CreateVMFirewallRule(ctx, vm, &proxmox.FirewallRule{
+		Type: "group", Action: "k8s", Enable: 1, Comment: "capmox",
+	}).Return(nil).Once().NotBefore(create)
+
+	require.NoError(t, reconcileFirewall(ctx, machineScope))
+}
+
+func TestReconcileFirewall_UpToDate(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	vm := newStoppedVM()
+	machineScope.SetVirtualMachine(vm)
+	machineScope.InfraCluster.ProxmoxCluster.Spec.Firewall = &infrav1.ClusterFirewallSpec{
+		FirewallSpec: infrav1.FirewallSpec{SecurityGroups: []string{"k8s"}},
+	}
+
+	proxmoxClient.EXPECT().GetVMFirewallOptions(ctx, vm).Return(&proxmox.FirewallVirtualMachineOption{Enable: true, PolicyIn: "DROP"}, nil).Once()
+	proxmoxClient.EXPECT().GetVMFirewallRules(ctx, vm).Return([]*proxmox.FirewallRule{
+		{Pos: 0, Type: "group", Action: "k8s", Enable: 1, Comment: "capmox"},
+		{Pos: 1, Type: "in", Action: "ACCEPT", Macro: "Ping", Enable: 1},
+	}, nil).Once()
+
+	require.NoError(t, reconcileFirewall(ctx, machineScope))
+}
+
+func TestReconcileFirewall_Disabled(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	vm := newStoppedVM()
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Firewall = &infrav1.FirewallSpec{Enabled: new(false)}
+
+	proxmoxClient.EXPECT().GetVMFirewallOptions(ctx, vm).Return(&proxmox.FirewallVirtualMachineOption{Enable: true}, nil).Once()
+	proxmoxClient.EXPECT().UpdateVMFirewallOptions(ctx, vm, &proxmox.FirewallVirtualMachineOption{}).Return(nil).Once()
+	proxmoxClient.EXPECT().GetVMFirewallRules(ctx, vm).Return(nil, nil).Once()
+
+	require.NoError(t, reconcileFirewall(ctx, machineScope))
+}
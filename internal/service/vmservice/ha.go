@@ -0,0 +1,152 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// haResourceComment marks the HA resources which are managed by the provider.
+// Resources without it were registered by hand and are never removed by a reconcile.
+const haResourceComment = "capmox"
+
+// haResourceID returns the id of the HA resource of a VM.
+func haResourceID(vmID int64) string {
+	return fmt.Sprintf("vm:%d", vmID)
+}
+
+// reconcileHighAvailability registers the VM as HA resource of the Proxmox HA manager
+// and keeps its settings in sync with the spec. The VM is only registered once the
+// machine is provisioned, as the HA manager starts and restarts the VM on its own.
+func reconcileHighAvailability(ctx context.Context, machineScope *scope.MachineScope) error {
+	pm := machineScope.ProxmoxMachine
+	if !ptr.Deref(pm.Status.Initialization.Provisioned, false) {
+		return nil
+	}
+
+	client := machineScope.InfraCluster.ProxmoxClient
+	vm := machineScope.VirtualMachine
+	sid := haResourceID(int64(vm.VMID))
+	desired := machineScope.HighAvailability()
+
+	if vm.HA.Managed == 0 {
+		if desired == nil {
+			return nil
+		}
+		resource := &proxmox.HAResourceCreateOption{
+			SID:         sid,
+			Group:       ptr.Deref(desired.Group, ""),
+			Comment:     haResourceComment,
+			State:       new(string(desired.GetState())),
+			MaxRestart:  int32ToIntPtr(desired.MaxRestart),
+			MaxRelocate: int32ToIntPtr(desired.MaxRelocate),
+		}
+		if err := client.CreateHAResource(ctx, resource); err != nil {
+			return errors.Wrapf(err, "unable to register vm %d as HA resource", vm.VMID)
+		}
+		record.Eventf(pm, "HAResourceRegistered", "Registered virtual machine %d as HA resource %s", vm.VMID, sid)
+		return nil
+	}
+
+	current, err := client.GetHAResource(ctx, sid)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get HA resource %s", sid)
+	}
+
+	if desired == nil {
+		if current.Comment != haResourceComment {
+			// registered by hand.
+			return nil
+		}
+		if err := client.DeleteHAResource(ctx, sid); err != nil {
+			return errors.Wrapf(err, "unable to deregister HA resource %s", sid)
+		}
+		record.Eventf(pm, "HAResourceDeregistered", "Deregistered virtual machine %d from HA resource %s", vm.VMID, sid)
+		return nil
+	}
+
+	update, changes := haResourceUpdate(current, desired)
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := client.UpdateHAResource(ctx, sid, update); err != nil {
+		return errors.Wrapf(err, "unable to update HA resource %s", sid)
+	}
+	record.Eventf(pm, "HAResourceUpdated", "Updated HA resource %s: %s", sid, strings.Join(changes, ", "))
+	return nil
+}
+
+// haResourceUpdate returns the update which brings the HA resource in line with the spec,
+// together with a description of the changed settings.
+// Restart and relocate limits which are not set in the spec are left at their current value.
+func haResourceUpdate(current *proxmox.HAResource, desired *infrav1.HighAvailabilitySpec) (*proxmox.HAResourceUpdateOption, []string) {
+	update := &proxmox.HAResourceUpdateOption{Comment: haResourceComment}
+	var changes []string
+
+	if group := ptr.Deref(desired.Group, ""); group != current.Group {
+		if group == "" {
+			update.Delete = "group"
+		} else {
+			update.Group = group
+		}
+		changes = append(changes, fmt.Sprintf("group %q", group))
+	}
+	if state := string(desired.GetState()); state != ptr.Deref(current.State, string(infrav1.HAStateStarted)) {
+		update.State = new(state)
+		changes = append(changes, fmt.Sprintf("state %s", state))
+	}
+	if desired.MaxRestart != nil && int(*desired.MaxRestart) != ptr.Deref(current.MaxRestart, -1) {
+		update.MaxRestart = int32ToIntPtr(desired.MaxRestart)
+		changes = append(changes, fmt.Sprintf("max_restart %d", *desired.MaxRestart))
+	}
+	if desired.MaxRelocate != nil && int(*desired.MaxRelocate) != ptr.Deref(current.MaxRelocate, -1) {
+		update.MaxRelocate = int32ToIntPtr(desired.MaxRelocate)
+		changes = append(changes, fmt.Sprintf("max_relocate %d", *desired.MaxRelocate))
+	}
+
+	return update, changes
+}
+
+// DeregisterHAResource removes the VM from the control of the HA manager.
+// This has to happen before the VM is deleted, otherwise the HA manager keeps starting it again.
+func DeregisterHAResource(ctx context.Context, clusterScope *scope.ClusterScope, vm *proxmox.VirtualMachine) error {
+	if vm.HA.Managed == 0 {
+		return nil
+	}
+	sid := haResourceID(int64(vm.VMID))
+	if err := clusterScope.ProxmoxClient.DeleteHAResource(ctx, sid); err != nil && !VMNotFound(err) {
+		return errors.Wrapf(err, "unable to deregister HA resource %s", sid)
+	}
+	return nil
+}
+
+func int32ToIntPtr(i *int32) *int {
+	if i == nil {
+		return nil
+	}
+	return new(int(*i))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+)
+
+func TestReconcileHighAvailability_NotProvisioned(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	machineScope.SetVirtualMachine(newRunningVM())
+	machineScope.ProxmoxMachine.Spec.HighAvailability = &infrav1.HighAvailabilitySpec{}
+
+	require.NoError(t, reconcileHighAvailability(context.Background(), machineScope))
+}
+
+func TestReconcileHighAvailability_Register(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	machineScope.SetVirtualMachine(newRunningVM())
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+	machineScope.ProxmoxMachine.Spec.HighAvailability = &infrav1.HighAvailabilitySpec{
+		Group:      new("prod"),
+		MaxRestart: new(int32(3)),
+	}
+
+	proxmoxClient.EXPECT().CreateHAResource(ctx, &proxmox.HAResourceCreateOption{
+		SID:        "vm:123",
+		Group:      "prod",
+		Comment:    "capmox",
+		State:      new("started"),
+		MaxRestart: new(3),
+	}).Return(nil).Once()
+
+	require.NoError(t, reconcileHighAvailability(ctx, machineScope))
+}
+
+func TestReconcileHighAvailability_UpToDate(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	vm := newRunningVM()
+	vm.HA.Managed = 1
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+	machineScope.ProxmoxMachine.Spec.HighAvailability = &infrav1.HighAvailabilitySpec{Group: new("prod")}
+
+	proxmoxClient.EXPECT().GetHAResource(ctx, "vm:123").Return(&proxmox.HAResource{
+		SID: "vm:123", Group: "prod", Comment: "capmox", State: new("started"), MaxRestart: new(1), MaxRelocate: new(1),
+	}, nil).Once()
+
+	require.NoError(t, reconcileHighAvailability(ctx, machineScope))
+}
+
+func TestReconcileHighAvailability_Update(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	vm := newRunningVM()
+	vm.HA.Managed = 1
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+	machineScope.ProxmoxMachine.Spec.HighAvailability = &infrav1.HighAvailabilitySpec{
+		State:       infrav1.HAStateStopped,
+		MaxRelocate: new(int32(2)),
+	}
+
+	proxmoxClient.EXPECT().GetHAResource(ctx, "vm:123").Return(&proxmox.HAResource{
+		SID: "vm:123", Group: "prod", Comment: "capmox", State: new("started"), MaxRelocate: new(1),
+	}, nil).Once()
+	proxmoxClient.EXPECT().UpdateHAResource(ctx, "vm:123", &proxmox.HAResourceUpdateOption{
+		Delete:      "group",
+		Comment:     "capmox",
+		State:       new("stopped"),
+		MaxRelocate: new(2),
+	}).Return(nil).Once()
+
+	require.NoError(t, reconcileHighAvailability(ctx, machineScope))
+}
+
+func TestReconcileHighAvailability_Deregister(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	vm := newRunningVM()
+	vm.HA.Managed = 1
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+
+	proxmoxClient.EXPECT().GetHAResource(ctx, "vm:123").Return(&proxmox.HAResource{SID: "vm:123", Comment: "capmox"}, nil).Once()
+	proxmoxClient.EXPECT().DeleteHAResource(ctx, "vm:123").Return(nil).Once()
+
+	require.NoError(t, reconcileHighAvailability(ctx, machineScope))
+}
+
+func TestReconcileHighAvailability_KeepsManualResource(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	ctx := context.Background()
+	vm := newRunningVM()
+	vm.HA.Managed = 1
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+
+	// registered by hand, no DeleteHAResource call is expected.
+	proxmoxClient.EXPECT().GetHAResource(ctx, "vm:123").Return(&proxmox.HAResource{SID: "vm:123"}, nil).Once()
+
+	require.NoError(t, reconcileHighAvailability(ctx, machineScope))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// reconcileMachineHealth observes the runtime state of a provisioned machine:
+// the power state of the VM, the liveness of the QEMU guest agent and the link
+// state and addresses of its network devices. The observations are published
+// in status.network and the VirtualMachineHealthy condition. The addresses of
+// devices configured with DHCP are refreshed from the same guest agent query.
+func reconcileMachineHealth(ctx context.Context, machineScope *scope.MachineScope) {
+	pm := machineScope.ProxmoxMachine
+	vm := machineScope.VirtualMachine
+
+	checkAgent := !machineScope.SkipQemuGuestCheck()
+	var ifaces []*proxmox.AgentNetworkIface
+	var agentErr error
+	if vm.IsRunning() && (checkAgent || pm.UsesDHCP()) {
+		ifaces, agentErr = machineScope.InfraCluster.ProxmoxClient.QemuAgentNetworkInterfaces(ctx, vm)
+	}
+
+	pm.Status.Network = networkStatus(machineScope, ifaces)
+
+	if vm.IsRunning() && agentErr == nil && pm.UsesDHCP() {
+		setDHCPAddresses(machineScope, ifaces)
+		if addr, err := getClusterAPIMachineAddresses(machineScope); err != nil {
+			machineScope.Logger.Info("unable to refresh machine addresses", "reason", err.Error())
+		} else {
+			machineScope.SetAddresses(addr)
+		}
+	}
+
+	var disconnected []string
+	for _, status := range pm.Status.Network {
+		if !ptr.Deref(status.Connected, false) {
+			disconnected = append(disconnected, string(status.NetworkName))
+		}
+	}
+
+	healthy := metav1.Condition{
+		Type:   infrav1.ProxmoxMachineVirtualMachineHealthyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.ProxmoxMachineVirtualMachineHealthyHealthyReason,
+	}
+	switch {
+	case !vm.IsRunning():
+		state := vm.Status
+		if vm.IsPaused() {
+			state = vm.QMPStatus
+		}
+		healthy.Status = metav1.ConditionFalse
+		healthy.Reason = infrav1.ProxmoxMachineVirtualMachineHealthyNotRunningReason
+		healthy.Message = fmt.Sprintf("virtual machine is %s", state)
+	case checkAgent && agentErr != nil:
+		healthy.Status = metav1.ConditionFalse
+		healthy.Reason = infrav1.ProxmoxMachineVirtualMachineHealthyGuestAgentNotRespondingReason
+		healthy.Message = agentErr.Error()
+	case len(disconnected) > 0:
+		healthy.Status = metav1.ConditionFalse
+		healthy.Reason = infrav1.ProxmoxMachineVirtualMachineHealthyNetworkDisconnectedReason
+		healthy.Message = fmt.Sprintf("link is down on %s", strings.Join(disconnected, ", "))
+	}
+	conditions.Set(pm, healthy)
+}
+
+// networkStatus returns the status of the proxmox network devices of a VM,
+// including the addresses the guest reports for them.
+func networkStatus(machineScope *scope.MachineScope, ifaces []*proxmox.AgentNetworkIface) []infrav1.NetworkStatus {
+	nets := machineScope.VirtualMachine.VirtualMachineConfig.Nets
+	linkNames := guestLinkNames(ptr.Deref(machineScope.ProxmoxMachine.Spec.Network, infrav1.NetworkSpec{}))
+
+	names := slices.SortedFunc(maps.Keys(nets), func(a, b string) int {
+		aOffset, _ := NetNameToOffset(infrav1.NetName(a))
+		bOffset, _ := NetNameToOffset(infrav1.NetName(b))
+		return aOffset - bOffset
+	})
+
+	status := make([]infrav1.NetworkStatus, 0, len(names))
+	for _, name := range names {
+		mac := extractMACAddress(nets[name])
+		if mac == "" {
+			continue
+		}
+		device := infrav1.NetworkStatus{
+			Connected:   new(!isLinkDown(nets[name])),
+			MACAddr:     mac,
+			NetworkName: infrav1.NetName(name),
+		}
+		if iface := findGuestInterface(ifaces, name, mac, linkNames); iface != nil {
+			ipv4, ipv6 := guestAddresses(iface)
+			device.IPAddrs = slices.Concat(ipv4, ipv6)
+		}
+		status = append(status, device)
+	}
+	return status
+}
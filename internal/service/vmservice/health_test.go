@@ -0,0 +1,166 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/goproxmox"
+)
+
+func requireHealthyCondition(t *testing.T, pm *infrav1.ProxmoxMachine, status metav1.ConditionStatus, reason string) {
+	t.Helper()
+	cond := conditions.Get(pm, infrav1.ProxmoxMachineVirtualMachineHealthyCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, status, cond.Status)
+	require.Equal(t, reason, cond.Reason)
+}
+
+func TestReconcileMachineHealth_Healthy(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0", "virtio=A6:23:64:4D:84:CC,bridge=vmbr1")
+	machineScope.SetVirtualMachine(vm)
+
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return([]*proxmox.AgentNetworkIface{
+		guestInterface("eth0", "A6:23:64:4D:84:CB", "10.0.0.10", "fe80::a423:64ff:fe4d:84cb"),
+		guestInterface("eth1", "A6:23:64:4D:84:CC", "192.168.1.10", "2001:db8::10"),
+	}, nil).Once()
+
+	reconcileMachineHealth(context.Background(), machineScope)
+
+	requireHealthyCondition(t, machineScope.ProxmoxMachine, metav1.ConditionTrue, infrav1.ProxmoxMachineVirtualMachineHealthyHealthyReason)
+	require.Equal(t, []infrav1.NetworkStatus{{
+		Connected:   new(true),
+		IPAddrs:     []string{"10.0.0.10"},
+		MACAddr:     "A6:23:64:4D:84:CB",
+		NetworkName: "net0",
+	}, {
+		Connected:   new(true),
+		IPAddrs:     []string{"192.168.1.10", "2001:db8::10"},
+		MACAddr:     "A6:23:64:4D:84:CC",
+		NetworkName: "net1",
+	}}, machineScope.ProxmoxMachine.Status.Network)
+}
+
+func TestReconcileMachineHealth_NotRunning(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	vm.Status = proxmox.StatusVirtualMachineStopped
+	vm.QMPStatus = proxmox.StatusVirtualMachineStopped
+	machineScope.SetVirtualMachine(vm)
+
+	// the guest agent is not queried for stopped machines.
+	reconcileMachineHealth(context.Background(), machineScope)
+
+	requireHealthyCondition(t, machineScope.ProxmoxMachine, metav1.ConditionFalse, infrav1.ProxmoxMachineVirtualMachineHealthyNotRunningReason)
+	require.Equal(t, "virtual machine is stopped", conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineHealthyCondition).Message)
+	require.Len(t, machineScope.ProxmoxMachine.Status.Network, 1)
+	require.Empty(t, machineScope.ProxmoxMachine.Status.Network[0].IPAddrs)
+}
+
+func TestReconcileMachineHealth_GuestAgentNotResponding(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	machineScope.SetVirtualMachine(vm)
+
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return(nil, goproxmox.ErrAgentNotRunning).Once()
+
+	reconcileMachineHealth(context.Background(), machineScope)
+	requireHealthyCondition(t, machineScope.ProxmoxMachine, metav1.ConditionFalse, infrav1.ProxmoxMachineVirtualMachineHealthyGuestAgentNotRespondingReason)
+}
+
+func TestReconcileMachineHealth_SkipQemuGuestAgent(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	machineScope.ProxmoxMachine.Spec.Checks = &infrav1.ProxmoxMachineChecks{SkipQemuGuestAgent: new(true)}
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	machineScope.SetVirtualMachine(vm)
+
+	reconcileMachineHealth(context.Background(), machineScope)
+	requireHealthyCondition(t, machineScope.ProxmoxMachine, metav1.ConditionTrue, infrav1.ProxmoxMachineVirtualMachineHealthyHealthyReason)
+}
+
+func TestReconcileMachineHealth_NetworkDisconnected(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0", "virtio=A6:23:64:4D:84:CC,bridge=vmbr1,link_down=1")
+	machineScope.SetVirtualMachine(vm)
+
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return([]*proxmox.AgentNetworkIface{
+		guestInterface("eth0", "A6:23:64:4D:84:CB", "10.0.0.10"),
+	}, nil).Once()
+
+	reconcileMachineHealth(context.Background(), machineScope)
+
+	requireHealthyCondition(t, machineScope.ProxmoxMachine, metav1.ConditionFalse, infrav1.ProxmoxMachineVirtualMachineHealthyNetworkDisconnectedReason)
+	require.Equal(t, "link is down on net1", conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineHealthyCondition).Message)
+	require.False(t, *machineScope.ProxmoxMachine.Status.Network[1].Connected)
+}
+
+func TestReconcileMachineHealth_DHCPLeaseChange(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
+		Name:            infrav1.DefaultNetworkDevice,
+		InterfaceConfig: infrav1.InterfaceConfig{DHCP4: new(true), DHCP6: new(true)},
+	}}
+	machineScope.ProxmoxMachine.Status.IPAddresses = []infrav1.IPAddressesSpec{
+		{NetName: "default", IPv4: []string{"192.168.1.50"}},
+		{NetName: string(infrav1.DefaultNetworkDevice), IPv4: []string{"192.168.1.50"}},
+	}
+
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return([]*proxmox.AgentNetworkIface{
+		guestInterface("eth0", "A6:23:64:4D:84:CB", "192.168.1.77", "2001:db8::77", "fe80::a423:64ff:fe4d:84cb"),
+	}, nil).Once()
+
+	reconcileMachineHealth(context.Background(), machineScope)
+
+	require.Equal(t, infrav1.IPAddressesSpec{NetName: "default", IPv4: []string{"192.168.1.77"}, IPv6: []string{"2001:db8::77"}},
+		*machineScope.ProxmoxMachine.GetIPAddressesNet("default"))
+	require.Equal(t, []clusterv1.MachineAddress{
+		{Type: clusterv1.MachineHostName, Address: machineScope.ProxmoxMachine.GetName()},
+		{Type: clusterv1.MachineInternalIP, Address: "192.168.1.77"},
+		{Type: clusterv1.MachineInternalIP, Address: "2001:db8::77"},
+	}, machineScope.ProxmoxMachine.Status.Addresses)
+}
+
+func TestReconcileMachineHealth_DHCPAgentUnavailable(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newVMWithNets("virtio=A6:23:64:4D:84:CB,bridge=vmbr0")
+	machineScope.SetVirtualMachine(vm)
+	machineScope.ProxmoxMachine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
+		Name:            infrav1.DefaultNetworkDevice,
+		InterfaceConfig: infrav1.InterfaceConfig{DHCP4: new(true)},
+	}}
+	status := []infrav1.IPAddressesSpec{
+		{NetName: "default", IPv4: []string{"192.168.1.50"}},
+		{NetName: string(infrav1.DefaultNetworkDevice), IPv4: []string{"192.168.1.50"}},
+	}
+	machineScope.ProxmoxMachine.Status.IPAddresses = status
+
+	proxmoxClient.EXPECT().QemuAgentNetworkInterfaces(context.Background(), vm).Return(nil, goproxmox.ErrAgentNotRunning).Once()
+
+	reconcileMachineHealth(context.Background(), machineScope)
+	require.Equal(t, status, machineScope.ProxmoxMachine.Status.IPAddresses)
+}
@@ -32,11 +32,13 @@ import (
 	fields "k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ipamicv1 "sigs.k8s.io/cluster-api-ipam-provider-in-cluster/api/v1alpha2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	utilrecord "sigs.k8s.io/cluster-api/util/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -50,6 +52,7 @@ import (
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/proxmoxtest"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/talos"
 )
 
 type FakeISOInjector struct {
@@ -72,6 +75,29 @@ func (f FakeIgnitionISOInjector) Inject(_ context.Context, _ inject.BootstrapDat
 	return f.Error
 }
 
+// events receives the events recorded by the services. record.InitFromRecorder only takes
+// effect once, so all tests share the recorder and the test setup drains it.
+var events = initEventRecorder()
+
+func initEventRecorder() chan string {
+	recorder := record.NewFakeRecorder(1000)
+	utilrecord.InitFromRecorder(recorder)
+	return recorder.Events
+}
+
+// recordedEvents returns the events recorded since the last call, formatted as "<type> <reason> <message>".
+func recordedEvents() []string {
+	var recorded []string
+	for {
+		select {
+		case event := <-events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
 // setupReconcilerTestWithCondition sets up a reconciler test with a condition for the proxmoxmachiens statemachine.
 func setupReconcilerTestWithCondition(t *testing.T, condition string) (*scope.MachineScope, *proxmoxtest.MockClient, client.Client) {
 	machineScope, mockClient, client := setupReconcilerTest(t)
@@ -87,6 +113,8 @@ func setupReconcilerTestWithCondition(t *testing.T, condition string) (*scope.Ma
 
 // setupReconcilerTest initializes a MachineScope with a mock Proxmox client and a fake controller-runtime client.
 func setupReconcilerTest(t *testing.T) (*scope.MachineScope, *proxmoxtest.MockClient, client.Client) {
+	recordedEvents()
+
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
@@ -542,6 +570,11 @@ func createBootstrapSecret(t *testing.T, c client.Client, machineScope *scope.Ma
 			"value":  []byte("{\"ignition\":{\"version\":\"2.3.0\"}}"),
 			"format": []byte("ignition"),
 		}
+	case talos.FormatTalos:
+		data = map[string][]byte{
+			"value":  []byte("version: v1alpha1\nmachine:\n  type: worker\n"),
+			"format": []byte("talos"),
+		}
 	}
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -23,6 +23,7 @@ import (
 	"net/netip"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -70,6 +71,9 @@ func reconcileIPAddresses(ctx context.Context, machineScope *scope.MachineScope)
 
 	machineScope.Logger.V(4).Info("updating the ProxmoxMachine's IP addresses.")
 	writeIPAddressStatus(pm, netPoolAddresses)
+	if len(netPoolAddresses) > 0 {
+		record.Eventf(pm, "IPAddressesAllocated", "Allocated IP addresses: %s", formatIPAddresses(netPoolAddresses))
+	}
 
 	conditions.Set(pm, metav1.Condition{
 		Type:   infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
@@ -105,6 +109,23 @@ func writeIPAddressStatus(pm *infrav1.ProxmoxMachine, netPoolAddresses map[infra
 	}
 }
 
+// formatIPAddresses renders the resolved addresses as "net=addr,addr" pairs,
+// ordered by net name, for use in events.
+func formatIPAddresses(netPoolAddresses map[infrav1.NetName]map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress) string {
+	nets := make([]string, 0, len(netPoolAddresses))
+	for _, net := range slices.Sorted(maps.Keys(netPoolAddresses)) {
+		var addresses []string
+		for _, pool := range netPoolAddresses[net] {
+			for _, address := range pool {
+				addresses = append(addresses, address.Spec.Address)
+			}
+		}
+		slices.Sort(addresses)
+		nets = append(nets, fmt.Sprintf("%s=%s", net, strings.Join(addresses, ",")))
+	}
+	return strings.Join(nets, " ")
+}
+
 // reconcileAddressRecovery republishes ProxmoxMachine IP/address status for an
 // already-running machine (e.g. one restored from a backup) whose status was
 // lost. It is read-only with respect to both Proxmox and the IPAM objects: it
@@ -249,6 +270,7 @@ func handleIPAddresses(ctx context.Context, machineScope *scope.MachineScope, ip
 		if err != nil {
 			return []ipamv1.IPAddress{}, errors.Wrapf(err, "unable to create IP address claim for machine %s", machineScope.Name())
 		}
+		record.Eventf(machineScope.ProxmoxMachine, "IPAddressClaimCreated", "Created IPAddressClaim %q for device %s", resolution.ClaimName, device)
 
 		// send the machine to requeue so ipaddresses can be created
 		return []ipamv1.IPAddress{}, nil
@@ -265,6 +287,9 @@ func handleIPAddresses(ctx context.Context, machineScope *scope.MachineScope, ip
 		} else {
 			message = fmt.Sprintf("Static IP claim %q is conflicting (%s); inspect the IPAddressClaim ownership, poolRef, and referenced IPAddress before provisioning can continue.", resolution.ClaimName, resolution.ConflictReason)
 		}
+		if c := conditions.Get(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition); c == nil || c.Message != message {
+			record.Warn(machineScope.ProxmoxMachine, "IPAddressClaimConflict", message)
+		}
 		conditions.Set(machineScope.ProxmoxMachine, metav1.Condition{
 			Type:    infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
 			Status:  metav1.ConditionFalse,
@@ -278,6 +303,35 @@ func handleIPAddresses(ctx context.Context, machineScope *scope.MachineScope, ip
 	}
 }
 
+// ipamDevice is a guest network device which is assigned IP addresses from IPAM.
+type ipamDevice struct {
+	name        infrav1.NetName
+	defaultIPv4 *bool
+	defaultIPv6 *bool
+	ipPoolRef   []corev1.TypedLocalObjectReference
+	vnet        string
+}
+
+// ipamDevices returns the proxmox network devices followed by the bonds, VLANs
+// and bridges of a network spec. Virtual devices use their guest name in place
+// of the proxmox device name.
+func ipamDevices(networkSpec infrav1.NetworkSpec) []ipamDevice {
+	devices := make([]ipamDevice, 0, len(networkSpec.NetworkDevices)+len(networkSpec.Bonds)+len(networkSpec.VLANs)+len(networkSpec.Bridges))
+	for _, nic := range networkSpec.NetworkDevices {
+		devices = append(devices, ipamDevice{nic.Name, nic.DefaultIPv4, nic.DefaultIPv6, nic.IPPoolRef, ptr.Deref(nic.VNet, "")})
+	}
+	for _, bond := range networkSpec.Bonds {
+		devices = append(devices, ipamDevice{infrav1.NetName(bond.Name), bond.DefaultIPv4, bond.DefaultIPv6, bond.IPPoolRef, ""})
+	}
+	for _, vlan := range networkSpec.VLANs {
+		devices = append(devices, ipamDevice{infrav1.NetName(vlan.Name), vlan.DefaultIPv4, vlan.DefaultIPv6, vlan.IPPoolRef, ""})
+	}
+	for _, bridge := range networkSpec.Bridges {
+		devices = append(devices, ipamDevice{infrav1.NetName(bridge.Name), bridge.DefaultIPv4, bridge.DefaultIPv6, bridge.IPPoolRef, ""})
+	}
+	return devices
+}
+
 // ipAddressResolver resolves the IPAddress(es) for a single device/pool claim
 // definition. handleDevices uses the create-capable handleIPAddresses; the
 // read-only status-recovery path uses resolveExistingIPAddress.
@@ -307,38 +361,54 @@ func collectDeviceAddresses(ctx context.Context, machineScope *scope.MachineScop
 	defaultPoolMap := make(map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress)
 
 	requeue := false
-	for _, net := range networkSpec.NetworkDevices {
+	for _, net := range ipamDevices(networkSpec) {
 		pools := []corev1.TypedLocalObjectReference{}
 
+		// devices attached to a SDN VNet use the pools of its subnets in place of the cluster pools.
+		ipv4PoolRef, ipv6PoolRef := poolsRef.IPv4, poolsRef.IPv6
+		if net.vnet != "" {
+			ipv4PoolRef, ipv6PoolRef = ipam.SDNPoolRefs(machineScope.InfraCluster.ProxmoxCluster, net.vnet)
+		}
+
 		// append default pools in front if they exist.
-		if ptr.Deref(net.DefaultIPv4, false) && poolsRef.IPv4 != nil {
-			pools = append(pools, *poolsRef.IPv4)
-			defaultPoolMap[*poolsRef.IPv4] = []ipamv1.IPAddress{}
+		if ptr.Deref(net.defaultIPv4, false) && ipv4PoolRef != nil {
+			pools = append(pools, *ipv4PoolRef)
+			defaultPoolMap[*ipv4PoolRef] = []ipamv1.IPAddress{}
+		}
+		if ptr.Deref(net.defaultIPv6, false) && ipv6PoolRef != nil {
+			pools = append(pools, *ipv6PoolRef)
+			defaultPoolMap[*ipv6PoolRef] = []ipamv1.IPAddress{}
 		}
-		if ptr.Deref(net.DefaultIPv6, false) && poolsRef.IPv6 != nil {
-			pools = append(pools, *poolsRef.IPv6)
-			defaultPoolMap[*poolsRef.IPv6] = []ipamv1.IPAddress{}
+
+		// devices attached to a VNet are assigned an address from each of its subnets.
+		if net.vnet != "" {
+			if ipv4PoolRef != nil && !ptr.Deref(net.defaultIPv4, false) {
+				pools = append(pools, *ipv4PoolRef)
+			}
+			if ipv6PoolRef != nil && !ptr.Deref(net.defaultIPv6, false) {
+				pools = append(pools, *ipv6PoolRef)
+			}
 		}
 
-		for i, ipPool := range slices.Concat(pools, net.InterfaceConfig.IPPoolRef) {
+		for i, ipPool := range slices.Concat(pools, net.ipPoolRef) {
 			ipClaimDef := ipam.IPClaimDef{
 				PoolRef: ipPool,
-				Device:  net.Name,
+				Device:  net.name,
 				Annotations: map[string]string{
 					infrav1.ProxmoxPoolOffsetAnnotation: fmt.Sprintf("%d", i),
 				},
 			}
 			// TODO: I hate this default pool logic
-			if ptr.Deref(net.DefaultIPv4, false) &&
-				ipPool == ptr.Deref(poolsRef.IPv4, corev1.TypedLocalObjectReference{}) ||
-				ptr.Deref(net.DefaultIPv6, false) &&
-					ipPool == ptr.Deref(poolsRef.IPv6, corev1.TypedLocalObjectReference{}) {
+			if ptr.Deref(net.defaultIPv4, false) &&
+				ipPool == ptr.Deref(ipv4PoolRef, corev1.TypedLocalObjectReference{}) ||
+				ptr.Deref(net.defaultIPv6, false) &&
+					ipPool == ptr.Deref(ipv6PoolRef, corev1.TypedLocalObjectReference{}) {
 				ipClaimDef.Annotations[infrav1.ProxmoxDefaultGatewayAnnotation] = "true"
 			}
 
 			ipAddresses, err := resolve(ctx, machineScope, ipClaimDef)
 			if err != nil {
-				return true, errors.Wrapf(err, "unable to handle IPAddress for device %+v, pool %s", net.Name, ipPool.Name)
+				return true, errors.Wrapf(err, "unable to handle IPAddress for device %+v, pool %s", net.name, ipPool.Name)
 			}
 			// fast track ip address generation with only one requeue
 			if len(ipAddresses) == 0 {
@@ -346,16 +416,16 @@ func collectDeviceAddresses(ctx context.Context, machineScope *scope.MachineScop
 				continue
 			}
 
-			poolMap := addresses[net.Name]
+			poolMap := addresses[net.name]
 			if poolMap == nil {
 				poolMap = make(map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress)
 			}
 
 			poolMap[ipPool] = append(poolMap[ipPool], ipAddresses...)
-			addresses[net.Name] = poolMap
+			addresses[net.name] = poolMap
 
 			// append default pool addresses to map
-			if _, exists := defaultPoolMap[ipPool]; exists && (ptr.Deref(net.DefaultIPv4, false) || ptr.Deref(net.DefaultIPv6, false)) {
+			if _, exists := defaultPoolMap[ipPool]; exists && (ptr.Deref(net.defaultIPv4, false) || ptr.Deref(net.defaultIPv6, false)) {
 				defaultPoolMap[ipPool] = append(defaultPoolMap[ipPool], ipAddresses...)
 			}
 		}
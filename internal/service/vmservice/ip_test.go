@@ -61,6 +61,10 @@ func TestReconcileIPAddresses_CreateDefaultClaim(t *testing.T) {
 	require.Equal(t, 1, len(*claimsDefaultPool))
 
 	requireConditionIsFalse(t, machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+
+	recorded := recordedEvents()
+	require.Len(t, recorded, 1)
+	require.Regexp(t, `^Normal IPAddressClaimCreated Created IPAddressClaim ".+" for device net0$`, recorded[0])
 }
 
 func TestReconcileIPAddresses_PendingClaimDoesNotCreateDuplicateClaim(t *testing.T) {
@@ -148,6 +152,48 @@ func TestReconcileIPAddresses_CreateAdditionalClaim(t *testing.T) {
 	requireConditionIsFalse(t, machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
 }
 
+func TestReconcileIPAddresses_CreateVNetClaim(t *testing.T) {
+	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForStaticIPAllocationReason)
+
+	defaultPool := corev1.TypedLocalObjectReference{
+		APIGroup: new(ipamicv1.GroupVersion.String()),
+		Kind:     reflect.ValueOf(ipamicv1.InClusterIPPool{}).Type().Name(),
+		Name:     getDefaultPoolRefs(machineScope).InClusterIPPoolRefV4.Name,
+	}
+
+	machineScope.InfraCluster.ProxmoxCluster.Spec.SDN = &infrav1.SDNSpec{
+		Zone: infrav1.SDNZoneSpec{Name: "capmox"},
+		VNets: []infrav1.SDNVNetSpec{{
+			Name:    "vnet0",
+			Subnets: []infrav1.SDNSubnetSpec{{CIDR: "10.20.0.0/24", Gateway: "10.20.0.1"}},
+		}},
+	}
+	require.NoError(t, machineScope.IPAMHelper.CreateOrUpdateInClusterIPPool(context.Background()))
+
+	machineScope.ProxmoxMachine.Spec.Network = &infrav1.NetworkSpec{
+		NetworkDevices: []infrav1.NetworkDevice{
+			{Name: infrav1.DefaultNetworkDevice, DefaultIPv4: new(true)},
+			{Name: "net1", VNet: new("vnet0")},
+		},
+	}
+
+	vm := newStoppedVM()
+	vm.VirtualMachineConfig.Tags = ipTag
+	machineScope.SetVirtualMachine(vm)
+
+	createIPAddress(t, kubeClient, machineScope, infrav1.DefaultNetworkDevice, "10.10.10.10", 0, &defaultPool)
+
+	requeue, err := reconcileIPAddresses(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+
+	// net1 is assigned an address from the pool of the VNet subnet, not the default pool.
+	claims := getIPAddressClaimsPerPool(t, kubeClient, machineScope, ipam.SDNPoolFormat(machineScope.InfraCluster.ProxmoxCluster, "vnet0", infrav1.IPv4Format))
+	require.NotNil(t, claims)
+	require.Len(t, *claims, 1)
+	require.Equal(t, "test-net1-00-inet", (*claims)[0].Name)
+}
+
 func TestReconcileIPAddresses_ClaimConflictSetsWaitingConditionAndDoesNotCreateClaim(t *testing.T) {
 	machineScope, _, kubeClient := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForStaticIPAllocationReason)
 
@@ -701,3 +747,24 @@ func TestReconcileIPAddresses_MachineIPPoolRef(t *testing.T) {
 
 	requireConditionIsFalse(t, machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
 }
+
+func TestFormatIPAddresses(t *testing.T) {
+	address := func(ip string) ipamv1.IPAddress {
+		return ipamv1.IPAddress{Spec: ipamv1.IPAddressSpec{Address: ip}}
+	}
+	pool := func(name string) corev1.TypedLocalObjectReference {
+		return corev1.TypedLocalObjectReference{Kind: "InClusterIPPool", Name: name}
+	}
+
+	addresses := map[infrav1.NetName]map[corev1.TypedLocalObjectReference][]ipamv1.IPAddress{
+		"net1": {
+			pool("v6"): {address("2001:db8::1")},
+			pool("v4"): {address("10.100.10.10")},
+		},
+		infrav1.DefaultNetworkDevice: {
+			pool("default"): {address("10.10.10.11"), address("10.10.10.10")},
+		},
+	}
+
+	require.Equal(t, "net0=10.10.10.10,10.10.10.11 net1=10.100.10.10,2001:db8::1", formatIPAddresses(addresses))
+}
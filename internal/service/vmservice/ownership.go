@@ -0,0 +1,196 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// ErrVMNotOwned is returned for a VM which does not carry the ownership markers of the ProxmoxMachine.
+var ErrVMNotOwned = errors.New("vm is not owned by this machine")
+
+// ownerMarkersHeader starts the block of the VM description which holds the ownership markers.
+const ownerMarkersHeader = "[capmox]"
+
+// OwnerMarkers identify the ProxmoxMachine a VM was created for.
+// They are kept in a block at the end of the VM description.
+type OwnerMarkers struct {
+	// Cluster is the namespaced name of the cluster, in the form namespace/name.
+	Cluster string
+	// ProxmoxMachine is the name of the ProxmoxMachine.
+	ProxmoxMachine string
+	// UID is the UID of the ProxmoxMachine.
+	UID types.UID
+}
+
+// String formats the markers as description block.
+func (m OwnerMarkers) String() string {
+	return fmt.Sprintf("%s\ncluster = %s\nproxmoxmachine = %s\nuid = %s", ownerMarkersHeader, m.Cluster, m.ProxmoxMachine, m.UID)
+}
+
+// ParseOwnerMarkers splits a VM description into the ownership markers and the remaining description.
+// found is false if the description carries no markers.
+func ParseOwnerMarkers(description string) (markers OwnerMarkers, userDescription string, found bool) {
+	lines := strings.Split(description, "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == ownerMarkersHeader {
+			start = i
+		}
+	}
+	if start < 0 {
+		return OwnerMarkers{}, description, false
+	}
+
+	for _, line := range lines[start+1:] {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "cluster":
+			markers.Cluster = value
+		case "proxmoxmachine":
+			markers.ProxmoxMachine = value
+		case "uid":
+			markers.UID = types.UID(value)
+		}
+	}
+
+	return markers, strings.TrimRight(strings.Join(lines[:start], "\n"), "\n"), true
+}
+
+// IsOwnedByCluster reports whether the VM description carries the ownership markers of the given cluster.
+func IsOwnedByCluster(description string, clusterScope *scope.ClusterScope) bool {
+	markers, _, found := ParseOwnerMarkers(description)
+	return found && markers.Cluster == clusterKey(clusterScope)
+}
+
+func clusterKey(clusterScope *scope.ClusterScope) string {
+	return clusterScope.Namespace() + "/" + clusterScope.Name()
+}
+
+func ownerMarkers(machineScope *scope.MachineScope) OwnerMarkers {
+	return OwnerMarkers{
+		Cluster:        clusterKey(machineScope.InfraCluster),
+		ProxmoxMachine: machineScope.ProxmoxMachine.GetName(),
+		UID:            machineScope.ProxmoxMachine.GetUID(),
+	}
+}
+
+// vmDescription returns the description of the VM, which is the description
+// of the ProxmoxMachine followed by the ownership markers.
+func vmDescription(machineScope *scope.MachineScope) string {
+	return withOwnerMarkers(ptr.Deref(machineScope.ProxmoxMachine.Spec.Description, ""), ownerMarkers(machineScope))
+}
+
+// withOwnerMarkers appends the ownership markers to a description.
+func withOwnerMarkers(description string, markers OwnerMarkers) string {
+	if description != "" {
+		return description + "\n\n" + markers.String()
+	}
+	return markers.String()
+}
+
+// verifyVMOwnership makes sure the VM was created for the ProxmoxMachine, before it is changed or deleted.
+// VMs created before the ownership markers were introduced are recognized by their name, until
+// reconcileOwnerMarkers has added the markers.
+// A ProxmoxMachine recreated by clusterctl move has a new UID, its VM is recognized by the
+// BIOS UUID of the provider ID.
+func verifyVMOwnership(machineScope *scope.MachineScope, vm *proxmox.VirtualMachine) error {
+	var description string
+	if vm.VirtualMachineConfig != nil {
+		description = vm.VirtualMachineConfig.Description
+	}
+
+	markers, _, found := ParseOwnerMarkers(description)
+	if !found {
+		if vm.Name != machineScope.ProxmoxMachine.GetName() {
+			return errors.Wrapf(ErrVMNotOwned, "vm %d is named %q", vm.VMID, vm.Name)
+		}
+		return nil
+	}
+
+	expected := ownerMarkers(machineScope)
+	if markers == expected {
+		return nil
+	}
+	if markers.Cluster == expected.Cluster && markers.ProxmoxMachine == expected.ProxmoxMachine && isMovedVM(machineScope, vm) {
+		return nil
+	}
+	return errors.Wrapf(ErrVMNotOwned, "vm %d belongs to ProxmoxMachine %s in cluster %s with UID %s",
+		vm.VMID, markers.ProxmoxMachine, markers.Cluster, markers.UID)
+}
+
+// isMovedVM reports whether the provider ID of the ProxmoxMachine points to the VM. The provider
+// ID is kept in the spec, so it survives clusterctl move.
+func isMovedVM(machineScope *scope.MachineScope, vm *proxmox.VirtualMachine) bool {
+	if vm.VirtualMachineConfig == nil {
+		return false
+	}
+	biosUUID := extractUUID(vm.VirtualMachineConfig.SMBios1)
+	return biosUUID != "" && machineScope.GetProviderID() == "proxmox://"+biosUUID
+}
+
+// isLegacyVM reports whether the VM was provisioned for the ProxmoxMachine before the ownership
+// markers were introduced. Such a VM carries the name of the machine and the VMID of its spec.
+func isLegacyVM(machineScope *scope.MachineScope, vm *proxmox.VirtualMachine) bool {
+	return ptr.Deref(machineScope.ProxmoxMachine.Status.Initialization.Provisioned, false) &&
+		vm.Name == machineScope.ProxmoxMachine.GetName() &&
+		int64(vm.VMID) == machineScope.ProxmoxMachine.GetVirtualMachineID()
+}
+
+// reconcileOwnerMarkers adds the ownership markers to a VM provisioned before they were introduced,
+// and updates the UID in the markers of a VM which was adopted by a ProxmoxMachine recreated by
+// clusterctl move. The rest of the description is kept.
+func reconcileOwnerMarkers(ctx context.Context, machineScope *scope.MachineScope) (requeue bool, err error) {
+	vm := machineScope.VirtualMachine
+	if vm == nil || vm.VirtualMachineConfig == nil {
+		return false, nil
+	}
+	markers, description, found := ParseOwnerMarkers(vm.VirtualMachineConfig.Description)
+	expected := ownerMarkers(machineScope)
+	if (!found && !isLegacyVM(machineScope, vm)) || markers == expected {
+		return false, nil
+	}
+
+	task, err := machineScope.InfraCluster.ProxmoxClient.ConfigureVM(ctx, vm, proxmox.VirtualMachineOption{
+		Name:  optionDescription,
+		Value: withOwnerMarkers(description, expected),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to update ownership markers of VM %s", machineScope.Name())
+	}
+	machineScope.ProxmoxMachine.Status.TaskRef = new(string(task.UPID))
+	if found {
+		record.Eventf(machineScope.ProxmoxMachine, "OwnerMarkersUpdated", "Updated UID of virtual machine %d from %s to %s", vm.VMID, markers.UID, expected.UID)
+	} else {
+		record.Eventf(machineScope.ProxmoxMachine, "OwnerMarkersAdded", "Added ownership markers to virtual machine %d", vm.VMID)
+	}
+	return true, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luthermonson/go-proxmox"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOwnerMarkers(t *testing.T) {
+	markers := OwnerMarkers{Cluster: "default/test", ProxmoxMachine: "test", UID: "test-machine-uid"}
+
+	parsed, description, found := ParseOwnerMarkers("my vm\n\n" + markers.String())
+	require.True(t, found)
+	require.Equal(t, markers, parsed)
+	require.Equal(t, "my vm", description)
+
+	parsed, description, found = ParseOwnerMarkers(markers.String())
+	require.True(t, found)
+	require.Equal(t, markers, parsed)
+	require.Empty(t, description)
+
+	// Proxmox may return the description with carriage returns.
+	parsed, _, found = ParseOwnerMarkers("[capmox]\r\ncluster = default/test\r\nproxmoxmachine = test\r\nuid = test-machine-uid\r\n")
+	require.True(t, found)
+	require.Equal(t, markers, parsed)
+
+	_, description, found = ParseOwnerMarkers("my vm")
+	require.False(t, found)
+	require.Equal(t, "my vm", description)
+}
+
+func TestVMDescription(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	require.Equal(t, "[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = test-machine-uid", vmDescription(machineScope))
+
+	machineScope.ProxmoxMachine.Spec.Description = new("my vm")
+	require.Equal(t, "my vm\n\n[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = test-machine-uid", vmDescription(machineScope))
+}
+
+func TestVerifyVMOwnership(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
+	require.NoError(t, verifyVMOwnership(machineScope, vm))
+
+	// VMs without markers are recognized by their name.
+	vm = newRunningVM()
+	require.NoError(t, verifyVMOwnership(machineScope, vm))
+	vm.Name = "other"
+	require.ErrorIs(t, verifyVMOwnership(machineScope, vm), ErrVMNotOwned)
+
+	// a VM with the same name in another cluster.
+	vm = newRunningVM()
+	vm.VirtualMachineConfig.Description = "[capmox]\ncluster = other/test\nproxmoxmachine = test\nuid = test-machine-uid"
+	require.ErrorIs(t, verifyVMOwnership(machineScope, vm), ErrVMNotOwned)
+
+	// a VM of a previous ProxmoxMachine with the same name.
+	vm.VirtualMachineConfig.Description = "[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = previous-uid"
+	require.ErrorIs(t, verifyVMOwnership(machineScope, vm), ErrVMNotOwned)
+
+	// a VM of a ProxmoxMachine recreated by clusterctl move, recognized by its provider ID.
+	vm.VirtualMachineConfig.SMBios1 = "uuid=56603c36-46b9-4608-90ae-c731c15eae64"
+	require.ErrorIs(t, verifyVMOwnership(machineScope, vm), ErrVMNotOwned)
+	machineScope.SetProviderID("56603c36-46b9-4608-90ae-c731c15eae64")
+	require.NoError(t, verifyVMOwnership(machineScope, vm))
+
+	// the provider ID does not adopt VMs of other machines.
+	vm.VirtualMachineConfig.Description = "[capmox]\ncluster = default/other\nproxmoxmachine = test\nuid = previous-uid"
+	require.ErrorIs(t, verifyVMOwnership(machineScope, vm), ErrVMNotOwned)
+}
+
+func TestReconcileOwnerMarkers(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = "my vm\n\n[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = previous-uid"
+	machineScope.SetVirtualMachine(vm)
+
+	proxmoxClient.EXPECT().ConfigureVM(context.Background(), vm, proxmox.VirtualMachineOption{
+		Name:  optionDescription,
+		Value: "my vm\n\n[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = test-machine-uid",
+	}).Return(newTask(), nil).Once()
+
+	requeue, err := reconcileOwnerMarkers(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+	require.Equal(t, "result", *machineScope.ProxmoxMachine.Status.TaskRef)
+
+	// up to date markers are left alone.
+	machineScope.ProxmoxMachine.Status.TaskRef = nil
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
+	requeue, err = reconcileOwnerMarkers(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+}
+
+func TestReconcileOwnerMarkers_LegacyVM(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
+	// a VM provisioned before the ownership markers were introduced.
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = "my vm"
+	machineScope.SetVirtualMachine(vm)
+
+	// VMs which are not provisioned yet get their description with the VM config.
+	requeue, err := reconcileOwnerMarkers(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+
+	// the VMID must match the spec.
+	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
+	machineScope.SetVirtualMachineID(124)
+	requeue, err = reconcileOwnerMarkers(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+
+	machineScope.SetVirtualMachineID(int64(vm.VMID))
+	proxmoxClient.EXPECT().ConfigureVM(context.Background(), vm, proxmox.VirtualMachineOption{
+		Name:  optionDescription,
+		Value: "my vm\n\n[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = test-machine-uid",
+	}).Return(newTask(), nil).Once()
+
+	requeue, err = reconcileOwnerMarkers(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+	require.Equal(t, "result", *machineScope.ProxmoxMachine.Status.TaskRef)
+}
+
+func TestIsOwnedByCluster(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+
+	require.True(t, IsOwnedByCluster(vmDescription(machineScope), machineScope.InfraCluster))
+	require.False(t, IsOwnedByCluster("[capmox]\ncluster = other/test", machineScope.InfraCluster))
+	require.False(t, IsOwnedByCluster("", machineScope.InfraCluster))
+}
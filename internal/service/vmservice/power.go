@@ -23,6 +23,7 @@ import (
 	"github.com/luthermonson/go-proxmox"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 	capmox "github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox"
@@ -45,11 +46,14 @@ func reconcilePowerState(ctx context.Context, machineScope *scope.MachineScope)
 			Reason:  infrav1.ProxmoxMachineVirtualMachineProvisionedPoweringOnFailedReason,
 			Message: err.Error(),
 		})
+		record.Warnf(machineScope.ProxmoxMachine, "PowerOnFailed", "Failed to power on virtual machine: %s", err)
 		return false, err
 	}
 
 	if t != nil {
 		machineScope.ProxmoxMachine.Status.TaskRef = new(string(t.UPID))
+		record.Eventf(machineScope.ProxmoxMachine, "PoweringOn", "Powering on virtual machine %d on node %s",
+			machineScope.VirtualMachine.VMID, machineScope.VirtualMachine.Node)
 		return true, nil
 	}
 
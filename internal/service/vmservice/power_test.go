@@ -37,6 +37,7 @@ func TestReconcilePowerState_SetTaskRef(t *testing.T) {
 	}}
 
 	vm := newStoppedVM()
+	vm.VMID = 123
 	task := newTask()
 	machineScope.SetVirtualMachine(vm)
 	proxmoxClient.EXPECT().StartVM(ctx, vm).Return(task, nil).Once()
@@ -45,6 +46,7 @@ func TestReconcilePowerState_SetTaskRef(t *testing.T) {
 	require.True(t, requeue)
 	require.NoError(t, err)
 	require.NotEmpty(t, *machineScope.ProxmoxMachine.Status.TaskRef)
+	require.Equal(t, []string{"Normal PoweringOn Powering on virtual machine 123 on node node1"}, recordedEvents())
 }
 
 func TestStartVirtualMachine_Paused(t *testing.T) {
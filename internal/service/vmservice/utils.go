@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/netip"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -100,6 +101,15 @@ func extractNetworkModel(input string) string {
 	return ""
 }
 
+// networkDeviceBridge returns the bridge a network device is attached to. Devices
+// attached to a SDN VNet use the bridge of the same name Proxmox creates for it.
+func networkDeviceBridge(device infrav1.NetworkDevice) string {
+	if device.VNet != nil {
+		return *device.VNet
+	}
+	return ptr.Deref(device.Bridge, "")
+}
+
 // extractNetworkBridge returns the bridge out of net device input e.g. virtio=A6:23:64:4D:84:CB,bridge=vmbr1,mtu=1500.
 func extractNetworkBridge(input string) string {
 	re := regexp.MustCompile(`bridge=(\w+)`)
@@ -140,6 +150,13 @@ func extractNetworkVLAN(input string) int32 {
 	return 0
 }
 
+// extractNetworkFirewall returns whether the firewall is enabled in net device input e.g. virtio=A6:23:64:4D:84:CB,bridge=vmbr1,firewall=1.
+func extractNetworkFirewall(input string) bool {
+	re := regexp.MustCompile(`firewall=(\d)`)
+	match := re.FindStringSubmatch(input)
+	return len(match) > 1 && match[1] == "1"
+}
+
 // extractNetworkQueue returns the queue out of net device input e.g. virtio=A6:23:64:4D:84:CB,bridge=vmbr1,mtu=1500,tag=100,queues=4.
 func extractNetworkQueue(input string) int32 {
 	re := regexp.MustCompile(`queues=(\d+)`)
@@ -163,57 +180,70 @@ func shouldUpdateNetworkDevices(machineScope *scope.MachineScope) bool {
 
 	nets := machineScope.VirtualMachine.VirtualMachineConfig.Nets
 
+	// devices are only compared with the firewall if the machine defines one.
+	firewall := machineFirewall(machineScope)
+
 	devices := machineScope.ProxmoxMachine.Spec.Network.NetworkDevices
 	for _, v := range devices {
 		name := v.Name
 		if len(name) == 0 {
 			name = infrav1.DefaultNetworkDevice
 		}
-		net := nets[string(name)]
-		// device is empty.
-		if len(net) == 0 {
+		if len(networkDeviceDrift(v, nets[string(name)], firewall)) > 0 {
 			return true
 		}
+	}
 
-		model := extractNetworkModel(net)
-		bridge := extractNetworkBridge(net)
+	return false
+}
 
-		// current is different from the desired spec.
-		if model != ptr.Deref(v.Model, "virtio") || bridge != ptr.Deref(v.Bridge, "") {
-			return true
-		}
+// networkDeviceDrift returns the differences between the desired spec of a network device
+// and its net device input e.g. virtio=A6:23:64:4D:84:CB,bridge=vmbr1,mtu=1500.
+// The firewall is only compared if it is not nil.
+func networkDeviceDrift(device infrav1.NetworkDevice, net string, firewall *infrav1.FirewallSpec) []string {
+	// device is empty.
+	if len(net) == 0 {
+		return []string{"device is missing"}
+	}
 
-		if v.MTU != nil {
-			mtu := extractNetworkMTU(net)
+	var drift []string
 
-			if mtu != *v.MTU {
-				return true
-			}
-		}
-
-		if v.VLAN != nil {
-			vlan := extractNetworkVLAN(net)
+	// current is different from the desired spec.
+	if model, want := extractNetworkModel(net), ptr.Deref(device.Model, "virtio"); model != want {
+		drift = append(drift, fmt.Sprintf("model is %s, expected %s", model, want))
+	}
+	if bridge, want := extractNetworkBridge(net), networkDeviceBridge(device); bridge != want {
+		drift = append(drift, fmt.Sprintf("bridge is %s, expected %s", bridge, want))
+	}
 
-			if vlan != *v.VLAN {
-				return true
-			}
+	if device.MTU != nil {
+		if mtu := extractNetworkMTU(net); mtu != *device.MTU {
+			drift = append(drift, fmt.Sprintf("mtu is %d, expected %d", mtu, *device.MTU))
 		}
+	}
 
-		if v.Queues != nil {
-			queues := extractNetworkQueue(net)
+	if device.VLAN != nil {
+		if vlan := extractNetworkVLAN(net); vlan != *device.VLAN {
+			drift = append(drift, fmt.Sprintf("vlan is %d, expected %d", vlan, *device.VLAN))
+		}
+	}
 
-			if queues != *v.Queues {
-				return true
-			}
+	if device.Queues != nil {
+		if queues := extractNetworkQueue(net); queues != *device.Queues {
+			drift = append(drift, fmt.Sprintf("queues is %d, expected %d", queues, *device.Queues))
 		}
 	}
 
-	return false
+	if firewall != nil && extractNetworkFirewall(net) != firewallEnabled(firewall) {
+		drift = append(drift, fmt.Sprintf("firewall is %t, expected %t", extractNetworkFirewall(net), firewallEnabled(firewall)))
+	}
+
+	return drift
 }
 
 // formatNetworkDevice formats a network device config
-// example 'virtio,bridge=vmbr0,tag=100,queues=4'.
-func formatNetworkDevice(model, bridge string, mtu *int32, vlan *int32, queues *int32) string {
+// example 'virtio,bridge=vmbr0,tag=100,queues=4,firewall=1'.
+func formatNetworkDevice(model, bridge string, mtu *int32, vlan *int32, queues *int32, firewall bool) string {
 	var components = []string{model, fmt.Sprintf("bridge=%s", bridge)}
 
 	if mtu != nil {
@@ -228,6 +258,10 @@ func formatNetworkDevice(model, bridge string, mtu *int32, vlan *int32, queues *
 		components = append(components, fmt.Sprintf("queues=%d", *queues))
 	}
 
+	if firewall {
+		components = append(components, "firewall=1")
+	}
+
 	return strings.Join(components, ",")
 }
 
@@ -241,6 +275,11 @@ func extractMACAddress(input string) string {
 	return ""
 }
 
+// isLinkDown returns true if the link of a net device input e.g. virtio=A6:23:64:4D:84:CB,bridge=vmbr1,link_down=1 is down.
+func isLinkDown(input string) bool {
+	return slices.Contains(strings.Split(input, ","), "link_down=1")
+}
+
 // NetNameToOffset converts a proxmox network name to a NetworkDevice offset.
 func NetNameToOffset(name infrav1.NetName) (int, error) {
 	offset, found := strings.CutPrefix(string(name), "net")
@@ -239,6 +239,53 @@ func TestShouldUpdateNetworkDevices_NoUpdate(t *testing.T) {
 	require.False(t, shouldUpdateNetworkDevices(machineScope))
 }
 
+func TestShouldUpdateNetworkDevices_VNet(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	machineScope.ProxmoxMachine.Spec.Network = &infrav1.NetworkSpec{
+		NetworkDevices: []infrav1.NetworkDevice{
+			{
+				Bridge: new("vmbr0"),
+				Model:  new("virtio"),
+			},
+			{
+				Name:  "net1",
+				VNet:  new("vnet0"),
+				Model: new("virtio"),
+			},
+		},
+	}
+	machineScope.SetVirtualMachine(newVMWithNets("virtio=A6:23:64:4D:84:CD,bridge=vmbr0", "virtio=A6:23:64:4D:84:CD,bridge=vnet0"))
+	require.False(t, shouldUpdateNetworkDevices(machineScope))
+
+	machineScope.SetVirtualMachine(newVMWithNets("virtio=A6:23:64:4D:84:CD,bridge=vmbr0", "virtio=A6:23:64:4D:84:CD,bridge=vmbr1"))
+	require.True(t, shouldUpdateNetworkDevices(machineScope))
+}
+
+func TestShouldUpdateNetworkDevices_Firewall(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTest(t)
+	machineScope.ProxmoxMachine.Spec.Network = &infrav1.NetworkSpec{
+		NetworkDevices: []infrav1.NetworkDevice{{Bridge: new("vmbr0"), Model: new("virtio")}},
+	}
+	machineScope.SetVirtualMachine(newVMWithNets("virtio=A6:23:64:4D:84:CD,bridge=vmbr0"))
+	require.False(t, shouldUpdateNetworkDevices(machineScope))
+
+	machineScope.InfraCluster.ProxmoxCluster.Spec.Firewall = &infrav1.ClusterFirewallSpec{}
+	require.True(t, shouldUpdateNetworkDevices(machineScope))
+
+	machineScope.SetVirtualMachine(newVMWithNets("virtio=A6:23:64:4D:84:CD,bridge=vmbr0,firewall=1"))
+	require.False(t, shouldUpdateNetworkDevices(machineScope))
+
+	machineScope.ProxmoxMachine.Spec.Firewall = &infrav1.FirewallSpec{Enabled: new(false)}
+	require.True(t, shouldUpdateNetworkDevices(machineScope))
+}
+
+func TestExtractNetworkFirewall(t *testing.T) {
+	require.True(t, extractNetworkFirewall("virtio=A6:23:64:4D:84:CB,bridge=vmbr1,firewall=1"))
+	require.True(t, extractNetworkFirewall("virtio=A6:23:64:4D:84:CB,bridge=vmbr1,firewall=1,mtu=1500"))
+	require.False(t, extractNetworkFirewall("virtio=A6:23:64:4D:84:CB,bridge=vmbr1,firewall=0"))
+	require.False(t, extractNetworkFirewall("virtio=A6:23:64:4D:84:CB,bridge=vmbr1"))
+}
+
 func TestExtractNetworkVLAN(t *testing.T) {
 	type match struct {
 		test     string
@@ -315,11 +362,12 @@ func TestExtractNetworkQueue(t *testing.T) {
 }
 
 func TestFormatNetworkDevice(t *testing.T) {
-	require.Equal(t, "virtio,bridge=vmbr0", formatNetworkDevice("virtio", "vmbr0", nil, nil, nil))
-	require.Equal(t, "virtio,bridge=vmbr0,mtu=1500", formatNetworkDevice("virtio", "vmbr0", new(int32(1500)), nil, nil))
-	require.Equal(t, "virtio,bridge=vmbr0,tag=100", formatNetworkDevice("virtio", "vmbr0", nil, new(int32(100)), nil))
-	require.Equal(t, "virtio,bridge=vmbr0,queues=4", formatNetworkDevice("virtio", "vmbr0", nil, nil, new(int32(4))))
-	require.Equal(t, "virtio,bridge=vmbr0,mtu=1500,tag=100,queues=4", formatNetworkDevice("virtio", "vmbr0", new(int32(1500)), new(int32(100)), new(int32(4))))
+	require.Equal(t, "virtio,bridge=vmbr0", formatNetworkDevice("virtio", "vmbr0", nil, nil, nil, false))
+	require.Equal(t, "virtio,bridge=vmbr0,mtu=1500", formatNetworkDevice("virtio", "vmbr0", new(int32(1500)), nil, nil, false))
+	require.Equal(t, "virtio,bridge=vmbr0,tag=100", formatNetworkDevice("virtio", "vmbr0", nil, new(int32(100)), nil, false))
+	require.Equal(t, "virtio,bridge=vmbr0,queues=4", formatNetworkDevice("virtio", "vmbr0", nil, nil, new(int32(4)), false))
+	require.Equal(t, "virtio,bridge=vmbr0,mtu=1500,tag=100,queues=4", formatNetworkDevice("virtio", "vmbr0", new(int32(1500)), new(int32(100)), new(int32(4)), false))
+	require.Equal(t, "virtio,bridge=vmbr0,firewall=1", formatNetworkDevice("virtio", "vmbr0", nil, nil, nil, true))
 }
 
 func TestExtractMACAddress(t *testing.T) {
@@ -350,6 +398,12 @@ func TestExtractMACAddress(t *testing.T) {
 	}
 }
 
+func TestIsLinkDown(t *testing.T) {
+	require.True(t, isLinkDown("virtio=A6:23:64:4D:84:CB,bridge=vmbr1,link_down=1"))
+	require.False(t, isLinkDown("virtio=A6:23:64:4D:84:CB,bridge=vmbr1,link_down=0"))
+	require.False(t, isLinkDown("virtio=A6:23:64:4D:84:CB,bridge=vmbr1"))
+}
+
 func TestParseRouteTarget(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -29,6 +29,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/internal/inject"
@@ -72,6 +73,11 @@ func ReconcileVM(ctx context.Context, scope *scope.MachineScope) (infrav1.Virtua
 	}
 	scope.Logger.V(4).Info("proxmox machine state", "state", conditions.GetReason(scope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition))
 
+	if requeue, err := reconcileReclone(ctx, scope); err != nil || requeue {
+		scope.Logger.V(4).Info("after reconcileReclone", "machineName", scope.ProxmoxMachine.GetName(), "requeue", requeue, "err", err)
+		return vm, err
+	} // VirtualMachineProvisioned reason is Cloning
+
 	// TODO: This requires a proper state machine. We're reusing
 	// the condition reasons in VirtualMachineProvisioned as a state machine
 	// for convenience, but this definitely needs to be refactored.
@@ -79,6 +85,11 @@ func ReconcileVM(ctx context.Context, scope *scope.MachineScope) (infrav1.Virtua
 		return vm, err
 	} // VirtualMachineProvisioned reason is Cloning
 
+	if requeue, err := reconcileOwnerMarkers(ctx, scope); err != nil || requeue {
+		scope.Logger.V(4).Info("after reconcileOwnerMarkers", "machineName", scope.ProxmoxMachine.GetName(), "requeue", requeue, "err", err)
+		return vm, err
+	}
+
 	// Recover IP/address status for already-running machines (e.g. restored from
 	// backup) whose status was lost. This is read-only and a no-op during normal
 	// provisioning; it does not advance the provisioning state machine below.
@@ -92,6 +103,12 @@ func ReconcileVM(ctx context.Context, scope *scope.MachineScope) (infrav1.Virtua
 		return vm, err
 	} // VirtualMachineProvisioned reason is WaitingForDiskReconciliation
 
+	// The firewall is kept in sync on every reconcile, from before the VM is started.
+	if err := reconcileFirewall(ctx, scope); err != nil {
+		scope.Logger.V(4).Info("after reconcileFirewall", "machineName", scope.ProxmoxMachine.GetName(), "err", err)
+		return vm, err
+	}
+
 	if err := reconcileDisks(ctx, scope); err != nil {
 		scope.Logger.V(4).Info("after reconcileDisks", "machineName", scope.ProxmoxMachine.GetName(), "err", err)
 		return vm, err
@@ -112,8 +129,8 @@ func ReconcileVM(ctx context.Context, scope *scope.MachineScope) (infrav1.Virtua
 		return vm, err
 	} // VirtualMachineProvisioned reason is WaitingForClusterAPIMachineAddresses
 
-	if err := reconcileMachineAddresses(scope); err != nil {
-		scope.Logger.V(4).Info("after reconcileMachineAddresses", "machineName", scope.ProxmoxMachine.GetName(), "err", err)
+	if requeue, err := reconcileMachineAddresses(ctx, scope); err != nil || requeue {
+		scope.Logger.V(4).Info("after reconcileMachineAddresses", "machineName", scope.ProxmoxMachine.GetName(), "requeue", requeue, "err", err)
 		return vm, err
 	} // VirtualMachineProvisioned reason is WaitingForCloudInit
 
@@ -136,6 +153,18 @@ func ReconcileVM(ctx context.Context, scope *scope.MachineScope) (infrav1.Virtua
 			return vm, errors.Wrapf(err, "failed to unmount cloud-init iso for vm %s", scope.Name())
 		}
 	} // State Machine is finished
+
+	if requeue, err := reconcileConfigDrift(ctx, scope); err != nil || requeue {
+		scope.Logger.V(4).Info("after reconcileConfigDrift", "machineName", scope.ProxmoxMachine.GetName(), "requeue", requeue, "err", err)
+		return vm, err
+	}
+
+	if err := reconcileHighAvailability(ctx, scope); err != nil {
+		scope.Logger.V(4).Info("after reconcileHighAvailability", "machineName", scope.ProxmoxMachine.GetName(), "err", err)
+		return vm, err
+	}
+
+	reconcileMachineHealth(ctx, scope)
 	scope.Logger.V(4).Info("condition", "condition", conditions.GetReason(scope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition))
 
 	vm.State = infrav1.VirtualMachineStateReady
@@ -186,6 +215,63 @@ func checkCloudInitStatus(ctx context.Context, machineScope *scope.MachineScope)
 	return false, nil
 }
 
+// reconcileReclone deletes the partially cloned VM of a failed clone task, releasing its
+// VMID, and sends the machine back to Cloning, so it is scheduled and cloned again.
+func reconcileReclone(ctx context.Context, machineScope *scope.MachineScope) (requeue bool, err error) {
+	if conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition) != infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason {
+		// Machine is in the wrong state to reconcile, we only reconcile machines whose clone failed.
+		return false, nil
+	}
+
+	vmID := machineScope.GetVirtualMachineID()
+	node := machineScope.LocateProxmoxNode()
+	if vmID > 0 {
+		requeue, err := deletePartiallyClonedVM(ctx, machineScope, node, vmID)
+		if requeue || err != nil {
+			return requeue, err
+		}
+	}
+
+	// The VM is gone or belongs to someone else, start over.
+	machineScope.ProxmoxMachine.Spec.VirtualMachineID = nil
+	machineScope.ProxmoxMachine.Status.ProxmoxNode = nil
+	machineScope.InfraCluster.ProxmoxCluster.RemoveNodeLocation(machineScope.Name(), util.IsControlPlaneMachine(machineScope.Machine))
+	conditions.Set(machineScope.ProxmoxMachine, metav1.Condition{
+		Type:   infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason,
+	})
+
+	return false, machineScope.InfraCluster.PatchObject()
+}
+
+// deletePartiallyClonedVM deletes the VM left behind by a failed clone task, unless it is gone
+// or not owned by the machine. It returns true while the VM is being deleted.
+func deletePartiallyClonedVM(ctx context.Context, machineScope *scope.MachineScope, node string, vmID int64) (requeue bool, err error) {
+	vm, err := machineScope.InfraCluster.ProxmoxClient.GetVM(ctx, node, vmID)
+	if err != nil {
+		if VMNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "unable to get partially cloned VM %d", vmID)
+	}
+	if err := verifyVMOwnership(machineScope, vm); err != nil {
+		record.Warnf(machineScope.ProxmoxMachine, "VirtualMachineNotOwned", "Not deleting virtual machine %d on node %s: %s", vmID, node, err)
+		return false, nil
+	}
+
+	task, err := machineScope.InfraCluster.ProxmoxClient.DeleteVM(ctx, node, vmID)
+	if err != nil {
+		if VMNotFound(err) || errors.Is(err, goproxmox.ErrVMIDFree) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "unable to delete partially cloned VM %d", vmID)
+	}
+	record.Eventf(machineScope.ProxmoxMachine, "DeletingVirtualMachine", "Deleting partially cloned virtual machine %d on node %s", vmID, node)
+	machineScope.ProxmoxMachine.Status.TaskRef = new(string(task.UPID))
+	return true, nil
+}
+
 // ensureVirtualMachine creates a Proxmox VM if it doesn't exist and updates the given MachineScope.
 func ensureVirtualMachine(ctx context.Context, machineScope *scope.MachineScope) (requeue bool, err error) {
 	// if there's an associated task, requeue.
@@ -250,6 +336,13 @@ func ensureVirtualMachine(ctx context.Context, machineScope *scope.MachineScope)
 		return true, nil
 	}
 
+	// rebuild the location of a VM whose status was lost, e.g. by clusterctl move.
+	if machineScope.ProxmoxMachine.Status.ProxmoxNode == nil && vmRef.Node != "" {
+		if err := recordVMLocation(machineScope, vmRef); err != nil {
+			return false, errors.Wrap(err, "error recording vm location")
+		}
+	}
+
 	// make sure spec.providerID is always set.
 	biosUUID := extractUUID(vmRef.VirtualMachineConfig.SMBios1)
 	machineScope.SetProviderID(biosUUID)
@@ -322,37 +415,38 @@ func reconcileVirtualMachineConfig(ctx context.Context, machineScope *scope.Mach
 		vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionMemory, Value: memory})
 	}
 
-	// Description
-	if machineScope.ProxmoxMachine.Spec.Description != nil {
-		if machineScope.VirtualMachine.VirtualMachineConfig.Description != *machineScope.ProxmoxMachine.Spec.Description {
-			vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionDescription, Value: machineScope.ProxmoxMachine.Spec.Description})
-		}
+	// Description, including the ownership markers.
+	if description := vmDescription(machineScope); vmConfig.Description != description {
+		vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionDescription, Value: description})
 	}
 
 	// Network vmbrs.
 	if machineScope.ProxmoxMachine.Spec.Network != nil && shouldUpdateNetworkDevices(machineScope) {
 		devices := machineScope.ProxmoxMachine.Spec.Network.NetworkDevices
+		firewall := firewallEnabled(machineFirewall(machineScope))
 		for _, v := range devices {
 			vmOptions = append(vmOptions, proxmox.VirtualMachineOption{
 				Name:  string(v.Name),
-				Value: formatNetworkDevice(ptr.Deref(v.Model, "virtio"), ptr.Deref(v.Bridge, ""), v.MTU, v.VLAN, v.Queues),
+				Value: formatNetworkDevice(ptr.Deref(v.Model, "virtio"), networkDeviceBridge(v), v.MTU, v.VLAN, v.Queues, firewall),
 			})
 		}
 	}
 
-	// custom tags
-	if machineScope.ProxmoxMachine.Spec.Tags != nil {
-		machineScope.VirtualMachine.SplitTags()
-		length := len(machineScope.VirtualMachine.VirtualMachineConfig.TagsSlice)
-		for _, tag := range machineScope.ProxmoxMachine.Spec.Tags {
-			if !machineScope.VirtualMachine.HasTag(tag) {
-				machineScope.VirtualMachine.VirtualMachineConfig.TagsSlice = append(machineScope.VirtualMachine.VirtualMachineConfig.TagsSlice, tag)
-			}
-		}
-		if len(machineScope.VirtualMachine.VirtualMachineConfig.TagsSlice) > length {
-			vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionTags, Value: strings.Join(machineScope.VirtualMachine.VirtualMachineConfig.TagsSlice, ";")})
+	// custom tags and the tag which marks the VM as owned by the cluster.
+	machineScope.VirtualMachine.SplitTags()
+	tags := slices.DeleteFunc(machineScope.VirtualMachine.VirtualMachineConfig.TagsSlice, func(tag string) bool {
+		return tag == ""
+	})
+	length := len(tags)
+	for _, tag := range append(slices.Clone(machineScope.ProxmoxMachine.Spec.Tags), machineScope.InfraCluster.VMOwnerTag()) {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
 		}
 	}
+	machineScope.VirtualMachine.VirtualMachineConfig.TagsSlice = tags
+	if len(tags) > length {
+		vmOptions = append(vmOptions, proxmox.VirtualMachineOption{Name: optionTags, Value: strings.Join(tags, ";")})
+	}
 
 	if len(vmOptions) == 0 {
 		return false, nil
@@ -375,16 +469,32 @@ func reconcileVirtualMachineConfig(ctx context.Context, machineScope *scope.Mach
 	return true, nil
 }
 
-func reconcileMachineAddresses(machineScope *scope.MachineScope) error {
+func reconcileMachineAddresses(ctx context.Context, machineScope *scope.MachineScope) (requeue bool, err error) {
 	if conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition) != infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForClusterAPIMachineAddressesReason {
 		// Machine is in the wrong state to reconcile, we only reconcile powered up VMs
-		return nil
+		return false, nil
+	}
+
+	// DHCP addresses are known once the guest agent is up and has reported a lease.
+	if machineScope.ProxmoxMachine.UsesDHCP() {
+		err = reconcileDHCPAddresses(ctx, machineScope)
+		if errors.Is(err, errGuestAgentNotRunning) {
+			machineScope.Logger.V(4).Info("waiting for guest agent")
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !hasDefaultAddresses(machineScope.ProxmoxMachine) {
+			machineScope.Logger.V(4).Info("waiting for DHCP leases")
+			return true, nil
+		}
 	}
 
 	addr, err := getClusterAPIMachineAddresses(machineScope)
 	if err != nil {
 		machineScope.Error(err, "failed to retrieve machine addresses")
-		return err
+		return false, err
 	}
 
 	machineScope.SetAddresses(addr)
@@ -394,7 +504,7 @@ func reconcileMachineAddresses(machineScope *scope.MachineScope) error {
 		Status: metav1.ConditionFalse,
 		Reason: infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForCloudInitReason,
 	})
-	return nil
+	return false, nil
 }
 
 func getClusterAPIMachineAddresses(scope *scope.MachineScope) ([]clusterv1.MachineAddress, error) {
@@ -413,7 +523,6 @@ func getClusterAPIMachineAddresses(scope *scope.MachineScope) ([]clusterv1.Machi
 	index := slices.IndexFunc(machineAddresses, func(s infrav1.IPAddressesSpec) bool {
 		return s.NetName == "default"
 	})
-	// TODO: DHCP as InternalIP
 	if index == -1 {
 		return addresses, errors.Errorf("Machine has no default IPAddresses")
 	}
@@ -443,6 +552,7 @@ func createVM(ctx context.Context, scope *scope.MachineScope) (proxmox.VMCloneRe
 				Reason:  infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason,
 				Message: err.Error(),
 			})
+			record.Warnf(scope.ProxmoxMachine, "VMIDRangeExhausted", "Unable to clone virtual machine: %s", err)
 		}
 		return proxmox.VMCloneResponse{}, err
 	}
@@ -453,15 +563,17 @@ func createVM(ctx context.Context, scope *scope.MachineScope) (proxmox.VMCloneRe
 		Name:  scope.ProxmoxMachine.GetName(),
 	}
 
-	if scope.ProxmoxMachine.Spec.Description != nil {
-		options.Description = *scope.ProxmoxMachine.Spec.Description
-	}
+	// the ownership markers are part of the VM from the start.
+	options.Description = vmDescription(scope)
 	if scope.ProxmoxMachine.Spec.Format != nil {
 		options.Format = string(*scope.ProxmoxMachine.Spec.Format)
 	}
 	options.Full = ptr.Deref(scope.ProxmoxMachine.Spec.Full, true)
 	if scope.ProxmoxMachine.Spec.Pool != nil {
 		options.Pool = *scope.ProxmoxMachine.Spec.Pool
+	} else {
+		// the pool of the cluster, if it manages one.
+		options.Pool = scope.InfraCluster.ProxmoxCluster.GetPoolName()
 	}
 	if scope.ProxmoxMachine.Spec.SnapName != nil {
 		options.SnapName = *scope.ProxmoxMachine.Spec.SnapName
@@ -474,7 +586,9 @@ func createVM(ctx context.Context, scope *scope.MachineScope) (proxmox.VMCloneRe
 		scope.InfraCluster.ProxmoxCluster.Status.NodeLocations = new(infrav1.NodeLocations)
 	}
 
-	if len(scope.InfraCluster.ProxmoxCluster.Spec.AllowedNodes) > 0 || len(scope.ProxmoxMachine.Spec.AllowedNodes) > 0 {
+	ha := scope.HighAvailability()
+	if len(scope.InfraCluster.ProxmoxCluster.Spec.AllowedNodes) > 0 || len(scope.ProxmoxMachine.Spec.AllowedNodes) > 0 ||
+		(ha != nil && ptr.Deref(ha.Group, "") != "") {
 		var err error
 		options.Target, err = selectNextNode(ctx, scope)
 		if err != nil {
@@ -485,9 +599,11 @@ func createVM(ctx context.Context, scope *scope.MachineScope) (proxmox.VMCloneRe
 					Reason:  infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason,
 					Message: err.Error(),
 				})
+				record.Warnf(scope.ProxmoxMachine, "InsufficientMemory", "Unable to schedule virtual machine: %s", err)
 			}
 			return proxmox.VMCloneResponse{}, err
 		}
+		record.Eventf(scope.ProxmoxMachine, "NodeScheduled", "Scheduled virtual machine on node %s", options.Target)
 	}
 
 	templateID := scope.ProxmoxMachine.GetTemplateID()
@@ -505,12 +621,14 @@ func createVM(ctx context.Context, scope *scope.MachineScope) (proxmox.VMCloneRe
 					Reason:  infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason,
 					Message: err.Error(),
 				})
+				record.Warnf(scope.ProxmoxMachine, "TemplateNotFound", "Unable to select a template: %s", err)
 			}
 			return proxmox.VMCloneResponse{}, err
 		}
 	}
 	res, err := scope.InfraCluster.ProxmoxClient.CloneVM(ctx, int(templateID), options)
 	if err != nil {
+		record.Warnf(scope.ProxmoxMachine, "CloneFailed", "Failed to clone template %d on node %s: %s", templateID, options.Node, err)
 		return res, err
 	}
 
@@ -518,6 +636,8 @@ func createVM(ctx context.Context, scope *scope.MachineScope) (proxmox.VMCloneRe
 	if node == "" {
 		node = options.Node
 	}
+	record.Eventf(scope.ProxmoxMachine, "CloneStarted", "Cloning template %d from node %s to virtual machine %d on node %s",
+		templateID, options.Node, res.NewID, node)
 
 	scope.ProxmoxMachine.Status.ProxmoxNode = new(node)
 
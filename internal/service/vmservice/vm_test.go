@@ -23,6 +23,7 @@ import (
 
 	lutherproxmox "github.com/luthermonson/go-proxmox"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -38,6 +39,7 @@ import (
 func TestReconcileVM_EverythingReady(t *testing.T) {
 	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForCloudInitReason)
 	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
 	machineScope.SetVirtualMachineID(int64(vm.VMID))
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
 	machineScope.ProxmoxMachine.Status.Initialization.Provisioned = new(true)
@@ -55,6 +57,7 @@ func TestReconcileVM_EverythingReady(t *testing.T) {
 func TestReconcileVM_QemuAgentCheckDisabled(t *testing.T) {
 	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapReadyReason)
 	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
 	machineScope.SetVirtualMachineID(int64(vm.VMID))
 	// machineScope.ProxmoxMachine.Status.IPAddresses = map[string]*infrav1.IPAddresses{infrav1.DefaultNetworkDevice: {IPv4: []string{"10.10.10.10"}}}
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
@@ -75,6 +78,7 @@ func TestReconcileVM_QemuAgentCheckDisabled(t *testing.T) {
 func TestReconcileVM_CloudInitCheckDisabled(t *testing.T) {
 	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForCloudInitReason)
 	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
 	machineScope.SetVirtualMachineID(int64(vm.VMID))
 	// machineScope.ProxmoxMachine.Status.IPAddresses = map[string]*infrav1.IPAddresses{infrav1.DefaultNetworkDevice: {IPv4: []string{"10.10.10.10"}}}
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
@@ -95,6 +99,7 @@ func TestReconcileVM_CloudInitCheckDisabled(t *testing.T) {
 func TestReconcileVM_InitCheckDisabled(t *testing.T) {
 	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForBootstrapReadyReason)
 	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
 	machineScope.SetVirtualMachineID(int64(vm.VMID))
 	// machineScope.ProxmoxMachine.Status.IPAddresses = map[string]*infrav1.IPAddresses{infrav1.DefaultNetworkDevice: {IPv4: []string{"10.10.10.10"}}}
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
@@ -124,7 +129,7 @@ func TestEnsureVirtualMachine_CreateVM_FullOptions(t *testing.T) {
 	expectedOptions := proxmox.VMCloneRequest{
 		Node:        "node1",
 		Name:        "test",
-		Description: "test vm",
+		Description: "test vm\n\n[capmox]\ncluster = default/test\nproxmoxmachine = test\nuid = test-machine-uid",
 		Format:      "raw",
 		Full:        true,
 		Pool:        "pool",
@@ -142,7 +147,12 @@ func TestEnsureVirtualMachine_CreateVM_FullOptions(t *testing.T) {
 
 	require.Equal(t, "node2", *machineScope.ProxmoxMachine.Status.ProxmoxNode)
 	require.True(t, machineScope.InfraCluster.ProxmoxCluster.HasMachine(machineScope.Name(), false))
-	requireConditionIsFalse(t, machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason,
+		conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition))
+	require.Equal(t, []string{
+		"Normal NodeScheduled Scheduled virtual machine on node node2",
+		"Normal CloneStarted Cloning template 123 from node node1 to virtual machine 123 on node node2",
+	}, recordedEvents())
 }
 
 func TestEnsureVirtualMachine_CreateVM_FullOptions_TemplateSelector(t *testing.T) {
@@ -167,7 +177,7 @@ func TestEnsureVirtualMachine_CreateVM_FullOptions_TemplateSelector(t *testing.T
 	expectedOptions := proxmox.VMCloneRequest{
 		Node:        "node1",
 		Name:        "test",
-		Description: "test vm",
+		Description: vmDescription(machineScope),
 		Format:      "raw",
 		Full:        true,
 		Pool:        "pool",
@@ -239,7 +249,7 @@ func TestEnsureVirtualMachine_CreateVM_SelectNode(t *testing.T) {
 	}
 	t.Cleanup(func() { selectNextNode = scheduler.ScheduleVM })
 
-	expectedOptions := proxmox.VMCloneRequest{Node: "node1", Name: "test", Target: "node3", Full: true}
+	expectedOptions := proxmox.VMCloneRequest{Node: "node1", Name: "test", Description: vmDescription(machineScope), Target: "node3", Full: true}
 	response := proxmox.VMCloneResponse{NewID: 123, Task: newTask()}
 	proxmoxClient.EXPECT().CloneVM(context.Background(), 123, expectedOptions).Return(response, nil).Once()
 
@@ -262,7 +272,7 @@ func TestEnsureVirtualMachine_CreateVM_SelectNode_MachineAllowedNodes(t *testing
 	}
 	t.Cleanup(func() { selectNextNode = scheduler.ScheduleVM })
 
-	expectedOptions := proxmox.VMCloneRequest{Node: "node1", Name: "test", Target: "node2", Full: true}
+	expectedOptions := proxmox.VMCloneRequest{Node: "node1", Name: "test", Description: vmDescription(machineScope), Target: "node2", Full: true}
 	response := proxmox.VMCloneResponse{NewID: 123, Task: newTask()}
 	proxmoxClient.EXPECT().CloneVM(context.Background(), 123, expectedOptions).Return(response, nil).Once()
 
@@ -299,7 +309,7 @@ func TestEnsureVirtualMachine_CreateVM_VMIDRange(t *testing.T) {
 		End:   1002,
 	}
 
-	expectedOptions := proxmox.VMCloneRequest{Node: "node1", NewID: 1001, Name: "test", Full: true}
+	expectedOptions := proxmox.VMCloneRequest{Node: "node1", NewID: 1001, Name: "test", Description: vmDescription(machineScope), Full: true}
 	response := proxmox.VMCloneResponse{Task: newTask(), NewID: int64(1001)}
 	proxmoxClient.Mock.On("CheckID", context.Background(), int64(1000)).Return(false, nil)
 	proxmoxClient.Mock.On("CheckID", context.Background(), int64(1001)).Return(true, nil)
@@ -378,7 +388,7 @@ func TestEnsureVirtualMachine_CreateVM_VMIDRangeCheckExisting(t *testing.T) {
 	_, err = ensureVirtualMachine(context.Background(), machineScopeVMThousand)
 	require.NoError(t, err)
 
-	expectedOptions := proxmox.VMCloneRequest{Node: "node1", NewID: 1002, Name: "test", Full: true}
+	expectedOptions := proxmox.VMCloneRequest{Node: "node1", NewID: 1002, Name: "test", Description: vmDescription(machineScope), Full: true}
 	response := proxmox.VMCloneResponse{Task: newTask(), NewID: int64(1002)}
 	proxmoxClient.EXPECT().CloneVM(context.Background(), 123, expectedOptions).Return(response, nil).Once()
 	proxmoxClient.Mock.On("CheckID", context.Background(), int64(1001)).Return(false, nil).Once()
@@ -404,6 +414,10 @@ func TestEnsureVirtualMachine_FindVM(t *testing.T) {
 
 	require.Equal(t, vm, machineScope.VirtualMachine)
 	require.Equal(t, "proxmox://56603c36-46b9-4608-90ae-c731c15eae64", machineScope.GetProviderID())
+
+	// the lost location of the VM is rebuilt.
+	require.Equal(t, "node1", *machineScope.ProxmoxMachine.Status.ProxmoxNode)
+	require.Equal(t, "node1", machineScope.InfraCluster.ProxmoxCluster.GetNode(machineScope.Name(), false))
 }
 
 func TestEnsureVirtualMachine_UpdateVMLocation_Error(t *testing.T) {
@@ -420,7 +434,8 @@ func TestEnsureVirtualMachine_UpdateVMLocation_Error(t *testing.T) {
 func TestReconcileVirtualMachineConfig_NoConfig(t *testing.T) {
 	machineScope, _, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason)
 	vm := newStoppedVM()
-	vm.VirtualMachineConfig.Description = machineScope.ProxmoxMachine.GetName()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
+	vm.VirtualMachineConfig.Tags = machineScope.InfraCluster.VMOwnerTag()
 	machineScope.SetVirtualMachine(vm)
 
 	requeue, err := reconcileVirtualMachineConfig(context.Background(), machineScope)
@@ -448,9 +463,10 @@ func TestReconcileVirtualMachineConfig_ApplyConfig(t *testing.T) {
 		proxmox.VirtualMachineOption{Name: optionSockets, Value: *machineScope.ProxmoxMachine.Spec.NumSockets},
 		proxmox.VirtualMachineOption{Name: optionCores, Value: *machineScope.ProxmoxMachine.Spec.NumCores},
 		proxmox.VirtualMachineOption{Name: optionMemory, Value: *machineScope.ProxmoxMachine.Spec.MemoryMiB},
-		proxmox.VirtualMachineOption{Name: optionDescription, Value: machineScope.ProxmoxMachine.Spec.Description},
-		proxmox.VirtualMachineOption{Name: "net0", Value: formatNetworkDevice("virtio", "vmbr0", new(int32(1500)), nil, nil)},
-		proxmox.VirtualMachineOption{Name: "net1", Value: formatNetworkDevice("virtio", "vmbr1", new(int32(1500)), nil, nil)},
+		proxmox.VirtualMachineOption{Name: optionDescription, Value: vmDescription(machineScope)},
+		proxmox.VirtualMachineOption{Name: "net0", Value: formatNetworkDevice("virtio", "vmbr0", new(int32(1500)), nil, nil, false)},
+		proxmox.VirtualMachineOption{Name: "net1", Value: formatNetworkDevice("virtio", "vmbr1", new(int32(1500)), nil, nil, false)},
+		proxmox.VirtualMachineOption{Name: optionTags, Value: machineScope.InfraCluster.VMOwnerTag()},
 	}
 
 	proxmoxClient.EXPECT().ConfigureVM(context.Background(), vm, expectedOptions...).Return(task, nil).Once()
@@ -472,7 +488,8 @@ func TestReconcileVirtualMachineConfigTags(t *testing.T) {
 	task := newTask()
 	machineScope.SetVirtualMachine(vm)
 	expectedOptions := []any{
-		proxmox.VirtualMachineOption{Name: optionTags, Value: "tag0;tag1;tag2"},
+		proxmox.VirtualMachineOption{Name: optionDescription, Value: vmDescription(machineScope)},
+		proxmox.VirtualMachineOption{Name: optionTags, Value: "tag0;tag1;tag2;" + machineScope.InfraCluster.VMOwnerTag()},
 	}
 
 	proxmoxClient.EXPECT().ConfigureVM(context.Background(), vm, expectedOptions...).Return(task, nil).Once()
@@ -490,7 +507,8 @@ func TestReconcileVirtualMachineConfigTags(t *testing.T) {
 	task = newTask()
 	machineScope.SetVirtualMachine(vm)
 	expectedOptions = []any{
-		proxmox.VirtualMachineOption{Name: optionDescription, Value: machineScope.ProxmoxMachine.Spec.Description},
+		proxmox.VirtualMachineOption{Name: optionDescription, Value: vmDescription(machineScope)},
+		proxmox.VirtualMachineOption{Name: optionTags, Value: "tag0;" + machineScope.InfraCluster.VMOwnerTag()},
 	}
 
 	proxmoxClient.EXPECT().ConfigureVM(context.Background(), vm, expectedOptions...).Return(task, nil).Once()
@@ -546,7 +564,9 @@ func TestReconcileMachineAddresses_IPv4(t *testing.T) {
 	}}
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
 
-	require.NoError(t, reconcileMachineAddresses(machineScope))
+	requeue, err := reconcileMachineAddresses(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
 	require.Equal(t, machineScope.ProxmoxMachine.Status.Addresses[0].Address, machineScope.ProxmoxMachine.GetName())
 	require.Equal(t, machineScope.ProxmoxMachine.Status.Addresses[1].Address, "10.10.10.10")
 }
@@ -572,7 +592,9 @@ func TestReconcileMachineAddresses_IPv6(t *testing.T) {
 	}}
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
 
-	require.NoError(t, reconcileMachineAddresses(machineScope))
+	requeue, err := reconcileMachineAddresses(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
 	require.Equal(t, machineScope.ProxmoxMachine.Status.Addresses[0].Address, machineScope.ProxmoxMachine.GetName())
 	require.Equal(t, machineScope.ProxmoxMachine.Status.Addresses[1].Address, "2001:db8::2")
 }
@@ -599,7 +621,9 @@ func TestReconcileMachineAddresses_DualStack(t *testing.T) {
 	}}
 	machineScope.ProxmoxMachine.Status.BootstrapDataProvided = new(true)
 
-	require.NoError(t, reconcileMachineAddresses(machineScope))
+	requeue, err := reconcileMachineAddresses(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
 	require.Equal(t, machineScope.ProxmoxMachine.Status.Addresses[0].Address, machineScope.ProxmoxMachine.GetName())
 	require.Equal(t, machineScope.ProxmoxMachine.Status.Addresses[1].Address, "10.10.10.10")
 	require.Equal(t, machineScope.ProxmoxMachine.Status.Addresses[2].Address, "2001:db8::2")
@@ -624,8 +648,10 @@ func TestReconcileVirtualMachineConfigVLAN(t *testing.T) {
 		proxmox.VirtualMachineOption{Name: optionSockets, Value: *machineScope.ProxmoxMachine.Spec.NumSockets},
 		proxmox.VirtualMachineOption{Name: optionCores, Value: *machineScope.ProxmoxMachine.Spec.NumCores},
 		proxmox.VirtualMachineOption{Name: optionMemory, Value: *machineScope.ProxmoxMachine.Spec.MemoryMiB},
-		proxmox.VirtualMachineOption{Name: "net0", Value: formatNetworkDevice("virtio", "vmbr0", nil, new(int32(100)), nil)},
-		proxmox.VirtualMachineOption{Name: "net1", Value: formatNetworkDevice("virtio", "vmbr1", nil, new(int32(100)), nil)},
+		proxmox.VirtualMachineOption{Name: optionDescription, Value: vmDescription(machineScope)},
+		proxmox.VirtualMachineOption{Name: "net0", Value: formatNetworkDevice("virtio", "vmbr0", nil, new(int32(100)), nil, false)},
+		proxmox.VirtualMachineOption{Name: "net1", Value: formatNetworkDevice("virtio", "vmbr1", nil, new(int32(100)), nil, false)},
+		proxmox.VirtualMachineOption{Name: optionTags, Value: machineScope.InfraCluster.VMOwnerTag()},
 	}
 
 	proxmoxClient.EXPECT().ConfigureVM(context.TODO(), vm, expectedOptions...).Return(task, nil).Once()
@@ -655,8 +681,10 @@ func TestReconcileVirtualMachineConfigQueue(t *testing.T) {
 		proxmox.VirtualMachineOption{Name: optionSockets, Value: *machineScope.ProxmoxMachine.Spec.NumSockets},
 		proxmox.VirtualMachineOption{Name: optionCores, Value: *machineScope.ProxmoxMachine.Spec.NumCores},
 		proxmox.VirtualMachineOption{Name: optionMemory, Value: *machineScope.ProxmoxMachine.Spec.MemoryMiB},
-		proxmox.VirtualMachineOption{Name: "net0", Value: formatNetworkDevice("virtio", "vmbr0", nil, new(int32(100)), new(int32(4)))},
-		proxmox.VirtualMachineOption{Name: "net1", Value: formatNetworkDevice("virtio", "vmbr1", nil, new(int32(100)), new(int32(4)))},
+		proxmox.VirtualMachineOption{Name: optionDescription, Value: vmDescription(machineScope)},
+		proxmox.VirtualMachineOption{Name: "net0", Value: formatNetworkDevice("virtio", "vmbr0", nil, new(int32(100)), new(int32(4)), false)},
+		proxmox.VirtualMachineOption{Name: "net1", Value: formatNetworkDevice("virtio", "vmbr1", nil, new(int32(100)), new(int32(4)), false)},
+		proxmox.VirtualMachineOption{Name: optionTags, Value: machineScope.InfraCluster.VMOwnerTag()},
 	}
 
 	proxmoxClient.EXPECT().ConfigureVM(context.TODO(), vm, expectedOptions...).Return(task, nil).Once()
@@ -682,6 +710,7 @@ func TestReconcileDisks_UnmountCloudInitISO(t *testing.T) {
 func TestReconcileVM_CloudInitFailed(t *testing.T) {
 	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForCloudInitReason)
 	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
 	machineScope.SetVirtualMachineID(int64(vm.VMID))
 	machineScope.ProxmoxMachine.Status.IPAddresses = []infrav1.IPAddressesSpec{{
 		NetName: string(infrav1.DefaultNetworkDevice),
@@ -708,6 +737,7 @@ func TestReconcileVM_CloudInitFailed(t *testing.T) {
 func TestReconcileVM_CloudInitRunning(t *testing.T) {
 	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedWaitingForCloudInitReason)
 	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = vmDescription(machineScope)
 	machineScope.SetVirtualMachineID(int64(vm.VMID))
 	machineScope.ProxmoxMachine.Status.IPAddresses = []infrav1.IPAddressesSpec{{
 		NetName: string(infrav1.DefaultNetworkDevice),
@@ -728,6 +758,84 @@ func TestReconcileVM_CloudInitRunning(t *testing.T) {
 	require.Equal(t, infrav1.VirtualMachineStatePending, result.State)
 }
 
+func TestReconcileReclone_DeletesPartialVM(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason)
+	machineScope.SetVirtualMachineID(123)
+	machineScope.ProxmoxMachine.Status.ProxmoxNode = new("node1")
+
+	proxmoxClient.EXPECT().GetVM(context.Background(), "node1", int64(123)).Return(newRunningVM(), nil).Once()
+	proxmoxClient.EXPECT().DeleteVM(context.Background(), "node1", int64(123)).Return(newTask(), nil).Once()
+
+	requeue, err := reconcileReclone(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.True(t, requeue)
+	require.Equal(t, "result", *machineScope.ProxmoxMachine.Status.TaskRef)
+	require.Equal(t, int64(123), machineScope.GetVirtualMachineID())
+	requireConditionIsFalse(t, machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition)
+}
+
+func TestReconcileReclone_VMGone(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason)
+	machineScope.SetVirtualMachineID(123)
+	machineScope.ProxmoxMachine.Status.ProxmoxNode = new("node1")
+	machineScope.InfraCluster.ProxmoxCluster.AddNodeLocation(infrav1.NodeLocation{
+		Machine: corev1.LocalObjectReference{Name: machineScope.Name()},
+		Node:    "node1",
+	}, false)
+
+	proxmoxClient.EXPECT().GetVM(context.Background(), "node1", int64(123)).Return(newRunningVM(), nil).Once()
+	proxmoxClient.EXPECT().DeleteVM(context.Background(), "node1", int64(123)).Return(nil, goproxmox.ErrVMIDFree).Once()
+
+	requeue, err := reconcileReclone(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Nil(t, machineScope.ProxmoxMachine.Spec.VirtualMachineID)
+	require.Nil(t, machineScope.ProxmoxMachine.Status.ProxmoxNode)
+	require.Empty(t, machineScope.InfraCluster.ProxmoxCluster.GetNode(machineScope.Name(), false))
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason,
+		conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition))
+}
+
+func TestReconcileReclone_DeleteFailed(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason)
+	machineScope.SetVirtualMachineID(123)
+	machineScope.ProxmoxMachine.Status.ProxmoxNode = new("node1")
+
+	proxmoxClient.EXPECT().GetVM(context.Background(), "node1", int64(123)).Return(newRunningVM(), nil).Once()
+	proxmoxClient.EXPECT().DeleteVM(context.Background(), "node1", int64(123)).Return(nil, fmt.Errorf("permission denied")).Once()
+
+	_, err := reconcileReclone(context.Background(), machineScope)
+	require.ErrorContains(t, err, "unable to delete partially cloned VM 123")
+	require.NotNil(t, machineScope.ProxmoxMachine.Spec.VirtualMachineID)
+}
+
+func TestReconcileReclone_NotOwned(t *testing.T) {
+	machineScope, proxmoxClient, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedRecloningReason)
+	machineScope.SetVirtualMachineID(123)
+	machineScope.ProxmoxMachine.Status.ProxmoxNode = new("node1")
+
+	vm := newRunningVM()
+	vm.VirtualMachineConfig.Description = "[capmox]\ncluster = other/test\nproxmoxmachine = test\nuid = other-uid"
+	proxmoxClient.EXPECT().GetVM(context.Background(), "node1", int64(123)).Return(vm, nil).Once()
+
+	requeue, err := reconcileReclone(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Nil(t, machineScope.ProxmoxMachine.Spec.VirtualMachineID)
+	require.Equal(t, infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason,
+		conditions.GetReason(machineScope.ProxmoxMachine, infrav1.ProxmoxMachineVirtualMachineProvisionedCondition))
+}
+
+func TestReconcileReclone_NotRecloning(t *testing.T) {
+	machineScope, _, _ := setupReconcilerTestWithCondition(t, infrav1.ProxmoxMachineVirtualMachineProvisionedCloningReason)
+	machineScope.SetVirtualMachineID(123)
+
+	requeue, err := reconcileReclone(context.Background(), machineScope)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.NotNil(t, machineScope.ProxmoxMachine.Spec.VirtualMachineID)
+}
+
 // This test is supposed to test the entire state machine transition.
 func TestReconcileVM_StateMachine(t *testing.T) {
 	machineScope, proxmoxClient, _ := setupReconcilerTest(t)
@@ -765,7 +873,7 @@ func TestReconcileVM_StateMachine(t *testing.T) {
 	proxmoxClient.EXPECT().CloneVM(context.Background(), 123, proxmox.VMCloneRequest{
 		Node:        "node1",
 		Name:        "test",
-		Description: "test vm",
+		Description: vmDescription(machineScope),
 		Format:      "raw",
 		Full:        true,
 		Pool:        "pool",
@@ -794,7 +902,8 @@ func TestReconcileVM_StateMachine(t *testing.T) {
 		proxmox.VirtualMachineOption{Name: optionSockets, Value: *machineScope.ProxmoxMachine.Spec.NumSockets},
 		proxmox.VirtualMachineOption{Name: optionCores, Value: *machineScope.ProxmoxMachine.Spec.NumCores},
 		proxmox.VirtualMachineOption{Name: optionMemory, Value: *machineScope.ProxmoxMachine.Spec.MemoryMiB},
-		proxmox.VirtualMachineOption{Name: optionDescription, Value: machineScope.ProxmoxMachine.Spec.Description},
+		proxmox.VirtualMachineOption{Name: optionDescription, Value: vmDescription(machineScope)},
+		proxmox.VirtualMachineOption{Name: optionTags, Value: machineScope.InfraCluster.VMOwnerTag()},
 	}
 
 	proxmoxClient.EXPECT().ConfigureVM(context.Background(), vm, expectedVMConfigureRequest...).Return(task, nil).Once()
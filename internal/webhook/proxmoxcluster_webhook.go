@@ -21,7 +21,9 @@ import (
 	"context"
 	"fmt"
 	"net/netip"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -79,6 +81,21 @@ func (*ProxmoxCluster) ValidateCreate(_ context.Context, obj runtime.Object) (wa
 		return warnings, err
 	}
 
+	if err := validateSDN(&cluster.Spec, cluster.GroupVersionKind().GroupKind(), cluster.GetName()); err != nil {
+		warnings = append(warnings, fmt.Sprintf("cannot create proxmox cluster %s", cluster.GetName()))
+		return warnings, err
+	}
+
+	if err := validateFirewall(&cluster.Spec, cluster.GroupVersionKind().GroupKind(), cluster.GetName()); err != nil {
+		warnings = append(warnings, fmt.Sprintf("cannot create proxmox cluster %s", cluster.GetName()))
+		return warnings, err
+	}
+
+	if err := validateKubeVIP(&cluster.Spec, cluster.GroupVersionKind().GroupKind(), cluster.GetName()); err != nil {
+		warnings = append(warnings, fmt.Sprintf("cannot create proxmox cluster %s", cluster.GetName()))
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
@@ -88,7 +105,7 @@ func (*ProxmoxCluster) ValidateDelete(_ context.Context, _ runtime.Object) (admi
 }
 
 // ValidateUpdate implements the update validation function.
-func (*ProxmoxCluster) ValidateUpdate(_ context.Context, _ runtime.Object, newObj runtime.Object) (warnings admission.Warnings, err error) {
+func (*ProxmoxCluster) ValidateUpdate(_ context.Context, oldObj runtime.Object, newObj runtime.Object) (warnings admission.Warnings, err error) {
 	newCluster, ok := newObj.(*infrav1.ProxmoxCluster)
 	if !ok {
 		return warnings, apierrors.NewBadRequest(fmt.Sprintf("expected a ProxmoxCluster but got %T", newCluster))
@@ -99,10 +116,238 @@ func (*ProxmoxCluster) ValidateUpdate(_ context.Context, _ runtime.Object, newOb
 		return warnings, err
 	}
 
+	if err := validateSDN(&newCluster.Spec, newCluster.GroupVersionKind().GroupKind(), newCluster.GetName()); err != nil {
+		warnings = append(warnings, fmt.Sprintf("cannot update proxmox cluster %s", newCluster.GetName()))
+		return warnings, err
+	}
+
+	if err := validateFirewall(&newCluster.Spec, newCluster.GroupVersionKind().GroupKind(), newCluster.GetName()); err != nil {
+		warnings = append(warnings, fmt.Sprintf("cannot update proxmox cluster %s", newCluster.GetName()))
+		return warnings, err
+	}
+
+	if err := validateKubeVIP(&newCluster.Spec, newCluster.GroupVersionKind().GroupKind(), newCluster.GetName()); err != nil {
+		warnings = append(warnings, fmt.Sprintf("cannot update proxmox cluster %s", newCluster.GetName()))
+		return warnings, err
+	}
+
+	if oldCluster, ok := oldObj.(*infrav1.ProxmoxCluster); ok {
+		if err := validateSDNUpdate(oldCluster.Spec.SDN, newCluster.Spec.SDN, newCluster.GroupVersionKind().GroupKind(), newCluster.GetName()); err != nil {
+			warnings = append(warnings, fmt.Sprintf("cannot update proxmox cluster %s", newCluster.GetName()))
+			return warnings, err
+		}
+
+		if err := validateFirewallUpdate(oldCluster, newCluster); err != nil {
+			warnings = append(warnings, fmt.Sprintf("cannot update proxmox cluster %s", newCluster.GetName()))
+			return warnings, err
+		}
+
+		if err := validatePoolUpdate(oldCluster, newCluster); err != nil {
+			warnings = append(warnings, fmt.Sprintf("cannot update proxmox cluster %s", newCluster.GetName()))
+			return warnings, err
+		}
+	}
+
 	return warnings, nil
 }
 
+// validateSDN validates the subnets and tags of the SDN VNets. The control plane endpoint
+// must not be handed out to machines attached to a VNet.
+func validateSDN(spec *infrav1.ProxmoxClusterSpec, gk schema.GroupKind, name string) error {
+	if spec.SDN == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	endpoint, endpointErr := netip.ParseAddr(spec.ControlPlaneEndpoint.Host)
+	checkEndpoint := endpointErr == nil && !ptr.Deref(spec.ExternalManagedControlPlane, false) && spec.ControlPlaneEndpointIPAM == nil
+
+	zoneType := spec.SDN.Zone.Type
+	for i, vnet := range spec.SDN.VNets {
+		vnetPath := field.NewPath("spec", "sdn", "vnets").Index(i)
+
+		switch {
+		case (zoneType == infrav1.SDNZoneTypeVLAN || zoneType == infrav1.SDNZoneTypeVXLAN) && vnet.Tag == nil:
+			allErrs = append(allErrs, field.Required(vnetPath.Child("tag"), "tag is required for vlan and vxlan zones"))
+		case zoneType == infrav1.SDNZoneTypeVLAN && *vnet.Tag > 4094:
+			allErrs = append(allErrs, field.Invalid(vnetPath.Child("tag"), *vnet.Tag, "vlan tags must be between 1 and 4094"))
+		}
+
+		families := map[bool]bool{}
+		for j, subnet := range vnet.Subnets {
+			subnetPath := vnetPath.Child("subnets").Index(j)
+
+			prefix, err := netip.ParsePrefix(subnet.CIDR)
+			if err != nil || prefix.Masked() != prefix {
+				allErrs = append(allErrs, field.Invalid(subnetPath.Child("cidr"), subnet.CIDR, "cidr is not a valid network"))
+				continue
+			}
+
+			if families[prefix.Addr().Is4()] {
+				allErrs = append(allErrs, field.Invalid(subnetPath.Child("cidr"), subnet.CIDR, "only one subnet per ip family is allowed"))
+			}
+			families[prefix.Addr().Is4()] = true
+
+			gateway, err := netip.ParseAddr(subnet.Gateway)
+			if err != nil || !prefix.Contains(gateway) {
+				allErrs = append(allErrs, field.Invalid(subnetPath.Child("gateway"), subnet.Gateway, "gateway must be an IP address inside the cidr"))
+			}
+
+			addresses := subnet.Addresses
+			if len(addresses) == 0 {
+				addresses = []string{subnet.CIDR}
+			}
+			set, err := buildSetFromAddresses(addresses)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(subnetPath.Child("addresses"), subnet.Addresses, "provided addresses are not valid IP addresses, ranges or CIDRs"))
+				continue
+			}
+			for _, r := range set.Ranges() {
+				if !prefix.Contains(r.From()) || !prefix.Contains(r.To()) {
+					allErrs = append(allErrs, field.Invalid(subnetPath.Child("addresses"), subnet.Addresses, "addresses must be inside the cidr"))
+					break
+				}
+			}
+
+			if checkEndpoint && set.Contains(endpoint) {
+				allErrs = append(allErrs, field.Invalid(subnetPath.Child("addresses"), addresses, "addresses may not contain the endpoint IP"))
+			}
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(gk, name, allErrs)
+	}
+	return nil
+}
+
+// validateSDNUpdate rejects changes to the zone and to the tags of existing VNets, which
+// cannot be applied to the objects in Proxmox. VNets and subnets may be added.
+func validateSDNUpdate(oldSDN, newSDN *infrav1.SDNSpec, gk schema.GroupKind, name string) error {
+	if oldSDN == nil || newSDN == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if !reflect.DeepEqual(oldSDN.Zone, newSDN.Zone) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "sdn", "zone"), "zone is immutable"))
+	}
+
+	for i, vnet := range newSDN.VNets {
+		index := slices.IndexFunc(oldSDN.VNets, func(v infrav1.SDNVNetSpec) bool { return v.Name == vnet.Name })
+		if index != -1 && !ptr.Equal(oldSDN.VNets[index].Tag, vnet.Tag) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "sdn", "vnets").Index(i).Child("tag"), "tag is immutable"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(gk, name, allErrs)
+	}
+	return nil
+}
+
+// validateFirewall validates the additional CIDRs of the firewall IPSet.
+func validateFirewall(spec *infrav1.ProxmoxClusterSpec, gk schema.GroupKind, name string) error {
+	if spec.Firewall == nil || spec.Firewall.IPSet == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	path := field.NewPath("spec", "firewall", "ipSet", "additionalCIDRs")
+	for i, cidr := range spec.Firewall.IPSet.AdditionalCIDRs {
+		if _, err := netip.ParsePrefix(cidr); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), cidr, "must be an IP address or CIDR"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(gk, name, allErrs)
+	}
+	return nil
+}
+
+// validateKubeVIP validates the BGP settings of kube-vip.
+func validateKubeVIP(spec *infrav1.ProxmoxClusterSpec, gk schema.GroupKind, name string) error {
+	if spec.KubeVIP == nil || spec.KubeVIP.BGP == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	path := field.NewPath("spec", "kubeVIP", "bgp")
+	bgp := spec.KubeVIP.BGP
+	if bgp.RouterID != nil {
+		if addr, err := netip.ParseAddr(*bgp.RouterID); err != nil || !addr.Is4() {
+			allErrs = append(allErrs, field.Invalid(path.Child("routerID"), *bgp.RouterID, "must be an IPv4 address"))
+		}
+	}
+	for i, peer := range bgp.Peers {
+		if _, err := netip.ParseAddr(peer.Address); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("peers").Index(i).Child("address"), peer.Address, "must be an IP address"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(gk, name, allErrs)
+	}
+	return nil
+}
+
+// validateFirewallUpdate rejects changes which would leave the IPSet of the cluster
+// behind in Proxmox, as the IPSet is identified by its name.
+func validateFirewallUpdate(oldCluster, newCluster *infrav1.ProxmoxCluster) error {
+	if oldCluster.Spec.Firewall == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	switch {
+	case newCluster.Spec.Firewall == nil:
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "firewall"), "firewall cannot be removed, disable it instead"))
+	case oldCluster.GetFirewallIPSetName() != newCluster.GetFirewallIPSetName():
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "firewall", "ipSet", "name"), "name is immutable"))
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(newCluster.GroupVersionKind().GroupKind(), newCluster.GetName(), allErrs)
+	}
+	return nil
+}
+
+// validatePoolUpdate rejects changes which would leave the pool of the cluster
+// behind in Proxmox, as the pool is identified by its name.
+func validatePoolUpdate(oldCluster, newCluster *infrav1.ProxmoxCluster) error {
+	if oldCluster.Spec.Pool == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	switch {
+	case newCluster.Spec.Pool == nil:
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "pool"), "pool cannot be removed"))
+	case oldCluster.GetPoolName() != newCluster.GetPoolName():
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "pool", "name"), "name is immutable"))
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(newCluster.GroupVersionKind().GroupKind(), newCluster.GetName(), allErrs)
+	}
+	return nil
+}
+
 func validateControlPlaneEndpoint(spec *infrav1.ProxmoxClusterSpec, gk schema.GroupKind, name string) error {
+	if spec.ControlPlaneEndpointIPAM != nil && ptr.Deref(spec.ExternalManagedControlPlane, false) {
+		return apierrors.NewInvalid(
+			gk,
+			name,
+			field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "controlPlaneEndpointIPAM"), spec.ControlPlaneEndpointIPAM, "may not be combined with externalManagedControlPlane"),
+			})
+	}
+
 	// Skipping the validation of the Control Plane endpoint in case of externally managed Control Plane:
 	// the Cluster API Control Plane provider will eventually provide the LB.
 	if ptr.Deref(spec.ExternalManagedControlPlane, false) {
@@ -111,6 +356,11 @@ func validateControlPlaneEndpoint(spec *infrav1.ProxmoxClusterSpec, gk schema.Gr
 
 	endpoint := spec.ControlPlaneEndpoint.Host
 
+	// The endpoint is claimed from IPAM and therefore allowed to be part of the pool addresses.
+	if spec.ControlPlaneEndpointIPAM != nil && endpoint == "" {
+		return nil
+	}
+
 	addr, err := netip.ParseAddr(endpoint)
 
 	/*
@@ -140,6 +390,10 @@ func validateControlPlaneEndpoint(spec *infrav1.ProxmoxClusterSpec, gk schema.Gr
 			})
 	}
 
+	if spec.ControlPlaneEndpointIPAM != nil {
+		return nil
+	}
+
 	// IPv4
 	if spec.IPv4Config != nil {
 		set, err := buildSetFromAddresses(spec.IPv4Config.Addresses)
@@ -21,6 +21,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -105,6 +106,102 @@ var _ = Describe("Controller Test", func() {
 			}
 			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("addresses may not contain the endpoint IP")))
 		})
+
+		It("should allow a valid sdn", func() {
+			cluster := sdnProxmoxCluster("succeed-test-cluster-with-sdn")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(Succeed())
+		})
+
+		It("should disallow a sdn gateway outside of the subnet", func() {
+			cluster := sdnProxmoxCluster("test-cluster")
+			cluster.Spec.SDN.VNets[0].Subnets[0].Gateway = "10.20.1.1"
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("gateway must be an IP address inside the cidr")))
+		})
+
+		It("should disallow two sdn subnets of the same ip family", func() {
+			cluster := sdnProxmoxCluster("test-cluster")
+			cluster.Spec.SDN.VNets[0].Subnets = append(cluster.Spec.SDN.VNets[0].Subnets, infrav1.SDNSubnetSpec{
+				CIDR:    "10.30.0.0/24",
+				Gateway: "10.30.0.1",
+			})
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("only one subnet per ip family is allowed")))
+		})
+
+		It("should disallow sdn vlan zones without vnet tags", func() {
+			cluster := sdnProxmoxCluster("test-cluster")
+			cluster.Spec.SDN.Zone.Type = infrav1.SDNZoneTypeVLAN
+			cluster.Spec.SDN.Zone.Bridge = new("vmbr0")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("tag is required for vlan and vxlan zones")))
+		})
+
+		It("should disallow sdn addresses containing the endpoint IP", func() {
+			cluster := sdnProxmoxCluster("test-cluster")
+			cluster.Spec.ControlPlaneEndpoint.Host = "10.20.0.50"
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("addresses may not contain the endpoint IP")))
+		})
+
+		It("should allow an empty endpoint claimed from ipam", func() {
+			cluster := validProxmoxCluster("succeed-test-cluster-with-endpoint-ipam")
+			cluster.Spec.ControlPlaneEndpoint = infrav1.APIEndpoint{}
+			cluster.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMSpec{}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(Succeed())
+		})
+
+		It("should allow an endpoint claimed from ipam to intersect with node IPs", func() {
+			cluster := invalidProxmoxCluster("succeed-test-cluster-with-claimed-endpoint")
+			cluster.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMSpec{}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(Succeed())
+		})
+
+		It("should disallow endpoint ipam with an externally managed control plane", func() {
+			cluster := validProxmoxCluster("test-cluster")
+			cluster.Spec.ExternalManagedControlPlane = new(true)
+			cluster.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMSpec{}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("may not be combined with externalManagedControlPlane")))
+		})
+
+		It("should disallow endpoint ipam pools of other kinds", func() {
+			cluster := validProxmoxCluster("test-cluster")
+			cluster.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMSpec{
+				PoolRef: &corev1.TypedLocalObjectReference{
+					APIGroup: new("ipam.cluster.x-k8s.io"),
+					Kind:     "IPPool",
+					Name:     "vip-pool",
+				},
+			}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("poolRef allows either InClusterIPPool or GlobalInClusterIPPool")))
+		})
+
+		It("should disallow invalid firewall ipset cidrs", func() {
+			cluster := validProxmoxCluster("test-cluster")
+			cluster.Spec.Firewall = &infrav1.ClusterFirewallSpec{
+				IPSet: &infrav1.FirewallIPSetSpec{AdditionalCIDRs: []string{"192.168.1.10", "bastion"}},
+			}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("must be an IP address or CIDR")))
+		})
+
+		It("should disallow invalid kube-vip bgp settings", func() {
+			cluster := validProxmoxCluster("test-cluster")
+			cluster.Spec.KubeVIP = &infrav1.KubeVIPSpec{
+				Mode: infrav1.KubeVIPModeBGP,
+				BGP: &infrav1.KubeVIPBGPSpec{
+					AS:       65000,
+					RouterID: new("2001:db8::1"),
+					Peers:    []infrav1.KubeVIPBGPPeer{{Address: "10.10.10.1", AS: 65001}},
+				},
+			}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("must be an IPv4 address")))
+
+			cluster.Spec.KubeVIP.BGP.RouterID = nil
+			cluster.Spec.KubeVIP.BGP.Peers[0].Address = "router.example.com"
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("must be an IP address")))
+		})
+
+		It("should require bgp settings in kube-vip bgp mode", func() {
+			cluster := validProxmoxCluster("test-cluster")
+			cluster.Spec.KubeVIP = &infrav1.KubeVIPSpec{Mode: infrav1.KubeVIPModeBGP}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("bgp is required in BGP mode")))
+		})
 	})
 
 	Context("update proxmox cluster", func() {
@@ -124,6 +221,68 @@ var _ = Describe("Controller Test", func() {
 				WithPolling(time.Second).
 				Should(Succeed())
 		})
+
+		It("should disallow changing the sdn zone", func() {
+			cluster := sdnProxmoxCluster("test-cluster-sdn-zone")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(Succeed())
+
+			g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cluster), &cluster)).To(Succeed())
+			cluster.Spec.SDN.Zone.Name = "other"
+
+			g.Expect(k8sClient.Update(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("zone is immutable")))
+
+			g.Eventually(func(g Gomega) {
+				g.Expect(client.IgnoreNotFound(k8sClient.Delete(testEnv.GetContext(), &cluster))).To(Succeed())
+			}).WithTimeout(time.Second * 10).
+				WithPolling(time.Second).
+				Should(Succeed())
+		})
+
+		It("should disallow changing the firewall ipset name", func() {
+			cluster := validProxmoxCluster("test-cluster-firewall")
+			cluster.Spec.Firewall = &infrav1.ClusterFirewallSpec{
+				FirewallSpec: infrav1.FirewallSpec{SecurityGroups: []string{"k8s"}},
+			}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(Succeed())
+
+			g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cluster), &cluster)).To(Succeed())
+			cluster.Spec.Firewall.IPSet = &infrav1.FirewallIPSetSpec{Name: new("other")}
+			g.Expect(k8sClient.Update(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("name is immutable")))
+
+			g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cluster), &cluster)).To(Succeed())
+			cluster.Spec.Firewall = nil
+			g.Expect(k8sClient.Update(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("firewall cannot be removed")))
+
+			g.Eventually(func(g Gomega) {
+				g.Expect(client.IgnoreNotFound(k8sClient.Delete(testEnv.GetContext(), &cluster))).To(Succeed())
+			}).WithTimeout(time.Second * 10).
+				WithPolling(time.Second).
+				Should(Succeed())
+		})
+
+		It("should disallow changing the pool name", func() {
+			cluster := validProxmoxCluster("test-cluster-pool")
+			cluster.Spec.Pool = &infrav1.ClusterPoolSpec{}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &cluster)).To(Succeed())
+
+			g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cluster), &cluster)).To(Succeed())
+			cluster.Spec.Pool.ACLs = []infrav1.PoolACL{{Group: new("tenants")}}
+			g.Expect(k8sClient.Update(testEnv.GetContext(), &cluster)).To(Succeed())
+
+			g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cluster), &cluster)).To(Succeed())
+			cluster.Spec.Pool.Name = new("other")
+			g.Expect(k8sClient.Update(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("name is immutable")))
+
+			g.Expect(k8sClient.Get(testEnv.GetContext(), client.ObjectKeyFromObject(&cluster), &cluster)).To(Succeed())
+			cluster.Spec.Pool = nil
+			g.Expect(k8sClient.Update(testEnv.GetContext(), &cluster)).To(MatchError(ContainSubstring("pool cannot be removed")))
+
+			g.Eventually(func(g Gomega) {
+				g.Expect(client.IgnoreNotFound(k8sClient.Delete(testEnv.GetContext(), &cluster))).To(Succeed())
+			}).WithTimeout(time.Second * 10).
+				WithPolling(time.Second).
+				Should(Succeed())
+		})
 	})
 })
 
@@ -159,3 +318,23 @@ func invalidProxmoxCluster(name string) infrav1.ProxmoxCluster {
 
 	return cl
 }
+
+func sdnProxmoxCluster(name string) infrav1.ProxmoxCluster {
+	cl := validProxmoxCluster(name)
+	cl.Spec.SDN = &infrav1.SDNSpec{
+		Zone: infrav1.SDNZoneSpec{
+			Name: "capmox",
+			Type: infrav1.SDNZoneTypeSimple,
+		},
+		VNets: []infrav1.SDNVNetSpec{{
+			Name: "vnet0",
+			Subnets: []infrav1.SDNSubnetSpec{{
+				CIDR:      "10.20.0.0/24",
+				Gateway:   "10.20.0.1",
+				Addresses: []string{"10.20.0.10-10.20.0.100"},
+			}},
+		}},
+	}
+
+	return cl
+}
@@ -20,6 +20,7 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"slices"
 	"strings"
 
@@ -27,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -143,6 +145,19 @@ func validateNetworks(machine *infrav1.ProxmoxMachine) error {
 			)
 		}
 
+		if networkDevice.Bridge != nil && networkDevice.VNet != nil {
+			return apierrors.NewInvalid(
+				gk,
+				name,
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec", "network", "networkDevices", fmt.Sprint(i), "vnet"),
+						*networkDevice.VNet,
+						"bridge and vnet are mutually exclusive",
+					),
+				})
+		}
+
 		defaultIPv4Count += b2i(networkDevice.DefaultIPv4)
 		defaultIPv6Count += b2i(networkDevice.DefaultIPv6)
 		if defaultIPv4Count > 1 || defaultIPv6Count > 1 {
@@ -158,6 +173,10 @@ func validateNetworks(machine *infrav1.ProxmoxMachine) error {
 				})
 		}
 
+		if err := validateDHCP(field.NewPath("spec", "network", "networkDevices", fmt.Sprint(i)), networkDevice.DefaultIPv4, networkDevice.DefaultIPv6, &networkDevice.InterfaceConfig); err != nil {
+			return apierrors.NewInvalid(gk, name, field.ErrorList{err})
+		}
+
 		err := validateNetworkDeviceMTU(&networkDevice)
 		if err != nil {
 			return apierrors.NewInvalid(
@@ -210,6 +229,14 @@ func validateNetworks(machine *infrav1.ProxmoxMachine) error {
 		}
 	}
 
+	if err := validateLinkDevices(machine.Spec.Network, defaultIPv4Count, defaultIPv6Count); err != nil {
+		return apierrors.NewInvalid(gk, name, field.ErrorList{err})
+	}
+
+	if err := validateRenderer(machine.GetNetworkRenderer(), machine.Spec.Network); err != nil {
+		return apierrors.NewInvalid(gk, name, field.ErrorList{err})
+	}
+
 	for i := range machine.Spec.Network.VirtualNetworkDevices.VRFs {
 		err := validateVRFConfigRoutingPolicy(&machine.Spec.Network.VirtualNetworkDevices.VRFs[i])
 		if err != nil {
@@ -227,6 +254,197 @@ func validateNetworks(machine *infrav1.ProxmoxMachine) error {
 	return nil
 }
 
+// validateRenderer rejects virtual devices the guest network renderer can not
+// render: networkConfigV1 has no VRFs, networkManager and eni only render
+// network devices.
+func validateRenderer(renderer infrav1.NetworkRenderer, spec *infrav1.NetworkSpec) *field.Error {
+	var unsupported []string
+	switch renderer {
+	case infrav1.NetworkRendererNetworkConfigV1:
+		unsupported = []string{"vrfs"}
+	case infrav1.NetworkRendererNetworkManager, infrav1.NetworkRendererENI:
+		unsupported = []string{"vrfs", "bonds", "vlans", "bridges"}
+	}
+
+	counts := map[string]int{
+		"vrfs":    len(spec.VRFs),
+		"bonds":   len(spec.Bonds),
+		"vlans":   len(spec.VLANs),
+		"bridges": len(spec.Bridges),
+	}
+	for _, kind := range unsupported {
+		if counts[kind] > 0 {
+			return field.Forbidden(field.NewPath("spec", "network", kind), fmt.Sprintf("renderer %s does not support %s", renderer, kind))
+		}
+	}
+	return nil
+}
+
+// linkDevice is the subset of a bond, VLAN or bridge which is validated alike.
+type linkDevice struct {
+	path        *field.Path
+	name        string
+	defaultIPv4 *bool
+	defaultIPv6 *bool
+	ifconfig    *infrav1.InterfaceConfig
+}
+
+func (d *linkDevice) hasIPConfig() bool {
+	return ptr.Deref(d.defaultIPv4, false) || ptr.Deref(d.defaultIPv6, false) ||
+		ptr.Deref(d.ifconfig.DHCP4, false) || ptr.Deref(d.ifconfig.DHCP6, false) ||
+		d.ifconfig.IPv6Mode != "" || d.ifconfig.AcceptRA != nil || d.ifconfig.IPv6Token != nil ||
+		len(d.ifconfig.IPPoolRef) > 0 || len(d.ifconfig.Routes) > 0 || len(d.ifconfig.RoutingPolicy) > 0
+}
+
+// validateLinkDevices validates bonds, VLANs and bridges: names are unique,
+// members exist and are enslaved at most once and without ip configuration of
+// their own, and there is at most one default IPv4/IPv6 device overall.
+func validateLinkDevices(spec *infrav1.NetworkSpec, defaultIPv4Count, defaultIPv6Count int) *field.Error {
+	devices := make(map[string]*linkDevice)
+	kinds := make(map[string]string)
+	for i := range spec.NetworkDevices {
+		nic := &spec.NetworkDevices[i]
+		devices[string(nic.Name)] = &linkDevice{name: string(nic.Name), defaultIPv4: nic.DefaultIPv4, defaultIPv6: nic.DefaultIPv6, ifconfig: &nic.InterfaceConfig}
+		kinds[string(nic.Name)] = "networkDevice"
+	}
+
+	var links []*linkDevice
+	add := func(kind string, i int, d *linkDevice) *field.Error {
+		d.path = field.NewPath("spec", "network", kind, fmt.Sprint(i))
+		if _, exists := kinds[d.name]; exists {
+			return field.Duplicate(d.path.Child("name"), d.name)
+		}
+		devices[d.name] = d
+		kinds[d.name] = kind
+		links = append(links, d)
+		return nil
+	}
+	for i := range spec.VRFs {
+		if _, exists := kinds[spec.VRFs[i].Name]; exists {
+			return field.Duplicate(field.NewPath("spec", "network", "vrfs", fmt.Sprint(i), "name"), spec.VRFs[i].Name)
+		}
+		kinds[spec.VRFs[i].Name] = "vrfs"
+	}
+	for i := range spec.Bonds {
+		bond := &spec.Bonds[i]
+		if err := add("bonds", i, &linkDevice{name: bond.Name, defaultIPv4: bond.DefaultIPv4, defaultIPv6: bond.DefaultIPv6, ifconfig: &bond.InterfaceConfig}); err != nil {
+			return err
+		}
+	}
+	for i := range spec.VLANs {
+		vlan := &spec.VLANs[i]
+		if err := add("vlans", i, &linkDevice{name: vlan.Name, defaultIPv4: vlan.DefaultIPv4, defaultIPv6: vlan.DefaultIPv6, ifconfig: &vlan.InterfaceConfig}); err != nil {
+			return err
+		}
+	}
+	for i := range spec.Bridges {
+		bridge := &spec.Bridges[i]
+		if err := add("bridges", i, &linkDevice{name: bridge.Name, defaultIPv4: bridge.DefaultIPv4, defaultIPv6: bridge.DefaultIPv6, ifconfig: &bridge.InterfaceConfig}); err != nil {
+			return err
+		}
+	}
+
+	enslaved := make(map[string]struct{})
+	enslave := func(path *field.Path, member string, allowed ...string) *field.Error {
+		if !slices.Contains(allowed, kinds[member]) {
+			return field.NotFound(path, member)
+		}
+		if _, exists := enslaved[member]; exists {
+			return field.Invalid(path, member, "device is already enslaved by another bond or bridge")
+		}
+		enslaved[member] = struct{}{}
+		if devices[member].hasIPConfig() {
+			return field.Invalid(path, member, "enslaved device must not carry ip configuration")
+		}
+		return nil
+	}
+	for i, bond := range spec.Bonds {
+		for j, member := range bond.Interfaces {
+			if err := enslave(field.NewPath("spec", "network", "bonds", fmt.Sprint(i), "interfaces", fmt.Sprint(j)), string(member), "networkDevice"); err != nil {
+				return err
+			}
+		}
+	}
+	for i, vlan := range spec.VLANs {
+		if kind := kinds[vlan.Link]; kind != "networkDevice" && kind != "bonds" {
+			return field.NotFound(field.NewPath("spec", "network", "vlans", fmt.Sprint(i), "link"), vlan.Link)
+		}
+	}
+	for i, bridge := range spec.Bridges {
+		for j, port := range bridge.Interfaces {
+			if err := enslave(field.NewPath("spec", "network", "bridges", fmt.Sprint(i), "interfaces", fmt.Sprint(j)), port, "networkDevice", "bonds", "vlans"); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, d := range links {
+		defaultIPv4Count += b2i(d.defaultIPv4)
+		defaultIPv6Count += b2i(d.defaultIPv6)
+		if defaultIPv4Count > 1 || defaultIPv6Count > 1 {
+			return field.Invalid(d.path, d.name, "More than one default IPv4/IPv6 interface in NetworkDevices")
+		}
+		if err := validateDHCP(d.path, d.defaultIPv4, d.defaultIPv6, d.ifconfig); err != nil {
+			return err
+		}
+		if err := validateInterfaceConfigMTU(d.ifconfig); err != nil {
+			return field.Invalid(d.path.Child("linkMtu"), d.ifconfig.LinkMTU, err.Error())
+		}
+		if err := validateRoutingPolicy(&d.ifconfig.RoutingPolicy); err != nil {
+			return field.Invalid(d.path.Child("routingPolicy"), d.ifconfig.RoutingPolicy, err.Error())
+		}
+		if err := validateRoutes(d.ifconfig.Routes); err != nil {
+			return field.Invalid(d.path.Child("routes"), d.ifconfig.Routes, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// validateDHCP rejects devices which are configured with DHCP and attached to
+// the host network of the same address family, and IPv6 settings which
+// contradict the IPv6 mode of the device.
+func validateDHCP(path *field.Path, defaultIPv4, defaultIPv6 *bool, ifconfig *infrav1.InterfaceConfig) *field.Error {
+	if ptr.Deref(ifconfig.DHCP4, false) && ptr.Deref(defaultIPv4, false) {
+		return field.Invalid(path.Child("dhcp4"), true, "dhcp4 and defaultIPv4 are mutually exclusive")
+	}
+	if ptr.Deref(ifconfig.DHCP6, false) && ptr.Deref(defaultIPv6, false) {
+		return field.Invalid(path.Child("dhcp6"), true, "dhcp6 and defaultIPv6 are mutually exclusive")
+	}
+	return validateIPv6Mode(path, defaultIPv6, ifconfig)
+}
+
+// validateIPv6Mode rejects IPv6 modes which contradict dhcp6 or the host
+// network, and router advertisement or token settings the mode can not use.
+func validateIPv6Mode(path *field.Path, defaultIPv6 *bool, ifconfig *infrav1.InterfaceConfig) *field.Error {
+	mode := ifconfig.IPv6Mode
+	if ptr.Deref(ifconfig.DHCP6, false) && mode != "" && mode != infrav1.IPv6ModeDHCPv6 {
+		return field.Invalid(path.Child("ipv6Mode"), mode, fmt.Sprintf("ipv6Mode %s contradicts dhcp6", mode))
+	}
+	if (mode == infrav1.IPv6ModeDHCPv6 || mode == infrav1.IPv6ModeSLAAC || mode == infrav1.IPv6ModeDisabled) && ptr.Deref(defaultIPv6, false) {
+		return field.Invalid(path.Child("ipv6Mode"), mode, fmt.Sprintf("ipv6Mode %s and defaultIPv6 are mutually exclusive", mode))
+	}
+
+	slaac := mode == infrav1.IPv6ModeSLAAC || mode == infrav1.IPv6ModeStaticSLAAC
+	if slaac && !ptr.Deref(ifconfig.AcceptRA, true) {
+		return field.Invalid(path.Child("acceptRA"), false, fmt.Sprintf("ipv6Mode %s requires router advertisements", mode))
+	}
+	if mode == infrav1.IPv6ModeDisabled && ptr.Deref(ifconfig.AcceptRA, false) {
+		return field.Invalid(path.Child("acceptRA"), true, "acceptRA requires IPv6, but ipv6Mode is disabled")
+	}
+
+	if ifconfig.IPv6Token != nil {
+		if !slaac {
+			return field.Invalid(path.Child("ipv6Token"), *ifconfig.IPv6Token, "ipv6Token requires the ipv6Mode slaac or staticSLAAC")
+		}
+		token, err := netip.ParseAddr(*ifconfig.IPv6Token)
+		if err != nil || !token.Is6() || token.Zone() != "" {
+			return field.Invalid(path.Child("ipv6Token"), *ifconfig.IPv6Token, "ipv6Token must be an IPv6 interface identifier, e.g. ::10")
+		}
+	}
+	return nil
+}
+
 func validateRoutingPolicy(policies *[]infrav1.RoutingPolicySpec) error {
 	for i, policy := range *policies {
 		if policy.Table == nil {
@@ -342,15 +560,57 @@ func (p *ProxmoxMachine) Default(_ context.Context, obj runtime.Object) error {
 		defaultIPv4Count += b2i(networkDevice.DefaultIPv4)
 		defaultIPv6Count += b2i(networkDevice.DefaultIPv6)
 	}
+	for _, bond := range machine.Spec.Network.Bonds {
+		defaultIPv4Count += b2i(bond.DefaultIPv4)
+		defaultIPv6Count += b2i(bond.DefaultIPv6)
+	}
+	for _, vlan := range machine.Spec.Network.VLANs {
+		defaultIPv4Count += b2i(vlan.DefaultIPv4)
+		defaultIPv6Count += b2i(vlan.DefaultIPv6)
+	}
+	for _, bridge := range machine.Spec.Network.Bridges {
+		defaultIPv4Count += b2i(bridge.DefaultIPv4)
+		defaultIPv6Count += b2i(bridge.DefaultIPv6)
+	}
+
+	// A device configured with DHCP, SLAAC or without IPv6 is not attached to
+	// the host network of the same address family.
+	defaultIPv4, defaultIPv6, ifconfig := defaultHostNetworkDevice(machine.Spec.Network)
+	if defaultIPv4Count == 0 && !ptr.Deref(ifconfig.DHCP4, false) {
+		*defaultIPv4 = new(true)
+	}
+	if defaultIPv6Count == 0 && !ifconfig.DynamicIPv6() && ifconfig.IPv6Mode != infrav1.IPv6ModeDisabled {
+		*defaultIPv6 = new(true)
+	}
 
+	return nil
+}
+
+// defaultHostNetworkDevice returns the default fields and the interface config
+// of the device the host network is attached to by default:
+// DefaultNetworkDevice, or the bond or bridge it is enslaved by.
+func defaultHostNetworkDevice(spec *infrav1.NetworkSpec) (defaultIPv4, defaultIPv6 **bool, ifconfig *infrav1.InterfaceConfig) {
 	// We guarantee that DefaultNetworkDevice is a valid proxmox network device.
 	offset, _ := vmservice.NetNameToOffset(infrav1.DefaultNetworkDevice)
-	if defaultIPv4Count == 0 {
-		machine.Spec.Network.NetworkDevices[offset].DefaultIPv4 = new(true)
+	defaultIPv4, defaultIPv6 = &spec.NetworkDevices[offset].DefaultIPv4, &spec.NetworkDevices[offset].DefaultIPv6
+	ifconfig = &spec.NetworkDevices[offset].InterfaceConfig
+
+	device := string(infrav1.DefaultNetworkDevice)
+	for i := range spec.Bonds {
+		if slices.Contains(spec.Bonds[i].Interfaces, infrav1.DefaultNetworkDevice) {
+			device = spec.Bonds[i].Name
+			defaultIPv4, defaultIPv6 = &spec.Bonds[i].DefaultIPv4, &spec.Bonds[i].DefaultIPv6
+			ifconfig = &spec.Bonds[i].InterfaceConfig
+			break
+		}
 	}
-	if defaultIPv6Count == 0 {
-		machine.Spec.Network.NetworkDevices[offset].DefaultIPv6 = new(true)
+	for i := range spec.Bridges {
+		if slices.Contains(spec.Bridges[i].Interfaces, device) {
+			defaultIPv4, defaultIPv6 = &spec.Bridges[i].DefaultIPv4, &spec.Bridges[i].DefaultIPv6
+			ifconfig = &spec.Bridges[i].InterfaceConfig
+			break
+		}
 	}
 
-	return nil
+	return defaultIPv4, defaultIPv6, ifconfig
 }
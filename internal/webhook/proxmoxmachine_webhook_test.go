@@ -205,11 +205,170 @@ var _ = Describe("Controller Test", func() {
 			g.Expect(*machine.Spec.Network.NetworkDevices[0].DefaultIPv6).To(BeTrue())
 		})
 
+		It("should move default ipv4/ipv6 pool tags to the bridge enslaving net0", func() {
+			machine := bondedProxmoxMachine("bridged-default-pools")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(Succeed())
+			g.Expect(machine.Spec.Network.NetworkDevices[0].DefaultIPv4).To(BeNil())
+			g.Expect(machine.Spec.Network.Bonds[0].DefaultIPv4).To(BeNil())
+			g.Expect(*machine.Spec.Network.Bridges[0].DefaultIPv4).To(BeTrue())
+			g.Expect(*machine.Spec.Network.Bridges[0].DefaultIPv6).To(BeTrue())
+		})
+
+		It("should allow bonds, vlans and bridges with the networkConfigV1 renderer", func() {
+			machine := bondedProxmoxMachine("v1-link-devices")
+			machine.Spec.Network.VRFs = nil
+			machine.Spec.Network.Renderer = infrav1.NetworkRendererNetworkConfigV1
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(Succeed())
+		})
+
+		It("should disallow vrfs with the networkConfigV1 renderer", func() {
+			machine := validProxmoxMachine("v1-vrfs")
+			machine.Spec.Network.Renderer = infrav1.NetworkRendererNetworkConfigV1
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("renderer networkConfigV1 does not support vrfs")))
+		})
+
+		It("should disallow bonds with the networkManager renderer", func() {
+			machine := bondedProxmoxMachine("networkmanager-bonds")
+			machine.Spec.Network.VRFs = nil
+			machine.Spec.Network.Renderer = infrav1.NetworkRendererNetworkManager
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("renderer networkManager does not support bonds")))
+		})
+
+		It("should not add default ipv4 pool tags to a dhcp4 host device", func() {
+			machine := validProxmoxMachine("dhcp4-default-device")
+			machine.Spec.Network.NetworkDevices[0].DHCP4 = new(true)
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(Succeed())
+			g.Expect(machine.Spec.Network.NetworkDevices[0].DefaultIPv4).To(BeNil())
+			g.Expect(*machine.Spec.Network.NetworkDevices[0].DefaultIPv6).To(BeTrue())
+		})
+
+		It("should disallow dhcp4 together with defaultIPv4", func() {
+			machine := validProxmoxMachine("dhcp4-and-default")
+			machine.Spec.Network.NetworkDevices[1].DHCP4 = new(true)
+			machine.Spec.Network.NetworkDevices[1].DefaultIPv4 = new(true)
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("dhcp4 and defaultIPv4 are mutually exclusive")))
+		})
+
+		It("should disallow dhcp6 together with defaultIPv6 on a virtual device", func() {
+			machine := bondedProxmoxMachine("dhcp6-and-default-link")
+			machine.Spec.Network.VLANs[0].DHCP6 = new(true)
+			machine.Spec.Network.VLANs[0].DefaultIPv6 = new(true)
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("dhcp6 and defaultIPv6 are mutually exclusive")))
+		})
+
+		It("should not add default ipv6 pool tags to a slaac host device", func() {
+			machine := validProxmoxMachine("slaac-default-device")
+			machine.Spec.Network.NetworkDevices[0].IPv6Mode = infrav1.IPv6ModeSLAAC
+			machine.Spec.Network.NetworkDevices[0].IPv6Token = new("::10")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(Succeed())
+			g.Expect(*machine.Spec.Network.NetworkDevices[0].DefaultIPv4).To(BeTrue())
+			g.Expect(machine.Spec.Network.NetworkDevices[0].DefaultIPv6).To(BeNil())
+		})
+
+		It("should allow static and slaac addresses on the host device", func() {
+			machine := validProxmoxMachine("static-slaac-default-device")
+			machine.Spec.Network.NetworkDevices[0].IPv6Mode = infrav1.IPv6ModeStaticSLAAC
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(Succeed())
+			g.Expect(*machine.Spec.Network.NetworkDevices[0].DefaultIPv6).To(BeTrue())
+		})
+
+		It("should disallow an ipv6Mode contradicting dhcp6", func() {
+			machine := validProxmoxMachine("dhcp6-and-slaac")
+			machine.Spec.Network.NetworkDevices[1].DHCP6 = new(true)
+			machine.Spec.Network.NetworkDevices[1].IPv6Mode = infrav1.IPv6ModeSLAAC
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("ipv6Mode slaac contradicts dhcp6")))
+		})
+
+		It("should disallow ipv6Mode disabled together with defaultIPv6", func() {
+			machine := validProxmoxMachine("ipv6-disabled-and-default")
+			machine.Spec.Network.NetworkDevices[1].IPv6Mode = infrav1.IPv6ModeDisabled
+			machine.Spec.Network.NetworkDevices[1].DefaultIPv6 = new(true)
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("ipv6Mode disabled and defaultIPv6 are mutually exclusive")))
+		})
+
+		It("should disallow slaac without router advertisements", func() {
+			machine := validProxmoxMachine("slaac-without-ra")
+			machine.Spec.Network.NetworkDevices[1].IPv6Mode = infrav1.IPv6ModeSLAAC
+			machine.Spec.Network.NetworkDevices[1].AcceptRA = new(false)
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("ipv6Mode slaac requires router advertisements")))
+		})
+
+		It("should disallow an ipv6Token without slaac", func() {
+			machine := validProxmoxMachine("token-without-slaac")
+			machine.Spec.Network.NetworkDevices[1].IPv6Mode = infrav1.IPv6ModeStatic
+			machine.Spec.Network.NetworkDevices[1].IPv6Token = new("::10")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("ipv6Token requires the ipv6Mode slaac or staticSLAAC")))
+		})
+
+		It("should disallow an invalid ipv6Token", func() {
+			machine := validProxmoxMachine("invalid-token")
+			machine.Spec.Network.NetworkDevices[1].IPv6Mode = infrav1.IPv6ModeSLAAC
+			machine.Spec.Network.NetworkDevices[1].IPv6Token = new("10.0.0.10")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("ipv6Token must be an IPv6 interface identifier")))
+		})
+
+		It("should disallow enslaving a device configured with dhcp", func() {
+			machine := bondedProxmoxMachine("bond-member-dhcp")
+			machine.Spec.Network.NetworkDevices[0].DHCP4 = new(true)
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("enslaved device must not carry ip configuration")))
+		})
+
+		It("should disallow bonds with unknown members", func() {
+			machine := bondedProxmoxMachine("bond-unknown-member")
+			machine.Spec.Network.Bonds[0].Interfaces = []infrav1.NetName{"net0", "net5"}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("spec.network.bonds[0].interfaces[1]: Not found")))
+		})
+
+		It("should disallow enslaving a device with ip configuration", func() {
+			machine := bondedProxmoxMachine("bond-member-ip-config")
+			machine.Spec.Network.Bonds[0].Interfaces = []infrav1.NetName{"net0", "net1"}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("enslaved device must not carry ip configuration")))
+		})
+
+		It("should disallow enslaving a device twice", func() {
+			machine := bondedProxmoxMachine("bridge-port-twice")
+			machine.Spec.Network.Bridges[0].Interfaces = []string{"bond0", "net0"}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("already enslaved")))
+		})
+
+		It("should disallow vlans on unknown links", func() {
+			machine := bondedProxmoxMachine("vlan-unknown-link")
+			machine.Spec.Network.VLANs[0].Link = "bond1"
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("spec.network.vlans[0].link: Not found")))
+		})
+
+		It("should disallow duplicate virtual device names", func() {
+			machine := bondedProxmoxMachine("duplicate-link-name")
+			machine.Spec.Network.VLANs[0].Name = "vrf-green"
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("Duplicate value")))
+		})
+
+		It("should error with multiple default ipv4 tags across virtual devices", func() {
+			machine := bondedProxmoxMachine("multiple-default-v4-link")
+			machine.Spec.Network.NetworkDevices[1].DefaultIPv4 = new(true)
+			machine.Spec.Network.VLANs[0].DefaultIPv4 = new(true)
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("More than one default IPv4/IPv6 interface in NetworkDevices")))
+		})
+
 		It("should not allow non consecutive network interface names ", func() {
 			machine := validProxmoxMachine("non-consecutive-netname")
 			machine.Spec.Network.NetworkDevices[1].Name = "net2"
 			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("consecutive")))
 		})
+
+		It("should disallow a network device with bridge and vnet", func() {
+			machine := validProxmoxMachine("bridge-and-vnet")
+			machine.Spec.Network.NetworkDevices[1].VNet = new("vnet0")
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("bridge and vnet are mutually exclusive")))
+		})
+
+		It("should disallow bootstrap delivery methods other than iso", func() {
+			machine := validProxmoxMachine("bootstrap-delivery-snippets")
+			machine.Spec.BootstrapDelivery = &infrav1.BootstrapDelivery{
+				Method: "snippets",
+			}
+			g.Expect(k8sClient.Create(testEnv.GetContext(), &machine)).To(MatchError(ContainSubstring("spec.bootstrapDelivery.method: Unsupported value")))
+		})
 	})
 
 	Context("update proxmox cluster", func() {
@@ -308,6 +467,25 @@ func validProxmoxMachine(name string) infrav1.ProxmoxMachine {
 	}
 }
 
+func bondedProxmoxMachine(name string) infrav1.ProxmoxMachine {
+	machine := validProxmoxMachine(name)
+	machine.Spec.Network.Bonds = []infrav1.BondDevice{{
+		Name:       "bond0",
+		Interfaces: []infrav1.NetName{"net0"},
+		Mode:       infrav1.BondModeActiveBackup,
+	}}
+	machine.Spec.Network.VLANs = []infrav1.VLANDevice{{
+		Name: "bond0.42",
+		Link: "bond0",
+		ID:   42,
+	}}
+	machine.Spec.Network.Bridges = []infrav1.BridgeDevice{{
+		Name:       "br0",
+		Interfaces: []string{"bond0"},
+	}}
+	return machine
+}
+
 func invalidMTUProxmoxMachine(name string) infrav1.ProxmoxMachine {
 	machine := validProxmoxMachine(name)
 	machine.Spec.Network.NetworkDevices = []infrav1.NetworkDevice{{
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const (
+	/* ifupdown interfaces template. */
+	eniTpl = `auto {{ .Name }}
+{{- template "iface" .IPv4 }}
+{{- template "iface" .IPv6 }}
+
+{{- define "iface" }}
+  {{- if .Method }}
+iface {{ .Name }} {{ .Family }} {{ .Method }}
+    {{- range .PreUp }}
+    pre-up {{ . }}
+    {{- end }}
+    {{- if eq .Method "static" }}
+      {{- range .Addresses }}
+    address {{ . }}
+      {{- end }}
+    {{- end }}
+    {{- if .DNS }}
+    dns-nameservers{{ range .DNS }} {{ . }}{{ end }}
+    {{- end }}
+    {{- range .Up }}
+    up {{ . }}
+    {{- end }}
+    {{- range .Down }}
+    down {{ . }}
+    {{- end }}
+  {{- end }}
+{{- end }}
+`
+
+	eniInterfacesDir = "/etc/network/interfaces.d"
+	eniUdevRulesFile = "/etc/udev/rules.d/70-capmox-net.rules"
+)
+
+// eniInterface is the data of an ifupdown interfaces file.
+type eniInterface struct {
+	Name string
+	IPv4 eniFamily
+	IPv6 eniFamily
+}
+
+// eniFamily is the data of an inet or inet6 stanza.
+type eniFamily struct {
+	Name      string
+	Family    string
+	Method    string
+	PreUp     []string
+	Addresses []string
+	DNS       []string
+	Up        []string
+	Down      []string
+}
+
+// ENIConfig provides functionality to render machine network configuration into
+// ifupdown interfaces files, which are written by an additional cloud-config part.
+//
+// ifupdown can not match devices by MAC address, so a udev rule names the devices
+// after their ConfigData.Name. Devices are renamed when the part is applied.
+//
+// It embeds network.Network to inherit the shared, renderer-agnostic validation
+// and layers its own checks on top via Validate.
+type ENIConfig struct {
+	network.Network
+}
+
+var _ UserDataNetworkRenderer = (*ENIConfig)(nil)
+
+// NewENIConfig returns a new ENIConfig object.
+func NewENIConfig(configs []network.ConfigData) *ENIConfig {
+	return &ENIConfig{network.Network{Devices: configs}}
+}
+
+// Inspect returns a serialized copy of the NetworkData. This is useful when
+// wanting to immutably inspect what goes into the renderer.
+func (r *ENIConfig) Inspect() ([]byte, error) {
+	return json.Marshal(r.Devices)
+}
+
+// Render returns a network-config which disables cloud-init networking.
+func (r *ENIConfig) Render() ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(DisabledNetworkConfig), nil
+}
+
+// RenderUserData returns userData with a cloud-config part which writes the udev
+// rules and one interfaces file per device, renames the devices and brings them up.
+func (r *ENIConfig) RenderUserData(userData []byte) ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	var rules strings.Builder
+	part := cloudConfigPart{}
+	for _, d := range r.Devices {
+		mac := strings.ToLower(d.MacAddress)
+		fmt.Fprintf(&rules, "SUBSYSTEM==\"net\", ACTION==\"add\", ATTR{address}==\"%s\", NAME=\"%s\"\n", mac, d.Name)
+
+		content, err := render(d.Name, eniTpl, newENIInterface(d))
+		if err != nil {
+			return nil, err
+		}
+
+		part.WriteFiles = append(part.WriteFiles, cloudConfigFile{
+			Path:        fmt.Sprintf("%s/capmox-%s", eniInterfacesDir, d.Name),
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     string(content),
+		})
+		part.RunCmd = append(part.RunCmd,
+			fmt.Sprintf(`for d in /sys/class/net/*; do if [ "$(cat "$d/address")" = "%s" ] && [ "${d##*/}" != "%s" ]; then ip link set dev "${d##*/}" down && ip link set dev "${d##*/}" name "%s"; fi; done`, mac, d.Name, d.Name),
+			fmt.Sprintf("ifup %s", d.Name),
+		)
+	}
+
+	part.WriteFiles = append([]cloudConfigFile{{
+		Path:        eniUdevRulesFile,
+		Owner:       "root:root",
+		Permissions: "0644",
+		Content:     rules.String(),
+	}}, part.WriteFiles...)
+
+	return appendCloudConfigPart(userData, part)
+}
+
+// Validate runs the shared, renderer-agnostic validation (embedded
+// network.Network) and rejects VRFs, which classic ifupdown does not support.
+func (r *ENIConfig) Validate() error {
+	if err := r.Network.Validate(); err != nil {
+		return err
+	}
+	if err := validateIPv6Defaults(r.Devices); err != nil {
+		return err
+	}
+	return validateEthernetOnly(r.Devices)
+}
+
+func newENIInterface(d network.ConfigData) eniInterface {
+	i := eniInterface{
+		Name: d.Name,
+		IPv4: eniFamily{Name: d.Name, Family: "inet"},
+		IPv6: eniFamily{Name: d.Name, Family: "inet6"},
+	}
+
+	family := func(is6 bool) *eniFamily {
+		if is6 {
+			return &i.IPv6
+		}
+		return &i.IPv4
+	}
+	ip := func(is6 bool) string {
+		if is6 {
+			return "ip -6"
+		}
+		return "ip"
+	}
+
+	for _, ipconfig := range d.IPConfigs {
+		f := family(ipconfig.IPAddress.Addr().Is6())
+		f.Addresses = append(f.Addresses, ipconfig.IPAddress.String())
+	}
+
+	for _, route := range d.Routes {
+		is6 := route.To.Addr().Is6()
+		f := family(is6)
+		cmd := fmt.Sprintf("%s route replace %s", ip(is6), route.To)
+		if route.Via.IsValid() {
+			cmd += fmt.Sprintf(" via %s", route.Via)
+		}
+		if route.Metric != nil {
+			cmd += fmt.Sprintf(" metric %d", *route.Metric)
+		}
+		if route.Table != nil {
+			cmd += fmt.Sprintf(" table %d", *route.Table)
+		}
+		f.Up = append(f.Up, cmd+" dev "+d.Name)
+	}
+
+	for _, rule := range d.FIBRules {
+		is6 := rule.To.Addr().Is6() || rule.From.Addr().Is6()
+		f := family(is6)
+		var selector string
+		if rule.From.IsValid() {
+			selector += " from " + rule.From.String()
+		}
+		if rule.To.IsValid() {
+			selector += " to " + rule.To.String()
+		}
+		if rule.Priority != nil {
+			selector += fmt.Sprintf(" priority %d", *rule.Priority)
+		}
+		if rule.Table != nil {
+			selector += fmt.Sprintf(" table %d", *rule.Table)
+		}
+		f.Up = append(f.Up, ip(is6)+" rule add"+selector)
+		f.Down = append(f.Down, ip(is6)+" rule del"+selector)
+	}
+
+	for _, dns := range d.DNSServers {
+		f := family(strings.Contains(dns, ":"))
+		f.DNS = append(f.DNS, dns)
+	}
+
+	for _, f := range []*eniFamily{&i.IPv4, &i.IPv6} {
+		dhcp := d.DHCP4
+		if f == &i.IPv6 {
+			dhcp = d.DHCP6
+		}
+		switch {
+		case dhcp:
+			f.Method = "dhcp"
+			// Static addresses next to DHCP are added explicitly.
+			for _, address := range f.Addresses {
+				f.Up = append([]string{fmt.Sprintf("%s address add %s dev %s", ip(f == &i.IPv6), address, d.Name)}, f.Up...)
+			}
+		case len(f.Addresses) > 0:
+			f.Method = "static"
+		}
+	}
+
+	// A device without addresses is brought up without configuring it.
+	if i.IPv4.Method == "" && i.IPv6.Method == "" {
+		i.IPv4.Method = "manual"
+	}
+
+	// The MTU is set once, by the first stanza.
+	if mtu := ptr.Deref(d.LinkMTU, 0); mtu != 0 {
+		setMTU := fmt.Sprintf("ip link set dev %s mtu %d", d.Name, mtu)
+		if i.IPv4.Method != "" {
+			i.IPv4.PreUp = append(i.IPv4.PreUp, setMTU)
+		} else {
+			i.IPv6.PreUp = append(i.IPv6.PreUp, setMTU)
+		}
+	}
+
+	// A family without a stanza can not carry routes, rules and nameservers: move them to the other one.
+	if i.IPv4.Method == "" && i.IPv6.Method != "" {
+		moveENIOptions(&i.IPv6, &i.IPv4)
+	}
+	if i.IPv6.Method == "" && i.IPv4.Method != "" {
+		moveENIOptions(&i.IPv4, &i.IPv6)
+	}
+
+	return i
+}
+
+func moveENIOptions(dst, src *eniFamily) {
+	dst.DNS = append(dst.DNS, src.DNS...)
+	dst.Up = append(dst.Up, src.Up...)
+	dst.Down = append(dst.Down, src.Down...)
+	src.DNS, src.Up, src.Down = nil, nil, nil
+}
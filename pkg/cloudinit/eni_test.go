@@ -0,0 +1,155 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const (
+	expectedValidENIConfig = `#cloud-config
+write_files:
+  - path: /etc/udev/rules.d/70-capmox-net.rules
+    owner: root:root
+    permissions: "0644"
+    content: |
+      SUBSYSTEM=="net", ACTION=="add", ATTR{address}=="92:60:a0:5b:22:c2", NAME="eth0"
+      SUBSYSTEM=="net", ACTION=="add", ATTR{address}=="b4:87:18:bf:a3:60", NAME="eth1"
+      SUBSYSTEM=="net", ACTION=="add", ATTR{address}=="5a:6e:28:0e:9b:14", NAME="eth2"
+  - path: /etc/network/interfaces.d/capmox-eth0
+    owner: root:root
+    permissions: "0644"
+    content: |
+      auto eth0
+      iface eth0 inet static
+          pre-up ip link set dev eth0 mtu 9000
+          address 10.10.10.12/24
+          dns-nameservers 8.8.8.8
+          up ip route replace 0.0.0.0/0 via 10.10.10.1 metric 100 dev eth0
+      iface eth0 inet6 static
+          address 2001:db8::2/64
+          dns-nameservers 2001:4860:4860::8888
+          up ip -6 route replace ::/0 via 2001:db8::1 dev eth0
+  - path: /etc/network/interfaces.d/capmox-eth1
+    owner: root:root
+    permissions: "0644"
+    content: |
+      auto eth1
+      iface eth1 inet dhcp
+          dns-nameservers 8.8.8.8
+          up ip address add 10.20.0.5/24 dev eth1
+          up ip route replace 172.16.0.0/16 via 10.20.0.1 table 500 dev eth1
+          up ip rule add from 10.20.0.0/24 priority 100 table 500
+          down ip rule del from 10.20.0.0/24 priority 100 table 500
+  - path: /etc/network/interfaces.d/capmox-eth2
+    owner: root:root
+    permissions: "0644"
+    content: |
+      auto eth2
+      iface eth2 inet manual
+          pre-up ip link set dev eth2 mtu 1400
+runcmd:
+  - for d in /sys/class/net/*; do if [ "$(cat "$d/address")" = "92:60:a0:5b:22:c2" ] && [ "${d##*/}" != "eth0" ]; then ip link set dev "${d##*/}" down && ip link set dev "${d##*/}" name "eth0"; fi; done
+  - ifup eth0
+  - for d in /sys/class/net/*; do if [ "$(cat "$d/address")" = "b4:87:18:bf:a3:60" ] && [ "${d##*/}" != "eth1" ]; then ip link set dev "${d##*/}" down && ip link set dev "${d##*/}" name "eth1"; fi; done
+  - ifup eth1
+  - for d in /sys/class/net/*; do if [ "$(cat "$d/address")" = "5a:6e:28:0e:9b:14" ] && [ "${d##*/}" != "eth2" ]; then ip link set dev "${d##*/}" down && ip link set dev "${d##*/}" name "eth2"; fi; done
+  - ifup eth2
+`
+)
+
+func TestENIConfig_RenderUserData(t *testing.T) {
+	userData := []byte("#cloud-config\nruncmd:\n  - kubeadm init\n")
+
+	nc := NewENIConfig([]network.ConfigData{
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth0",
+			MacAddress: "92:60:A0:5B:22:C2",
+			IPConfigs: []network.IPConfig{
+				{IPAddress: netip.MustParsePrefix("10.10.10.12/24")},
+				{IPAddress: netip.MustParsePrefix("2001:db8::2/64")},
+			},
+			DNSServers: []string{"8.8.8.8", "2001:4860:4860::8888"},
+			LinkMTU:    new(int32(9000)),
+			Routes: []network.RoutingData{
+				{To: netip.MustParsePrefix("0.0.0.0/0"), Via: netip.MustParseAddr("10.10.10.1"), Metric: new(int32(100))},
+				{To: netip.MustParsePrefix("::/0"), Via: netip.MustParseAddr("2001:db8::1")},
+			},
+		},
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth1",
+			MacAddress: "b4:87:18:bf:a3:60",
+			DHCP4:      true,
+			IPConfigs:  []network.IPConfig{{IPAddress: netip.MustParsePrefix("10.20.0.5/24")}},
+			DNSServers: []string{"8.8.8.8"},
+			Routes: []network.RoutingData{
+				{To: netip.MustParsePrefix("172.16.0.0/16"), Via: netip.MustParseAddr("10.20.0.1"), Table: new(int32(500))},
+			},
+			FIBRules: []network.FIBRuleData{
+				{From: netip.MustParsePrefix("10.20.0.0/24"), Table: new(int32(500)), Priority: new(int64(100))},
+			},
+		},
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth2",
+			MacAddress: "5a:6e:28:0e:9b:14",
+			LinkMTU:    new(int32(1400)),
+		},
+	})
+
+	networkConfig, err := nc.Render()
+	require.NoError(t, err)
+	require.Equal(t, DisabledNetworkConfig, string(networkConfig))
+
+	got, err := nc.RenderUserData(userData)
+	require.NoError(t, err)
+
+	bootstrap, network := splitUserData(t, got)
+	require.Equal(t, string(userData), bootstrap)
+	require.Equal(t, expectedValidENIConfig, network)
+}
+
+func TestENIConfig_Validate(t *testing.T) {
+	nc := NewENIConfig(nil)
+	require.ErrorIs(t, nc.Validate(), ErrMissingNetworkConfigData)
+
+	nc = NewENIConfig([]network.ConfigData{
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth0",
+			MacAddress: "92:60:a0:5b:22:c2",
+			DHCP4:      true,
+		},
+		{
+			Type:     network.TypeVRF,
+			Name:     "vrf-blue",
+			Table:    new(int32(500)),
+			Children: []string{"eth0"},
+		},
+	})
+	require.ErrorIs(t, nc.Validate(), ErrUnsupportedDevice)
+
+	_, err := nc.RenderUserData([]byte("#cloud-config"))
+	require.ErrorIs(t, err, ErrUnsupportedDevice)
+}
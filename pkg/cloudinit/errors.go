@@ -29,6 +29,21 @@ var (
 	// ErrMissingInstanceID returns an error if required hostname is empty.
 	ErrMissingInstanceID = errors.New("instance-id is not set")
 
+	// ErrUnsupportedDevice is returned for device types a network renderer can not configure.
+	ErrUnsupportedDevice = errors.New("device type is not supported by the network renderer")
+
+	// ErrUnsupportedRoutingTable is returned for routes or routing policies which
+	// require a routing table the network renderer can not configure.
+	ErrUnsupportedRoutingTable = errors.New("routing tables and routing policies are not supported by the network renderer")
+
+	// ErrUnsupportedIPv6Mode is returned for devices with an ipv6 mode, router
+	// advertisement or token setting the network renderer can not configure.
+	ErrUnsupportedIPv6Mode = errors.New("ipv6 modes, router advertisements and tokens are not supported by the network renderer")
+
+	// ErrMultipartUserData is returned if a cloud-config part, e.g. the network configuration,
+	// can not be added to user-data because it already is a MIME multipart archive.
+	ErrMultipartUserData = errors.New("multipart user-data is not supported")
+
 	// The following are structural errors shared with other renderers; they
 	// live in pkg/network and are re-exported here for backwards compatibility.
 
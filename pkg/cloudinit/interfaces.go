@@ -24,3 +24,12 @@ type Renderer interface {
 	Render() ([]byte, error)
 	Inspect() ([]byte, error)
 }
+
+// UserDataNetworkRenderer is implemented by network renderers which configure the
+// guest network through an additional cloud-config part of the user-data. Their
+// Render returns a network-config which disables cloud-init networking.
+type UserDataNetworkRenderer interface {
+	Renderer
+	// RenderUserData returns userData with the network configuration added.
+	RenderUserData(userData []byte) ([]byte, error)
+}
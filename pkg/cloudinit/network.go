@@ -40,6 +40,54 @@ const (
       {{- template "commonSettings" $element }}
   {{- end -}}
 {{- end -}}
+{{- $bond := 0 -}}
+{{- range $index, $element := .NetworkConfigData }}
+  {{- if eq $element.Type "bond" }}
+  {{- if eq $bond 0 }}
+  bonds:
+  {{- $bond = 1 }}
+  {{- end }}
+    {{ $element.Name }}:
+    {{- template "interfaces" $element }}
+      parameters:
+      {{- if $element.BondMode }}
+        mode: {{ $element.BondMode }}
+      {{- end }}
+      {{- if $element.BondMIIMon }}
+        mii-monitor-interval: {{ $element.BondMIIMon }}
+      {{- end }}
+    {{- template "commonSettings" $element }}
+  {{- end }}
+{{- end -}}
+{{- $bridge := 0 -}}
+{{- range $index, $element := .NetworkConfigData }}
+  {{- if eq $element.Type "bridge" }}
+  {{- if eq $bridge 0 }}
+  bridges:
+  {{- $bridge = 1 }}
+  {{- end }}
+    {{ $element.Name }}:
+    {{- template "interfaces" $element }}
+    {{- if $element.STP }}
+      parameters:
+        stp: {{ $element.STP }}
+    {{- end }}
+    {{- template "commonSettings" $element }}
+  {{- end }}
+{{- end -}}
+{{- $vlan := 0 -}}
+{{- range $index, $element := .NetworkConfigData }}
+  {{- if eq $element.Type "vlan" }}
+  {{- if eq $vlan 0 }}
+  vlans:
+  {{- $vlan = 1 }}
+  {{- end }}
+    {{ $element.Name }}:
+      id: {{ $element.VLANID }}
+      link: {{ $element.Link }}
+    {{- template "commonSettings" $element }}
+  {{- end }}
+{{- end -}}
 {{- $vrf := 0 -}}
 {{- range $index, $element := .NetworkConfigData }}
   {{- if eq $element.Type "vrf" }}
@@ -51,14 +99,17 @@ const (
       table: {{ $element.Table }}
     {{- template "routes" . }}
     {{- template "rules" . }}
-    {{- $interfaces := $element.Children }}
-    {{- if $interfaces }}
+    {{- template "interfaces" $element }}
+  {{- end }}
+{{- end -}}
+
+{{- define "interfaces" }}
+    {{- if .Children }}
       interfaces:
-      {{- range $interfaces }}
+      {{- range .Children }}
         - '{{ . }}'
       {{- end -}}
     {{- end -}}
-  {{- end }}
 {{- end -}}
 
   {{- define "dns" }}
@@ -76,6 +127,18 @@ const (
       dhcp6: {{ if .DHCP6 }}true{{ else }}false{{ end }}
 {{- end -}}
 
+{{- define "ipv6" }}
+    {{- if eq .IPv6Mode "disabled" }}
+      link-local: []
+    {{- end }}
+    {{- with .IPv6AcceptRA }}
+      accept-ra: {{ . }}
+    {{- end }}
+    {{- if .IPv6Token }}
+      ipv6-address-token: '{{ .IPv6Token }}'
+    {{- end }}
+{{- end -}}
+
 {{- define "rules" }}
     {{- if .FIBRules }}
       routing-policy:
@@ -119,6 +182,7 @@ const (
 
 {{- define "commonSettings" }}
     {{- template "dhcp" . }}
+    {{- template "ipv6" . }}
     {{- template "ipAddresses" . }}
     {{- template "routes" . }}
     {{- template "rules" . }}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
 )
 
@@ -214,6 +215,40 @@ const (
         macaddress: 92:60:a0:5b:22:c2
       dhcp4: false
       dhcp6: true
+      nameservers:
+        addresses:
+          - '8.8.8.8'
+          - '8.8.4.4'`
+
+	expectedValidNetworkConfigStaticSLAAC = `network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eth0:
+      match:
+        macaddress: 92:60:a0:5b:22:c2
+      dhcp4: true
+      dhcp6: false
+      accept-ra: true
+      ipv6-address-token: '::10'
+      addresses:
+        - '2001:db8::12/64'
+      nameservers:
+        addresses:
+          - '8.8.8.8'
+          - '8.8.4.4'`
+
+	expectedValidNetworkConfigIPv6Disabled = `network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eth0:
+      match:
+        macaddress: 92:60:a0:5b:22:c2
+      dhcp4: true
+      dhcp6: false
+      link-local: []
+      accept-ra: false
       nameservers:
         addresses:
           - '8.8.8.8'
@@ -456,6 +491,67 @@ const (
       table: 500
       routing-policy:
         - { "from": "10.10.0.0/16", }`
+
+	expectedValidNetworkConfigBondVLANBridge = `network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eth0:
+      match:
+        macaddress: 92:60:a0:5b:22:c2
+      dhcp4: false
+      dhcp6: false
+      nameservers:
+        addresses:
+          - '8.8.8.8'
+    eth1:
+      match:
+        macaddress: b4:87:18:bf:a3:60
+      dhcp4: false
+      dhcp6: false
+      nameservers:
+        addresses:
+          - '8.8.8.8'
+  bonds:
+    bond0:
+      interfaces:
+        - 'eth0'
+        - 'eth1'
+      parameters:
+        mode: 802.3ad
+        mii-monitor-interval: 100
+      dhcp4: false
+      dhcp6: false
+      addresses:
+        - '10.10.10.12/24'
+      routes:
+        - { "to": "0.0.0.0/0",  "via": "10.10.10.1",  "metric": 100, }
+      nameservers:
+        addresses:
+          - '8.8.8.8'
+  bridges:
+    br0:
+      interfaces:
+        - 'vlan100'
+      parameters:
+        stp: false
+      dhcp4: false
+      dhcp6: false
+      addresses:
+        - '192.168.100.124/24'
+      nameservers:
+        addresses:
+          - '8.8.8.8'
+      mtu: 9000
+  vlans:
+    vlan100:
+      id: 100
+      link: bond0
+      dhcp4: false
+      dhcp6: false
+      nameservers:
+        addresses:
+          - '8.8.8.8'`
 )
 
 func TestNetworkConfig_Render(t *testing.T) {
@@ -947,6 +1043,67 @@ func TestNetworkConfig_Render(t *testing.T) {
 				err:     nil,
 			},
 		},
+		"ValidNetworkConfigStaticSLAAC": {
+			reason: "render valid network-config with static ipv6 and slaac addresses",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						IPv6Mode:   infrav1.IPv6ModeStaticSLAAC,
+						IPv6Token:  "::10",
+						IPConfigs: []network.IPConfig{{
+							IPAddress: netip.MustParsePrefix("2001:db8::12/64"),
+						}},
+						DNSServers: []string{"8.8.8.8", "8.8.4.4"},
+					},
+				},
+			},
+			want: want{
+				network: expectedValidNetworkConfigStaticSLAAC,
+				err:     nil,
+			},
+		},
+		"ValidNetworkConfigIPv6Disabled": {
+			reason: "render valid network-config with ipv6 disabled",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						IPv6Mode:   infrav1.IPv6ModeDisabled,
+						DNSServers: []string{"8.8.8.8", "8.8.4.4"},
+					},
+				},
+			},
+			want: want{
+				network: expectedValidNetworkConfigIPv6Disabled,
+				err:     nil,
+			},
+		},
+		"InvalidNetworkConfigIPv6Mode": {
+			reason: "slaac addresses require router advertisements",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						IPv6Mode:   infrav1.IPv6ModeSLAAC,
+						AcceptRA:   new(false),
+					},
+				},
+			},
+			want: want{
+				network: "",
+				err:     network.ErrMalformedIPv6Mode,
+			},
+		},
 		"ValidNetworkConfigMultipleNicsVRF": {
 			reason: "valid config multiple nics attached to VRF",
 			args: args{
@@ -1007,6 +1164,63 @@ func TestNetworkConfig_Render(t *testing.T) {
 				err:     nil,
 			},
 		},
+		"ValidNetworkConfigBondVLANBridge": {
+			reason: "valid config bond with a vlan attached to a bridge",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DNSServers: []string{"8.8.8.8"},
+					},
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth1",
+						MacAddress: "b4:87:18:bf:a3:60",
+						DNSServers: []string{"8.8.8.8"},
+					},
+					{
+						Type:       network.TypeBond,
+						Name:       "bond0",
+						BondMode:   "802.3ad",
+						BondMIIMon: new(int32(100)),
+						Children:   []string{"eth0", "eth1"},
+						IPConfigs: []network.IPConfig{{
+							IPAddress: netip.MustParsePrefix("10.10.10.12/24"),
+						}},
+						DNSServers: []string{"8.8.8.8"},
+						Routes: []network.RoutingData{{
+							To:     netip.MustParsePrefix("0.0.0.0/0"),
+							Via:    netip.MustParseAddr("10.10.10.1"),
+							Metric: new(int32(100)),
+						}},
+					},
+					{
+						Type:       network.TypeVLAN,
+						Name:       "vlan100",
+						VLANID:     new(int32(100)),
+						Link:       "bond0",
+						DNSServers: []string{"8.8.8.8"},
+					},
+					{
+						Type:     network.TypeBridge,
+						Name:     "br0",
+						STP:      new(false),
+						Children: []string{"vlan100"},
+						IPConfigs: []network.IPConfig{{
+							IPAddress: netip.MustParsePrefix("192.168.100.124/24"),
+						}},
+						DNSServers: []string{"8.8.8.8"},
+						LinkMTU:    new(int32(9000)),
+					},
+				},
+			},
+			want: want{
+				network: expectedValidNetworkConfigBondVLANBridge,
+				err:     nil,
+			},
+		},
 		"ValidNetworkConfigMultipleNicsMultipleVRF": {
 			reason: "valid config multiple nics attached to multiple VRFs",
 			args: args{
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const (
+	/* network-config v1 template. */
+	networkConfigV1Tpl = `version: 1
+config:
+{{- range $element := .NetworkConfigData }}
+  {{- if eq $element.Type "ethernet" }}
+  - type: physical
+    name: {{ $element.Name }}
+    mac_address: '{{ $element.MacAddress }}'
+  {{- else if eq $element.Type "bond" }}
+  - type: bond
+    name: {{ $element.Name }}
+    bond_interfaces:
+    {{- range $element.Children }}
+      - {{ . }}
+    {{- end }}
+    params:
+      bond-mode: {{ $element.BondMode }}
+    {{- if $element.BondMIIMon }}
+      bond-miimon: {{ $element.BondMIIMon }}
+    {{- end }}
+  {{- else if eq $element.Type "vlan" }}
+  - type: vlan
+    name: {{ $element.Name }}
+    vlan_link: {{ $element.Link }}
+    vlan_id: {{ $element.VLANID }}
+  {{- else if eq $element.Type "bridge" }}
+  - type: bridge
+    name: {{ $element.Name }}
+    {{- if $element.Children }}
+    bridge_interfaces:
+    {{- range $element.Children }}
+      - {{ . }}
+    {{- end }}
+    {{- else }}
+    bridge_interfaces: []
+    {{- end }}
+    {{- with $element.STP }}
+    params:
+      bridge_stp: '{{ onOff . }}'
+    {{- end }}
+  {{- end }}
+  {{- if $element.LinkMTU }}
+    mtu: {{ $element.LinkMTU }}
+  {{- end }}
+  {{- if or $element.DHCP4 $element.DHCP6 $element.IPConfigs }}
+    subnets:
+    {{- if $element.DHCP4 }}
+      - type: dhcp4
+    {{- end }}
+    {{- if $element.DHCP6 }}
+      - type: dhcp6
+    {{- end }}
+    {{- range $element.IPConfigs }}
+      - type: {{ if is6 .IPAddress.String }}static6{{ else }}static{{ end }}
+        address: '{{ .IPAddress }}'
+    {{- end }}
+  {{- end }}
+{{- end }}
+{{- range $element := .NetworkConfigData }}
+  {{- range $route := $element.Routes }}
+  - type: route
+    destination: '{{ $route.To }}'
+    {{- if $route.Via.IsValid }}
+    gateway: '{{ $route.Via }}'
+    {{- end }}
+    {{- if $route.Metric }}
+    metric: {{ $route.Metric }}
+    {{- end }}
+  {{- end }}
+{{- end }}
+{{- with nameservers .NetworkConfigData }}
+  - type: nameserver
+    address:
+  {{- range . }}
+      - '{{ . }}'
+  {{- end }}
+{{- end }}
+`
+
+	// DisabledNetworkConfig is a network-config which disables cloud-init networking.
+	DisabledNetworkConfig = `config: disabled`
+)
+
+// NetworkConfigV1 provides functionality to render machine network-config version 1,
+// which cloud-init translates with the renderer of the distribution (e.g. sysconfig,
+// NetworkManager or ENI). Devices are rendered in order, so bonds, VLANs and bridges
+// follow the devices they are built on.
+//
+// It embeds network.Network to inherit the shared, renderer-agnostic validation
+// and layers its own checks on top via Validate.
+type NetworkConfigV1 struct {
+	network.Network
+}
+
+var _ Renderer = (*NetworkConfigV1)(nil)
+
+// NewNetworkConfigV1 returns a new NetworkConfigV1 object.
+func NewNetworkConfigV1(configs []network.ConfigData) *NetworkConfigV1 {
+	return &NetworkConfigV1{network.Network{Devices: configs}}
+}
+
+// Inspect returns a serialized copy of the NetworkData. This is useful when
+// wanting to immutably inspect what goes into the renderer.
+func (r *NetworkConfigV1) Inspect() ([]byte, error) {
+	return json.Marshal(r.Devices)
+}
+
+// Render returns rendered network-config version 1.
+func (r *NetworkConfigV1) Render() ([]byte, error) {
+	// Validate inputs to template
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	nc, err := render("network-config-v1", networkConfigV1Tpl, BaseCloudInitData{NetworkConfigData: r.Devices})
+	if err != nil {
+		return nil, err
+	}
+
+	// Check YAML render to be valid
+	var unused any
+	err = yaml.Unmarshal(nc, &unused)
+	if err != nil {
+		return nil, errors.Wrap(err,
+			"Template produced invalid YAML. Please file a bug at: "+
+				"https://github.com/ionos-cloud/cluster-api-provider-proxmox/")
+	}
+
+	return nc, nil
+}
+
+// Validate runs the shared, renderer-agnostic validation (embedded
+// network.Network) and rejects what network-config version 1 can not express:
+// VRFs, routing policies and routes into other tables.
+func (r *NetworkConfigV1) Validate() error {
+	if err := r.Network.Validate(); err != nil {
+		return err
+	}
+	if err := validateIPv6Defaults(r.Devices); err != nil {
+		return err
+	}
+	return validateMainTableOnly(r.Devices)
+}
+
+// validateMainTableOnly rejects VRFs, routing policies and routes into tables
+// other than main.
+func validateMainTableOnly(devices []network.ConfigData) error {
+	for _, d := range devices {
+		if d.Type == network.TypeVRF {
+			return ErrUnsupportedDevice
+		}
+		if len(d.FIBRules) > 0 {
+			return ErrUnsupportedRoutingTable
+		}
+		for _, route := range d.Routes {
+			if route.Table != nil {
+				return ErrUnsupportedRoutingTable
+			}
+		}
+	}
+	return nil
+}
+
+// validateEthernetOnly rejects all devices but ethernet devices.
+func validateEthernetOnly(devices []network.ConfigData) error {
+	for _, d := range devices {
+		if d.Type != network.TypeEthernet {
+			return ErrUnsupportedDevice
+		}
+	}
+	return nil
+}
+
+// validateIPv6Defaults rejects devices whose handling of router advertisements
+// or whose SLAAC token differs from the defaults of the guest. Only the ipv6
+// mode dhcpv6 is equal to dhcp6 and therefore supported.
+func validateIPv6Defaults(devices []network.ConfigData) error {
+	for _, d := range devices {
+		if d.IPv6AcceptRA() != nil || d.IPv6Token != "" {
+			return ErrUnsupportedIPv6Mode
+		}
+	}
+	return nil
+}
+
+// onOff formats a flag as the on or off value of ifupdown options.
+func onOff(b *bool) string {
+	if *b {
+		return "on"
+	}
+	return "off"
+}
+
+// nameservers returns the DNS servers of all devices in order, without duplicates.
+func nameservers(devices []network.ConfigData) []string {
+	var servers []string
+	for _, d := range devices {
+		for _, dns := range d.DNSServers {
+			if !slices.Contains(servers, dns) {
+				servers = append(servers, dns)
+			}
+		}
+	}
+	return servers
+}
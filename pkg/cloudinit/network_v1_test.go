@@ -0,0 +1,297 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const (
+	expectedValidNetworkConfigV1 = `version: 1
+config:
+  - type: physical
+    name: eth0
+    mac_address: '92:60:a0:5b:22:c2'
+    mtu: 9000
+    subnets:
+      - type: static
+        address: '10.10.10.12/24'
+      - type: static6
+        address: '2001:db8::2/64'
+  - type: physical
+    name: eth1
+    mac_address: 'b4:87:18:bf:a3:60'
+    subnets:
+      - type: dhcp4
+  - type: physical
+    name: eth2
+    mac_address: '5a:6e:28:0e:9b:14'
+  - type: route
+    destination: '0.0.0.0/0'
+    gateway: '10.10.10.1'
+    metric: 100
+  - type: route
+    destination: '::/0'
+    gateway: '2001:db8::1'
+  - type: nameserver
+    address:
+      - '8.8.8.8'
+      - '2001:4860:4860::8888'
+`
+
+	expectedLinkDevicesNetworkConfigV1 = `version: 1
+config:
+  - type: physical
+    name: eth0
+    mac_address: '92:60:a0:5b:22:c2'
+  - type: physical
+    name: eth1
+    mac_address: 'b4:87:18:bf:a3:60'
+  - type: bond
+    name: bond0
+    bond_interfaces:
+      - eth0
+      - eth1
+    params:
+      bond-mode: 802.3ad
+      bond-miimon: 100
+    mtu: 9000
+    subnets:
+      - type: dhcp4
+  - type: vlan
+    name: vlan100
+    vlan_link: bond0
+    vlan_id: 100
+    subnets:
+      - type: static
+        address: '10.10.10.12/24'
+  - type: bridge
+    name: br0
+    bridge_interfaces:
+      - vlan100
+    params:
+      bridge_stp: 'off'
+  - type: route
+    destination: '0.0.0.0/0'
+    gateway: '10.10.10.1'
+  - type: nameserver
+    address:
+      - '8.8.8.8'
+`
+)
+
+func TestNetworkConfigV1_Render(t *testing.T) {
+	type args struct {
+		nics []network.ConfigData
+	}
+
+	type want struct {
+		network string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ValidNetworkConfig": {
+			reason: "render valid network-config v1 with static, dhcp and unconfigured devices",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("10.10.10.12/24")},
+							{IPAddress: netip.MustParsePrefix("2001:db8::2/64")},
+						},
+						DNSServers: []string{"8.8.8.8", "2001:4860:4860::8888"},
+						LinkMTU:    new(int32(9000)),
+						Routes: []network.RoutingData{
+							{To: netip.MustParsePrefix("0.0.0.0/0"), Via: netip.MustParseAddr("10.10.10.1"), Metric: new(int32(100))},
+							{To: netip.MustParsePrefix("::/0"), Via: netip.MustParseAddr("2001:db8::1")},
+						},
+					},
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth1",
+						MacAddress: "b4:87:18:bf:a3:60",
+						DHCP4:      true,
+						DNSServers: []string{"8.8.8.8"},
+					},
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth2",
+						MacAddress: "5a:6e:28:0e:9b:14",
+					},
+				},
+			},
+			want: want{
+				network: expectedValidNetworkConfigV1,
+			},
+		},
+		"LinkDevices": {
+			reason: "render bonds, vlans and bridges after the devices they are built on",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+					},
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth1",
+						MacAddress: "b4:87:18:bf:a3:60",
+					},
+					{
+						Type:       network.TypeBond,
+						Name:       "bond0",
+						Children:   []string{"eth0", "eth1"},
+						BondMode:   "802.3ad",
+						BondMIIMon: new(int32(100)),
+						LinkMTU:    new(int32(9000)),
+						DHCP4:      true,
+						DNSServers: []string{"8.8.8.8"},
+					},
+					{
+						Type:   network.TypeVLAN,
+						Name:   "vlan100",
+						Link:   "bond0",
+						VLANID: new(int32(100)),
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("10.10.10.12/24")},
+						},
+						Routes: []network.RoutingData{
+							{To: netip.MustParsePrefix("0.0.0.0/0"), Via: netip.MustParseAddr("10.10.10.1")},
+						},
+					},
+					{
+						Type:     network.TypeBridge,
+						Name:     "br0",
+						Children: []string{"vlan100"},
+						STP:      new(false),
+					},
+				},
+			},
+			want: want{
+				network: expectedLinkDevicesNetworkConfigV1,
+			},
+		},
+		"MissingNetworkConfigData": {
+			reason: "network config data is required",
+			args:   args{},
+			want: want{
+				err: ErrMissingNetworkConfigData,
+			},
+		},
+		"VRFIsNotSupported": {
+			reason: "network-config v1 can not express vrfs",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+					},
+					{
+						Type:     network.TypeVRF,
+						Name:     "vrf-blue",
+						Table:    new(int32(500)),
+						Children: []string{"eth0"},
+					},
+				},
+			},
+			want: want{
+				err: ErrUnsupportedDevice,
+			},
+		},
+		"IPv6ModeIsNotSupported": {
+			reason: "network-config v1 can not express router advertisement settings",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						IPv6Mode:   infrav1.IPv6ModeSLAAC,
+					},
+				},
+			},
+			want: want{
+				err: ErrUnsupportedIPv6Mode,
+			},
+		},
+		"RoutingTableIsNotSupported": {
+			reason: "network-config v1 can not express routes into other tables",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						Routes: []network.RoutingData{
+							{To: netip.MustParsePrefix("10.0.0.0/8"), Via: netip.MustParseAddr("10.10.10.1"), Table: new(int32(500))},
+						},
+					},
+				},
+			},
+			want: want{
+				err: ErrUnsupportedRoutingTable,
+			},
+		},
+		"RoutingPolicyIsNotSupported": {
+			reason: "network-config v1 can not express routing policies",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						FIBRules: []network.FIBRuleData{
+							{From: netip.MustParsePrefix("10.10.10.0/24"), Table: new(int32(500))},
+						},
+					},
+				},
+			},
+			want: want{
+				err: ErrUnsupportedRoutingTable,
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			nc := NewNetworkConfigV1(tc.args.nics)
+			network, err := nc.Render()
+			require.ErrorIs(t, err, tc.want.err)
+			require.Equal(t, tc.want.network, string(network))
+		})
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const (
+	/* NetworkManager keyfile template. */
+	networkManagerTpl = `[connection]
+id={{ .ID }}
+type=ethernet
+autoconnect-priority=100
+
+[ethernet]
+mac-address={{ .MacAddress }}
+{{- if .MTU }}
+mtu={{ .MTU }}
+{{- end }}
+{{ template "ip" .IPv4 }}
+{{ template "ip" .IPv6 }}
+
+{{- define "ip" }}
+[{{ .Family }}]
+method={{ .Method }}
+  {{- range $index, $address := .Addresses }}
+address{{ inc $index }}={{ $address }}
+  {{- end }}
+  {{- range $index, $route := .Routes }}
+route{{ inc $index }}={{ $route.Spec }}
+    {{- if $route.Table }}
+route{{ inc $index }}_options=table={{ $route.Table }}
+    {{- end }}
+  {{- end }}
+  {{- range $index, $rule := .Rules }}
+routing-rule{{ inc $index }}={{ $rule }}
+  {{- end }}
+  {{- if and .DNS (ne .Method "disabled") }}
+dns={{ range .DNS }}{{ . }};{{ end }}
+  {{- end }}
+{{- end }}
+`
+
+	networkManagerConnectionsDir = "/etc/NetworkManager/system-connections"
+)
+
+// nmConnection is the data of a NetworkManager ethernet connection keyfile.
+type nmConnection struct {
+	ID         string
+	MacAddress string
+	MTU        int32
+	IPv4       nmIPConfig
+	IPv6       nmIPConfig
+}
+
+// nmIPConfig is the data of an ipv4 or ipv6 keyfile section.
+type nmIPConfig struct {
+	Family    string
+	Method    string
+	Addresses []string
+	Routes    []nmRoute
+	Rules     []string
+	DNS       []string
+}
+
+type nmRoute struct {
+	Spec  string
+	Table int32
+}
+
+// NetworkManagerConfig provides functionality to render machine network configuration
+// into NetworkManager keyfiles, which are written by an additional cloud-config part.
+//
+// It embeds network.Network to inherit the shared, renderer-agnostic validation
+// and layers its own checks on top via Validate.
+type NetworkManagerConfig struct {
+	network.Network
+}
+
+var _ UserDataNetworkRenderer = (*NetworkManagerConfig)(nil)
+
+// NewNetworkManagerConfig returns a new NetworkManagerConfig object.
+func NewNetworkManagerConfig(configs []network.ConfigData) *NetworkManagerConfig {
+	return &NetworkManagerConfig{network.Network{Devices: configs}}
+}
+
+// Inspect returns a serialized copy of the NetworkData. This is useful when
+// wanting to immutably inspect what goes into the renderer.
+func (r *NetworkManagerConfig) Inspect() ([]byte, error) {
+	return json.Marshal(r.Devices)
+}
+
+// Render returns a network-config which disables cloud-init networking.
+func (r *NetworkManagerConfig) Render() ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(DisabledNetworkConfig), nil
+}
+
+// RenderUserData returns userData with a cloud-config part which writes one keyfile
+// per device and activates the connections.
+func (r *NetworkManagerConfig) RenderUserData(userData []byte) ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	part := cloudConfigPart{RunCmd: []string{"nmcli connection reload"}}
+	for _, d := range r.Devices {
+		connection := newNMConnection(d)
+		content, err := render(connection.ID, networkManagerTpl, connection)
+		if err != nil {
+			return nil, err
+		}
+
+		part.WriteFiles = append(part.WriteFiles, cloudConfigFile{
+			Path:        fmt.Sprintf("%s/%s.nmconnection", networkManagerConnectionsDir, connection.ID),
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content:     string(content),
+		})
+		part.RunCmd = append(part.RunCmd, fmt.Sprintf("nmcli connection up %s", connection.ID))
+	}
+
+	return appendCloudConfigPart(userData, part)
+}
+
+// Validate runs the shared, renderer-agnostic validation (embedded
+// network.Network) and rejects VRFs, which are not rendered as keyfiles.
+func (r *NetworkManagerConfig) Validate() error {
+	if err := r.Network.Validate(); err != nil {
+		return err
+	}
+	if err := validateIPv6Defaults(r.Devices); err != nil {
+		return err
+	}
+	return validateEthernetOnly(r.Devices)
+}
+
+func newNMConnection(d network.ConfigData) nmConnection {
+	c := nmConnection{
+		ID:         "capmox-" + d.Name,
+		MacAddress: d.MacAddress,
+		MTU:        ptr.Deref(d.LinkMTU, 0),
+		IPv4:       nmIPConfig{Family: "ipv4"},
+		IPv6:       nmIPConfig{Family: "ipv6"},
+	}
+
+	family := func(is6 bool) *nmIPConfig {
+		if is6 {
+			return &c.IPv6
+		}
+		return &c.IPv4
+	}
+
+	for _, ipconfig := range d.IPConfigs {
+		f := family(ipconfig.IPAddress.Addr().Is6())
+		f.Addresses = append(f.Addresses, ipconfig.IPAddress.String())
+	}
+
+	for _, route := range d.Routes {
+		f := family(route.To.Addr().Is6())
+		f.Routes = append(f.Routes, nmRoute{Spec: nmRouteSpec(route), Table: ptr.Deref(route.Table, 0)})
+	}
+
+	for _, rule := range d.FIBRules {
+		f := family(rule.To.Addr().Is6() || rule.From.Addr().Is6())
+		f.Rules = append(f.Rules, nmRoutingRule(rule))
+	}
+
+	for _, dns := range d.DNSServers {
+		if addr, err := netip.ParseAddr(dns); err == nil {
+			f := family(addr.Is6())
+			f.DNS = append(f.DNS, dns)
+		}
+	}
+
+	c.IPv4.Method = nmMethod(d.DHCP4, c.IPv4)
+	c.IPv6.Method = nmMethod(d.DHCP6, c.IPv6)
+
+	return c
+}
+
+func nmMethod(dhcp bool, config nmIPConfig) string {
+	switch {
+	case dhcp:
+		return "auto"
+	case len(config.Addresses) > 0:
+		return "manual"
+	default:
+		return "disabled"
+	}
+}
+
+// nmRouteSpec returns a route in the keyfile format "dest/prefix[,gateway[,metric]]".
+func nmRouteSpec(route network.RoutingData) string {
+	spec := route.To.String()
+	if !route.Via.IsValid() && route.Metric == nil {
+		return spec
+	}
+
+	gateway := netip.IPv4Unspecified()
+	if route.To.Addr().Is6() {
+		gateway = netip.IPv6Unspecified()
+	}
+	if route.Via.IsValid() {
+		gateway = route.Via
+	}
+	spec += "," + gateway.String()
+
+	if route.Metric != nil {
+		spec += fmt.Sprintf(",%d", *route.Metric)
+	}
+	return spec
+}
+
+// nmRoutingRule returns a routing rule in the keyfile format.
+func nmRoutingRule(rule network.FIBRuleData) string {
+	var fields []string
+	if rule.Priority != nil {
+		fields = append(fields, fmt.Sprintf("priority %d", *rule.Priority))
+	}
+	if rule.From.IsValid() {
+		fields = append(fields, "from "+rule.From.String())
+	}
+	if rule.To.IsValid() {
+		fields = append(fields, "to "+rule.To.String())
+	}
+	if rule.Table != nil {
+		fields = append(fields, fmt.Sprintf("table %d", *rule.Table))
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const (
+	expectedValidNetworkManagerConfig = `#cloud-config
+write_files:
+  - path: /etc/NetworkManager/system-connections/capmox-eth0.nmconnection
+    owner: root:root
+    permissions: "0600"
+    content: |
+      [connection]
+      id=capmox-eth0
+      type=ethernet
+      autoconnect-priority=100
+
+      [ethernet]
+      mac-address=92:60:a0:5b:22:c2
+      mtu=9000
+
+      [ipv4]
+      method=manual
+      address1=10.10.10.12/24
+      route1=0.0.0.0/0,10.10.10.1,100
+      dns=8.8.8.8;
+
+      [ipv6]
+      method=manual
+      address1=2001:db8::2/64
+      route1=::/0,2001:db8::1
+      dns=2001:4860:4860::8888;
+  - path: /etc/NetworkManager/system-connections/capmox-eth1.nmconnection
+    owner: root:root
+    permissions: "0600"
+    content: |
+      [connection]
+      id=capmox-eth1
+      type=ethernet
+      autoconnect-priority=100
+
+      [ethernet]
+      mac-address=b4:87:18:bf:a3:60
+
+      [ipv4]
+      method=auto
+      route1=172.16.0.0/16,10.20.0.1
+      route1_options=table=500
+      routing-rule1=priority 100 from 10.20.0.0/24 table 500
+      dns=8.8.8.8;
+
+      [ipv6]
+      method=disabled
+runcmd:
+  - nmcli connection reload
+  - nmcli connection up capmox-eth0
+  - nmcli connection up capmox-eth1
+`
+)
+
+func TestNetworkManagerConfig_RenderUserData(t *testing.T) {
+	userData := []byte("#cloud-config\nruncmd:\n  - kubeadm init\n")
+
+	nc := NewNetworkManagerConfig([]network.ConfigData{
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth0",
+			MacAddress: "92:60:a0:5b:22:c2",
+			IPConfigs: []network.IPConfig{
+				{IPAddress: netip.MustParsePrefix("10.10.10.12/24")},
+				{IPAddress: netip.MustParsePrefix("2001:db8::2/64")},
+			},
+			DNSServers: []string{"8.8.8.8", "2001:4860:4860::8888"},
+			LinkMTU:    new(int32(9000)),
+			Routes: []network.RoutingData{
+				{To: netip.MustParsePrefix("0.0.0.0/0"), Via: netip.MustParseAddr("10.10.10.1"), Metric: new(int32(100))},
+				{To: netip.MustParsePrefix("::/0"), Via: netip.MustParseAddr("2001:db8::1")},
+			},
+		},
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth1",
+			MacAddress: "b4:87:18:bf:a3:60",
+			DHCP4:      true,
+			DNSServers: []string{"8.8.8.8"},
+			Routes: []network.RoutingData{
+				{To: netip.MustParsePrefix("172.16.0.0/16"), Via: netip.MustParseAddr("10.20.0.1"), Table: new(int32(500))},
+			},
+			FIBRules: []network.FIBRuleData{
+				{From: netip.MustParsePrefix("10.20.0.0/24"), Table: new(int32(500)), Priority: new(int64(100))},
+			},
+		},
+	})
+
+	networkConfig, err := nc.Render()
+	require.NoError(t, err)
+	require.Equal(t, DisabledNetworkConfig, string(networkConfig))
+
+	got, err := nc.RenderUserData(userData)
+	require.NoError(t, err)
+
+	bootstrap, network := splitUserData(t, got)
+	require.Equal(t, string(userData), bootstrap)
+	require.Equal(t, expectedValidNetworkManagerConfig, network)
+}
+
+func TestNetworkManagerConfig_Validate(t *testing.T) {
+	nc := NewNetworkManagerConfig(nil)
+	require.ErrorIs(t, nc.Validate(), ErrMissingNetworkConfigData)
+
+	nc = NewNetworkManagerConfig([]network.ConfigData{
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth0",
+			MacAddress: "92:60:a0:5b:22:c2",
+			DHCP4:      true,
+		},
+		{
+			Type:     network.TypeVRF,
+			Name:     "vrf-blue",
+			Table:    new(int32(500)),
+			Children: []string{"eth0"},
+		},
+	})
+	require.ErrorIs(t, nc.Validate(), ErrUnsupportedDevice)
+
+	_, err := nc.Render()
+	require.ErrorIs(t, err, ErrUnsupportedDevice)
+
+	_, err = nc.RenderUserData([]byte("#cloud-config"))
+	require.ErrorIs(t, err, ErrUnsupportedDevice)
+}
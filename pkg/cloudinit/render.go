@@ -28,8 +28,12 @@ func is6(addr string) bool {
 	return netip.MustParsePrefix(addr).Addr().Is6()
 }
 
-func render(name string, tpl string, data BaseCloudInitData) ([]byte, error) {
-	f := map[string]any{"is6": is6}
+func inc(i int) int {
+	return i + 1
+}
+
+func render(name string, tpl string, data any) ([]byte, error) {
+	f := map[string]any{"is6": is6, "nameservers": nameservers, "inc": inc, "onOff": onOff}
 	mt, err := template.New(name).Funcs(f).Parse(tpl)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to parse %s template", name)
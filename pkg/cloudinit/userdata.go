@@ -0,0 +1,177 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// userDataBoundary separates the parts of the multipart user-data.
+	userDataBoundary = "==CAPMOX-NETWORK-CONFIG=="
+
+	// cloudConfigPartMergeType prepends the lists (write_files, runcmd) of a cloud-config part to the
+	// ones of the bootstrap data, so e.g. the network is configured before the bootstrap commands run.
+	cloudConfigPartMergeType = "list(prepend)+dict(no_replace,recurse_list)+str()"
+)
+
+// userDataHeader is the header of the multipart user-data created by appendCloudConfigPart.
+var userDataHeader = fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"\nMIME-Version: 1.0\n\n", userDataBoundary)
+
+// cloudConfigFile is a file written by cloud-init.
+type cloudConfigFile struct {
+	Path        string `yaml:"path"`
+	Owner       string `yaml:"owner"`
+	Permissions string `yaml:"permissions"`
+	Content     string `yaml:"content"`
+}
+
+// cloudConfigPart is a cloud-config which writes files and runs commands, e.g. to
+// configure the guest network.
+type cloudConfigPart struct {
+	WriteFiles []cloudConfigFile `yaml:"write_files,omitempty"`
+	RunCmd     []string          `yaml:"runcmd,omitempty"`
+}
+
+// userDataPart is a part of a multipart user-data.
+type userDataPart struct {
+	header  textproto.MIMEHeader
+	content []byte
+}
+
+// AppendFile returns userData with a cloud-config part which writes a file owned by root,
+// e.g. a static pod manifest.
+func AppendFile(userData []byte, path, permissions string, content []byte) ([]byte, error) {
+	return appendCloudConfigPart(userData, cloudConfigPart{
+		WriteFiles: []cloudConfigFile{{
+			Path:        path,
+			Owner:       "root:root",
+			Permissions: permissions,
+			Content:     string(content),
+		}},
+	})
+}
+
+// AppendService returns userData with a cloud-config part which writes a script owned by root and
+// starts it as a transient systemd unit. The script runs in the background and may outlive cloud-init,
+// e.g. to wait for the bootstrap commands.
+func AppendService(userData []byte, path, unit string, script []byte) ([]byte, error) {
+	return appendCloudConfigPart(userData, cloudConfigPart{
+		WriteFiles: []cloudConfigFile{{
+			Path:        path,
+			Owner:       "root:root",
+			Permissions: "0700",
+			Content:     string(script),
+		}},
+		RunCmd: []string{fmt.Sprintf("systemd-run --unit %s %s", unit, path)},
+	})
+}
+
+// appendCloudConfigPart returns a MIME multipart user-data consisting of userData and
+// a cloud-config part holding the given files and commands.
+//
+// userData is passed as text/plain, which lets cloud-init detect its type
+// (e.g. #cloud-config or a jinja template). If userData already is a multipart
+// user-data created by this function, the part is added to it.
+func appendCloudConfigPart(userData []byte, part cloudConfigPart) ([]byte, error) {
+	parts, err := userDataParts(userData)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &bytes.Buffer{}
+	enc := yaml.NewEncoder(config)
+	enc.SetIndent(2)
+	if err := enc.Encode(part); err != nil {
+		return nil, errors.Wrap(err, "failed to render cloud-config part")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to render cloud-config part")
+	}
+
+	parts = append(parts, userDataPart{
+		header: textproto.MIMEHeader{
+			"Content-Type": {`text/cloud-config; charset="utf-8"`},
+			"Merge-Type":   {cloudConfigPartMergeType},
+		},
+		content: append([]byte("#cloud-config\n"), config.Bytes()...),
+	})
+
+	buf := bytes.NewBufferString(userDataHeader)
+
+	w := multipart.NewWriter(buf)
+	if err := w.SetBoundary(userDataBoundary); err != nil {
+		return nil, err
+	}
+
+	for _, p := range parts {
+		pw, err := w.CreatePart(p.header)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create user-data part")
+		}
+		if _, err := pw.Write(p.content); err != nil {
+			return nil, errors.Wrap(err, "failed to write user-data part")
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close user-data")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// userDataParts returns the parts of a multipart user-data created by appendCloudConfigPart,
+// or userData as a single text/plain part.
+func userDataParts(userData []byte) ([]userDataPart, error) {
+	body, found := bytes.CutPrefix(userData, []byte(userDataHeader))
+	if !found {
+		if bytes.HasPrefix(bytes.TrimSpace(userData), []byte("Content-Type: multipart/")) {
+			return nil, ErrMultipartUserData
+		}
+		return []userDataPart{{
+			header: textproto.MIMEHeader{
+				"Content-Type": {`text/plain; charset="utf-8"`},
+			},
+			content: userData,
+		}}, nil
+	}
+
+	var parts []userDataPart
+	r := multipart.NewReader(bytes.NewReader(body), userDataBoundary)
+	for {
+		p, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read user-data part")
+		}
+		content, err := io.ReadAll(p)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read user-data part")
+		}
+		parts = append(parts, userDataPart{header: p.Header, content: content})
+	}
+}
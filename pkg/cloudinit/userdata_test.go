@@ -0,0 +1,134 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// splitUserData returns the bootstrap data and the cloud-config part of a multipart user-data.
+func splitUserData(t *testing.T, userData []byte) (string, string) {
+	t.Helper()
+
+	header, body, found := bytes.Cut(userData, []byte("\n\n"))
+	require.True(t, found)
+
+	mediaType, params, err := mime.ParseMediaType(string(bytes.TrimPrefix(bytes.Split(header, []byte("\n"))[0], []byte("Content-Type: "))))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+
+	bootstrap, err := r.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, `text/plain; charset="utf-8"`, bootstrap.Header.Get("Content-Type"))
+	bootstrapData, err := io.ReadAll(bootstrap)
+	require.NoError(t, err)
+
+	network, err := r.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, `text/cloud-config; charset="utf-8"`, network.Header.Get("Content-Type"))
+	require.Equal(t, cloudConfigPartMergeType, network.Header.Get("Merge-Type"))
+	networkData, err := io.ReadAll(network)
+	require.NoError(t, err)
+
+	_, err = r.NextPart()
+	require.ErrorIs(t, err, io.EOF)
+
+	return string(bootstrapData), string(networkData)
+}
+
+func TestAppendCloudConfigPart(t *testing.T) {
+	userData := []byte("## template: jinja\n#cloud-config\nruncmd:\n  - kubeadm init\n")
+
+	got, err := appendCloudConfigPart(userData, cloudConfigPart{
+		WriteFiles: []cloudConfigFile{{Path: "/etc/test", Owner: "root:root", Permissions: "0644", Content: "a\nb\n"}},
+		RunCmd:     []string{"true"},
+	})
+	require.NoError(t, err)
+
+	bootstrap, network := splitUserData(t, got)
+	require.Equal(t, string(userData), bootstrap)
+	require.Equal(t, `#cloud-config
+write_files:
+  - path: /etc/test
+    owner: root:root
+    permissions: "0644"
+    content: |
+      a
+      b
+runcmd:
+  - "true"
+`, network)
+}
+
+func TestAppendCloudConfigPart_Multipart(t *testing.T) {
+	_, err := appendCloudConfigPart([]byte("Content-Type: multipart/mixed; boundary=\"foo\"\n\n--foo--\n"), cloudConfigPart{})
+	require.ErrorIs(t, err, ErrMultipartUserData)
+}
+
+func TestAppendCloudConfigPart_Twice(t *testing.T) {
+	userData := []byte("#cloud-config\nruncmd:\n  - kubeadm join\n")
+
+	got, err := AppendFile(userData, "/etc/kubernetes/manifests/test.yaml", "0644", []byte("kind: Pod\n"))
+	require.NoError(t, err)
+	got, err = appendCloudConfigPart(got, cloudConfigPart{RunCmd: []string{"true"}})
+	require.NoError(t, err)
+
+	parts, err := userDataParts(got)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	require.Equal(t, string(userData), string(parts[0].content))
+	require.Equal(t, `#cloud-config
+write_files:
+  - path: /etc/kubernetes/manifests/test.yaml
+    owner: root:root
+    permissions: "0644"
+    content: |
+      kind: Pod
+`, string(parts[1].content))
+	require.Equal(t, cloudConfigPartMergeType, parts[2].header.Get("Merge-Type"))
+	require.Equal(t, "#cloud-config\nruncmd:\n  - \"true\"\n", string(parts[2].content))
+}
+
+func TestAppendService(t *testing.T) {
+	userData := []byte("#cloud-config\nruncmd:\n  - kubeadm init\n")
+
+	got, err := AppendService(userData, "/etc/test.sh", "test.service", []byte("#!/bin/sh\ntrue\n"))
+	require.NoError(t, err)
+
+	parts, err := userDataParts(got)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	require.Equal(t, `#cloud-config
+write_files:
+  - path: /etc/test.sh
+    owner: root:root
+    permissions: "0700"
+    content: |
+      #!/bin/sh
+      true
+runcmd:
+  - systemd-run --unit test.service /etc/test.sh
+`, string(parts[1].content))
+}
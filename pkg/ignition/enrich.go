@@ -27,9 +27,24 @@ import (
 	ignitionTypes "github.com/flatcar/ignition/config/v2_3/types"
 	"github.com/pkg/errors"
 
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/kubevip"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
 )
 
+// adminKubeconfigUnit runs the script switching kube-vip back to admin.conf. It waits for
+// kubeadm in the background and does not delay the boot.
+const adminKubeconfigUnit = `[Unit]
+Description=Switch kube-vip to admin.conf after kubeadm init
+After=network-online.target
+
+[Service]
+Type=simple
+ExecStart=%s
+
+[Install]
+WantedBy=multi-user.target
+`
+
 // Enricher is responsible for enriching the Ignition config with additional data.
 type Enricher struct {
 	BootstrapData     []byte
@@ -38,6 +53,10 @@ type Enricher struct {
 	ProviderID        string
 	Network           []network.ConfigData
 	KubernetesVersion string
+
+	// KubeVIP adds a kube-vip static pod announcing the control plane endpoint
+	// on the interface carrying the default gateway of Network.
+	KubeVIP *kubevip.Manifest
 }
 
 // Enrich enriches the Ignition config with additional data.
@@ -108,13 +127,54 @@ func (e *Enricher) getEnrichConfig() (*ignitionTypes.Config, error) {
 		})
 	}
 
+	if e.KubeVIP != nil {
+		manifest, err := e.KubeVIP.Render(e.Network)
+		if err != nil {
+			return nil, errors.Wrap(err, "rendering kube-vip manifest")
+		}
+
+		ign.Storage.Files = append(ign.Storage.Files, ignitionTypes.File{
+			Node: ignitionTypes.Node{
+				Filesystem: "root",
+				Path:       kubevip.ManifestPath,
+				Overwrite:  new(true),
+			},
+			FileEmbedded1: ignitionTypes.FileEmbedded1{
+				Mode: new(0644),
+				Contents: ignitionTypes.FileContents{
+					Source: fmt.Sprintf("data:,%s", url.PathEscape(string(manifest))),
+				},
+			},
+		})
+
+		if e.KubeVIP.SuperAdminKubeconfig {
+			ign.Storage.Files = append(ign.Storage.Files, ignitionTypes.File{
+				Node: ignitionTypes.Node{
+					Filesystem: "root",
+					Path:       kubevip.AdminKubeconfigScriptPath,
+					Overwrite:  new(true),
+				},
+				FileEmbedded1: ignitionTypes.FileEmbedded1{
+					Mode: new(0700),
+					Contents: ignitionTypes.FileContents{
+						Source: fmt.Sprintf("data:,%s", url.PathEscape(string(kubevip.AdminKubeconfigScript()))),
+					},
+				},
+			})
+			ign.Systemd.Units = append(ign.Systemd.Units, ignitionTypes.Unit{
+				Name:     kubevip.AdminKubeconfigUnit,
+				Enable:   true,
+				Contents: fmt.Sprintf(adminKubeconfigUnit, kubevip.AdminKubeconfigScriptPath),
+			})
+		}
+	}
+
 	return ign, nil
 }
 
 func (e *Enricher) getProxmoxEnvContent() string {
 	var content strings.Builder
 	fmt.Fprintf(&content, "COREOS_CUSTOM_HOSTNAME=%s\nCOREOS_CUSTOM_INSTANCE_ID=%s\nCOREOS_CUSTOM_PROVIDER_ID=%s", e.Hostname, e.InstanceID, e.ProviderID)
-	// TODO: consider adding a kube-vip config field to NetworkConfigData
 	for _, network := range e.Network {
 		for _, ipconfig := range network.IPConfigs {
 			if ipconfig.IPAddress.Addr().Is4() && ipconfig.Default {
@@ -19,11 +19,17 @@ package ignition
 import (
 	"encoding/json"
 	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
 	"testing"
 
 	ignition "github.com/flatcar/ignition/config/v2_3"
+	ignitionTypes "github.com/flatcar/ignition/config/v2_3/types"
 	"github.com/stretchr/testify/require"
 
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/kubevip"
 	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
 )
 
@@ -128,3 +134,56 @@ func TestEnricher_Enrich(t *testing.T) {
 	_, _, err = e.Enrich()
 	require.Error(t, err, "parsing ignition Config")
 }
+
+func TestEnricher_Enrich_KubeVIP(t *testing.T) {
+	e := &Enricher{
+		BootstrapData: []byte(`{"ignition": {"version": "2.3.0"}}`),
+		Hostname:      "my-custom-vm",
+		Network: []network.ConfigData{{
+			Name:      "eth0",
+			IPConfigs: []network.IPConfig{{IPAddress: netip.MustParsePrefix("10.1.1.9/24"), Default: true}},
+			Routes: []network.RoutingData{{
+				To:  netip.MustParsePrefix("0.0.0.0/0"),
+				Via: netip.MustParseAddr("10.1.1.1"),
+			}},
+		}},
+		KubeVIP: &kubevip.Manifest{
+			Spec:     infrav1.KubeVIPSpec{Mode: infrav1.KubeVIPModeARP},
+			Endpoint: infrav1.APIEndpoint{Host: "10.1.1.100", Port: 6443},
+		},
+	}
+
+	userdata, _, err := e.Enrich()
+	require.NoError(t, err)
+
+	cfg, _, err := ignition.Parse(userdata)
+	require.NoError(t, err)
+
+	var manifest string
+	for _, file := range cfg.Storage.Files {
+		if file.Path == kubevip.ManifestPath {
+			manifest, err = url.PathUnescape(strings.TrimPrefix(file.Contents.Source, "data:,"))
+			require.NoError(t, err)
+		}
+	}
+	require.Contains(t, manifest, "value: eth0")
+	require.Contains(t, manifest, "value: 10.1.1.100")
+	require.Len(t, cfg.Systemd.Units, 1)
+
+	// kubeadm init switches kube-vip back to admin.conf once it succeeded.
+	e.KubeVIP.SuperAdminKubeconfig = true
+	userdata, _, err = e.Enrich()
+	require.NoError(t, err)
+	cfg, _, err = ignition.Parse(userdata)
+	require.NoError(t, err)
+	require.True(t, slices.ContainsFunc(cfg.Storage.Files, func(f ignitionTypes.File) bool {
+		return f.Path == kubevip.AdminKubeconfigScriptPath
+	}))
+	require.True(t, slices.ContainsFunc(cfg.Systemd.Units, func(u ignitionTypes.Unit) bool {
+		return u.Name == kubevip.AdminKubeconfigUnit && strings.Contains(u.Contents, "ExecStart="+kubevip.AdminKubeconfigScriptPath)
+	}))
+
+	e.KubeVIP.Endpoint = infrav1.APIEndpoint{}
+	_, _, err = e.Enrich()
+	require.ErrorIs(t, err, kubevip.ErrMissingEndpoint)
+}
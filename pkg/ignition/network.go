@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/netip"
+	"slices"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -80,11 +81,7 @@ Table={{ $route.Table }}
   {{- end }}
 {{- end }}
 
-{{- $element := . -}}
-{{- $type := $element.Type -}}
-{{- if eq $type "ethernet" -}}
-[Match]
-MACAddress={{ $element.MacAddress }}
+{{- define "network" }}
   {{- if .LinkMTU }}
 [Link]
 MTUBytes={{ .LinkMTU }}
@@ -93,15 +90,34 @@ MTUBytes={{ .LinkMTU }}
   {{- if .VRF }}
 VRF={{ .VRF }}
   {{- end }}
-  {{- if and $element.DHCP4 $element.DHCP6 }}
+  {{- if .Bond }}
+Bond={{ .Bond }}
+  {{- end }}
+  {{- if .Bridge }}
+Bridge={{ .Bridge }}
+  {{- end }}
+  {{- range .VLANs }}
+VLAN={{ . }}
+  {{- end }}
+  {{- if and .DHCP4 .DHCP6 }}
 DHCP=yes
-  {{- else if $element.DHCP4 }}
+  {{- else if .DHCP4 }}
 DHCP=ipv4
-  {{- else if $element.DHCP6 }}
+  {{- else if .DHCP6 }}
 DHCP=ipv6
+  {{- end }}
+  {{- if eq .IPv6Mode "disabled" }}
+LinkLocalAddressing=no
+  {{- end }}
+  {{- with .IPv6AcceptRA }}
+IPv6AcceptRA={{ . }}
   {{- end }}
   {{- template "dns" . }}
-  {{- range $ipconfig := $element.IPConfigs }}
+  {{- if .IPv6Token }}
+[IPv6AcceptRA]
+Token={{ .IPv6Token }}
+  {{- end }}
+  {{- range $ipconfig := .IPConfigs }}
     {{- if .IPAddress }}
 [Address]
 Address={{ (.IPAddress).String }}
@@ -109,6 +125,23 @@ Address={{ (.IPAddress).String }}
   {{- end }}
   {{- template "routes" . }}
   {{- template "rules" . }}
+{{- end }}
+
+{{- $element := . -}}
+{{- $type := $element.Type -}}
+{{- if eq $type "ethernet" -}}
+[Match]
+MACAddress={{ $element.MacAddress }}
+  {{- /* bonds, bridges and VLANs inherit the MAC address of their members. */}}
+  {{- if or .Bond .Bridge .VLANs }}
+Type=ether
+  {{- end }}
+  {{- template "network" . }}
+{{- end -}}
+{{- if or (eq $type "bond") (eq $type "bridge") (eq $type "vlan") -}}
+[Match]
+Name={{ $element.Name }}
+  {{- template "network" . }}
 {{- end -}}
 {{- if eq $type "vrf" -}}
 [Match]
@@ -119,13 +152,30 @@ Name={{ $element.Name }}
 `
 
 	netDevConfigTpl = `{{- $element := . -}}
-{{- if eq $element.Type "vrf" -}}
 [NetDev]
 Name={{ $element.Name }}
 Kind={{ $element.Type }}
+{{- if eq $element.Type "vrf" }}
 [VRF]
 Table={{ $element.Table }}
 {{- end }}
+{{- if eq $element.Type "bond" }}
+[Bond]
+  {{- if $element.BondMode }}
+Mode={{ $element.BondMode }}
+  {{- end }}
+  {{- if $element.BondMIIMon }}
+MIIMonitorSec={{ $element.BondMIIMon }}ms
+  {{- end }}
+{{- end }}
+{{- if eq $element.Type "vlan" }}
+[VLAN]
+Id={{ $element.VLANID }}
+{{- end }}
+{{- if and (eq $element.Type "bridge") $element.STP }}
+[Bridge]
+STP={{ $element.STP }}
+{{- end }}
 `
 )
 
@@ -174,20 +224,22 @@ func RenderNetworkConfigData(data []network.ConfigData) (map[string][]byte, erro
 
 	// adjust VRFs
 	adjustVrfs(data)
+	// adjust bonds, bridges and VLANs
+	adjustVirtualDevices(data)
 
-	// Add VRFs first so that they are created before the ethernet interfaces.
+	// Add virtual devices first so that they are created before the ethernet interfaces.
 	n := 0
 	for i, networkConfig := range data {
-		// the []data.NetworkConfigData have types ethernet and vrf
-		// we need to make sure to add vrf netdev first.
-		// and that's why we use n to keep track of the vrf index.
-		if networkConfig.Type == network.TypeVRF {
+		// the []data.NetworkConfigData have types ethernet, vrf, bond, bridge and vlan
+		// we need to make sure to add the virtual netdevs first.
+		// and that's why we use n to keep track of the netdev index.
+		if isVirtualDevice(networkConfig.Type) {
 			config, err := render(fmt.Sprintf("%d-%s", i, networkConfig.Type), netDevConfigTpl, networkConfig)
 			if err != nil {
 				return nil, err
 			}
 
-			name := fmt.Sprintf("%02d-vrf%d.netdev", n, n)
+			name := fmt.Sprintf("%02d-%s%d.netdev", n, networkConfig.Type, n)
 
 			n++
 			configs[name] = config
@@ -204,8 +256,8 @@ func RenderNetworkConfigData(data []network.ConfigData) (map[string][]byte, erro
 		switch {
 		case networkConfig.Type == network.TypeEthernet:
 			name = fmt.Sprintf("%02d-eth%d.network", i, i)
-		case networkConfig.Type == network.TypeVRF:
-			name = fmt.Sprintf("%02d-vrf%d.network", i, i)
+		case isVirtualDevice(networkConfig.Type):
+			name = fmt.Sprintf("%02d-%s%d.network", i, networkConfig.Type, i)
 		}
 
 		configs[name] = config
@@ -214,6 +266,15 @@ func RenderNetworkConfigData(data []network.ConfigData) (map[string][]byte, erro
 	return configs, nil
 }
 
+// isVirtualDevice reports whether a device type is backed by a netdev.
+func isVirtualDevice(t string) bool {
+	switch t {
+	case network.TypeVRF, network.TypeBond, network.TypeBridge, network.TypeVLAN:
+		return true
+	}
+	return false
+}
+
 func is6(addr string) bool {
 	return netip.MustParsePrefix(addr).Addr().Is6()
 }
@@ -253,3 +314,26 @@ func adjustVrfs(data []network.ConfigData) {
 		}
 	}
 }
+
+// adjustVirtualDevices adds the name of the controlling bond or bridge to each
+// member interface, and the names of its VLANs to each VLAN link.
+func adjustVirtualDevices(data []network.ConfigData) {
+	for i := range data {
+		for j := range data {
+			switch data[i].Type {
+			case network.TypeBond:
+				if slices.Contains(data[i].Children, data[j].Name) {
+					data[j].Bond = data[i].Name
+				}
+			case network.TypeBridge:
+				if slices.Contains(data[i].Children, data[j].Name) {
+					data[j].Bridge = data[i].Name
+				}
+			case network.TypeVLAN:
+				if data[i].Link == data[j].Name && !slices.Contains(data[j].VLANs, data[i].Name) {
+					data[j].VLANs = append(data[j].VLANs, data[i].Name)
+				}
+			}
+		}
+	}
+}
@@ -99,6 +99,28 @@ Metric=100
 `,
 	}
 
+	expectedValidNetworkdConfigIPv6Modes = map[string]string{
+		"00-eth0.network": `[Match]
+MACAddress=E2:B8:FE:E7:50:75
+[Network]
+DHCP=ipv4
+IPv6AcceptRA=true
+DNS=10.0.1.1
+[IPv6AcceptRA]
+Token=::10
+[Address]
+Address=2001:db8:1::10/64
+`,
+		"01-eth1.network": `[Match]
+MACAddress=E2:8E:95:1F:EB:36
+[Network]
+LinkLocalAddressing=no
+IPv6AcceptRA=false
+[Address]
+Address=10.0.1.84/25
+`,
+	}
+
 	expectedValidNetworkConfigWithVRFPolicies = map[string]string{
 		"00-vrf0.netdev": `[NetDev]
 Name=vrf0
@@ -148,6 +170,65 @@ To=8.7.6.5/32
 From=1.1.1.1/32
 Priority=100
 Table=644
+`,
+	}
+
+	expectedValidNetworkConfigBondVLANBridge = map[string]string{
+		"00-bond0.netdev": `[NetDev]
+Name=bond0
+Kind=bond
+[Bond]
+Mode=802.3ad
+MIIMonitorSec=100ms
+`,
+		"01-vlan1.netdev": `[NetDev]
+Name=vlan100
+Kind=vlan
+[VLAN]
+Id=100
+`,
+		"02-bridge2.netdev": `[NetDev]
+Name=br0
+Kind=bridge
+[Bridge]
+STP=true
+`,
+		"00-eth0.network": `[Match]
+MACAddress=E2:B8:FE:E7:50:75
+Type=ether
+[Network]
+Bond=bond0
+`,
+		"01-eth1.network": `[Match]
+MACAddress=E2:8E:95:1F:EB:36
+Type=ether
+[Network]
+Bond=bond0
+`,
+		"02-bond2.network": `[Match]
+Name=bond0
+[Network]
+VLAN=vlan100
+DNS=10.0.1.1
+[Address]
+Address=10.0.0.98/25
+[Route]
+Destination=0.0.0.0/0
+Gateway=10.0.0.1
+Metric=100
+`,
+		"03-vlan3.network": `[Match]
+Name=vlan100
+[Network]
+Bridge=br0
+`,
+		"04-bridge4.network": `[Match]
+Name=br0
+[Link]
+MTUBytes=9000
+[Network]
+[Address]
+Address=10.0.1.84/25
 `,
 	}
 )
@@ -277,6 +358,40 @@ func TestRenderNetworkConfigData(t *testing.T) {
 				err:   nil,
 			},
 		},
+		"ValidNetworkdConfigIPv6Modes": {
+			reason: "render valid networkd with static and slaac addresses, and ipv6 disabled",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       "ethernet",
+						Name:       "eth0",
+						MacAddress: "E2:B8:FE:E7:50:75",
+						DHCP4:      true,
+						IPv6Mode:   infrav1.IPv6ModeStaticSLAAC,
+						IPv6Token:  "::10",
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("2001:db8:1::10/64")},
+						},
+						ProxName:   infrav1.DefaultNetworkDevice,
+						DNSServers: []string{"10.0.1.1"},
+					},
+					{
+						Type:       "ethernet",
+						Name:       "eth1",
+						MacAddress: "E2:8E:95:1F:EB:36",
+						IPv6Mode:   infrav1.IPv6ModeDisabled,
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("10.0.1.84/25")},
+						},
+						ProxName: "net1",
+					},
+				},
+			},
+			want: want{
+				units: expectedValidNetworkdConfigIPv6Modes,
+				err:   nil,
+			},
+		},
 		"ValidNetworkdConfigWithVRFPolicies": {
 			reason: "render valid networkd with static ip and VRF and policies",
 			args: args{
@@ -341,6 +456,61 @@ func TestRenderNetworkConfigData(t *testing.T) {
 				err:   nil,
 			},
 		},
+		"ValidNetworkdConfigBondVLANBridge": {
+			reason: "render valid networkd with a vlan on a bond attached to a bridge",
+			args: args{
+				nics: []network.ConfigData{
+					{
+						Type:       "ethernet",
+						Name:       "eth0",
+						MacAddress: "E2:B8:FE:E7:50:75",
+						ProxName:   infrav1.DefaultNetworkDevice,
+					},
+					{
+						Type:       "ethernet",
+						Name:       "eth1",
+						MacAddress: "E2:8E:95:1F:EB:36",
+						ProxName:   "net1",
+					},
+					{
+						Type:       "bond",
+						Name:       "bond0",
+						BondMode:   "802.3ad",
+						BondMIIMon: new(int32(100)),
+						Children:   []string{"eth0", "eth1"},
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("10.0.0.98/25")},
+						},
+						DNSServers: []string{"10.0.1.1"},
+						Routes: []network.RoutingData{{
+							To:     netip.MustParsePrefix("0.0.0.0/0"),
+							Via:    netip.MustParseAddr("10.0.0.1"),
+							Metric: new(int32(100)),
+						}},
+					},
+					{
+						Type:   "vlan",
+						Name:   "vlan100",
+						VLANID: new(int32(100)),
+						Link:   "bond0",
+					},
+					{
+						Type:     "bridge",
+						Name:     "br0",
+						STP:      new(true),
+						Children: []string{"vlan100"},
+						LinkMTU:  new(int32(9000)),
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("10.0.1.84/25")},
+						},
+					},
+				},
+			},
+			want: want{
+				units: expectedValidNetworkConfigBondVLANBridge,
+				err:   nil,
+			},
+		},
 	}
 
 	for n, tc := range cases {
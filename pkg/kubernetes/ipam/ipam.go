@@ -31,7 +31,9 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -71,6 +73,51 @@ func InClusterPoolFormat(cluster *infrav1.ProxmoxCluster, zone infrav1.Zone, for
 	return fmt.Sprintf("%s-%s-icip", cluster.GetName(), format)
 }
 
+// SDNPoolFormat returns the name of the `InClusterIPPool` for a subnet of a SDN VNet.
+func SDNPoolFormat(cluster *infrav1.ProxmoxCluster, vnet, format string) string {
+	return fmt.Sprintf("%s-sdn-%s-%s-icip", cluster.GetName(), vnet, format)
+}
+
+// SDNPoolRefs returns the references to the `InClusterIPPool`s of a SDN VNet owned by the cluster.
+// A reference is nil if the VNet has no subnet of that IP family.
+func SDNPoolRefs(cluster *infrav1.ProxmoxCluster, vnet string) (ipv4, ipv6 *corev1.TypedLocalObjectReference) {
+	if cluster.Spec.SDN == nil {
+		return nil, nil
+	}
+
+	index := slices.IndexFunc(cluster.Spec.SDN.VNets, func(v infrav1.SDNVNetSpec) bool {
+		return v.Name == vnet
+	})
+	if index == -1 {
+		return nil, nil
+	}
+
+	for _, subnet := range cluster.Spec.SDN.VNets[index].Subnets {
+		prefix, err := netip.ParsePrefix(subnet.CIDR)
+		if err != nil {
+			continue
+		}
+
+		format := infrav1.IPv4Format
+		if !prefix.Addr().Is4() {
+			format = infrav1.IPv6Format
+		}
+		ref := &corev1.TypedLocalObjectReference{
+			APIGroup: GetIPAMInClusterAPIGroup(),
+			Kind:     GetInClusterIPPoolKind(),
+			Name:     SDNPoolFormat(cluster, vnet, format),
+		}
+
+		if prefix.Addr().Is4() {
+			ipv4 = ref
+		} else {
+			ipv6 = ref
+		}
+	}
+
+	return ipv4, ipv6
+}
+
 // IPAddressFormat returns an ipaddress name.
 func IPAddressFormat(machineName string, proxDeviceName infrav1.NetName, offset int, suffix string) string {
 	return fmt.Sprintf("%s-%s-%02d-%s", machineName, proxDeviceName, offset, suffix)
@@ -123,32 +170,11 @@ func (h *Helper) poolFromObjectRef(ctx context.Context, o any, namespace *string
 		return nil, fmt.Errorf("invalid Type: %s", t)
 	}
 
-	key := client.ObjectKey{Name: ref.Name}
-
-	var ret client.Object
-	var err error
-	switch ref.Kind {
-	case GetInClusterIPPoolKind():
-		key.Namespace = h.cluster.GetNamespace()
-
-		pool := new(ipamicv1.InClusterIPPool)
-		err = h.ctrlClient.Get(ctx, key, pool)
-
-		ret = pool
-	case GetGlobalInClusterIPPoolKind():
-		pool := new(ipamicv1.GlobalInClusterIPPool)
-		err = h.ctrlClient.Get(ctx, key, pool)
-
-		ret = pool
-	default:
-		return nil, errors.Errorf("unsupported pool type %s", ref.Kind)
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	return ret, nil
+	return h.GetIPPool(ctx, corev1.TypedLocalObjectReference{
+		APIGroup: ref.APIGroup,
+		Kind:     ref.Kind,
+		Name:     ref.Name,
+	})
 }
 
 // GetInClusterPools returns the IPPools belonging to the ProxmoxCluster relative to its Zone.
@@ -276,71 +302,53 @@ func (h *Helper) CreateOrUpdateInClusterIPPool(ctx context.Context) error {
 				family = infrav1.IPv6Type
 			}
 
-			pool := &ipamicv1.InClusterIPPool{
-				TypeMeta: metav1.TypeMeta{
-					APIVersion: ipamicv1.GroupVersion.String(),
-					// Thank you ipamic for making InClusterIPPoolKind private
-					Kind: GetInClusterIPPoolKind(),
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      InClusterPoolFormat(h.cluster, zoneSpec.Zone, format),
-					Namespace: h.cluster.GetNamespace(),
-					Annotations: func() map[string]string {
-						metric := ""
-						if ptr.Deref(poolSpec.Metric, -1) >= 0 {
-							metric = fmt.Sprintf("%d", *poolSpec.Metric)
-						}
-						annotations := map[string]string{
-							infrav1.ProxmoxIPFamilyAnnotation:      family,
-							infrav1.ProxmoxGatewayMetricAnnotation: metric,
-						}
-
-						// Field deprecated by prefixed value. We need to retag all
-						// annotations before we can remove this.
-						if poolSpec.Metric != nil {
-							annotations["metric"] = metric
-						}
-						return annotations
-					}(),
-					Labels: func() map[string]string {
-						if zoneSpec.Zone != nil {
-							return map[string]string{infrav1.ProxmoxZoneLabel: *zoneSpec.Zone}
-						}
-						return map[string]string{infrav1.ProxmoxZoneLabel: "default"}
-					}(),
-				},
-				Spec: ipamicv1.InClusterIPPoolSpec{
-					Addresses: poolSpec.Addresses,
-					Prefix:    int(poolSpec.Prefix),
-					Gateway:   poolSpec.Gateway,
-					// TODO(v0.9): decide whether to expose this as a knob or change the default.
-					// 60s covers typical ARP cache expiry on switches during node replacement.
-					AddressReuseGracePeriodSeconds: new(int32(60)),
-				},
+			labels := map[string]string{infrav1.ProxmoxZoneLabel: "default"}
+			if zoneSpec.Zone != nil {
+				labels[infrav1.ProxmoxZoneLabel] = *zoneSpec.Zone
 			}
 
-			desired := pool.DeepCopy()
-			_, err = controllerutil.CreateOrUpdate(ctx, h.ctrlClient, pool, func() error {
-				pool.Spec = desired.Spec
+			err = h.createOrUpdatePool(ctx, InClusterPoolFormat(h.cluster, zoneSpec.Zone, format), family, labels, poolSpec)
+			if err != nil {
+				return err
+			}
+		}
+	}
 
-				if pool.ObjectMeta.Annotations == nil && desired.ObjectMeta.Annotations != nil {
-					pool.ObjectMeta.Annotations = make(map[string]string)
-				}
-				if desired.ObjectMeta.Annotations != nil {
-					pool.ObjectMeta.Annotations["metric"] = desired.ObjectMeta.Annotations["metric"]
-					pool.ObjectMeta.Annotations[infrav1.ProxmoxGatewayMetricAnnotation] =
-						desired.ObjectMeta.Annotations[infrav1.ProxmoxGatewayMetricAnnotation]
-					// IPFamily of a pool should be immutable, but nothing in ipamic
-					// protects a pool from it.
-					pool.ObjectMeta.Annotations[infrav1.ProxmoxIPFamilyAnnotation] =
-						desired.ObjectMeta.Annotations[infrav1.ProxmoxIPFamilyAnnotation]
-				}
-				// Deleting annotations no longer happens because we need to store ip family
+	return h.createOrUpdateSDNPools(ctx)
+}
 
-				// Never update label "node.kubernetes.io/proxmox-zone". It's supposed to be immutable.
+// createOrUpdateSDNPools creates or updates an `InClusterIPPool` for every subnet of the SDN VNets
+// owned by the cluster.
+func (h *Helper) createOrUpdateSDNPools(ctx context.Context) error {
+	if h.cluster.Spec.SDN == nil {
+		return nil
+	}
+
+	for _, vnet := range h.cluster.Spec.SDN.VNets {
+		for _, subnet := range vnet.Subnets {
+			prefix, err := netip.ParsePrefix(subnet.CIDR)
+			if err != nil {
+				return errors.Wrapf(err, "invalid cidr of sdn vnet %s", vnet.Name)
+			}
+
+			format := infrav1.IPv4Format
+			family := infrav1.IPv4Type
+			if !prefix.Addr().Is4() {
+				format = infrav1.IPv6Format
+				family = infrav1.IPv6Type
+			}
+
+			addresses := subnet.Addresses
+			if len(addresses) == 0 {
+				addresses = []string{subnet.CIDR}
+			}
 
-				// set the owner reference to the cluster
-				return controllerutil.SetControllerReference(h.cluster, pool, h.ctrlClient.Scheme())
+			// SDN pools are not bound to a deployment zone, thus no zone label.
+			err = h.createOrUpdatePool(ctx, SDNPoolFormat(h.cluster, vnet.Name, format), family, nil, &infrav1.IPConfigSpec{
+				Addresses: addresses,
+				Prefix:    int32(prefix.Bits()),
+				Gateway:   subnet.Gateway,
+				Metric:    subnet.Metric,
 			})
 			if err != nil {
 				return err
@@ -351,6 +359,80 @@ func (h *Helper) CreateOrUpdateInClusterIPPool(ctx context.Context) error {
 	return nil
 }
 
+func (h *Helper) createOrUpdatePool(ctx context.Context, name, family string, labels map[string]string, poolSpec *infrav1.IPConfigSpec) error {
+	pool := &ipamicv1.InClusterIPPool{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ipamicv1.GroupVersion.String(),
+			// Thank you ipamic for making InClusterIPPoolKind private
+			Kind: GetInClusterIPPoolKind(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: h.cluster.GetNamespace(),
+			Annotations: func() map[string]string {
+				metric := ""
+				if ptr.Deref(poolSpec.Metric, -1) >= 0 {
+					metric = fmt.Sprintf("%d", *poolSpec.Metric)
+				}
+				annotations := map[string]string{
+					infrav1.ProxmoxIPFamilyAnnotation:      family,
+					infrav1.ProxmoxGatewayMetricAnnotation: metric,
+				}
+
+				// Field deprecated by prefixed value. We need to retag all
+				// annotations before we can remove this.
+				if poolSpec.Metric != nil {
+					annotations["metric"] = metric
+				}
+				return annotations
+			}(),
+			Labels: labels,
+		},
+		Spec: ipamicv1.InClusterIPPoolSpec{
+			Addresses: poolSpec.Addresses,
+			Prefix:    int(poolSpec.Prefix),
+			Gateway:   poolSpec.Gateway,
+			// TODO(v0.9): decide whether to expose this as a knob or change the default.
+			// 60s covers typical ARP cache expiry on switches during node replacement.
+			AddressReuseGracePeriodSeconds: new(int32(60)),
+		},
+	}
+
+	desired := pool.DeepCopy()
+	_, err := controllerutil.CreateOrUpdate(ctx, h.ctrlClient, pool, func() error {
+		pool.Spec = desired.Spec
+
+		if pool.ObjectMeta.Annotations == nil && desired.ObjectMeta.Annotations != nil {
+			pool.ObjectMeta.Annotations = make(map[string]string)
+		}
+		if desired.ObjectMeta.Annotations != nil {
+			pool.ObjectMeta.Annotations["metric"] = desired.ObjectMeta.Annotations["metric"]
+			pool.ObjectMeta.Annotations[infrav1.ProxmoxGatewayMetricAnnotation] =
+				desired.ObjectMeta.Annotations[infrav1.ProxmoxGatewayMetricAnnotation]
+			// IPFamily of a pool should be immutable, but nothing in ipamic
+			// protects a pool from it.
+			pool.ObjectMeta.Annotations[infrav1.ProxmoxIPFamilyAnnotation] =
+				desired.ObjectMeta.Annotations[infrav1.ProxmoxIPFamilyAnnotation]
+		}
+		// Deleting annotations no longer happens because we need to store ip family
+
+		// Never update label "node.kubernetes.io/proxmox-zone". It's supposed to be immutable.
+
+		// The cluster name label makes the pool part of the cluster for clusterctl move.
+		if clusterName, ok := h.cluster.GetLabels()[clusterv1.ClusterNameLabel]; ok {
+			if pool.ObjectMeta.Labels == nil {
+				pool.ObjectMeta.Labels = make(map[string]string)
+			}
+			pool.ObjectMeta.Labels[clusterv1.ClusterNameLabel] = clusterName
+		}
+
+		// set the owner reference to the cluster
+		return controllerutil.SetControllerReference(h.cluster, pool, h.ctrlClient.Scheme())
+	})
+
+	return err
+}
+
 // GetDefaultInClusterIPPool attempts to retrieve the `InClusterIPPool`
 // which is managed by the cluster.
 func (h *Helper) GetDefaultInClusterIPPool(ctx context.Context, format string) (*ipamicv1.InClusterIPPool, error) {
@@ -361,7 +443,8 @@ func (h *Helper) GetDefaultInClusterIPPool(ctx context.Context, format string) (
 	})
 }
 
-// GetIPPool attempts to retrieve a pool from a reference.
+// GetIPPool attempts to retrieve a pool from a reference. Pools of the in-cluster IPAM provider
+// are returned typed, pools of other IPAM providers as unstructured object.
 func (h *Helper) GetIPPool(ctx context.Context, ref corev1.TypedLocalObjectReference) (client.Object, error) {
 	var ret client.Object
 	var err error
@@ -381,7 +464,7 @@ func (h *Helper) GetIPPool(ctx context.Context, ref corev1.TypedLocalObjectRefer
 
 		ret = pool
 	default:
-		return nil, errors.Errorf("unsupported pool type %s", ref.Kind)
+		return h.getProviderIPPool(ctx, ref)
 	}
 
 	if err != nil {
@@ -391,6 +474,34 @@ func (h *Helper) GetIPPool(ctx context.Context, ref corev1.TypedLocalObjectRefer
 	return ret, nil
 }
 
+// getProviderIPPool retrieves a pool of an IPAM provider implementing the Cluster API IPAM contract,
+// e.g. NetBox or Infoblox. The pool is read as unstructured object, its version and scope are looked
+// up with the REST mapper. Namespaced pools are expected in the namespace of the cluster.
+func (h *Helper) getProviderIPPool(ctx context.Context, ref corev1.TypedLocalObjectReference) (client.Object, error) {
+	group := normalizedAPIGroup(ptr.Deref(ref.APIGroup, ""))
+	if group == "" {
+		return nil, errors.Errorf("unsupported pool type %s without apiGroup", ref.Kind)
+	}
+
+	mapping, err := h.ctrlClient.RESTMapper().RESTMapping(schema.GroupKind{Group: group, Kind: ref.Kind})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unsupported pool type %s", ref.Kind)
+	}
+
+	key := client.ObjectKey{Name: ref.Name}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		key.Namespace = h.cluster.GetNamespace()
+	}
+
+	pool := new(unstructured.Unstructured)
+	pool.SetGroupVersionKind(mapping.GroupVersionKind)
+	if err := h.ctrlClient.Get(ctx, key, pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
 // GetInClusterIPPool attempts to retrieve the referenced `InClusterIPPool`.
 func (h *Helper) GetInClusterIPPool(ctx context.Context, ref corev1.TypedLocalObjectReference) (*ipamicv1.InClusterIPPool, error) {
 	out, err := h.GetIPPool(ctx, ref)
@@ -571,12 +682,20 @@ func (h *Helper) ResolveIPAddressClaim(ctx context.Context, moxm *infrav1.Proxmo
 
 // CreateIPAddressClaim creates an IPAddressClaim for a given object.
 func (h *Helper) CreateIPAddressClaim(ctx context.Context, owner client.Object, ipClaimRef IPClaimDef) error {
+	claimName, err := ipClaimName(owner, ipClaimRef)
+	if err != nil {
+		return err
+	}
+
+	return h.createIPAddressClaim(ctx, owner, claimName, ipClaimRef.PoolRef, ipClaimRef.Annotations)
+}
+
+// createIPAddressClaim creates an IPAddressClaim with the given name, which is controlled by owner.
+func (h *Helper) createIPAddressClaim(ctx context.Context, owner client.Object, claimName string, ref corev1.TypedLocalObjectReference, annotations map[string]string) error {
 	key := client.ObjectKey{
 		Namespace: owner.GetNamespace(),
 		Name:      owner.GetName(),
 	}
-	ref := ipClaimRef.PoolRef
-	annotations := ipClaimRef.Annotations
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
@@ -615,11 +734,6 @@ func (h *Helper) CreateIPAddressClaim(ctx context.Context, owner client.Object,
 		labels[infrav1.ProxmoxZoneLabel] = key
 	}
 
-	claimName, err := ipClaimName(owner, ipClaimRef)
-	if err != nil {
-		return err
-	}
-
 	desired := &ipamv1.IPAddressClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        claimName,
@@ -643,6 +757,71 @@ func (h *Helper) CreateIPAddressClaim(ctx context.Context, owner client.Object,
 	return err
 }
 
+// ControlPlaneEndpointClaimName returns the name of the IPAddressClaim of the control plane endpoint of a cluster.
+func ControlPlaneEndpointClaimName(cluster *infrav1.ProxmoxCluster) string {
+	return fmt.Sprintf("%s-control-plane-endpoint", cluster.GetName())
+}
+
+// ControlPlaneEndpointPoolRef returns the reference to the pool the control plane endpoint of a cluster
+// is claimed from: the configured pool, otherwise the IPv4 in-cluster pool of the cluster, or its
+// IPv6 in-cluster pool if the cluster has no IPv4 config.
+func ControlPlaneEndpointPoolRef(cluster *infrav1.ProxmoxCluster) corev1.TypedLocalObjectReference {
+	if spec := cluster.Spec.ControlPlaneEndpointIPAM; spec != nil && spec.PoolRef != nil {
+		return *spec.PoolRef
+	}
+
+	format := infrav1.IPv4Format
+	if cluster.Spec.IPv4Config == nil {
+		format = infrav1.IPv6Format
+	}
+	return corev1.TypedLocalObjectReference{
+		APIGroup: GetIPAMInClusterAPIGroup(),
+		Kind:     GetInClusterIPPoolKind(),
+		Name:     InClusterPoolFormat(cluster, nil, format),
+	}
+}
+
+// ClaimControlPlaneEndpoint makes sure the IPAddressClaim of the control plane endpoint exists
+// and returns the claimed IPAddress, or nil while the claim is pending.
+func (h *Helper) ClaimControlPlaneEndpoint(ctx context.Context) (*ipamv1.IPAddress, error) {
+	poolRef := ControlPlaneEndpointPoolRef(h.cluster)
+	key := client.ObjectKey{Name: ControlPlaneEndpointClaimName(h.cluster), Namespace: h.cluster.GetNamespace()}
+
+	claim := &ipamv1.IPAddressClaim{}
+	if err := h.ctrlClient.Get(ctx, key, claim); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, h.createIPAddressClaim(ctx, h.cluster, key.Name, poolRef, nil)
+	}
+
+	if !metav1.IsControlledBy(claim, h.cluster) {
+		return nil, errors.Errorf("IPAddressClaim %s is not owned by the cluster", key.Name)
+	}
+	if !matchesClaimPoolRef(*claim, poolRef) {
+		return nil, errors.Errorf("IPAddressClaim %s does not reference pool %s", key.Name, poolRef.Name)
+	}
+	if claim.Status.AddressRef.Name == "" {
+		return nil, nil
+	}
+
+	return h.GetIPAddress(ctx, client.ObjectKey{Name: claim.Status.AddressRef.Name, Namespace: claim.Namespace})
+}
+
+// ReleaseControlPlaneEndpoint deletes the IPAddressClaim of the control plane endpoint, if the cluster owns one.
+func (h *Helper) ReleaseControlPlaneEndpoint(ctx context.Context) error {
+	claim := &ipamv1.IPAddressClaim{}
+	key := client.ObjectKey{Name: ControlPlaneEndpointClaimName(h.cluster), Namespace: h.cluster.GetNamespace()}
+	if err := h.ctrlClient.Get(ctx, key, claim); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !metav1.IsControlledBy(claim, h.cluster) {
+		return nil
+	}
+	return client.IgnoreNotFound(h.ctrlClient.Delete(ctx, claim))
+}
+
 // GetIPAddress attempts to retrieve the IPAddress.
 func (h *Helper) GetIPAddress(ctx context.Context, key client.ObjectKey) (*ipamv1.IPAddress, error) {
 	out := &ipamv1.IPAddress{}
@@ -25,7 +25,10 @@ import (
 	"github.com/stretchr/testify/suite"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	ipamicv1 "sigs.k8s.io/cluster-api-ipam-provider-in-cluster/api/v1alpha2"
@@ -184,6 +187,70 @@ func (s *IPAMTestSuite) Test_CreateOrUpdateInClusterIPPool_AddressReuseGracePeri
 	s.EqualValues(60, *poolV6.Spec.AddressReuseGracePeriodSeconds)
 }
 
+// Test_CreateOrUpdateInClusterIPPool_ClusterNameLabel verifies that the pools carry
+// the cluster name label of the ProxmoxCluster, so they are moved with the cluster.
+func (s *IPAMTestSuite) Test_CreateOrUpdateInClusterIPPool_ClusterNameLabel() {
+	s.cluster.SetLabels(map[string]string{clusterv1.ClusterNameLabel: "test-cluster"})
+
+	s.NoError(s.helper.CreateOrUpdateInClusterIPPool(s.ctx))
+
+	var pool ipamicv1.InClusterIPPool
+	s.NoError(s.cl.Get(s.ctx, types.NamespacedName{
+		Namespace: "test",
+		Name:      "test-cluster-v4-icip",
+	}, &pool))
+	s.Equal("test-cluster", pool.GetLabels()[clusterv1.ClusterNameLabel])
+	s.Equal("default", pool.GetLabels()[infrav1.ProxmoxZoneLabel])
+}
+
+func (s *IPAMTestSuite) Test_CreateOrUpdateInClusterIPPool_SDN() {
+	s.cluster.Spec.SDN = &infrav1.SDNSpec{
+		Zone: infrav1.SDNZoneSpec{Name: "capmox"},
+		VNets: []infrav1.SDNVNetSpec{{
+			Name: "vnet0",
+			Subnets: []infrav1.SDNSubnetSpec{{
+				CIDR:    "10.20.0.0/24",
+				Gateway: "10.20.0.1",
+				Metric:  new(int32(200)),
+			}, {
+				CIDR:      "2001:db8:1::/64",
+				Gateway:   "2001:db8:1::1",
+				Addresses: []string{"2001:db8:1::10-2001:db8:1::20"},
+			}},
+		}},
+	}
+
+	s.NoError(s.helper.CreateOrUpdateInClusterIPPool(s.ctx))
+
+	var poolV4 ipamicv1.InClusterIPPool
+	s.NoError(s.cl.Get(s.ctx, types.NamespacedName{
+		Namespace: "test",
+		Name:      "test-cluster-sdn-vnet0-v4-icip",
+	}, &poolV4))
+	s.Equal([]string{"10.20.0.0/24"}, poolV4.Spec.Addresses)
+	s.Equal(24, poolV4.Spec.Prefix)
+	s.Equal("10.20.0.1", poolV4.Spec.Gateway)
+	s.Equal("200", poolV4.ObjectMeta.Annotations[infrav1.ProxmoxGatewayMetricAnnotation])
+	s.NotContains(poolV4.ObjectMeta.Labels, infrav1.ProxmoxZoneLabel)
+
+	var poolV6 ipamicv1.InClusterIPPool
+	s.NoError(s.cl.Get(s.ctx, types.NamespacedName{
+		Namespace: "test",
+		Name:      "test-cluster-sdn-vnet0-v6-icip",
+	}, &poolV6))
+	s.Equal([]string{"2001:db8:1::10-2001:db8:1::20"}, poolV6.Spec.Addresses)
+	s.Equal(64, poolV6.Spec.Prefix)
+	s.Equal(infrav1.IPv6Type, poolV6.ObjectMeta.Annotations[infrav1.ProxmoxIPFamilyAnnotation])
+
+	ipv4, ipv6 := SDNPoolRefs(s.cluster, "vnet0")
+	s.Equal("test-cluster-sdn-vnet0-v4-icip", ipv4.Name)
+	s.Equal("test-cluster-sdn-vnet0-v6-icip", ipv6.Name)
+
+	ipv4, ipv6 = SDNPoolRefs(s.cluster, "unknown")
+	s.Nil(ipv4)
+	s.Nil(ipv6)
+}
+
 func (s *IPAMTestSuite) Test_GetDefaultInClusterIPPool() {
 	notFound, err := s.helper.GetDefaultInClusterIPPool(s.ctx, infrav1.IPv4Format)
 	s.Nil(notFound)
@@ -286,6 +353,66 @@ func (s *IPAMTestSuite) Test_GetGlobalInClusterIPPool() {
 	s.Equal(&pool, found)
 }
 
+func (s *IPAMTestSuite) Test_GetProviderIPPool() {
+	gvk := schema.GroupVersionKind{Group: "ipam.example.com", Version: "v1alpha1", Kind: "ExampleIPPool"}
+
+	// the fake client maps all types of its scheme, the pool kind is added by the provider CRD.
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for known := range s.cl.Scheme().AllKnownTypes() {
+		mapper.Add(known, meta.RESTScopeNamespace)
+	}
+	mapper.Add(gvk, meta.RESTScopeNamespace)
+
+	pool := new(unstructured.Unstructured)
+	pool.SetGroupVersionKind(gvk)
+	pool.SetName("example-pool")
+	pool.SetNamespace("test")
+	pool.SetAnnotations(map[string]string{infrav1.ProxmoxGatewayMetricAnnotation: "100"})
+
+	cl := fake.NewClientBuilder().
+		WithScheme(s.cl.Scheme()).
+		WithRESTMapper(mapper).
+		WithObjects(s.cluster, s.capiCluster, pool).
+		Build()
+	helper := NewHelper(cl, s.cluster)
+
+	poolRef := corev1.TypedLocalObjectReference{
+		APIGroup: new("ipam.example.com"),
+		Kind:     "ExampleIPPool",
+		Name:     "example-pool",
+	}
+
+	found, err := helper.GetIPPool(s.ctx, poolRef)
+	s.NoError(err)
+	s.Equal("example-pool", found.GetName())
+	s.Equal("100", found.GetAnnotations()[infrav1.ProxmoxGatewayMetricAnnotation])
+
+	_, err = helper.GetIPPool(s.ctx, corev1.TypedLocalObjectReference{Kind: "ExampleIPPool", Name: "example-pool"})
+	s.ErrorContains(err, "unsupported pool type ExampleIPPool without apiGroup")
+
+	_, err = helper.GetIPPool(s.ctx, corev1.TypedLocalObjectReference{APIGroup: new("ipam.example.com"), Kind: "UnknownIPPool", Name: "example-pool"})
+	s.ErrorContains(err, "unsupported pool type UnknownIPPool")
+
+	// claims reference the pool of the provider.
+	s.NoError(helper.CreateIPAddressClaim(s.ctx, getCluster(), IPClaimDef{
+		Device:  infrav1.DefaultNetworkDevice,
+		PoolRef: poolRef,
+	}))
+
+	var claim ipamv1.IPAddressClaim
+	s.NoError(cl.Get(s.ctx, types.NamespacedName{
+		Namespace: "test",
+		Name:      IPAddressFormat(getCluster().GetName(), infrav1.DefaultNetworkDevice, 0, infrav1.DefaultSuffix),
+	}, &claim))
+	s.Equal(ipamv1.IPPoolReference{APIGroup: "ipam.example.com", Kind: "ExampleIPPool", Name: "example-pool"}, claim.Spec.PoolRef)
+
+	annotations, err := helper.GetIPPoolAnnotations(s.ctx, &ipamv1.IPAddress{
+		Spec: ipamv1.IPAddressSpec{PoolRef: claim.Spec.PoolRef},
+	})
+	s.NoError(err)
+	s.Equal("100", annotations[infrav1.ProxmoxGatewayMetricAnnotation])
+}
+
 func (s *IPAMTestSuite) Test_GetIPPoolAnnotations() {
 	s.NoError(s.helper.CreateOrUpdateInClusterIPPool(s.ctx))
 
@@ -522,6 +649,83 @@ func (s *IPAMTestSuite) Test_CreateIPAddressClaimv2() {
 	s.NoError(err)
 }
 
+func (s *IPAMTestSuite) Test_ClaimControlPlaneEndpoint() {
+	s.NoError(s.helper.CreateOrUpdateInClusterIPPool(s.ctx))
+	s.cluster.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMSpec{}
+
+	address, err := s.helper.ClaimControlPlaneEndpoint(s.ctx)
+	s.NoError(err)
+	s.Nil(address)
+
+	var claim ipamv1.IPAddressClaim
+	nn := types.NamespacedName{Name: "test-cluster-control-plane-endpoint", Namespace: "test"}
+	s.NoError(s.cl.Get(s.ctx, nn, &claim))
+	s.Equal("test-cluster-v4-icip", claim.Spec.PoolRef.Name)
+	s.Equal(GetInClusterIPPoolKind(), claim.Spec.PoolRef.Kind)
+	s.Equal("test-cluster", claim.Labels[clusterv1.ClusterNameLabel])
+	s.True(metav1.IsControlledBy(&claim, s.cluster))
+
+	// pending until the claim is fulfilled
+	address, err = s.helper.ClaimControlPlaneEndpoint(s.ctx)
+	s.NoError(err)
+	s.Nil(address)
+
+	s.NoError(s.cl.Create(s.ctx, s.testIPAddress("test", "endpoint-address", "test-cluster-v4-icip")))
+	claim.Status.AddressRef.Name = "endpoint-address"
+	s.NoError(s.cl.Update(s.ctx, &claim))
+
+	address, err = s.helper.ClaimControlPlaneEndpoint(s.ctx)
+	s.NoError(err)
+	s.NotNil(address)
+	s.Equal("192.0.2.1", address.Spec.Address)
+
+	s.NoError(s.helper.ReleaseControlPlaneEndpoint(s.ctx))
+	s.True(apierrors.IsNotFound(s.cl.Get(s.ctx, nn, &claim)))
+
+	// releasing twice is a no-op
+	s.NoError(s.helper.ReleaseControlPlaneEndpoint(s.ctx))
+}
+
+func (s *IPAMTestSuite) Test_ClaimControlPlaneEndpointNotOwned() {
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster-control-plane-endpoint",
+			Namespace: "test",
+		},
+		Spec: ipamv1.IPAddressClaimSpec{
+			PoolRef: ipamv1.IPPoolReference{
+				APIGroup: ipamicv1.GroupVersion.Group,
+				Kind:     GetInClusterIPPoolKind(),
+				Name:     "test-cluster-v4-icip",
+			},
+		},
+	}
+	s.NoError(s.cl.Create(s.ctx, claim))
+
+	_, err := s.helper.ClaimControlPlaneEndpoint(s.ctx)
+	s.ErrorContains(err, "is not owned by the cluster")
+
+	s.NoError(s.helper.ReleaseControlPlaneEndpoint(s.ctx))
+	s.NoError(s.cl.Get(s.ctx, client.ObjectKeyFromObject(claim), claim))
+}
+
+func (s *IPAMTestSuite) Test_ControlPlaneEndpointPoolRef() {
+	cluster := getCluster()
+	s.Equal("test-cluster-v4-icip", ControlPlaneEndpointPoolRef(cluster).Name)
+
+	cluster.Spec.IPv4Config = nil
+	cluster.Spec.IPv6Config = &infrav1.IPConfigSpec{Addresses: []string{"2001:db8::/64"}, Prefix: 64}
+	s.Equal("test-cluster-v6-icip", ControlPlaneEndpointPoolRef(cluster).Name)
+
+	poolRef := corev1.TypedLocalObjectReference{
+		APIGroup: GetIPAMInClusterAPIGroup(),
+		Kind:     GetGlobalInClusterIPPoolKind(),
+		Name:     "vip-pool",
+	}
+	cluster.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMSpec{PoolRef: &poolRef}
+	s.Equal(poolRef, ControlPlaneEndpointPoolRef(cluster))
+}
+
 func (s *IPAMTestSuite) Test_GetIPAddress() {
 	s.NoError(s.helper.CreateOrUpdateInClusterIPPool(s.ctx))
 
@@ -0,0 +1,31 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubevip
+
+import "errors"
+
+var (
+	// ErrMissingEndpoint is returned if the control plane endpoint is not set.
+	ErrMissingEndpoint = errors.New("control plane endpoint is not set")
+
+	// ErrMissingInterface is returned if no interface is configured and the machine
+	// has no device carrying the default gateway.
+	ErrMissingInterface = errors.New("unable to determine the kube-vip interface")
+
+	// ErrMissingBGPConfig is returned if BGP mode is used without a BGP configuration.
+	ErrMissingBGPConfig = errors.New("bgp mode requires a bgp configuration")
+)
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubevip renders the kube-vip static pod which announces the control plane endpoint.
+package kubevip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const (
+	// ManifestPath is the path of the static pod manifest on the control plane machines.
+	ManifestPath = "/etc/kubernetes/manifests/kube-vip.yaml"
+
+	// DefaultImage is the kube-vip image used if none is configured.
+	DefaultImage = "ghcr.io/kube-vip/kube-vip:v0.8.0"
+
+	// AdminKubeconfigScriptPath is the path of the script which switches kube-vip back to
+	// the admin kubeconfig once kubeadm init succeeded.
+	AdminKubeconfigScriptPath = "/etc/kube-vip-admin-conf.sh"
+
+	// AdminKubeconfigUnit is the systemd unit running the script at AdminKubeconfigScriptPath.
+	AdminKubeconfigUnit = "kube-vip-admin-conf.service"
+
+	adminKubeconfig      = "/etc/kubernetes/admin.conf"
+	superAdminKubeconfig = "/etc/kubernetes/super-admin.conf"
+
+	// bootstrapSentinel is written by the Cluster API bootstrap data once kubeadm succeeded.
+	bootstrapSentinel = "/run/cluster-api/bootstrap-success.complete"
+)
+
+// adminKubeconfigScript waits for kubeadm init to succeed and switches the kube-vip manifest
+// back to the admin kubeconfig, which is authorized once the control plane is up.
+var adminKubeconfigScript = fmt.Sprintf(`#!/bin/sh
+# Switch kube-vip back to admin.conf, the super-admin credentials are only needed during kubeadm init.
+# xref: https://github.com/kube-vip/kube-vip/issues/684
+until [ -f %[1]s ]; do
+  sleep 10
+done
+sed -i 's#path: %[2]s#path: %[3]s#' %[4]s
+`, bootstrapSentinel, superAdminKubeconfig, adminKubeconfig, ManifestPath)
+
+// Manifest is responsible for rendering the kube-vip static pod manifest.
+type Manifest struct {
+	Spec     infrav1.KubeVIPSpec
+	Endpoint infrav1.APIEndpoint
+
+	// SuperAdminKubeconfig makes kube-vip use the super-admin kubeconfig. On Kubernetes
+	// v1.29 and later this is required on the machine running kubeadm init, because
+	// admin.conf is not authorized before the control plane is up. The bootstrap data must
+	// run AdminKubeconfigScript to switch back to admin.conf once kubeadm init succeeded.
+	// xref: https://github.com/kube-vip/kube-vip/issues/684
+	SuperAdminKubeconfig bool
+}
+
+// AdminKubeconfigScript returns the script which switches kube-vip back to the admin kubeconfig
+// once kubeadm init succeeded. It waits for kubeadm and must be run in the background.
+func AdminKubeconfigScript() []byte {
+	return []byte(adminKubeconfigScript)
+}
+
+// Render returns the kube-vip static pod manifest. Unless an interface is configured,
+// the address is announced on the device of devices carrying the default gateway.
+func (m *Manifest) Render(devices []network.ConfigData) ([]byte, error) {
+	if m.Endpoint.IsZero() {
+		return nil, ErrMissingEndpoint
+	}
+
+	iface := ptr.Deref(m.Spec.Interface, network.DefaultDevice(devices))
+	if iface == "" {
+		return nil, ErrMissingInterface
+	}
+
+	env, err := m.env(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig := adminKubeconfig
+	if m.SuperAdminKubeconfig {
+		kubeconfig = superAdminKubeconfig
+	}
+
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-vip",
+			Namespace: metav1.NamespaceSystem,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:            "kube-vip",
+				Image:           ptr.Deref(m.Spec.Image, DefaultImage),
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Args:            []string{"manager"},
+				Env:             env,
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{
+						Add: []corev1.Capability{"NET_ADMIN", "NET_RAW"},
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "kubeconfig",
+					MountPath: adminKubeconfig,
+				}},
+			}},
+			HostAliases: []corev1.HostAlias{{
+				IP:        "127.0.0.1",
+				Hostnames: []string{"localhost", "kubernetes"},
+			}},
+			HostNetwork: true,
+			Volumes: []corev1.Volume{{
+				Name: "kubeconfig",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: kubeconfig,
+						Type: new(corev1.HostPathFileOrCreate),
+					},
+				},
+			}},
+		},
+	}
+
+	manifest, err := yaml.Marshal(pod)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render kube-vip manifest")
+	}
+	return manifest, nil
+}
+
+// env returns the environment of the kube-vip container, which holds its configuration.
+func (m *Manifest) env(iface string) ([]corev1.EnvVar, error) {
+	env := []corev1.EnvVar{
+		{Name: "cp_enable", Value: "true"},
+		{Name: "vip_interface", Value: iface},
+		{Name: "address", Value: m.Endpoint.Host},
+		{Name: "port", Value: fmt.Sprintf("%d", m.Endpoint.Port)},
+	}
+
+	switch m.Spec.Mode {
+	case infrav1.KubeVIPModeBGP:
+		bgp := m.Spec.BGP
+		if bgp == nil {
+			return nil, ErrMissingBGPConfig
+		}
+
+		peers := make([]string, 0, len(bgp.Peers))
+		for _, peer := range bgp.Peers {
+			// kube-vip expects <address>:<as>:<password>:<multihop>.
+			peers = append(peers, fmt.Sprintf("%s:%d::false", peer.Address, peer.AS))
+		}
+
+		env = append(env,
+			corev1.EnvVar{Name: "bgp_enable", Value: "true"},
+			corev1.EnvVar{Name: "bgp_as", Value: fmt.Sprintf("%d", bgp.AS)},
+			corev1.EnvVar{Name: "bgp_peers", Value: strings.Join(peers, ",")},
+		)
+		if bgp.RouterID != nil {
+			env = append(env, corev1.EnvVar{Name: "bgp_routerid", Value: *bgp.RouterID})
+		} else {
+			env = append(env, corev1.EnvVar{Name: "bgp_routerinterface", Value: iface})
+		}
+	default:
+		env = append(env,
+			corev1.EnvVar{Name: "vip_arp", Value: "true"},
+			corev1.EnvVar{Name: "vip_leaderelection", Value: "true"},
+			corev1.EnvVar{Name: "vip_leaseduration", Value: "15"},
+			corev1.EnvVar{Name: "vip_renewdeadline", Value: "10"},
+			corev1.EnvVar{Name: "vip_retryperiod", Value: "2"},
+		)
+	}
+
+	return env, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubevip
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+var devices = []network.ConfigData{{
+	Name:      "eth0",
+	IPConfigs: []network.IPConfig{{IPAddress: netip.MustParsePrefix("10.0.0.10/24"), Default: true}},
+}}
+
+func renderPod(t *testing.T, m *Manifest) *corev1.Pod {
+	t.Helper()
+
+	manifest, err := m.Render(devices)
+	require.NoError(t, err)
+
+	pod := &corev1.Pod{}
+	require.NoError(t, yaml.UnmarshalStrict(manifest, pod))
+	return pod
+}
+
+func env(pod *corev1.Pod) map[string]string {
+	env := map[string]string{}
+	for _, e := range pod.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	return env
+}
+
+func TestManifest_Render_ARP(t *testing.T) {
+	pod := renderPod(t, &Manifest{
+		Spec:     infrav1.KubeVIPSpec{Mode: infrav1.KubeVIPModeARP},
+		Endpoint: infrav1.APIEndpoint{Host: "10.0.0.100", Port: 6443},
+	})
+
+	require.Equal(t, "kube-vip", pod.Name)
+	require.Equal(t, "kube-system", pod.Namespace)
+	require.True(t, pod.Spec.HostNetwork)
+	require.Equal(t, DefaultImage, pod.Spec.Containers[0].Image)
+	require.Equal(t, adminKubeconfig, pod.Spec.Volumes[0].HostPath.Path)
+	require.Equal(t, map[string]string{
+		"cp_enable":          "true",
+		"vip_interface":      "eth0",
+		"address":            "10.0.0.100",
+		"port":               "6443",
+		"vip_arp":            "true",
+		"vip_leaderelection": "true",
+		"vip_leaseduration":  "15",
+		"vip_renewdeadline":  "10",
+		"vip_retryperiod":    "2",
+	}, env(pod))
+}
+
+func TestManifest_Render_BGP(t *testing.T) {
+	pod := renderPod(t, &Manifest{
+		Spec: infrav1.KubeVIPSpec{
+			Mode:      infrav1.KubeVIPModeBGP,
+			Image:     new("registry.example.com/kube-vip:v1"),
+			Interface: new("lo"),
+			BGP: &infrav1.KubeVIPBGPSpec{
+				AS: 65000,
+				Peers: []infrav1.KubeVIPBGPPeer{
+					{Address: "10.0.0.1", AS: 65001},
+					{Address: "10.0.0.2", AS: 65001},
+				},
+			},
+		},
+		Endpoint:             infrav1.APIEndpoint{Host: "10.0.0.100", Port: 6443},
+		SuperAdminKubeconfig: true,
+	})
+
+	require.Equal(t, "registry.example.com/kube-vip:v1", pod.Spec.Containers[0].Image)
+	require.Equal(t, superAdminKubeconfig, pod.Spec.Volumes[0].HostPath.Path)
+	require.Equal(t, adminKubeconfig, pod.Spec.Containers[0].VolumeMounts[0].MountPath)
+	require.Equal(t, map[string]string{
+		"cp_enable":           "true",
+		"vip_interface":       "lo",
+		"address":             "10.0.0.100",
+		"port":                "6443",
+		"bgp_enable":          "true",
+		"bgp_as":              "65000",
+		"bgp_peers":           "10.0.0.1:65001::false,10.0.0.2:65001::false",
+		"bgp_routerinterface": "lo",
+	}, env(pod))
+}
+
+func TestManifest_Render_Errors(t *testing.T) {
+	m := &Manifest{Spec: infrav1.KubeVIPSpec{Mode: infrav1.KubeVIPModeARP}}
+	_, err := m.Render(devices)
+	require.ErrorIs(t, err, ErrMissingEndpoint)
+
+	m.Endpoint = infrav1.APIEndpoint{Host: "10.0.0.100", Port: 6443}
+	_, err = m.Render([]network.ConfigData{{Name: "eth0"}})
+	require.ErrorIs(t, err, ErrMissingInterface)
+
+	m.Spec.Mode = infrav1.KubeVIPModeBGP
+	_, err = m.Render(devices)
+	require.ErrorIs(t, err, ErrMissingBGPConfig)
+}
+
+func TestAdminKubeconfigScript(t *testing.T) {
+	script := string(AdminKubeconfigScript())
+	require.Contains(t, script, "until [ -f /run/cluster-api/bootstrap-success.complete ]")
+	require.Contains(t, script, "sed -i 's#path: /etc/kubernetes/super-admin.conf#path: /etc/kubernetes/admin.conf#' "+ManifestPath)
+}
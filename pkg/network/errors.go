@@ -36,4 +36,22 @@ var (
 
 	// ErrMalformedFIBRule is returned if a FIB rule can not be assembled.
 	ErrMalformedFIBRule = errors.New("routing policy is malformed")
+
+	// ErrDuplicateDevice is returned if two devices share the same name.
+	ErrDuplicateDevice = errors.New("device name is not unique")
+	// ErrUnknownDevice is returned if a device references a device which does not exist.
+	ErrUnknownDevice = errors.New("referenced device does not exist")
+	// ErrInvalidMember is returned if a device can not be attached to its bond,
+	// bridge or VRF, or is attached to more than one of them.
+	ErrInvalidMember = errors.New("device can not be attached")
+	// ErrEnslavedDeviceConfig is returned if a bond member or bridge port carries
+	// ip configuration of its own.
+	ErrEnslavedDeviceConfig = errors.New("enslaved device must not carry ip configuration")
+	// ErrMalformedBond is returned if a bond has no members.
+	ErrMalformedBond = errors.New("bond is malformed")
+	// ErrMalformedVLAN is returned if a VLAN has no valid ID or link.
+	ErrMalformedVLAN = errors.New("vlan is malformed")
+	// ErrMalformedIPv6Mode is returned if the ipv6 configuration of a device
+	// contradicts its ipv6 mode.
+	ErrMalformedIPv6Mode = errors.New("ipv6 configuration does not match the ipv6 mode")
 )
@@ -20,6 +20,7 @@ package network
 
 import (
 	"net/netip"
+	"slices"
 
 	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 )
@@ -30,6 +31,12 @@ const (
 	TypeEthernet = "ethernet"
 	// TypeVRF identifies a VRF device.
 	TypeVRF = "vrf"
+	// TypeBond identifies a bond device.
+	TypeBond = "bond"
+	// TypeVLAN identifies a VLAN device.
+	TypeVLAN = "vlan"
+	// TypeBridge identifies a bridge device.
+	TypeBridge = "bridge"
 )
 
 // ConfigData is used to render network-config.
@@ -40,12 +47,17 @@ type ConfigData struct {
 	MacAddress string
 	DHCP4      bool
 	DHCP6      bool
+	// IPv6Mode is how the device obtains its IPv6 addresses, DHCP6 must be set
+	// for the mode dhcpv6. Empty leaves router advertisements to the guest.
+	IPv6Mode   infrav1.IPv6Mode
+	AcceptRA   *bool  // accept IPv6 router advertisements, see IPv6AcceptRA.
+	IPv6Token  string // static interface identifier of SLAAC addresses.
 	IPConfigs  []IPConfig
 	DNSServers []string
 	Type       string
 	Name       string
 	// Children holds the names of the devices controlled by this one (e.g. the
-	// NICs attached to a VRF).
+	// NICs attached to a VRF, enslaved by a bond or the ports of a bridge).
 	//
 	// Relationships are referenced by name rather than by *ConfigData
 	// on purpose: ConfigData is a flat value type that the renderers
@@ -58,6 +70,36 @@ type ConfigData struct {
 	FIBRules []FIBRuleData // Forwarding information block for routing.
 	LinkMTU  infrav1.MTU   // linux network device MTU.
 	VRF      string        // linux VRF name // only used in networkd config.
+
+	BondMode   string   // bonding mode of a bond device.
+	BondMIIMon *int32   // MII link monitoring interval in milliseconds.
+	VLANID     *int32   // 802.1Q tag of a VLAN device.
+	Link       string   // name of the device a VLAN is created on.
+	STP        *bool    // spanning tree protocol on a bridge device.
+	Bond       string   // linux bond name // only used in networkd config.
+	Bridge     string   // linux bridge name // only used in networkd config.
+	VLANs      []string // linux VLANs on this device // only used in networkd config.
+}
+
+// SLAAC returns true if the device forms IPv6 addresses from router advertisements.
+func (d ConfigData) SLAAC() bool {
+	return d.IPv6Mode == infrav1.IPv6ModeSLAAC || d.IPv6Mode == infrav1.IPv6ModeStaticSLAAC
+}
+
+// IPv6AcceptRA returns whether the device accepts IPv6 router advertisements:
+// AcceptRA if it is set, otherwise the default of the IPv6 mode.
+// It returns nil if the guest decides.
+func (d ConfigData) IPv6AcceptRA() *bool {
+	if d.AcceptRA != nil {
+		return d.AcceptRA
+	}
+	switch d.IPv6Mode {
+	case infrav1.IPv6ModeSLAAC, infrav1.IPv6ModeStaticSLAAC:
+		return new(true)
+	case infrav1.IPv6ModeStatic, infrav1.IPv6ModeDisabled:
+		return new(false)
+	}
+	return nil
 }
 
 // IPConfig stores IP configuration.
@@ -102,3 +144,44 @@ func IsRouteTargetPlaceholder(s *string) bool {
 func IsConcreteRouteTarget(s *string) bool {
 	return s != nil && *s != "" && !IsRouteTargetPlaceholder(s)
 }
+
+// DefaultDevice returns the name of the device carrying the default gateway: the first
+// device with an address flagged as default, otherwise the first device with a default
+// route in the main routing table, otherwise the first device using DHCP or SLAAC.
+// It returns an empty string if there is no such device.
+func DefaultDevice(devices []ConfigData) string {
+	for _, d := range devices {
+		for _, ip := range d.IPConfigs {
+			if ip.Default {
+				return d.Name
+			}
+		}
+	}
+
+	var vrfMembers []string
+	for _, d := range devices {
+		if d.Type == TypeVRF {
+			vrfMembers = append(vrfMembers, d.Children...)
+		}
+	}
+
+	for _, d := range devices {
+		// routes of VRFs and their members live in the routing table of the VRF.
+		if d.Type == TypeVRF || slices.Contains(vrfMembers, d.Name) {
+			continue
+		}
+		for _, route := range d.Routes {
+			if route.Table == nil && route.To.IsValid() && route.To.Bits() == 0 {
+				return d.Name
+			}
+		}
+	}
+
+	for _, d := range devices {
+		if d.DHCP4 || d.DHCP6 || d.SLAAC() {
+			return d.Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDevice(t *testing.T) {
+	cases := map[string]struct {
+		devices []ConfigData
+		want    string
+	}{
+		"no devices": {},
+		"default address": {
+			devices: []ConfigData{
+				{Name: "eth0", Routes: []RoutingData{defaultRoute(100)}},
+				{Name: "eth1", IPConfigs: []IPConfig{{IPAddress: netip.MustParsePrefix("10.1.0.10/24"), Default: true}}},
+			},
+			want: "eth1",
+		},
+		"default route": {
+			devices: []ConfigData{
+				{Name: "eth0", Routes: []RoutingData{{To: netip.MustParsePrefix("10.2.0.0/16"), Via: netip.MustParseAddr("10.0.0.1")}}},
+				{Name: "eth1", Routes: []RoutingData{defaultRoute(100)}},
+			},
+			want: "eth1",
+		},
+		"vrf member": {
+			devices: []ConfigData{
+				{Name: "eth0", Routes: []RoutingData{defaultRoute(100)}},
+				{Name: "eth1", Routes: []RoutingData{defaultRoute(100)}},
+				{Name: "vrf-blue", Type: TypeVRF, Children: []string{"eth0"}, Table: new(int32(500))},
+			},
+			want: "eth1",
+		},
+		"dhcp": {
+			devices: []ConfigData{
+				{Name: "eth0"},
+				{Name: "eth1", DHCP4: true},
+			},
+			want: "eth1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, DefaultDevice(tc.devices))
+		})
+	}
+}
@@ -18,8 +18,12 @@ package network
 
 import (
 	"fmt"
+	"net/netip"
+	"slices"
 
 	"k8s.io/utils/ptr"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 )
 
 // Network implements an interface that is used to centrally validate and
@@ -41,10 +45,13 @@ func (n *Network) Validate() error {
 	routeCollision := make(map[string]struct{})
 
 	// Tracks whether any device contributes a default gateway, either via a
-	// default route or implicitly via DHCP.
-	// TODO: IPv6 slaac.
+	// default route or implicitly via DHCP or SLAAC.
 	hasGateway := false
 
+	if err := validateTopology(n.Devices); err != nil {
+		return err
+	}
+
 	// Resolve each member interface to its controlling VRF (O(1) lookup)
 	vrfByMember := make(map[string]*ConfigData)
 	for i := range n.Devices {
@@ -100,9 +107,12 @@ func (n *Network) Validate() error {
 		if err := validateFIBRules(d.FIBRules, d.Type == TypeVRF); err != nil {
 			return err
 		}
+		if err := validateIPv6Mode(d); err != nil {
+			return err
+		}
 
-		// DHCP may produce a default gateway.
-		if d.DHCP4 || d.DHCP6 {
+		// DHCP and router advertisements may produce a default gateway.
+		if d.DHCP4 || d.DHCP6 || d.SLAAC() {
 			hasGateway = true
 		}
 	}
@@ -118,6 +128,118 @@ func (n *Network) Validate() error {
 	return nil
 }
 
+// validateTopology checks the relationships between devices: names are
+// unique, every referenced device exists and has a type its bond, bridge, VLAN
+// or VRF can use, and a device has at most one controlling device.
+func validateTopology(devices []ConfigData) error {
+	byName := make(map[string]*ConfigData, len(devices))
+	for i := range devices {
+		d := &devices[i]
+		if _, exists := byName[d.Name]; exists {
+			return ErrDuplicateDevice
+		}
+		byName[d.Name] = d
+	}
+
+	// A device is controlled by at most one bond, bridge or VRF.
+	controlled := make(map[string]struct{})
+	for i := range devices {
+		d := &devices[i]
+
+		var allowed []string
+		switch d.Type {
+		case TypeBond:
+			if len(d.Children) == 0 {
+				return ErrMalformedBond
+			}
+			allowed = []string{TypeEthernet}
+		case TypeBridge:
+			allowed = []string{TypeEthernet, TypeBond, TypeVLAN}
+		case TypeVRF:
+			allowed = []string{TypeEthernet, TypeBond, TypeVLAN, TypeBridge}
+		case TypeVLAN:
+			if id := ptr.Deref(d.VLANID, 0); id < 1 || id > 4094 || d.Link == "" {
+				return ErrMalformedVLAN
+			}
+			link, exists := byName[d.Link]
+			if !exists {
+				return ErrUnknownDevice
+			}
+			if link.Type != TypeEthernet && link.Type != TypeBond {
+				return ErrInvalidMember
+			}
+		}
+
+		for _, child := range d.Children {
+			member, exists := byName[child]
+			if !exists {
+				return ErrUnknownDevice
+			}
+			if !slices.Contains(allowed, member.Type) {
+				return ErrInvalidMember
+			}
+			if _, exists := controlled[child]; exists {
+				return ErrInvalidMember
+			}
+			controlled[child] = struct{}{}
+
+			// Bond members and bridge ports are layer 2 only.
+			if d.Type != TypeVRF && hasIPConfig(member) {
+				return ErrEnslavedDeviceConfig
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasIPConfig(d *ConfigData) bool {
+	return d.DHCP4 || d.DHCP6 || len(d.IPConfigs) > 0 || len(d.Routes) > 0 || len(d.FIBRules) > 0 ||
+		d.IPv6Mode != "" || d.AcceptRA != nil || d.IPv6Token != ""
+}
+
+// validateIPv6Mode checks that DHCPv6, the IPv6 addresses and routes, router
+// advertisements and the token of a device agree with its IPv6 mode.
+func validateIPv6Mode(d *ConfigData) error {
+	hasIPv6Addresses := slices.ContainsFunc(d.IPConfigs, func(ip IPConfig) bool {
+		return ip.IPAddress.Addr().Is6()
+	})
+	acceptRA := ptr.Deref(d.IPv6AcceptRA(), true)
+
+	switch d.IPv6Mode {
+	case infrav1.IPv6ModeDHCPv6:
+		if !d.DHCP6 {
+			return ErrMalformedIPv6Mode
+		}
+	case infrav1.IPv6ModeSLAAC:
+		if !acceptRA || hasIPv6Addresses {
+			return ErrMalformedIPv6Mode
+		}
+	case infrav1.IPv6ModeStaticSLAAC:
+		if !acceptRA {
+			return ErrMalformedIPv6Mode
+		}
+	case infrav1.IPv6ModeDisabled:
+		hasIPv6Routes := slices.ContainsFunc(d.Routes, func(route RoutingData) bool {
+			return route.To.Addr().Is6() || route.Via.Is6()
+		})
+		if acceptRA || hasIPv6Addresses || hasIPv6Routes {
+			return ErrMalformedIPv6Mode
+		}
+	}
+	if d.DHCP6 && d.IPv6Mode != "" && d.IPv6Mode != infrav1.IPv6ModeDHCPv6 {
+		return ErrMalformedIPv6Mode
+	}
+
+	if d.IPv6Token != "" {
+		token, err := netip.ParseAddr(d.IPv6Token)
+		if err != nil || !token.Is6() || !d.SLAAC() {
+			return ErrMalformedIPv6Mode
+		}
+	}
+	return nil
+}
+
 func validateRoutes(routes []RoutingData, deviceTable *int32, hasGateway *bool, routeCollisionMap map[string]struct{}) error {
 	// No support for blackhole, etc.pp. Add iff you require this.
 	for _, route := range routes {
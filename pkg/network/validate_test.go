@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
 )
 
 func defaultRoute(metric int32) RoutingData {
@@ -131,6 +133,149 @@ func TestNetwork_Validate(t *testing.T) {
 				}}},
 			},
 		},
+		"duplicate device name": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "vlan", Name: "eth0", Link: "eth0", VLANID: new(int32(100))},
+			},
+			err: ErrDuplicateDevice,
+		},
+		"bond with vlan and bridge is valid": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0"},
+				{Type: "ethernet", Name: "eth1"},
+				{Type: "bond", Name: "bond0", BondMode: "802.3ad", Children: []string{"eth0", "eth1"}},
+				{Type: "vlan", Name: "vlan100", Link: "bond0", VLANID: new(int32(100))},
+				{Type: "bridge", Name: "br0", Children: []string{"vlan100"}, Routes: []RoutingData{defaultRoute(100)}},
+			},
+		},
+		"bond without members is malformed": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "bond", Name: "bond0"},
+			},
+			err: ErrMalformedBond,
+		},
+		"bond member does not exist": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "bond", Name: "bond0", Children: []string{"eth1"}},
+			},
+			err: ErrUnknownDevice,
+		},
+		"bond member must be ethernet": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "vlan", Name: "vlan100", Link: "eth0", VLANID: new(int32(100))},
+				{Type: "bond", Name: "bond0", Children: []string{"vlan100"}},
+			},
+			err: ErrInvalidMember,
+		},
+		"device enslaved twice": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "ethernet", Name: "eth1"},
+				{Type: "bond", Name: "bond0", Children: []string{"eth1"}},
+				{Type: "bridge", Name: "br0", Children: []string{"eth1"}},
+			},
+			err: ErrInvalidMember,
+		},
+		"bond member with addresses": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "bond", Name: "bond0", Children: []string{"eth0"}},
+			},
+			err: ErrEnslavedDeviceConfig,
+		},
+		"vlan without id is malformed": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "vlan", Name: "vlan0", Link: "eth0"},
+			},
+			err: ErrMalformedVLAN,
+		},
+		"vlan link does not exist": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true},
+				{Type: "vlan", Name: "vlan100", Link: "bond0", VLANID: new(int32(100))},
+			},
+			err: ErrUnknownDevice,
+		},
+		"vlan on a bridge is not supported": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0"},
+				{Type: "bridge", Name: "br0", DHCP4: true, Children: []string{"eth0"}},
+				{Type: "vlan", Name: "vlan100", Link: "br0", VLANID: new(int32(100))},
+			},
+			err: ErrInvalidMember,
+		},
+		"slaac satisfies gateway": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", IPv6Mode: infrav1.IPv6ModeSLAAC, IPv6Token: "::10"},
+			},
+		},
+		"static and slaac addresses": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", IPv6Mode: infrav1.IPv6ModeStaticSLAAC, IPConfigs: []IPConfig{{IPAddress: netip.MustParsePrefix("2001:db8::10/64")}}},
+			},
+		},
+		"slaac with static addresses": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", IPv6Mode: infrav1.IPv6ModeSLAAC, IPConfigs: []IPConfig{{IPAddress: netip.MustParsePrefix("2001:db8::10/64")}}},
+			},
+			err: ErrMalformedIPv6Mode,
+		},
+		"slaac without router advertisements": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true, IPv6Mode: infrav1.IPv6ModeSLAAC, AcceptRA: new(false)},
+			},
+			err: ErrMalformedIPv6Mode,
+		},
+		"dhcpv6 mode requires dhcp6": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true, IPv6Mode: infrav1.IPv6ModeDHCPv6},
+			},
+			err: ErrMalformedIPv6Mode,
+		},
+		"dhcp6 contradicts the static mode": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP6: true, IPv6Mode: infrav1.IPv6ModeStatic},
+			},
+			err: ErrMalformedIPv6Mode,
+		},
+		"disabled ipv6 with ipv4 only": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true, IPv6Mode: infrav1.IPv6ModeDisabled},
+			},
+		},
+		"disabled ipv6 with an ipv6 route": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", IPv6Mode: infrav1.IPv6ModeDisabled, Routes: []RoutingData{defaultRoute(100), {
+					To:  netip.MustParsePrefix("::/0"),
+					Via: netip.MustParseAddr("2001:db8::1"),
+				}}},
+			},
+			err: ErrMalformedIPv6Mode,
+		},
+		"token requires slaac": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", DHCP4: true, IPv6Mode: infrav1.IPv6ModeStatic, IPv6Token: "::10"},
+			},
+			err: ErrMalformedIPv6Mode,
+		},
+		"token must be an ipv6 address": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", IPv6Mode: infrav1.IPv6ModeSLAAC, IPv6Token: "10.0.0.10"},
+			},
+			err: ErrMalformedIPv6Mode,
+		},
+		"bond member with an ipv6 mode": {
+			devices: []ConfigData{
+				{Type: "ethernet", Name: "eth0", IPv6Mode: infrav1.IPv6ModeDisabled},
+				{Type: "bond", Name: "bond0", DHCP4: true, Children: []string{"eth0"}},
+			},
+			err: ErrEnslavedDeviceConfig,
+		},
 	}
 
 	for name, tc := range cases {
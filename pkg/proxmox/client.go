@@ -1,5 +1,5 @@
 /*
-Copyright 2023-2025 IONOS Cloud.
+Copyright 2023-2026 IONOS Cloud.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -30,6 +30,8 @@ type Client interface {
 	ConfigureVM(ctx context.Context, vm *proxmox.VirtualMachine, options ...VirtualMachineOption) (*proxmox.Task, error)
 
 	FindVMResource(ctx context.Context, vmID uint64) (*proxmox.ClusterResource, error)
+	GetVMResources(ctx context.Context) ([]*proxmox.ClusterResource, error)
+	FindVMResourcesByTag(ctx context.Context, tag string) ([]*proxmox.ClusterResource, error)
 	FindVMTemplateByTags(ctx context.Context, templateTags []string, resolutionPolicy string) (string, int32, error)
 
 	CheckID(ctx context.Context, vmID int64) (bool, error)
@@ -39,9 +41,14 @@ type Client interface {
 	DeleteVM(ctx context.Context, nodeName string, vmID int64) (*proxmox.Task, error)
 
 	GetTask(ctx context.Context, upID string) (*proxmox.Task, error)
+	GetTaskLog(ctx context.Context, upID string) ([]string, error)
 
 	GetReservableMemoryBytes(ctx context.Context, nodeName string, nodeMemoryAdjustment int64) (uint64, error)
 
+	GetNodes(ctx context.Context) ([]*proxmox.NodeStatus, error)
+	GetNodeStorages(ctx context.Context, nodeName string) ([]*proxmox.Storage, error)
+	GetNodeNetworks(ctx context.Context, nodeName string) ([]*proxmox.NodeNetwork, error)
+
 	ResizeDisk(ctx context.Context, vm *proxmox.VirtualMachine, disk, size string) (*proxmox.Task, error)
 
 	ResumeVM(ctx context.Context, vm *proxmox.VirtualMachine) (*proxmox.Task, error)
@@ -55,4 +62,45 @@ type Client interface {
 	CloudInitStatus(ctx context.Context, vm *proxmox.VirtualMachine) (bool, error)
 
 	QemuAgentStatus(ctx context.Context, vm *proxmox.VirtualMachine) error
+	QemuAgentNetworkInterfaces(ctx context.Context, vm *proxmox.VirtualMachine) ([]*proxmox.AgentNetworkIface, error)
+
+	GetSDNZones(ctx context.Context) ([]*SDNZone, error)
+	CreateSDNZone(ctx context.Context, zone *proxmox.SDNZoneOptions) error
+	DeleteSDNZone(ctx context.Context, name string) error
+	GetSDNVNets(ctx context.Context) ([]*proxmox.VNet, error)
+	CreateSDNVNet(ctx context.Context, vnet *proxmox.VNetOptions) error
+	DeleteSDNVNet(ctx context.Context, name string) error
+	GetSDNSubnets(ctx context.Context, vnet string) ([]*proxmox.VNetSubnet, error)
+	CreateSDNSubnet(ctx context.Context, vnet string, subnet *proxmox.SDNSubnetOptions) error
+	DeleteSDNSubnet(ctx context.Context, vnet string, subnet *proxmox.VNetSubnet) error
+	ApplySDN(ctx context.Context) error
+
+	GetVMFirewallOptions(ctx context.Context, vm *proxmox.VirtualMachine) (*proxmox.FirewallVirtualMachineOption, error)
+	UpdateVMFirewallOptions(ctx context.Context, vm *proxmox.VirtualMachine, options *proxmox.FirewallVirtualMachineOption) error
+	GetVMFirewallRules(ctx context.Context, vm *proxmox.VirtualMachine) ([]*proxmox.FirewallRule, error)
+	CreateVMFirewallRule(ctx context.Context, vm *proxmox.VirtualMachine, rule *proxmox.FirewallRule) error
+	DeleteVMFirewallRule(ctx context.Context, vm *proxmox.VirtualMachine, pos int) error
+
+	GetFirewallIPSets(ctx context.Context) ([]*proxmox.FirewallIPSet, error)
+	CreateFirewallIPSet(ctx context.Context, name, comment string) error
+	DeleteFirewallIPSet(ctx context.Context, name string) error
+	GetFirewallIPSetEntries(ctx context.Context, name string) ([]*proxmox.FirewallIPSetEntry, error)
+	AddFirewallIPSetEntry(ctx context.Context, name, cidr string) error
+	DeleteFirewallIPSetEntry(ctx context.Context, name, cidr string) error
+
+	GetHAResource(ctx context.Context, sid string) (*proxmox.HAResource, error)
+	CreateHAResource(ctx context.Context, resource *proxmox.HAResourceCreateOption) error
+	UpdateHAResource(ctx context.Context, sid string, resource *proxmox.HAResourceUpdateOption) error
+	DeleteHAResource(ctx context.Context, sid string) error
+	GetHAGroup(ctx context.Context, name string) (*proxmox.HAGroup, error)
+
+	GetPools(ctx context.Context) ([]*proxmox.Pool, error)
+	GetPool(ctx context.Context, name string) (*proxmox.Pool, error)
+	CreatePool(ctx context.Context, name, comment string) error
+	DeletePool(ctx context.Context, name string) error
+	AddPoolVMs(ctx context.Context, name string, vmIDs []int64) error
+	GetPoolACLs(ctx context.Context, name string) ([]*proxmox.ACL, error)
+	UpdatePoolACL(ctx context.Context, name string, acl *proxmox.ACL, remove bool) error
+
+	GetPermissions(ctx context.Context) (proxmox.Permissions, error)
 }
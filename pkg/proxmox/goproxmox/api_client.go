@@ -23,6 +23,7 @@ import (
 	"maps"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -39,6 +40,9 @@ var _ capmox.Client = &APIClient{}
 // ErrVMIDFree is returned if the VMID is free.
 var ErrVMIDFree = errors.New("VMID is free")
 
+// taskLogLimit is the maximum number of task log lines fetched by GetTaskLog.
+const taskLogLimit = 1000
+
 // APIClient Proxmox API client object.
 type APIClient struct {
 	*proxmox.Client
@@ -145,6 +149,51 @@ func (c *APIClient) FindVMResource(ctx context.Context, vmID uint64) (*proxmox.C
 	return nil, fmt.Errorf("unable to find VM with ID %d on any of the nodes", vmID)
 }
 
+// GetVMResources returns the VMs and templates of the whole cluster.
+func (c *APIClient) GetVMResources(ctx context.Context) ([]*proxmox.ClusterResource, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster status: %w", err)
+	}
+
+	vmResources, err := cluster.Resources(ctx, "vm")
+	if err != nil {
+		return nil, fmt.Errorf("could not list vm resources: %w", err)
+	}
+
+	return vmResources, nil
+}
+
+// FindVMResourcesByTag returns the VMs, excluding templates, which carry the given tag across the whole cluster.
+func (c *APIClient) FindVMResourcesByTag(ctx context.Context, tag string) ([]*proxmox.ClusterResource, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster status: %w", err)
+	}
+
+	vmResources, err := cluster.Resources(ctx, "vm")
+	if err != nil {
+		return nil, fmt.Errorf("could not list vm resources: %w", err)
+	}
+
+	// Proxmox VM tags are always lowercase
+	tag = strings.ToLower(tag)
+	var vms []*proxmox.ClusterResource
+	for _, vm := range vmResources {
+		if vm.Template != 0 || vm.Type != "qemu" {
+			continue
+		}
+		for vmTag := range strings.SplitSeq(vm.Tags, ";") {
+			if strings.ToLower(strings.TrimSpace(vmTag)) == tag {
+				vms = append(vms, vm)
+				break
+			}
+		}
+	}
+
+	return vms, nil
+}
+
 // FindVMTemplateByTags tries to find a VMID by its tags across the whole cluster.
 func (c *APIClient) FindVMTemplateByTags(ctx context.Context, templateTags []string, matchPolicy string) (string, int32, error) {
 	logger := log.FromContext(ctx)
@@ -277,6 +326,36 @@ func (c *APIClient) GetTask(ctx context.Context, upID string) (*proxmox.Task, er
 	return task, nil
 }
 
+// GetTaskLog returns the log of the task with the given UPID, oldest line first.
+// At most the last taskLogLimit lines are returned. go-proxmox drops the total line count
+// of the log, so the log is read in pages of taskLogLimit lines until its end.
+func (c *APIClient) GetTaskLog(ctx context.Context, upID string) ([]string, error) {
+	task := proxmox.NewTask(proxmox.UPID(upID), c.Client)
+	if task == nil || task.Node == "" {
+		return nil, fmt.Errorf("invalid task UPID %q", upID)
+	}
+
+	var lines []string
+	for start := 0; ; start += taskLogLimit {
+		log, err := task.Log(ctx, start, taskLogLimit)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get log of task with UPID %s: %w", upID, err)
+		}
+
+		for _, n := range slices.Sorted(maps.Keys(log)) {
+			lines = append(lines, log[n])
+		}
+		if len(lines) > taskLogLimit {
+			lines = slices.Clone(lines[len(lines)-taskLogLimit:])
+		}
+
+		// a short page is the end of the log.
+		if len(log) < taskLogLimit {
+			return lines, nil
+		}
+	}
+}
+
 // GetReservableMemoryBytes returns the memory that can be reserved by a new VM, in bytes.
 func (c *APIClient) GetReservableMemoryBytes(ctx context.Context, nodeName string, nodeMemoryAdjustment int64) (uint64, error) {
 	node := (&proxmox.Node{}).New(c.Client, nodeName)
@@ -324,6 +403,33 @@ func (c *APIClient) GetReservableMemoryBytes(ctx context.Context, nodeName strin
 	return reservableMemory, nil
 }
 
+// GetNodes returns the nodes of the cluster together with their status.
+func (c *APIClient) GetNodes(ctx context.Context) ([]*proxmox.NodeStatus, error) {
+	nodes, err := c.Nodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list nodes: %w", err)
+	}
+	return nodes, nil
+}
+
+// GetNodeStorages returns the storages which are configured for a node.
+func (c *APIClient) GetNodeStorages(ctx context.Context, nodeName string) ([]*proxmox.Storage, error) {
+	storages, err := (&proxmox.Node{}).New(c.Client, nodeName).Storages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list storages of node %s: %w", nodeName, err)
+	}
+	return storages, nil
+}
+
+// GetNodeNetworks returns the network interfaces of a node.
+func (c *APIClient) GetNodeNetworks(ctx context.Context, nodeName string) ([]*proxmox.NodeNetwork, error) {
+	networks, err := (&proxmox.Node{}).New(c.Client, nodeName).Networks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list networks of node %s: %w", nodeName, err)
+	}
+	return networks, nil
+}
+
 // ResizeDisk resizes a VM disk to the specified size.
 func (c *APIClient) ResizeDisk(ctx context.Context, vm *proxmox.VirtualMachine, disk, size string) (*proxmox.Task, error) {
 	return vm.ResizeDisk(ctx, disk, size)
@@ -391,3 +497,444 @@ func (c *APIClient) QemuAgentStatus(ctx context.Context, vm *proxmox.VirtualMach
 
 	return nil
 }
+
+// QemuAgentNetworkInterfaces returns the network interfaces of the VM as reported by the qemu-agent.
+// ErrAgentNotRunning is returned if the agent does not respond.
+func (c *APIClient) QemuAgentNetworkInterfaces(ctx context.Context, vm *proxmox.VirtualMachine) ([]*proxmox.AgentNetworkIface, error) {
+	ifaces, err := vm.AgentGetNetworkIFaces(ctx)
+	if err != nil {
+		// Proxmox only reports the state of the agent in the status text.
+		if strings.Contains(err.Error(), ErrAgentNotRunning.Error()) {
+			err = ErrAgentNotRunning
+		}
+		return nil, errors.Wrap(err, "unable to get network interfaces from agent")
+	}
+
+	return ifaces, nil
+}
+
+// GetSDNZones returns the SDN zones of the cluster.
+// The zones are read directly, as proxmox.SDNZone does not hold the bridge of a zone.
+func (c *APIClient) GetSDNZones(ctx context.Context) ([]*capmox.SDNZone, error) {
+	var zones []*capmox.SDNZone
+	if err := c.Get(ctx, "/cluster/sdn/zones", &zones); err != nil {
+		return nil, fmt.Errorf("cannot list sdn zones: %w", err)
+	}
+	return zones, nil
+}
+
+// CreateSDNZone creates a SDN zone. Changes to the SDN take effect with ApplySDN.
+func (c *APIClient) CreateSDNZone(ctx context.Context, zone *proxmox.SDNZoneOptions) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.NewSDNZone(ctx, zone); err != nil {
+		return fmt.Errorf("unable to create sdn zone %s: %w", zone.Name, err)
+	}
+	return nil
+}
+
+// DeleteSDNZone deletes a SDN zone. Changes to the SDN take effect with ApplySDN.
+func (c *APIClient) DeleteSDNZone(ctx context.Context, name string) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.DeleteSDNZone(ctx, name); err != nil {
+		return fmt.Errorf("unable to delete sdn zone %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetSDNVNets returns the SDN VNets of the cluster.
+func (c *APIClient) GetSDNVNets(ctx context.Context) ([]*proxmox.VNet, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	vnets, err := cluster.SDNVNets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list sdn vnets: %w", err)
+	}
+	return vnets, nil
+}
+
+// CreateSDNVNet creates a SDN VNet. Changes to the SDN take effect with ApplySDN.
+func (c *APIClient) CreateSDNVNet(ctx context.Context, vnet *proxmox.VNetOptions) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.NewSDNVNet(ctx, vnet); err != nil {
+		return fmt.Errorf("unable to create sdn vnet %s: %w", vnet.Name, err)
+	}
+	return nil
+}
+
+// DeleteSDNVNet deletes a SDN VNet. Changes to the SDN take effect with ApplySDN.
+func (c *APIClient) DeleteSDNVNet(ctx context.Context, name string) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.DeleteSDNVNet(ctx, name); err != nil {
+		return fmt.Errorf("unable to delete sdn vnet %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetSDNSubnets returns the subnets of a SDN VNet.
+func (c *APIClient) GetSDNSubnets(ctx context.Context, vnet string) ([]*proxmox.VNetSubnet, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	subnets, err := cluster.SDNSubnets(ctx, vnet)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list subnets of sdn vnet %s: %w", vnet, err)
+	}
+	return subnets, nil
+}
+
+// CreateSDNSubnet creates a subnet in a SDN VNet. Changes to the SDN take effect with ApplySDN.
+func (c *APIClient) CreateSDNSubnet(ctx context.Context, vnet string, subnet *proxmox.SDNSubnetOptions) error {
+	subnet.Type = "subnet"
+	subnet.VNet = vnet
+
+	if err := c.Post(ctx, fmt.Sprintf("/cluster/sdn/vnets/%s/subnets", vnet), subnet, nil); err != nil {
+		return fmt.Errorf("unable to create subnet %s in sdn vnet %s: %w", subnet.Subnet, vnet, err)
+	}
+	return nil
+}
+
+// DeleteSDNSubnet deletes a subnet of a SDN VNet. Changes to the SDN take effect with ApplySDN.
+func (c *APIClient) DeleteSDNSubnet(ctx context.Context, vnet string, subnet *proxmox.VNetSubnet) error {
+	// Proxmox identifies subnets by zone and cidr, e.g. zone1-10.0.0.0-24.
+	id := subnet.ID
+	if id == "" {
+		id = fmt.Sprintf("%s-%s", subnet.Zone, strings.ReplaceAll(subnet.CIDR, "/", "-"))
+	}
+
+	if err := c.Delete(ctx, fmt.Sprintf("/cluster/sdn/vnets/%s/subnets/%s", vnet, id), nil); err != nil {
+		return fmt.Errorf("unable to delete subnet %s of sdn vnet %s: %w", id, vnet, err)
+	}
+	return nil
+}
+
+// ApplySDN applies the pending SDN configuration to all nodes and waits for it to finish.
+func (c *APIClient) ApplySDN(ctx context.Context) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	task, err := cluster.SDNApply(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to apply sdn configuration: %w", err)
+	}
+
+	// reloading the network of all nodes usually finishes within seconds.
+	if err := task.WaitFor(ctx, 30); err != nil {
+		return fmt.Errorf("unable to apply sdn configuration: %w", err)
+	}
+	return nil
+}
+
+// GetVMFirewallOptions returns the firewall options of a VM.
+func (c *APIClient) GetVMFirewallOptions(ctx context.Context, vm *proxmox.VirtualMachine) (*proxmox.FirewallVirtualMachineOption, error) {
+	options := &proxmox.FirewallVirtualMachineOption{}
+	if err := c.Get(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/firewall/options", vm.Node, vm.VMID), options); err != nil {
+		return nil, fmt.Errorf("cannot get firewall options of vm %d: %w", vm.VMID, err)
+	}
+	return options, nil
+}
+
+// UpdateVMFirewallOptions updates the enable flag and the policies of the firewall of a VM.
+// Empty policies are left unchanged.
+func (c *APIClient) UpdateVMFirewallOptions(ctx context.Context, vm *proxmox.VirtualMachine, options *proxmox.FirewallVirtualMachineOption) error {
+	// the enable flag is omitted from the marshalled options if false, so it is always sent explicitly.
+	body := map[string]any{"enable": 0}
+	if options.Enable {
+		body["enable"] = 1
+	}
+	if options.PolicyIn != "" {
+		body["policy_in"] = options.PolicyIn
+	}
+	if options.PolicyOut != "" {
+		body["policy_out"] = options.PolicyOut
+	}
+
+	if err := c.Put(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/firewall/options", vm.Node, vm.VMID), body, nil); err != nil {
+		return fmt.Errorf("unable to update firewall options of vm %d: %w", vm.VMID, err)
+	}
+	return nil
+}
+
+// GetVMFirewallRules returns the firewall rules of a VM ordered by their position.
+func (c *APIClient) GetVMFirewallRules(ctx context.Context, vm *proxmox.VirtualMachine) ([]*proxmox.FirewallRule, error) {
+	var rules []*proxmox.FirewallRule
+	if err := c.Get(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/firewall/rules", vm.Node, vm.VMID), &rules); err != nil {
+		return nil, fmt.Errorf("cannot list firewall rules of vm %d: %w", vm.VMID, err)
+	}
+	return rules, nil
+}
+
+// CreateVMFirewallRule creates a firewall rule of a VM. Proxmox inserts new rules at the top.
+func (c *APIClient) CreateVMFirewallRule(ctx context.Context, vm *proxmox.VirtualMachine, rule *proxmox.FirewallRule) error {
+	if err := c.Post(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/firewall/rules", vm.Node, vm.VMID), rule, nil); err != nil {
+		return fmt.Errorf("unable to create firewall rule of vm %d: %w", vm.VMID, err)
+	}
+	return nil
+}
+
+// DeleteVMFirewallRule deletes the firewall rule at the given position of a VM.
+func (c *APIClient) DeleteVMFirewallRule(ctx context.Context, vm *proxmox.VirtualMachine, pos int) error {
+	if err := c.Delete(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/firewall/rules/%d", vm.Node, vm.VMID, pos), nil); err != nil {
+		return fmt.Errorf("unable to delete firewall rule %d of vm %d: %w", pos, vm.VMID, err)
+	}
+	return nil
+}
+
+// GetFirewallIPSets returns the IPSets of the datacenter firewall.
+func (c *APIClient) GetFirewallIPSets(ctx context.Context) ([]*proxmox.FirewallIPSet, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	ipsets, err := cluster.FirewallIPSets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list firewall ipsets: %w", err)
+	}
+	return ipsets, nil
+}
+
+// CreateFirewallIPSet creates an IPSet in the datacenter firewall.
+func (c *APIClient) CreateFirewallIPSet(ctx context.Context, name, comment string) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.NewFirewallIPSet(ctx, &proxmox.FirewallIPSetCreationOption{Name: name, Comment: comment}); err != nil {
+		return fmt.Errorf("unable to create firewall ipset %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteFirewallIPSet deletes an IPSet including its entries from the datacenter firewall.
+func (c *APIClient) DeleteFirewallIPSet(ctx context.Context, name string) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.FirewallIPSetDelete(ctx, name, true); err != nil {
+		return fmt.Errorf("unable to delete firewall ipset %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetFirewallIPSetEntries returns the entries of an IPSet of the datacenter firewall.
+func (c *APIClient) GetFirewallIPSetEntries(ctx context.Context, name string) ([]*proxmox.FirewallIPSetEntry, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	entries, err := cluster.FirewallIPSet(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list entries of firewall ipset %s: %w", name, err)
+	}
+	return entries, nil
+}
+
+// AddFirewallIPSetEntry adds an address or CIDR to an IPSet of the datacenter firewall.
+func (c *APIClient) AddFirewallIPSetEntry(ctx context.Context, name, cidr string) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.NewFirewallIPSetEntry(ctx, name, &proxmox.FirewallIPSetEntryCreationOption{CIDR: cidr}); err != nil {
+		return fmt.Errorf("unable to add %s to firewall ipset %s: %w", cidr, name, err)
+	}
+	return nil
+}
+
+// DeleteFirewallIPSetEntry removes an address or CIDR from an IPSet of the datacenter firewall.
+func (c *APIClient) DeleteFirewallIPSetEntry(ctx context.Context, name, cidr string) error {
+	// a CIDR contains a slash, so it must be escaped in the path.
+	if err := c.Delete(ctx, fmt.Sprintf("/cluster/firewall/ipset/%s/%s", name, url.PathEscape(cidr)), nil); err != nil {
+		return fmt.Errorf("unable to remove %s from firewall ipset %s: %w", cidr, name, err)
+	}
+	return nil
+}
+
+// GetHAResource returns the HA resource with the given service ID, e.g. vm:100.
+func (c *APIClient) GetHAResource(ctx context.Context, sid string) (*proxmox.HAResource, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	resource, err := cluster.HAResource(ctx, sid)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get ha resource %s: %w", sid, err)
+	}
+	return resource, nil
+}
+
+// CreateHAResource puts a guest under the control of the HA manager.
+func (c *APIClient) CreateHAResource(ctx context.Context, resource *proxmox.HAResourceCreateOption) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.NewHAResource(ctx, resource); err != nil {
+		return fmt.Errorf("unable to create ha resource %s: %w", resource.SID, err)
+	}
+	return nil
+}
+
+// UpdateHAResource updates the settings of a HA resource.
+func (c *APIClient) UpdateHAResource(ctx context.Context, sid string, resource *proxmox.HAResourceUpdateOption) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.HAResourceUpdate(ctx, sid, resource); err != nil {
+		return fmt.Errorf("unable to update ha resource %s: %w", sid, err)
+	}
+	return nil
+}
+
+// DeleteHAResource removes a guest from the control of the HA manager. The guest itself is kept.
+func (c *APIClient) DeleteHAResource(ctx context.Context, sid string) error {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	if err := cluster.HAResourceDelete(ctx, sid, true); err != nil {
+		return fmt.Errorf("unable to delete ha resource %s: %w", sid, err)
+	}
+	return nil
+}
+
+// GetHAGroup returns the HA group with the given name.
+func (c *APIClient) GetHAGroup(ctx context.Context, name string) (*proxmox.HAGroup, error) {
+	cluster, err := c.Cluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster: %w", err)
+	}
+
+	group, err := cluster.HAGroup(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get ha group %s: %w", name, err)
+	}
+	return group, nil
+}
+
+// GetPools returns the resource pools, without their members.
+func (c *APIClient) GetPools(ctx context.Context) ([]*proxmox.Pool, error) {
+	pools, err := c.Pools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list pools: %w", err)
+	}
+	return pools, nil
+}
+
+// GetPool returns the resource pool with the given name, including its members.
+func (c *APIClient) GetPool(ctx context.Context, name string) (*proxmox.Pool, error) {
+	pool, err := c.Pool(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pool %s: %w", name, err)
+	}
+	return pool, nil
+}
+
+// CreatePool creates a resource pool.
+func (c *APIClient) CreatePool(ctx context.Context, name, comment string) error {
+	if err := c.NewPool(ctx, name, comment); err != nil {
+		return fmt.Errorf("unable to create pool %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeletePool deletes a resource pool. Proxmox refuses to delete a pool which still has members.
+func (c *APIClient) DeletePool(ctx context.Context, name string) error {
+	if err := c.Delete(ctx, "/pools?"+url.Values{"poolid": {name}}.Encode(), nil); err != nil {
+		return fmt.Errorf("unable to delete pool %s: %w", name, err)
+	}
+	return nil
+}
+
+// AddPoolVMs adds virtual machines to a resource pool.
+func (c *APIClient) AddPoolVMs(ctx context.Context, name string, vmIDs []int64) error {
+	ids := make([]string, 0, len(vmIDs))
+	for _, id := range vmIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	body := map[string]string{"poolid": name, "vms": strings.Join(ids, ",")}
+	if err := c.Put(ctx, "/pools", body, nil); err != nil {
+		return fmt.Errorf("unable to add vms to pool %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetPoolACLs returns the access control entries of a resource pool.
+func (c *APIClient) GetPoolACLs(ctx context.Context, name string) ([]*proxmox.ACL, error) {
+	acls, err := c.ACL(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list acls: %w", err)
+	}
+
+	var poolACLs []*proxmox.ACL
+	for _, acl := range acls {
+		if acl.Path == "/pool/"+name {
+			poolACLs = append(poolACLs, acl)
+		}
+	}
+	return poolACLs, nil
+}
+
+// UpdatePoolACL grants or, if remove is set, revokes the role of a user or group on a resource pool.
+func (c *APIClient) UpdatePoolACL(ctx context.Context, name string, acl *proxmox.ACL, remove bool) error {
+	options := proxmox.ACLOptions{
+		Path:      "/pool/" + name,
+		Roles:     acl.RoleID,
+		Propagate: true,
+		Delete:    proxmox.IntOrBool(remove),
+	}
+	switch acl.Type {
+	case "group":
+		options.Groups = acl.UGID
+	case "token":
+		options.Tokens = acl.UGID
+	default:
+		options.Users = acl.UGID
+	}
+	if err := c.UpdateACL(ctx, options); err != nil {
+		return fmt.Errorf("unable to update acl of pool %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetPermissions returns the effective privileges of the authenticated user or token, by path.
+func (c *APIClient) GetPermissions(ctx context.Context) (proxmox.Permissions, error) {
+	permissions, err := c.Permissions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get permissions: %w", err)
+	}
+	return permissions, nil
+}
@@ -18,8 +18,11 @@ package goproxmox
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -373,6 +376,28 @@ func TestProxmoxAPIClient_FindVMResource(t *testing.T) {
 	}
 }
 
+func TestProxmoxAPIClient_FindVMResourcesByTag(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/status`,
+		newJSONResponder(200, proxmox.NodeStatuses{{Name: "test"}}))
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/resources`,
+		newJSONResponder(200, proxmox.ClusterResources{
+			&proxmox.ClusterResource{VMID: 101, Type: "qemu", Tags: "capmox-cluster.default.test"},
+			&proxmox.ClusterResource{VMID: 102, Type: "qemu", Tags: "manual;Capmox-Cluster.default.test"},
+			&proxmox.ClusterResource{VMID: 103, Type: "qemu", Tags: "capmox-cluster.default.other"},
+			&proxmox.ClusterResource{VMID: 104, Type: "qemu", Tags: "capmox-cluster.default.test", Template: 1},
+			&proxmox.ClusterResource{VMID: 105, Type: "lxc", Tags: "capmox-cluster.default.test"},
+			&proxmox.ClusterResource{VMID: 106, Type: "qemu"},
+		}))
+
+	vms, err := client.FindVMResourcesByTag(context.Background(), "capmox-cluster.default.test")
+	require.NoError(t, err)
+	require.Len(t, vms, 2)
+	require.Equal(t, uint64(101), vms[0].VMID)
+	require.Equal(t, uint64(102), vms[1].VMID)
+}
+
 func TestProxmoxAPIClient_FindVMTemplateByTags(t *testing.T) {
 	proxmoxClusterResources := proxmox.ClusterResources{
 		&proxmox.ClusterResource{VMID: 101, Name: "k8s-node01", Node: "capmox01", Tags: ""},
@@ -629,6 +654,63 @@ func TestProxmoxAPIClient_GetTask(t *testing.T) {
 	}
 }
 
+func TestProxmoxAPIClient_GetTaskLog(t *testing.T) {
+	upid := "UPID:test:000D6BDA:041E0A54:654A5A1D:qmclone:100:root@pam:"
+
+	t.Run("get", func(t *testing.T) {
+		client := newTestClient(t)
+		// Proxmox numbers log lines starting at 1; return them out of order.
+		httpmock.RegisterResponder(http.MethodGet, `=~/nodes/test/tasks/`+upid+`/log`,
+			newJSONResponder(200, []map[string]any{
+				{"n": 2, "t": "TASK ERROR: clone failed"},
+				{"n": 1, "t": "create full clone of drive scsi0"},
+			}))
+
+		lines, err := client.GetTaskLog(context.Background(), upid)
+		require.NoError(t, err)
+		require.Equal(t, []string{"create full clone of drive scsi0", "TASK ERROR: clone failed"}, lines)
+	})
+
+	t.Run("get tail of long log", func(t *testing.T) {
+		client := newTestClient(t)
+		total, requests := taskLogLimit*2+500, 0
+		httpmock.RegisterResponder(http.MethodGet, `=~/nodes/test/tasks/`+upid+`/log`,
+			func(req *http.Request) (*http.Response, error) {
+				requests++
+				start, _ := strconv.Atoi(req.URL.Query().Get("start"))
+				limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+				log := []map[string]any{}
+				for n := start + 1; n <= min(start+limit, total); n++ {
+					log = append(log, map[string]any{"n": n, "t": fmt.Sprintf("line %d", n)})
+				}
+				return httpmock.NewJsonResponse(200, map[string]any{"data": log, "total": total})
+			})
+
+		lines, err := client.GetTaskLog(context.Background(), upid)
+		require.NoError(t, err)
+		require.Len(t, lines, taskLogLimit)
+		require.Equal(t, fmt.Sprintf("line %d", total-taskLogLimit+1), lines[0])
+		require.Equal(t, fmt.Sprintf("line %d", total), lines[len(lines)-1])
+		require.Equal(t, 3, requests)
+	})
+
+	t.Run("get fails", func(t *testing.T) {
+		client := newTestClient(t)
+		httpmock.RegisterResponder(http.MethodGet, `=~/nodes/test/tasks/`,
+			newJSONResponder(501, nil))
+
+		_, err := client.GetTaskLog(context.Background(), upid)
+		require.ErrorContains(t, err, "cannot get log of task with UPID")
+	})
+
+	t.Run("invalid upid", func(t *testing.T) {
+		client := newTestClient(t)
+
+		_, err := client.GetTaskLog(context.Background(), "foo")
+		require.ErrorContains(t, err, `invalid task UPID "foo"`)
+	})
+}
+
 func TestProxmoxAPIClient_CloudInitStatus(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -740,3 +822,342 @@ func TestProxmoxAPIClient_CloudInitStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestProxmoxAPIClient_QemuAgentNetworkInterfaces(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve/status`,
+		newJSONResponder(200, proxmox.Node{Name: "pve"}))
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve/qemu/1111/status/current`,
+		newJSONResponder(200, proxmox.VirtualMachine{VMID: 1111, Name: "legit-worker", Node: "pve"}))
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve/qemu/1111/config`,
+		newJSONResponder(200, proxmox.VirtualMachineConfig{Name: "legit-worker"}))
+
+	vm, err := client.GetVM(context.Background(), "pve", 1111)
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve/qemu/1111/agent/network-get-interfaces`,
+		newJSONResponder(200, map[string]any{
+			"result": []*proxmox.AgentNetworkIface{
+				{Name: "lo", HardwareAddress: "00:00:00:00:00:00"},
+				{Name: "eth0", HardwareAddress: "92:60:a0:5b:22:c2", IPAddresses: []*proxmox.AgentNetworkIPAddress{
+					{IPAddressType: "ipv4", IPAddress: "10.10.10.12", Prefix: 24},
+				}},
+			},
+		}))
+
+	ifaces, err := client.QemuAgentNetworkInterfaces(context.Background(), vm)
+	require.NoError(t, err)
+	require.Len(t, ifaces, 1)
+	require.Equal(t, "92:60:a0:5b:22:c2", ifaces[0].HardwareAddress)
+	require.Equal(t, "10.10.10.12", ifaces[0].IPAddresses[0].IPAddress)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve/qemu/1111/agent/network-get-interfaces`,
+		func(*http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(500, "")
+			resp.Status = "500 QEMU guest agent is not running"
+			return resp, nil
+		})
+
+	_, err = client.QemuAgentNetworkInterfaces(context.Background(), vm)
+	require.ErrorIs(t, err, ErrAgentNotRunning)
+	require.ErrorContains(t, err, "unable to get network interfaces from agent")
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve/qemu/1111/agent/network-get-interfaces`,
+		httpmock.NewStringResponder(500, ""))
+
+	_, err = client.QemuAgentNetworkInterfaces(context.Background(), vm)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrAgentNotRunning)
+}
+
+func TestProxmoxAPIClient_GetSDNZones(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/sdn/zones\z`,
+		newJSONResponder(200, []map[string]any{{"zone": "zone0", "type": "vlan", "bridge": "vmbr0", "nodes": "pve1,pve2", "mtu": 1450}}))
+
+	zones, err := client.GetSDNZones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	require.Equal(t, "zone0", zones[0].Name)
+	require.Equal(t, "vlan", zones[0].Type)
+	require.Equal(t, "vmbr0", zones[0].Bridge)
+	require.Equal(t, proxmox.CSV{"pve1", "pve2"}, zones[0].Nodes)
+	require.Equal(t, 1450, zones[0].MTU)
+}
+
+func TestProxmoxAPIClient_CreateSDNSubnet(t *testing.T) {
+	client := newTestClient(t)
+
+	var body map[string]any
+	httpmock.RegisterResponder(http.MethodPost, `=~/cluster/sdn/vnets/vnet0/subnets\z`,
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{"data": nil})
+		})
+
+	err := client.CreateSDNSubnet(context.Background(), "vnet0", &proxmox.SDNSubnetOptions{Subnet: "10.0.0.0/24", Gateway: "10.0.0.1"})
+	require.NoError(t, err)
+	require.Equal(t, "subnet", body["type"])
+	require.Equal(t, "vnet0", body["vnet"])
+	require.Equal(t, "10.0.0.0/24", body["subnet"])
+}
+
+func TestProxmoxAPIClient_DeleteSDNSubnet(t *testing.T) {
+	tests := []struct {
+		name   string
+		subnet *proxmox.VNetSubnet
+		path   string
+	}{
+		{
+			name:   "with id",
+			subnet: &proxmox.VNetSubnet{ID: "zone0-10.0.0.0-24", CIDR: "10.0.0.0/24"},
+			path:   `=~/cluster/sdn/vnets/vnet0/subnets/zone0-10.0.0.0-24\z`,
+		},
+		{
+			name:   "without id",
+			subnet: &proxmox.VNetSubnet{Zone: "zone1", CIDR: "2001:db8::/64"},
+			path:   `=~/cluster/sdn/vnets/vnet0/subnets/zone1-2001:db8::-64\z`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t)
+
+			httpmock.RegisterResponder(http.MethodDelete, test.path, newJSONResponder(200, nil))
+
+			require.NoError(t, client.DeleteSDNSubnet(context.Background(), "vnet0", test.subnet))
+		})
+	}
+}
+
+func TestProxmoxAPIClient_ApplySDN(t *testing.T) {
+	upid := "UPID:test:000D6BDA:041E0A54:654A5A1D:reloadnetworkall::root@pam:"
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/status`,
+		newJSONResponder(200, proxmox.NodeStatuses{{Name: "test"}}))
+	httpmock.RegisterResponder(http.MethodPut, `=~/cluster/sdn`,
+		newJSONResponder(200, upid))
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/test/tasks/`+upid+`/status`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{"data": proxmox.Task{UPID: proxmox.UPID(upid), Status: "stopped", ExitStatus: "OK"}}))
+
+	require.NoError(t, client.ApplySDN(context.Background()))
+}
+
+func TestProxmoxAPIClient_UpdateVMFirewallOptions(t *testing.T) {
+	client := newTestClient(t)
+	vm := &proxmox.VirtualMachine{Node: "test", VMID: 100}
+
+	var body map[string]any
+	httpmock.RegisterResponder(http.MethodPut, `=~/nodes/test/qemu/100/firewall/options\z`,
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{"data": nil})
+		})
+
+	// disabling the firewall must send the enable flag.
+	require.NoError(t, client.UpdateVMFirewallOptions(context.Background(), vm, &proxmox.FirewallVirtualMachineOption{PolicyIn: "DROP"}))
+	require.Equal(t, map[string]any{"enable": float64(0), "policy_in": "DROP"}, body)
+}
+
+func TestProxmoxAPIClient_DeleteFirewallIPSetEntry(t *testing.T) {
+	client := newTestClient(t)
+
+	var path string
+	httpmock.RegisterResponder(http.MethodDelete, `=~/cluster/firewall/ipset/k8s/`,
+		func(req *http.Request) (*http.Response, error) {
+			path = req.URL.EscapedPath()
+			return httpmock.NewJsonResponse(200, map[string]any{"data": nil})
+		})
+
+	require.NoError(t, client.DeleteFirewallIPSetEntry(context.Background(), "k8s", "10.0.0.0/24"))
+	require.True(t, strings.HasSuffix(path, "/cluster/firewall/ipset/k8s/10.0.0.0%2F24"), path)
+}
+
+func TestProxmoxAPIClient_CreateHAResource(t *testing.T) {
+	client := newTestClient(t)
+
+	var body map[string]any
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/status`,
+		newJSONResponder(200, proxmox.NodeStatuses{{Name: "test"}}))
+	httpmock.RegisterResponder(http.MethodPost, `=~/cluster/ha/resources\z`,
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{"data": nil})
+		})
+
+	err := client.CreateHAResource(context.Background(), &proxmox.HAResourceCreateOption{
+		SID: "vm:100", Group: "cp", State: new("started"), MaxRestart: new(0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "vm:100", body["sid"])
+	require.Equal(t, "cp", body["group"])
+	require.Equal(t, "started", body["state"])
+	require.InDelta(t, 0, body["max_restart"], 0)
+	require.NotContains(t, body, "max_relocate")
+}
+
+func TestProxmoxAPIClient_DeleteHAResource(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/status`,
+		newJSONResponder(200, proxmox.NodeStatuses{{Name: "test"}}))
+	httpmock.RegisterResponder(http.MethodDelete, `=~/cluster/ha/resources/vm:100\?purge=1\z`,
+		newJSONResponder(200, nil))
+
+	require.NoError(t, client.DeleteHAResource(context.Background(), "vm:100"))
+}
+
+func TestProxmoxAPIClient_GetHAGroup(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/status`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{"data": proxmox.NodeStatuses{{Name: "test"}}}))
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/ha/groups/cp\z`,
+		newJSONResponder(200, proxmox.HAGroup{Group: "cp", Nodes: "node1:2,node2"}))
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/ha/groups/missing\z`,
+		newJSONResponder(500, nil))
+
+	group, err := client.GetHAGroup(context.Background(), "cp")
+	require.NoError(t, err)
+	require.Equal(t, "node1:2,node2", group.Nodes)
+
+	_, err = client.GetHAGroup(context.Background(), "missing")
+	require.Error(t, err)
+}
+
+func TestProxmoxAPIClient_AddPoolVMs(t *testing.T) {
+	client := newTestClient(t)
+
+	var body map[string]any
+	httpmock.RegisterResponder(http.MethodPut, `=~/pools\z`,
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{"data": nil})
+		})
+
+	require.NoError(t, client.AddPoolVMs(context.Background(), "tenant", []int64{100, 101}))
+	require.Equal(t, "tenant", body["poolid"])
+	require.Equal(t, "100,101", body["vms"])
+}
+
+func TestProxmoxAPIClient_GetPoolACLs(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/access/acl\z`,
+		newJSONResponder(200, proxmox.ACLs{
+			{Path: "/pool/tenant", Type: "user", UGID: "tenant@pve", RoleID: "PVEAuditor"},
+			{Path: "/pool/other", Type: "user", UGID: "other@pve", RoleID: "PVEAuditor"},
+			{Path: "/", Type: "group", UGID: "admins", RoleID: "Administrator"},
+		}))
+
+	acls, err := client.GetPoolACLs(context.Background(), "tenant")
+	require.NoError(t, err)
+	require.Len(t, acls, 1)
+	require.Equal(t, "tenant@pve", acls[0].UGID)
+}
+
+func TestProxmoxAPIClient_UpdatePoolACL(t *testing.T) {
+	client := newTestClient(t)
+
+	var body map[string]any
+	httpmock.RegisterResponder(http.MethodPut, `=~/access/acl\z`,
+		func(req *http.Request) (*http.Response, error) {
+			body = nil
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{"data": nil})
+		})
+
+	acl := &proxmox.ACL{Type: "group", UGID: "tenants", RoleID: "PVEAuditor"}
+	require.NoError(t, client.UpdatePoolACL(context.Background(), "tenant", acl, false))
+	require.Equal(t, "/pool/tenant", body["path"])
+	require.Equal(t, "tenants", body["groups"])
+	require.Equal(t, "PVEAuditor", body["roles"])
+	require.NotContains(t, body, "users")
+	require.NotContains(t, body, "delete")
+
+	acl = &proxmox.ACL{Type: "user", UGID: "tenant@pve", RoleID: "PVEAuditor"}
+	require.NoError(t, client.UpdatePoolACL(context.Background(), "tenant", acl, true))
+	require.Equal(t, "tenant@pve", body["users"])
+	require.Contains(t, body, "delete")
+}
+
+func TestProxmoxAPIClient_GetVMResources(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/status`,
+		newJSONResponder(200, proxmox.NodeStatuses{{Name: "test"}}))
+	httpmock.RegisterResponder(http.MethodGet, `=~/cluster/resources`,
+		newJSONResponder(200, proxmox.ClusterResources{
+			{VMID: 100, Type: "qemu", Node: "pve1"},
+			{VMID: 9000, Type: "qemu", Node: "pve1", Template: 1},
+		}))
+
+	resources, err := client.GetVMResources(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	require.Equal(t, uint64(9000), resources[1].VMID)
+}
+
+func TestProxmoxAPIClient_GetNodeStorages(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve1/storage\z`,
+		newJSONResponder(200, proxmox.Storages{
+			{Name: "local", Enabled: 1, Active: 1, Content: "iso,snippets"},
+		}))
+
+	storages, err := client.GetNodeStorages(context.Background(), "pve1")
+	require.NoError(t, err)
+	require.Len(t, storages, 1)
+	require.Equal(t, "local", storages[0].Name)
+	require.Equal(t, "pve1", storages[0].Node)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve2/storage\z`, newJSONResponder(500, nil))
+	_, err = client.GetNodeStorages(context.Background(), "pve2")
+	require.Error(t, err)
+}
+
+func TestProxmoxAPIClient_GetNodeNetworks(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/nodes/pve1/network\z`,
+		newJSONResponder(200, proxmox.NodeNetworks{
+			{Iface: "vmbr0", Type: "bridge", BridgeVLANAware: 1},
+			{Iface: "eno1", Type: "eth"},
+		}))
+
+	networks, err := client.GetNodeNetworks(context.Background(), "pve1")
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	require.Equal(t, 1, networks[0].BridgeVLANAware)
+}
+
+func TestProxmoxAPIClient_GetPermissions(t *testing.T) {
+	client := newTestClient(t)
+
+	httpmock.RegisterResponder(http.MethodGet, `=~/access/permissions\z`,
+		newJSONResponder(200, map[string]map[string]int{
+			"/":     {"Sys.Audit": 1},
+			"/vms":  {"VM.Allocate": 1, "VM.Audit": 0},
+			"/pool": {},
+		}))
+
+	permissions, err := client.GetPermissions(context.Background())
+	require.NoError(t, err)
+	require.Len(t, permissions, 3)
+	require.True(t, bool(permissions["/vms"]["VM.Allocate"]))
+	require.False(t, bool(permissions["/vms"]["VM.Audit"]))
+}
@@ -24,4 +24,7 @@ var (
 
 	// ErrTemplateNotFound is returned when a VM template is not found.
 	ErrTemplateNotFound = errors.New("VM template not found")
+
+	// ErrAgentNotRunning is returned when the QEMU guest agent of a VM is not running (yet).
+	ErrAgentNotRunning = errors.New("QEMU guest agent is not running")
 )
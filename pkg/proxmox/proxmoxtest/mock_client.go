@@ -24,6 +24,148 @@ func (_m *MockClient) EXPECT() *MockClient_Expecter {
 	return &MockClient_Expecter{mock: &_m.Mock}
 }
 
+// AddFirewallIPSetEntry provides a mock function with given fields: ctx, name, cidr
+func (_m *MockClient) AddFirewallIPSetEntry(ctx context.Context, name string, cidr string) error {
+	ret := _m.Called(ctx, name, cidr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFirewallIPSetEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, cidr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_AddFirewallIPSetEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFirewallIPSetEntry'
+type MockClient_AddFirewallIPSetEntry_Call struct {
+	*mock.Call
+}
+
+// AddFirewallIPSetEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - cidr string
+func (_e *MockClient_Expecter) AddFirewallIPSetEntry(ctx interface{}, name interface{}, cidr interface{}) *MockClient_AddFirewallIPSetEntry_Call {
+	return &MockClient_AddFirewallIPSetEntry_Call{Call: _e.mock.On("AddFirewallIPSetEntry", ctx, name, cidr)}
+}
+
+func (_c *MockClient_AddFirewallIPSetEntry_Call) Run(run func(ctx context.Context, name string, cidr string)) *MockClient_AddFirewallIPSetEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_AddFirewallIPSetEntry_Call) Return(_a0 error) *MockClient_AddFirewallIPSetEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_AddFirewallIPSetEntry_Call) RunAndReturn(run func(context.Context, string, string) error) *MockClient_AddFirewallIPSetEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddPoolVMs provides a mock function with given fields: ctx, name, vmIDs
+func (_m *MockClient) AddPoolVMs(ctx context.Context, name string, vmIDs []int64) error {
+	ret := _m.Called(ctx, name, vmIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPoolVMs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int64) error); ok {
+		r0 = rf(ctx, name, vmIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_AddPoolVMs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPoolVMs'
+type MockClient_AddPoolVMs_Call struct {
+	*mock.Call
+}
+
+// AddPoolVMs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - vmIDs []int64
+func (_e *MockClient_Expecter) AddPoolVMs(ctx interface{}, name interface{}, vmIDs interface{}) *MockClient_AddPoolVMs_Call {
+	return &MockClient_AddPoolVMs_Call{Call: _e.mock.On("AddPoolVMs", ctx, name, vmIDs)}
+}
+
+func (_c *MockClient_AddPoolVMs_Call) Run(run func(ctx context.Context, name string, vmIDs []int64)) *MockClient_AddPoolVMs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockClient_AddPoolVMs_Call) Return(_a0 error) *MockClient_AddPoolVMs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_AddPoolVMs_Call) RunAndReturn(run func(context.Context, string, []int64) error) *MockClient_AddPoolVMs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApplySDN provides a mock function with given fields: ctx
+func (_m *MockClient) ApplySDN(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplySDN")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_ApplySDN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplySDN'
+type MockClient_ApplySDN_Call struct {
+	*mock.Call
+}
+
+// ApplySDN is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ApplySDN(ctx interface{}) *MockClient_ApplySDN_Call {
+	return &MockClient_ApplySDN_Call{Call: _e.mock.On("ApplySDN", ctx)}
+}
+
+func (_c *MockClient_ApplySDN_Call) Run(run func(ctx context.Context)) *MockClient_ApplySDN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ApplySDN_Call) Return(_a0 error) *MockClient_ApplySDN_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_ApplySDN_Call) RunAndReturn(run func(context.Context) error) *MockClient_ApplySDN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckID provides a mock function with given fields: ctx, vmID
 func (_m *MockClient) CheckID(ctx context.Context, vmID int64) (bool, error) {
 	ret := _m.Called(ctx, vmID)
@@ -270,89 +412,1978 @@ func (_c *MockClient_ConfigureVM_Call) RunAndReturn(run func(context.Context, *g
 	return _c
 }
 
-// DeleteVM provides a mock function with given fields: ctx, nodeName, vmID
-func (_m *MockClient) DeleteVM(ctx context.Context, nodeName string, vmID int64) (*go_proxmox.Task, error) {
-	ret := _m.Called(ctx, nodeName, vmID)
+// CreateFirewallIPSet provides a mock function with given fields: ctx, name, comment
+func (_m *MockClient) CreateFirewallIPSet(ctx context.Context, name string, comment string) error {
+	ret := _m.Called(ctx, name, comment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteVM")
+		panic("no return value specified for CreateFirewallIPSet")
 	}
 
-	var r0 *go_proxmox.Task
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*go_proxmox.Task, error)); ok {
-		return rf(ctx, nodeName, vmID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, comment)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *go_proxmox.Task); ok {
-		r0 = rf(ctx, nodeName, vmID)
+
+	return r0
+}
+
+// MockClient_CreateFirewallIPSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFirewallIPSet'
+type MockClient_CreateFirewallIPSet_Call struct {
+	*mock.Call
+}
+
+// CreateFirewallIPSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - comment string
+func (_e *MockClient_Expecter) CreateFirewallIPSet(ctx interface{}, name interface{}, comment interface{}) *MockClient_CreateFirewallIPSet_Call {
+	return &MockClient_CreateFirewallIPSet_Call{Call: _e.mock.On("CreateFirewallIPSet", ctx, name, comment)}
+}
+
+func (_c *MockClient_CreateFirewallIPSet_Call) Run(run func(ctx context.Context, name string, comment string)) *MockClient_CreateFirewallIPSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateFirewallIPSet_Call) Return(_a0 error) *MockClient_CreateFirewallIPSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateFirewallIPSet_Call) RunAndReturn(run func(context.Context, string, string) error) *MockClient_CreateFirewallIPSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateHAResource provides a mock function with given fields: ctx, resource
+func (_m *MockClient) CreateHAResource(ctx context.Context, resource *go_proxmox.HAResourceCreateOption) error {
+	ret := _m.Called(ctx, resource)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHAResource")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.HAResourceCreateOption) error); ok {
+		r0 = rf(ctx, resource)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*go_proxmox.Task)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = rf(ctx, nodeName, vmID)
+	return r0
+}
+
+// MockClient_CreateHAResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHAResource'
+type MockClient_CreateHAResource_Call struct {
+	*mock.Call
+}
+
+// CreateHAResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resource *go_proxmox.HAResourceCreateOption
+func (_e *MockClient_Expecter) CreateHAResource(ctx interface{}, resource interface{}) *MockClient_CreateHAResource_Call {
+	return &MockClient_CreateHAResource_Call{Call: _e.mock.On("CreateHAResource", ctx, resource)}
+}
+
+func (_c *MockClient_CreateHAResource_Call) Run(run func(ctx context.Context, resource *go_proxmox.HAResourceCreateOption)) *MockClient_CreateHAResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*go_proxmox.HAResourceCreateOption))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateHAResource_Call) Return(_a0 error) *MockClient_CreateHAResource_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateHAResource_Call) RunAndReturn(run func(context.Context, *go_proxmox.HAResourceCreateOption) error) *MockClient_CreateHAResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePool provides a mock function with given fields: ctx, name, comment
+func (_m *MockClient) CreatePool(ctx context.Context, name string, comment string) error {
+	ret := _m.Called(ctx, name, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePool")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, comment)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockClient_DeleteVM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVM'
-type MockClient_DeleteVM_Call struct {
+// MockClient_CreatePool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePool'
+type MockClient_CreatePool_Call struct {
 	*mock.Call
 }
 
-// DeleteVM is a helper method to define mock.On call
+// CreatePool is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nodeName string
-//   - vmID int64
-func (_e *MockClient_Expecter) DeleteVM(ctx interface{}, nodeName interface{}, vmID interface{}) *MockClient_DeleteVM_Call {
-	return &MockClient_DeleteVM_Call{Call: _e.mock.On("DeleteVM", ctx, nodeName, vmID)}
+//   - name string
+//   - comment string
+func (_e *MockClient_Expecter) CreatePool(ctx interface{}, name interface{}, comment interface{}) *MockClient_CreatePool_Call {
+	return &MockClient_CreatePool_Call{Call: _e.mock.On("CreatePool", ctx, name, comment)}
 }
 
-func (_c *MockClient_DeleteVM_Call) Run(run func(ctx context.Context, nodeName string, vmID int64)) *MockClient_DeleteVM_Call {
+func (_c *MockClient_CreatePool_Call) Run(run func(ctx context.Context, name string, comment string)) *MockClient_CreatePool_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_DeleteVM_Call) Return(_a0 *go_proxmox.Task, _a1 error) *MockClient_DeleteVM_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockClient_CreatePool_Call) Return(_a0 error) *MockClient_CreatePool_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockClient_DeleteVM_Call) RunAndReturn(run func(context.Context, string, int64) (*go_proxmox.Task, error)) *MockClient_DeleteVM_Call {
+func (_c *MockClient_CreatePool_Call) RunAndReturn(run func(context.Context, string, string) error) *MockClient_CreatePool_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindVMResource provides a mock function with given fields: ctx, vmID
-func (_m *MockClient) FindVMResource(ctx context.Context, vmID uint64) (*go_proxmox.ClusterResource, error) {
-	ret := _m.Called(ctx, vmID)
+// CreateSDNSubnet provides a mock function with given fields: ctx, vnet, subnet
+func (_m *MockClient) CreateSDNSubnet(ctx context.Context, vnet string, subnet *go_proxmox.SDNSubnetOptions) error {
+	ret := _m.Called(ctx, vnet, subnet)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindVMResource")
+		panic("no return value specified for CreateSDNSubnet")
 	}
 
-	var r0 *go_proxmox.ClusterResource
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*go_proxmox.ClusterResource, error)); ok {
-		return rf(ctx, vmID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *go_proxmox.SDNSubnetOptions) error); ok {
+		r0 = rf(ctx, vnet, subnet)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateSDNSubnet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSDNSubnet'
+type MockClient_CreateSDNSubnet_Call struct {
+	*mock.Call
+}
+
+// CreateSDNSubnet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vnet string
+//   - subnet *go_proxmox.SDNSubnetOptions
+func (_e *MockClient_Expecter) CreateSDNSubnet(ctx interface{}, vnet interface{}, subnet interface{}) *MockClient_CreateSDNSubnet_Call {
+	return &MockClient_CreateSDNSubnet_Call{Call: _e.mock.On("CreateSDNSubnet", ctx, vnet, subnet)}
+}
+
+func (_c *MockClient_CreateSDNSubnet_Call) Run(run func(ctx context.Context, vnet string, subnet *go_proxmox.SDNSubnetOptions)) *MockClient_CreateSDNSubnet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*go_proxmox.SDNSubnetOptions))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateSDNSubnet_Call) Return(_a0 error) *MockClient_CreateSDNSubnet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateSDNSubnet_Call) RunAndReturn(run func(context.Context, string, *go_proxmox.SDNSubnetOptions) error) *MockClient_CreateSDNSubnet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSDNVNet provides a mock function with given fields: ctx, vnet
+func (_m *MockClient) CreateSDNVNet(ctx context.Context, vnet *go_proxmox.VNetOptions) error {
+	ret := _m.Called(ctx, vnet)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSDNVNet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VNetOptions) error); ok {
+		r0 = rf(ctx, vnet)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateSDNVNet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSDNVNet'
+type MockClient_CreateSDNVNet_Call struct {
+	*mock.Call
+}
+
+// CreateSDNVNet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vnet *go_proxmox.VNetOptions
+func (_e *MockClient_Expecter) CreateSDNVNet(ctx interface{}, vnet interface{}) *MockClient_CreateSDNVNet_Call {
+	return &MockClient_CreateSDNVNet_Call{Call: _e.mock.On("CreateSDNVNet", ctx, vnet)}
+}
+
+func (_c *MockClient_CreateSDNVNet_Call) Run(run func(ctx context.Context, vnet *go_proxmox.VNetOptions)) *MockClient_CreateSDNVNet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*go_proxmox.VNetOptions))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateSDNVNet_Call) Return(_a0 error) *MockClient_CreateSDNVNet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateSDNVNet_Call) RunAndReturn(run func(context.Context, *go_proxmox.VNetOptions) error) *MockClient_CreateSDNVNet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSDNZone provides a mock function with given fields: ctx, zone
+func (_m *MockClient) CreateSDNZone(ctx context.Context, zone *go_proxmox.SDNZoneOptions) error {
+	ret := _m.Called(ctx, zone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSDNZone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.SDNZoneOptions) error); ok {
+		r0 = rf(ctx, zone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateSDNZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSDNZone'
+type MockClient_CreateSDNZone_Call struct {
+	*mock.Call
+}
+
+// CreateSDNZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zone *go_proxmox.SDNZoneOptions
+func (_e *MockClient_Expecter) CreateSDNZone(ctx interface{}, zone interface{}) *MockClient_CreateSDNZone_Call {
+	return &MockClient_CreateSDNZone_Call{Call: _e.mock.On("CreateSDNZone", ctx, zone)}
+}
+
+func (_c *MockClient_CreateSDNZone_Call) Run(run func(ctx context.Context, zone *go_proxmox.SDNZoneOptions)) *MockClient_CreateSDNZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*go_proxmox.SDNZoneOptions))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateSDNZone_Call) Return(_a0 error) *MockClient_CreateSDNZone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateSDNZone_Call) RunAndReturn(run func(context.Context, *go_proxmox.SDNZoneOptions) error) *MockClient_CreateSDNZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVMFirewallRule provides a mock function with given fields: ctx, vm, rule
+func (_m *MockClient) CreateVMFirewallRule(ctx context.Context, vm *go_proxmox.VirtualMachine, rule *go_proxmox.FirewallRule) error {
+	ret := _m.Called(ctx, vm, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVMFirewallRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine, *go_proxmox.FirewallRule) error); ok {
+		r0 = rf(ctx, vm, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateVMFirewallRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVMFirewallRule'
+type MockClient_CreateVMFirewallRule_Call struct {
+	*mock.Call
+}
+
+// CreateVMFirewallRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vm *go_proxmox.VirtualMachine
+//   - rule *go_proxmox.FirewallRule
+func (_e *MockClient_Expecter) CreateVMFirewallRule(ctx interface{}, vm interface{}, rule interface{}) *MockClient_CreateVMFirewallRule_Call {
+	return &MockClient_CreateVMFirewallRule_Call{Call: _e.mock.On("CreateVMFirewallRule", ctx, vm, rule)}
+}
+
+func (_c *MockClient_CreateVMFirewallRule_Call) Run(run func(ctx context.Context, vm *go_proxmox.VirtualMachine, rule *go_proxmox.FirewallRule)) *MockClient_CreateVMFirewallRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*go_proxmox.VirtualMachine), args[2].(*go_proxmox.FirewallRule))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateVMFirewallRule_Call) Return(_a0 error) *MockClient_CreateVMFirewallRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateVMFirewallRule_Call) RunAndReturn(run func(context.Context, *go_proxmox.VirtualMachine, *go_proxmox.FirewallRule) error) *MockClient_CreateVMFirewallRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFirewallIPSet provides a mock function with given fields: ctx, name
+func (_m *MockClient) DeleteFirewallIPSet(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFirewallIPSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteFirewallIPSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFirewallIPSet'
+type MockClient_DeleteFirewallIPSet_Call struct {
+	*mock.Call
+}
+
+// DeleteFirewallIPSet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) DeleteFirewallIPSet(ctx interface{}, name interface{}) *MockClient_DeleteFirewallIPSet_Call {
+	return &MockClient_DeleteFirewallIPSet_Call{Call: _e.mock.On("DeleteFirewallIPSet", ctx, name)}
+}
+
+func (_c *MockClient_DeleteFirewallIPSet_Call) Run(run func(ctx context.Context, name string)) *MockClient_DeleteFirewallIPSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteFirewallIPSet_Call) Return(_a0 error) *MockClient_DeleteFirewallIPSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteFirewallIPSet_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeleteFirewallIPSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFirewallIPSetEntry provides a mock function with given fields: ctx, name, cidr
+func (_m *MockClient) DeleteFirewallIPSetEntry(ctx context.Context, name string, cidr string) error {
+	ret := _m.Called(ctx, name, cidr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFirewallIPSetEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, cidr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteFirewallIPSetEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFirewallIPSetEntry'
+type MockClient_DeleteFirewallIPSetEntry_Call struct {
+	*mock.Call
+}
+
+// DeleteFirewallIPSetEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - cidr string
+func (_e *MockClient_Expecter) DeleteFirewallIPSetEntry(ctx interface{}, name interface{}, cidr interface{}) *MockClient_DeleteFirewallIPSetEntry_Call {
+	return &MockClient_DeleteFirewallIPSetEntry_Call{Call: _e.mock.On("DeleteFirewallIPSetEntry", ctx, name, cidr)}
+}
+
+func (_c *MockClient_DeleteFirewallIPSetEntry_Call) Run(run func(ctx context.Context, name string, cidr string)) *MockClient_DeleteFirewallIPSetEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteFirewallIPSetEntry_Call) Return(_a0 error) *MockClient_DeleteFirewallIPSetEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteFirewallIPSetEntry_Call) RunAndReturn(run func(context.Context, string, string) error) *MockClient_DeleteFirewallIPSetEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteHAResource provides a mock function with given fields: ctx, sid
+func (_m *MockClient) DeleteHAResource(ctx context.Context, sid string) error {
+	ret := _m.Called(ctx, sid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteHAResource")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, sid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteHAResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteHAResource'
+type MockClient_DeleteHAResource_Call struct {
+	*mock.Call
+}
+
+// DeleteHAResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sid string
+func (_e *MockClient_Expecter) DeleteHAResource(ctx interface{}, sid interface{}) *MockClient_DeleteHAResource_Call {
+	return &MockClient_DeleteHAResource_Call{Call: _e.mock.On("DeleteHAResource", ctx, sid)}
+}
+
+func (_c *MockClient_DeleteHAResource_Call) Run(run func(ctx context.Context, sid string)) *MockClient_DeleteHAResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteHAResource_Call) Return(_a0 error) *MockClient_DeleteHAResource_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteHAResource_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeleteHAResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePool provides a mock function with given fields: ctx, name
+func (_m *MockClient) DeletePool(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePool")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeletePool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePool'
+type MockClient_DeletePool_Call struct {
+	*mock.Call
+}
+
+// DeletePool is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) DeletePool(ctx interface{}, name interface{}) *MockClient_DeletePool_Call {
+	return &MockClient_DeletePool_Call{Call: _e.mock.On("DeletePool", ctx, name)}
+}
+
+func (_c *MockClient_DeletePool_Call) Run(run func(ctx context.Context, name string)) *MockClient_DeletePool_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeletePool_Call) Return(_a0 error) *MockClient_DeletePool_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeletePool_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeletePool_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSDNSubnet provides a mock function with given fields: ctx, vnet, subnet
+func (_m *MockClient) DeleteSDNSubnet(ctx context.Context, vnet string, subnet *go_proxmox.VNetSubnet) error {
+	ret := _m.Called(ctx, vnet, subnet)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSDNSubnet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *go_proxmox.VNetSubnet) error); ok {
+		r0 = rf(ctx, vnet, subnet)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteSDNSubnet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSDNSubnet'
+type MockClient_DeleteSDNSubnet_Call struct {
+	*mock.Call
+}
+
+// DeleteSDNSubnet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vnet string
+//   - subnet *go_proxmox.VNetSubnet
+func (_e *MockClient_Expecter) DeleteSDNSubnet(ctx interface{}, vnet interface{}, subnet interface{}) *MockClient_DeleteSDNSubnet_Call {
+	return &MockClient_DeleteSDNSubnet_Call{Call: _e.mock.On("DeleteSDNSubnet", ctx, vnet, subnet)}
+}
+
+func (_c *MockClient_DeleteSDNSubnet_Call) Run(run func(ctx context.Context, vnet string, subnet *go_proxmox.VNetSubnet)) *MockClient_DeleteSDNSubnet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*go_proxmox.VNetSubnet))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteSDNSubnet_Call) Return(_a0 error) *MockClient_DeleteSDNSubnet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteSDNSubnet_Call) RunAndReturn(run func(context.Context, string, *go_proxmox.VNetSubnet) error) *MockClient_DeleteSDNSubnet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSDNVNet provides a mock function with given fields: ctx, name
+func (_m *MockClient) DeleteSDNVNet(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSDNVNet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteSDNVNet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSDNVNet'
+type MockClient_DeleteSDNVNet_Call struct {
+	*mock.Call
+}
+
+// DeleteSDNVNet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) DeleteSDNVNet(ctx interface{}, name interface{}) *MockClient_DeleteSDNVNet_Call {
+	return &MockClient_DeleteSDNVNet_Call{Call: _e.mock.On("DeleteSDNVNet", ctx, name)}
+}
+
+func (_c *MockClient_DeleteSDNVNet_Call) Run(run func(ctx context.Context, name string)) *MockClient_DeleteSDNVNet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteSDNVNet_Call) Return(_a0 error) *MockClient_DeleteSDNVNet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteSDNVNet_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeleteSDNVNet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSDNZone provides a mock function with given fields: ctx, name
+func (_m *MockClient) DeleteSDNZone(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSDNZone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteSDNZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSDNZone'
+type MockClient_DeleteSDNZone_Call struct {
+	*mock.Call
+}
+
+// DeleteSDNZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) DeleteSDNZone(ctx interface{}, name interface{}) *MockClient_DeleteSDNZone_Call {
+	return &MockClient_DeleteSDNZone_Call{Call: _e.mock.On("DeleteSDNZone", ctx, name)}
+}
+
+func (_c *MockClient_DeleteSDNZone_Call) Run(run func(ctx context.Context, name string)) *MockClient_DeleteSDNZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteSDNZone_Call) Return(_a0 error) *MockClient_DeleteSDNZone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteSDNZone_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeleteSDNZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVM provides a mock function with given fields: ctx, nodeName, vmID
+func (_m *MockClient) DeleteVM(ctx context.Context, nodeName string, vmID int64) (*go_proxmox.Task, error) {
+	ret := _m.Called(ctx, nodeName, vmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVM")
+	}
+
+	var r0 *go_proxmox.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*go_proxmox.Task, error)); ok {
+		return rf(ctx, nodeName, vmID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *go_proxmox.Task); ok {
+		r0 = rf(ctx, nodeName, vmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*go_proxmox.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, nodeName, vmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteVM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVM'
+type MockClient_DeleteVM_Call struct {
+	*mock.Call
+}
+
+// DeleteVM is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeName string
+//   - vmID int64
+func (_e *MockClient_Expecter) DeleteVM(ctx interface{}, nodeName interface{}, vmID interface{}) *MockClient_DeleteVM_Call {
+	return &MockClient_DeleteVM_Call{Call: _e.mock.On("DeleteVM", ctx, nodeName, vmID)}
+}
+
+func (_c *MockClient_DeleteVM_Call) Run(run func(ctx context.Context, nodeName string, vmID int64)) *MockClient_DeleteVM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteVM_Call) Return(_a0 *go_proxmox.Task, _a1 error) *MockClient_DeleteVM_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteVM_Call) RunAndReturn(run func(context.Context, string, int64) (*go_proxmox.Task, error)) *MockClient_DeleteVM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVMFirewallRule provides a mock function with given fields: ctx, vm, pos
+func (_m *MockClient) DeleteVMFirewallRule(ctx context.Context, vm *go_proxmox.VirtualMachine, pos int) error {
+	ret := _m.Called(ctx, vm, pos)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVMFirewallRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine, int) error); ok {
+		r0 = rf(ctx, vm, pos)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteVMFirewallRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVMFirewallRule'
+type MockClient_DeleteVMFirewallRule_Call struct {
+	*mock.Call
+}
+
+// DeleteVMFirewallRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vm *go_proxmox.VirtualMachine
+//   - pos int
+func (_e *MockClient_Expecter) DeleteVMFirewallRule(ctx interface{}, vm interface{}, pos interface{}) *MockClient_DeleteVMFirewallRule_Call {
+	return &MockClient_DeleteVMFirewallRule_Call{Call: _e.mock.On("DeleteVMFirewallRule", ctx, vm, pos)}
+}
+
+func (_c *MockClient_DeleteVMFirewallRule_Call) Run(run func(ctx context.Context, vm *go_proxmox.VirtualMachine, pos int)) *MockClient_DeleteVMFirewallRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*go_proxmox.VirtualMachine), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteVMFirewallRule_Call) Return(_a0 error) *MockClient_DeleteVMFirewallRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteVMFirewallRule_Call) RunAndReturn(run func(context.Context, *go_proxmox.VirtualMachine, int) error) *MockClient_DeleteVMFirewallRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindVMResource provides a mock function with given fields: ctx, vmID
+func (_m *MockClient) FindVMResource(ctx context.Context, vmID uint64) (*go_proxmox.ClusterResource, error) {
+	ret := _m.Called(ctx, vmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVMResource")
+	}
+
+	var r0 *go_proxmox.ClusterResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*go_proxmox.ClusterResource, error)); ok {
+		return rf(ctx, vmID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *go_proxmox.ClusterResource); ok {
+		r0 = rf(ctx, vmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*go_proxmox.ClusterResource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, vmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_FindVMResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindVMResource'
+type MockClient_FindVMResource_Call struct {
+	*mock.Call
+}
+
+// FindVMResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vmID uint64
+func (_e *MockClient_Expecter) FindVMResource(ctx interface{}, vmID interface{}) *MockClient_FindVMResource_Call {
+	return &MockClient_FindVMResource_Call{Call: _e.mock.On("FindVMResource", ctx, vmID)}
+}
+
+func (_c *MockClient_FindVMResource_Call) Run(run func(ctx context.Context, vmID uint64)) *MockClient_FindVMResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockClient_FindVMResource_Call) Return(_a0 *go_proxmox.ClusterResource, _a1 error) *MockClient_FindVMResource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_FindVMResource_Call) RunAndReturn(run func(context.Context, uint64) (*go_proxmox.ClusterResource, error)) *MockClient_FindVMResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindVMResourcesByTag provides a mock function with given fields: ctx, tag
+func (_m *MockClient) FindVMResourcesByTag(ctx context.Context, tag string) ([]*go_proxmox.ClusterResource, error) {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVMResourcesByTag")
+	}
+
+	var r0 []*go_proxmox.ClusterResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*go_proxmox.ClusterResource, error)); ok {
+		return rf(ctx, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*go_proxmox.ClusterResource); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.ClusterResource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_FindVMResourcesByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindVMResourcesByTag'
+type MockClient_FindVMResourcesByTag_Call struct {
+	*mock.Call
+}
+
+// FindVMResourcesByTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag string
+func (_e *MockClient_Expecter) FindVMResourcesByTag(ctx interface{}, tag interface{}) *MockClient_FindVMResourcesByTag_Call {
+	return &MockClient_FindVMResourcesByTag_Call{Call: _e.mock.On("FindVMResourcesByTag", ctx, tag)}
+}
+
+func (_c *MockClient_FindVMResourcesByTag_Call) Run(run func(ctx context.Context, tag string)) *MockClient_FindVMResourcesByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_FindVMResourcesByTag_Call) Return(_a0 []*go_proxmox.ClusterResource, _a1 error) *MockClient_FindVMResourcesByTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_FindVMResourcesByTag_Call) RunAndReturn(run func(context.Context, string) ([]*go_proxmox.ClusterResource, error)) *MockClient_FindVMResourcesByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindVMTemplateByTags provides a mock function with given fields: ctx, templateTags, resolutionPolicy
+func (_m *MockClient) FindVMTemplateByTags(ctx context.Context, templateTags []string, resolutionPolicy string) (string, int32, error) {
+	ret := _m.Called(ctx, templateTags, resolutionPolicy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVMTemplateByTags")
+	}
+
+	var r0 string
+	var r1 int32
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) (string, int32, error)); ok {
+		return rf(ctx, templateTags, resolutionPolicy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) string); ok {
+		r0 = rf(ctx, templateTags, resolutionPolicy)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) int32); ok {
+		r1 = rf(ctx, templateTags, resolutionPolicy)
+	} else {
+		r1 = ret.Get(1).(int32)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, string) error); ok {
+		r2 = rf(ctx, templateTags, resolutionPolicy)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockClient_FindVMTemplateByTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindVMTemplateByTags'
+type MockClient_FindVMTemplateByTags_Call struct {
+	*mock.Call
+}
+
+// FindVMTemplateByTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateTags []string
+//   - resolutionPolicy string
+func (_e *MockClient_Expecter) FindVMTemplateByTags(ctx interface{}, templateTags interface{}, resolutionPolicy interface{}) *MockClient_FindVMTemplateByTags_Call {
+	return &MockClient_FindVMTemplateByTags_Call{Call: _e.mock.On("FindVMTemplateByTags", ctx, templateTags, resolutionPolicy)}
+}
+
+func (_c *MockClient_FindVMTemplateByTags_Call) Run(run func(ctx context.Context, templateTags []string, resolutionPolicy string)) *MockClient_FindVMTemplateByTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_FindVMTemplateByTags_Call) Return(_a0 string, _a1 int32, _a2 error) *MockClient_FindVMTemplateByTags_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockClient_FindVMTemplateByTags_Call) RunAndReturn(run func(context.Context, []string, string) (string, int32, error)) *MockClient_FindVMTemplateByTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirewallIPSetEntries provides a mock function with given fields: ctx, name
+func (_m *MockClient) GetFirewallIPSetEntries(ctx context.Context, name string) ([]*go_proxmox.FirewallIPSetEntry, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirewallIPSetEntries")
+	}
+
+	var r0 []*go_proxmox.FirewallIPSetEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*go_proxmox.FirewallIPSetEntry, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*go_proxmox.FirewallIPSetEntry); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.FirewallIPSetEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetFirewallIPSetEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirewallIPSetEntries'
+type MockClient_GetFirewallIPSetEntries_Call struct {
+	*mock.Call
+}
+
+// GetFirewallIPSetEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) GetFirewallIPSetEntries(ctx interface{}, name interface{}) *MockClient_GetFirewallIPSetEntries_Call {
+	return &MockClient_GetFirewallIPSetEntries_Call{Call: _e.mock.On("GetFirewallIPSetEntries", ctx, name)}
+}
+
+func (_c *MockClient_GetFirewallIPSetEntries_Call) Run(run func(ctx context.Context, name string)) *MockClient_GetFirewallIPSetEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetFirewallIPSetEntries_Call) Return(_a0 []*go_proxmox.FirewallIPSetEntry, _a1 error) *MockClient_GetFirewallIPSetEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetFirewallIPSetEntries_Call) RunAndReturn(run func(context.Context, string) ([]*go_proxmox.FirewallIPSetEntry, error)) *MockClient_GetFirewallIPSetEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirewallIPSets provides a mock function with given fields: ctx
+func (_m *MockClient) GetFirewallIPSets(ctx context.Context) ([]*go_proxmox.FirewallIPSet, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirewallIPSets")
+	}
+
+	var r0 []*go_proxmox.FirewallIPSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*go_proxmox.FirewallIPSet, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*go_proxmox.FirewallIPSet); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.FirewallIPSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetFirewallIPSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirewallIPSets'
+type MockClient_GetFirewallIPSets_Call struct {
+	*mock.Call
+}
+
+// GetFirewallIPSets is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetFirewallIPSets(ctx interface{}) *MockClient_GetFirewallIPSets_Call {
+	return &MockClient_GetFirewallIPSets_Call{Call: _e.mock.On("GetFirewallIPSets", ctx)}
+}
+
+func (_c *MockClient_GetFirewallIPSets_Call) Run(run func(ctx context.Context)) *MockClient_GetFirewallIPSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetFirewallIPSets_Call) Return(_a0 []*go_proxmox.FirewallIPSet, _a1 error) *MockClient_GetFirewallIPSets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetFirewallIPSets_Call) RunAndReturn(run func(context.Context) ([]*go_proxmox.FirewallIPSet, error)) *MockClient_GetFirewallIPSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHAGroup provides a mock function with given fields: ctx, name
+func (_m *MockClient) GetHAGroup(ctx context.Context, name string) (*go_proxmox.HAGroup, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHAGroup")
+	}
+
+	var r0 *go_proxmox.HAGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*go_proxmox.HAGroup, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *go_proxmox.HAGroup); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*go_proxmox.HAGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetHAGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHAGroup'
+type MockClient_GetHAGroup_Call struct {
+	*mock.Call
+}
+
+// GetHAGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) GetHAGroup(ctx interface{}, name interface{}) *MockClient_GetHAGroup_Call {
+	return &MockClient_GetHAGroup_Call{Call: _e.mock.On("GetHAGroup", ctx, name)}
+}
+
+func (_c *MockClient_GetHAGroup_Call) Run(run func(ctx context.Context, name string)) *MockClient_GetHAGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetHAGroup_Call) Return(_a0 *go_proxmox.HAGroup, _a1 error) *MockClient_GetHAGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetHAGroup_Call) RunAndReturn(run func(context.Context, string) (*go_proxmox.HAGroup, error)) *MockClient_GetHAGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHAResource provides a mock function with given fields: ctx, sid
+func (_m *MockClient) GetHAResource(ctx context.Context, sid string) (*go_proxmox.HAResource, error) {
+	ret := _m.Called(ctx, sid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHAResource")
+	}
+
+	var r0 *go_proxmox.HAResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*go_proxmox.HAResource, error)); ok {
+		return rf(ctx, sid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *go_proxmox.HAResource); ok {
+		r0 = rf(ctx, sid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*go_proxmox.HAResource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetHAResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHAResource'
+type MockClient_GetHAResource_Call struct {
+	*mock.Call
+}
+
+// GetHAResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sid string
+func (_e *MockClient_Expecter) GetHAResource(ctx interface{}, sid interface{}) *MockClient_GetHAResource_Call {
+	return &MockClient_GetHAResource_Call{Call: _e.mock.On("GetHAResource", ctx, sid)}
+}
+
+func (_c *MockClient_GetHAResource_Call) Run(run func(ctx context.Context, sid string)) *MockClient_GetHAResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetHAResource_Call) Return(_a0 *go_proxmox.HAResource, _a1 error) *MockClient_GetHAResource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetHAResource_Call) RunAndReturn(run func(context.Context, string) (*go_proxmox.HAResource, error)) *MockClient_GetHAResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNodeNetworks provides a mock function with given fields: ctx, nodeName
+func (_m *MockClient) GetNodeNetworks(ctx context.Context, nodeName string) ([]*go_proxmox.NodeNetwork, error) {
+	ret := _m.Called(ctx, nodeName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNodeNetworks")
+	}
+
+	var r0 []*go_proxmox.NodeNetwork
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*go_proxmox.NodeNetwork, error)); ok {
+		return rf(ctx, nodeName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*go_proxmox.NodeNetwork); ok {
+		r0 = rf(ctx, nodeName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.NodeNetwork)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, nodeName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetNodeNetworks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNodeNetworks'
+type MockClient_GetNodeNetworks_Call struct {
+	*mock.Call
+}
+
+// GetNodeNetworks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeName string
+func (_e *MockClient_Expecter) GetNodeNetworks(ctx interface{}, nodeName interface{}) *MockClient_GetNodeNetworks_Call {
+	return &MockClient_GetNodeNetworks_Call{Call: _e.mock.On("GetNodeNetworks", ctx, nodeName)}
+}
+
+func (_c *MockClient_GetNodeNetworks_Call) Run(run func(ctx context.Context, nodeName string)) *MockClient_GetNodeNetworks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetNodeNetworks_Call) Return(_a0 []*go_proxmox.NodeNetwork, _a1 error) *MockClient_GetNodeNetworks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetNodeNetworks_Call) RunAndReturn(run func(context.Context, string) ([]*go_proxmox.NodeNetwork, error)) *MockClient_GetNodeNetworks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNodeStorages provides a mock function with given fields: ctx, nodeName
+func (_m *MockClient) GetNodeStorages(ctx context.Context, nodeName string) ([]*go_proxmox.Storage, error) {
+	ret := _m.Called(ctx, nodeName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNodeStorages")
+	}
+
+	var r0 []*go_proxmox.Storage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*go_proxmox.Storage, error)); ok {
+		return rf(ctx, nodeName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*go_proxmox.Storage); ok {
+		r0 = rf(ctx, nodeName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.Storage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, nodeName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetNodeStorages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNodeStorages'
+type MockClient_GetNodeStorages_Call struct {
+	*mock.Call
+}
+
+// GetNodeStorages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeName string
+func (_e *MockClient_Expecter) GetNodeStorages(ctx interface{}, nodeName interface{}) *MockClient_GetNodeStorages_Call {
+	return &MockClient_GetNodeStorages_Call{Call: _e.mock.On("GetNodeStorages", ctx, nodeName)}
+}
+
+func (_c *MockClient_GetNodeStorages_Call) Run(run func(ctx context.Context, nodeName string)) *MockClient_GetNodeStorages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetNodeStorages_Call) Return(_a0 []*go_proxmox.Storage, _a1 error) *MockClient_GetNodeStorages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetNodeStorages_Call) RunAndReturn(run func(context.Context, string) ([]*go_proxmox.Storage, error)) *MockClient_GetNodeStorages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNodes provides a mock function with given fields: ctx
+func (_m *MockClient) GetNodes(ctx context.Context) ([]*go_proxmox.NodeStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNodes")
+	}
+
+	var r0 []*go_proxmox.NodeStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*go_proxmox.NodeStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*go_proxmox.NodeStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.NodeStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNodes'
+type MockClient_GetNodes_Call struct {
+	*mock.Call
+}
+
+// GetNodes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetNodes(ctx interface{}) *MockClient_GetNodes_Call {
+	return &MockClient_GetNodes_Call{Call: _e.mock.On("GetNodes", ctx)}
+}
+
+func (_c *MockClient_GetNodes_Call) Run(run func(ctx context.Context)) *MockClient_GetNodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetNodes_Call) Return(_a0 []*go_proxmox.NodeStatus, _a1 error) *MockClient_GetNodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetNodes_Call) RunAndReturn(run func(context.Context) ([]*go_proxmox.NodeStatus, error)) *MockClient_GetNodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPermissions provides a mock function with given fields: ctx
+func (_m *MockClient) GetPermissions(ctx context.Context) (go_proxmox.Permissions, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 go_proxmox.Permissions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (go_proxmox.Permissions, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) go_proxmox.Permissions); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(go_proxmox.Permissions)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPermissions'
+type MockClient_GetPermissions_Call struct {
+	*mock.Call
+}
+
+// GetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetPermissions(ctx interface{}) *MockClient_GetPermissions_Call {
+	return &MockClient_GetPermissions_Call{Call: _e.mock.On("GetPermissions", ctx)}
+}
+
+func (_c *MockClient_GetPermissions_Call) Run(run func(ctx context.Context)) *MockClient_GetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetPermissions_Call) Return(_a0 go_proxmox.Permissions, _a1 error) *MockClient_GetPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetPermissions_Call) RunAndReturn(run func(context.Context) (go_proxmox.Permissions, error)) *MockClient_GetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPool provides a mock function with given fields: ctx, name
+func (_m *MockClient) GetPool(ctx context.Context, name string) (*go_proxmox.Pool, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPool")
+	}
+
+	var r0 *go_proxmox.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*go_proxmox.Pool, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *go_proxmox.Pool); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*go_proxmox.Pool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetPool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPool'
+type MockClient_GetPool_Call struct {
+	*mock.Call
+}
+
+// GetPool is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) GetPool(ctx interface{}, name interface{}) *MockClient_GetPool_Call {
+	return &MockClient_GetPool_Call{Call: _e.mock.On("GetPool", ctx, name)}
+}
+
+func (_c *MockClient_GetPool_Call) Run(run func(ctx context.Context, name string)) *MockClient_GetPool_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetPool_Call) Return(_a0 *go_proxmox.Pool, _a1 error) *MockClient_GetPool_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetPool_Call) RunAndReturn(run func(context.Context, string) (*go_proxmox.Pool, error)) *MockClient_GetPool_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPoolACLs provides a mock function with given fields: ctx, name
+func (_m *MockClient) GetPoolACLs(ctx context.Context, name string) ([]*go_proxmox.ACL, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPoolACLs")
+	}
+
+	var r0 []*go_proxmox.ACL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*go_proxmox.ACL, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*go_proxmox.ACL); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.ACL)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetPoolACLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPoolACLs'
+type MockClient_GetPoolACLs_Call struct {
+	*mock.Call
+}
+
+// GetPoolACLs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockClient_Expecter) GetPoolACLs(ctx interface{}, name interface{}) *MockClient_GetPoolACLs_Call {
+	return &MockClient_GetPoolACLs_Call{Call: _e.mock.On("GetPoolACLs", ctx, name)}
+}
+
+func (_c *MockClient_GetPoolACLs_Call) Run(run func(ctx context.Context, name string)) *MockClient_GetPoolACLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetPoolACLs_Call) Return(_a0 []*go_proxmox.ACL, _a1 error) *MockClient_GetPoolACLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetPoolACLs_Call) RunAndReturn(run func(context.Context, string) ([]*go_proxmox.ACL, error)) *MockClient_GetPoolACLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPools provides a mock function with given fields: ctx
+func (_m *MockClient) GetPools(ctx context.Context) ([]*go_proxmox.Pool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPools")
+	}
+
+	var r0 []*go_proxmox.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*go_proxmox.Pool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*go_proxmox.Pool); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.Pool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetPools_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPools'
+type MockClient_GetPools_Call struct {
+	*mock.Call
+}
+
+// GetPools is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetPools(ctx interface{}) *MockClient_GetPools_Call {
+	return &MockClient_GetPools_Call{Call: _e.mock.On("GetPools", ctx)}
+}
+
+func (_c *MockClient_GetPools_Call) Run(run func(ctx context.Context)) *MockClient_GetPools_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetPools_Call) Return(_a0 []*go_proxmox.Pool, _a1 error) *MockClient_GetPools_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetPools_Call) RunAndReturn(run func(context.Context) ([]*go_proxmox.Pool, error)) *MockClient_GetPools_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReservableMemoryBytes provides a mock function with given fields: ctx, nodeName, nodeMemoryAdjustment
+func (_m *MockClient) GetReservableMemoryBytes(ctx context.Context, nodeName string, nodeMemoryAdjustment int64) (uint64, error) {
+	ret := _m.Called(ctx, nodeName, nodeMemoryAdjustment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservableMemoryBytes")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (uint64, error)); ok {
+		return rf(ctx, nodeName, nodeMemoryAdjustment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) uint64); ok {
+		r0 = rf(ctx, nodeName, nodeMemoryAdjustment)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, nodeName, nodeMemoryAdjustment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetReservableMemoryBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReservableMemoryBytes'
+type MockClient_GetReservableMemoryBytes_Call struct {
+	*mock.Call
+}
+
+// GetReservableMemoryBytes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeName string
+//   - nodeMemoryAdjustment int64
+func (_e *MockClient_Expecter) GetReservableMemoryBytes(ctx interface{}, nodeName interface{}, nodeMemoryAdjustment interface{}) *MockClient_GetReservableMemoryBytes_Call {
+	return &MockClient_GetReservableMemoryBytes_Call{Call: _e.mock.On("GetReservableMemoryBytes", ctx, nodeName, nodeMemoryAdjustment)}
+}
+
+func (_c *MockClient_GetReservableMemoryBytes_Call) Run(run func(ctx context.Context, nodeName string, nodeMemoryAdjustment int64)) *MockClient_GetReservableMemoryBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetReservableMemoryBytes_Call) Return(_a0 uint64, _a1 error) *MockClient_GetReservableMemoryBytes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetReservableMemoryBytes_Call) RunAndReturn(run func(context.Context, string, int64) (uint64, error)) *MockClient_GetReservableMemoryBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSDNSubnets provides a mock function with given fields: ctx, vnet
+func (_m *MockClient) GetSDNSubnets(ctx context.Context, vnet string) ([]*go_proxmox.VNetSubnet, error) {
+	ret := _m.Called(ctx, vnet)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSDNSubnets")
+	}
+
+	var r0 []*go_proxmox.VNetSubnet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*go_proxmox.VNetSubnet, error)); ok {
+		return rf(ctx, vnet)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*go_proxmox.VNetSubnet); ok {
+		r0 = rf(ctx, vnet)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.VNetSubnet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, vnet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetSDNSubnets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSDNSubnets'
+type MockClient_GetSDNSubnets_Call struct {
+	*mock.Call
+}
+
+// GetSDNSubnets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vnet string
+func (_e *MockClient_Expecter) GetSDNSubnets(ctx interface{}, vnet interface{}) *MockClient_GetSDNSubnets_Call {
+	return &MockClient_GetSDNSubnets_Call{Call: _e.mock.On("GetSDNSubnets", ctx, vnet)}
+}
+
+func (_c *MockClient_GetSDNSubnets_Call) Run(run func(ctx context.Context, vnet string)) *MockClient_GetSDNSubnets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetSDNSubnets_Call) Return(_a0 []*go_proxmox.VNetSubnet, _a1 error) *MockClient_GetSDNSubnets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetSDNSubnets_Call) RunAndReturn(run func(context.Context, string) ([]*go_proxmox.VNetSubnet, error)) *MockClient_GetSDNSubnets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSDNVNets provides a mock function with given fields: ctx
+func (_m *MockClient) GetSDNVNets(ctx context.Context) ([]*go_proxmox.VNet, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSDNVNets")
+	}
+
+	var r0 []*go_proxmox.VNet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*go_proxmox.VNet, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*go_proxmox.VNet); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.VNet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetSDNVNets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSDNVNets'
+type MockClient_GetSDNVNets_Call struct {
+	*mock.Call
+}
+
+// GetSDNVNets is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetSDNVNets(ctx interface{}) *MockClient_GetSDNVNets_Call {
+	return &MockClient_GetSDNVNets_Call{Call: _e.mock.On("GetSDNVNets", ctx)}
+}
+
+func (_c *MockClient_GetSDNVNets_Call) Run(run func(ctx context.Context)) *MockClient_GetSDNVNets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetSDNVNets_Call) Return(_a0 []*go_proxmox.VNet, _a1 error) *MockClient_GetSDNVNets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetSDNVNets_Call) RunAndReturn(run func(context.Context) ([]*go_proxmox.VNet, error)) *MockClient_GetSDNVNets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSDNZones provides a mock function with given fields: ctx
+func (_m *MockClient) GetSDNZones(ctx context.Context) ([]*proxmox.SDNZone, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSDNZones")
+	}
+
+	var r0 []*proxmox.SDNZone
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*proxmox.SDNZone, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*proxmox.SDNZone); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*proxmox.SDNZone)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetSDNZones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSDNZones'
+type MockClient_GetSDNZones_Call struct {
+	*mock.Call
+}
+
+// GetSDNZones is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetSDNZones(ctx interface{}) *MockClient_GetSDNZones_Call {
+	return &MockClient_GetSDNZones_Call{Call: _e.mock.On("GetSDNZones", ctx)}
+}
+
+func (_c *MockClient_GetSDNZones_Call) Run(run func(ctx context.Context)) *MockClient_GetSDNZones_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetSDNZones_Call) Return(_a0 []*proxmox.SDNZone, _a1 error) *MockClient_GetSDNZones_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetSDNZones_Call) RunAndReturn(run func(context.Context) ([]*proxmox.SDNZone, error)) *MockClient_GetSDNZones_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTask provides a mock function with given fields: ctx, upID
+func (_m *MockClient) GetTask(ctx context.Context, upID string) (*go_proxmox.Task, error) {
+	ret := _m.Called(ctx, upID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTask")
+	}
+
+	var r0 *go_proxmox.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*go_proxmox.Task, error)); ok {
+		return rf(ctx, upID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *go_proxmox.Task); ok {
+		r0 = rf(ctx, upID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*go_proxmox.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, upID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTask'
+type MockClient_GetTask_Call struct {
+	*mock.Call
+}
+
+// GetTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - upID string
+func (_e *MockClient_Expecter) GetTask(ctx interface{}, upID interface{}) *MockClient_GetTask_Call {
+	return &MockClient_GetTask_Call{Call: _e.mock.On("GetTask", ctx, upID)}
+}
+
+func (_c *MockClient_GetTask_Call) Run(run func(ctx context.Context, upID string)) *MockClient_GetTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetTask_Call) Return(_a0 *go_proxmox.Task, _a1 error) *MockClient_GetTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetTask_Call) RunAndReturn(run func(context.Context, string) (*go_proxmox.Task, error)) *MockClient_GetTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskLog provides a mock function with given fields: ctx, upID
+func (_m *MockClient) GetTaskLog(ctx context.Context, upID string) ([]string, error) {
+	ret := _m.Called(ctx, upID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskLog")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, upID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, upID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, upID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetTaskLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskLog'
+type MockClient_GetTaskLog_Call struct {
+	*mock.Call
+}
+
+// GetTaskLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - upID string
+func (_e *MockClient_Expecter) GetTaskLog(ctx interface{}, upID interface{}) *MockClient_GetTaskLog_Call {
+	return &MockClient_GetTaskLog_Call{Call: _e.mock.On("GetTaskLog", ctx, upID)}
+}
+
+func (_c *MockClient_GetTaskLog_Call) Run(run func(ctx context.Context, upID string)) *MockClient_GetTaskLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetTaskLog_Call) Return(_a0 []string, _a1 error) *MockClient_GetTaskLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetTaskLog_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockClient_GetTaskLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVM provides a mock function with given fields: ctx, nodeName, vmID
+func (_m *MockClient) GetVM(ctx context.Context, nodeName string, vmID int64) (*go_proxmox.VirtualMachine, error) {
+	ret := _m.Called(ctx, nodeName, vmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVM")
+	}
+
+	var r0 *go_proxmox.VirtualMachine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*go_proxmox.VirtualMachine, error)); ok {
+		return rf(ctx, nodeName, vmID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) *go_proxmox.ClusterResource); ok {
-		r0 = rf(ctx, vmID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *go_proxmox.VirtualMachine); ok {
+		r0 = rf(ctx, nodeName, vmID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*go_proxmox.ClusterResource)
+			r0 = ret.Get(0).(*go_proxmox.VirtualMachine)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = rf(ctx, vmID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, nodeName, vmID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -360,121 +2391,118 @@ func (_m *MockClient) FindVMResource(ctx context.Context, vmID uint64) (*go_prox
 	return r0, r1
 }
 
-// MockClient_FindVMResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindVMResource'
-type MockClient_FindVMResource_Call struct {
+// MockClient_GetVM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVM'
+type MockClient_GetVM_Call struct {
 	*mock.Call
 }
 
-// FindVMResource is a helper method to define mock.On call
+// GetVM is a helper method to define mock.On call
 //   - ctx context.Context
-//   - vmID uint64
-func (_e *MockClient_Expecter) FindVMResource(ctx interface{}, vmID interface{}) *MockClient_FindVMResource_Call {
-	return &MockClient_FindVMResource_Call{Call: _e.mock.On("FindVMResource", ctx, vmID)}
+//   - nodeName string
+//   - vmID int64
+func (_e *MockClient_Expecter) GetVM(ctx interface{}, nodeName interface{}, vmID interface{}) *MockClient_GetVM_Call {
+	return &MockClient_GetVM_Call{Call: _e.mock.On("GetVM", ctx, nodeName, vmID)}
 }
 
-func (_c *MockClient_FindVMResource_Call) Run(run func(ctx context.Context, vmID uint64)) *MockClient_FindVMResource_Call {
+func (_c *MockClient_GetVM_Call) Run(run func(ctx context.Context, nodeName string, vmID int64)) *MockClient_GetVM_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
 	})
 	return _c
 }
 
-func (_c *MockClient_FindVMResource_Call) Return(_a0 *go_proxmox.ClusterResource, _a1 error) *MockClient_FindVMResource_Call {
+func (_c *MockClient_GetVM_Call) Return(_a0 *go_proxmox.VirtualMachine, _a1 error) *MockClient_GetVM_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_FindVMResource_Call) RunAndReturn(run func(context.Context, uint64) (*go_proxmox.ClusterResource, error)) *MockClient_FindVMResource_Call {
+func (_c *MockClient_GetVM_Call) RunAndReturn(run func(context.Context, string, int64) (*go_proxmox.VirtualMachine, error)) *MockClient_GetVM_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindVMTemplateByTags provides a mock function with given fields: ctx, templateTags, resolutionPolicy
-func (_m *MockClient) FindVMTemplateByTags(ctx context.Context, templateTags []string, resolutionPolicy string) (string, int32, error) {
-	ret := _m.Called(ctx, templateTags, resolutionPolicy)
+// GetVMFirewallOptions provides a mock function with given fields: ctx, vm
+func (_m *MockClient) GetVMFirewallOptions(ctx context.Context, vm *go_proxmox.VirtualMachine) (*go_proxmox.FirewallVirtualMachineOption, error) {
+	ret := _m.Called(ctx, vm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindVMTemplateByTags")
+		panic("no return value specified for GetVMFirewallOptions")
 	}
 
-	var r0 string
-	var r1 int32
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string, string) (string, int32, error)); ok {
-		return rf(ctx, templateTags, resolutionPolicy)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string, string) string); ok {
-		r0 = rf(ctx, templateTags, resolutionPolicy)
-	} else {
-		r0 = ret.Get(0).(string)
+	var r0 *go_proxmox.FirewallVirtualMachineOption
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine) (*go_proxmox.FirewallVirtualMachineOption, error)); ok {
+		return rf(ctx, vm)
 	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, []string, string) int32); ok {
-		r1 = rf(ctx, templateTags, resolutionPolicy)
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine) *go_proxmox.FirewallVirtualMachineOption); ok {
+		r0 = rf(ctx, vm)
 	} else {
-		r1 = ret.Get(1).(int32)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*go_proxmox.FirewallVirtualMachineOption)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, []string, string) error); ok {
-		r2 = rf(ctx, templateTags, resolutionPolicy)
+	if rf, ok := ret.Get(1).(func(context.Context, *go_proxmox.VirtualMachine) error); ok {
+		r1 = rf(ctx, vm)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockClient_FindVMTemplateByTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindVMTemplateByTags'
-type MockClient_FindVMTemplateByTags_Call struct {
+// MockClient_GetVMFirewallOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVMFirewallOptions'
+type MockClient_GetVMFirewallOptions_Call struct {
 	*mock.Call
 }
 
-// FindVMTemplateByTags is a helper method to define mock.On call
+// GetVMFirewallOptions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - templateTags []string
-//   - resolutionPolicy string
-func (_e *MockClient_Expecter) FindVMTemplateByTags(ctx interface{}, templateTags interface{}, resolutionPolicy interface{}) *MockClient_FindVMTemplateByTags_Call {
-	return &MockClient_FindVMTemplateByTags_Call{Call: _e.mock.On("FindVMTemplateByTags", ctx, templateTags, resolutionPolicy)}
+//   - vm *go_proxmox.VirtualMachine
+func (_e *MockClient_Expecter) GetVMFirewallOptions(ctx interface{}, vm interface{}) *MockClient_GetVMFirewallOptions_Call {
+	return &MockClient_GetVMFirewallOptions_Call{Call: _e.mock.On("GetVMFirewallOptions", ctx, vm)}
 }
 
-func (_c *MockClient_FindVMTemplateByTags_Call) Run(run func(ctx context.Context, templateTags []string, resolutionPolicy string)) *MockClient_FindVMTemplateByTags_Call {
+func (_c *MockClient_GetVMFirewallOptions_Call) Run(run func(ctx context.Context, vm *go_proxmox.VirtualMachine)) *MockClient_GetVMFirewallOptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string), args[2].(string))
+		run(args[0].(context.Context), args[1].(*go_proxmox.VirtualMachine))
 	})
 	return _c
 }
 
-func (_c *MockClient_FindVMTemplateByTags_Call) Return(_a0 string, _a1 int32, _a2 error) *MockClient_FindVMTemplateByTags_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockClient_GetVMFirewallOptions_Call) Return(_a0 *go_proxmox.FirewallVirtualMachineOption, _a1 error) *MockClient_GetVMFirewallOptions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_FindVMTemplateByTags_Call) RunAndReturn(run func(context.Context, []string, string) (string, int32, error)) *MockClient_FindVMTemplateByTags_Call {
+func (_c *MockClient_GetVMFirewallOptions_Call) RunAndReturn(run func(context.Context, *go_proxmox.VirtualMachine) (*go_proxmox.FirewallVirtualMachineOption, error)) *MockClient_GetVMFirewallOptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReservableMemoryBytes provides a mock function with given fields: ctx, nodeName, nodeMemoryAdjustment
-func (_m *MockClient) GetReservableMemoryBytes(ctx context.Context, nodeName string, nodeMemoryAdjustment int64) (uint64, error) {
-	ret := _m.Called(ctx, nodeName, nodeMemoryAdjustment)
+// GetVMFirewallRules provides a mock function with given fields: ctx, vm
+func (_m *MockClient) GetVMFirewallRules(ctx context.Context, vm *go_proxmox.VirtualMachine) ([]*go_proxmox.FirewallRule, error) {
+	ret := _m.Called(ctx, vm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReservableMemoryBytes")
+		panic("no return value specified for GetVMFirewallRules")
 	}
 
-	var r0 uint64
+	var r0 []*go_proxmox.FirewallRule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (uint64, error)); ok {
-		return rf(ctx, nodeName, nodeMemoryAdjustment)
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine) ([]*go_proxmox.FirewallRule, error)); ok {
+		return rf(ctx, vm)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) uint64); ok {
-		r0 = rf(ctx, nodeName, nodeMemoryAdjustment)
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine) []*go_proxmox.FirewallRule); ok {
+		r0 = rf(ctx, vm)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*go_proxmox.FirewallRule)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = rf(ctx, nodeName, nodeMemoryAdjustment)
+	if rf, ok := ret.Get(1).(func(context.Context, *go_proxmox.VirtualMachine) error); ok {
+		r1 = rf(ctx, vm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -482,59 +2510,58 @@ func (_m *MockClient) GetReservableMemoryBytes(ctx context.Context, nodeName str
 	return r0, r1
 }
 
-// MockClient_GetReservableMemoryBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReservableMemoryBytes'
-type MockClient_GetReservableMemoryBytes_Call struct {
+// MockClient_GetVMFirewallRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVMFirewallRules'
+type MockClient_GetVMFirewallRules_Call struct {
 	*mock.Call
 }
 
-// GetReservableMemoryBytes is a helper method to define mock.On call
+// GetVMFirewallRules is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nodeName string
-//   - nodeMemoryAdjustment int64
-func (_e *MockClient_Expecter) GetReservableMemoryBytes(ctx interface{}, nodeName interface{}, nodeMemoryAdjustment interface{}) *MockClient_GetReservableMemoryBytes_Call {
-	return &MockClient_GetReservableMemoryBytes_Call{Call: _e.mock.On("GetReservableMemoryBytes", ctx, nodeName, nodeMemoryAdjustment)}
+//   - vm *go_proxmox.VirtualMachine
+func (_e *MockClient_Expecter) GetVMFirewallRules(ctx interface{}, vm interface{}) *MockClient_GetVMFirewallRules_Call {
+	return &MockClient_GetVMFirewallRules_Call{Call: _e.mock.On("GetVMFirewallRules", ctx, vm)}
 }
 
-func (_c *MockClient_GetReservableMemoryBytes_Call) Run(run func(ctx context.Context, nodeName string, nodeMemoryAdjustment int64)) *MockClient_GetReservableMemoryBytes_Call {
+func (_c *MockClient_GetVMFirewallRules_Call) Run(run func(ctx context.Context, vm *go_proxmox.VirtualMachine)) *MockClient_GetVMFirewallRules_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+		run(args[0].(context.Context), args[1].(*go_proxmox.VirtualMachine))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetReservableMemoryBytes_Call) Return(_a0 uint64, _a1 error) *MockClient_GetReservableMemoryBytes_Call {
+func (_c *MockClient_GetVMFirewallRules_Call) Return(_a0 []*go_proxmox.FirewallRule, _a1 error) *MockClient_GetVMFirewallRules_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetReservableMemoryBytes_Call) RunAndReturn(run func(context.Context, string, int64) (uint64, error)) *MockClient_GetReservableMemoryBytes_Call {
+func (_c *MockClient_GetVMFirewallRules_Call) RunAndReturn(run func(context.Context, *go_proxmox.VirtualMachine) ([]*go_proxmox.FirewallRule, error)) *MockClient_GetVMFirewallRules_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTask provides a mock function with given fields: ctx, upID
-func (_m *MockClient) GetTask(ctx context.Context, upID string) (*go_proxmox.Task, error) {
-	ret := _m.Called(ctx, upID)
+// GetVMResources provides a mock function with given fields: ctx
+func (_m *MockClient) GetVMResources(ctx context.Context) ([]*go_proxmox.ClusterResource, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTask")
+		panic("no return value specified for GetVMResources")
 	}
 
-	var r0 *go_proxmox.Task
+	var r0 []*go_proxmox.ClusterResource
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*go_proxmox.Task, error)); ok {
-		return rf(ctx, upID)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*go_proxmox.ClusterResource, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *go_proxmox.Task); ok {
-		r0 = rf(ctx, upID)
+	if rf, ok := ret.Get(0).(func(context.Context) []*go_proxmox.ClusterResource); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*go_proxmox.Task)
+			r0 = ret.Get(0).([]*go_proxmox.ClusterResource)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, upID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -542,58 +2569,57 @@ func (_m *MockClient) GetTask(ctx context.Context, upID string) (*go_proxmox.Tas
 	return r0, r1
 }
 
-// MockClient_GetTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTask'
-type MockClient_GetTask_Call struct {
+// MockClient_GetVMResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVMResources'
+type MockClient_GetVMResources_Call struct {
 	*mock.Call
 }
 
-// GetTask is a helper method to define mock.On call
+// GetVMResources is a helper method to define mock.On call
 //   - ctx context.Context
-//   - upID string
-func (_e *MockClient_Expecter) GetTask(ctx interface{}, upID interface{}) *MockClient_GetTask_Call {
-	return &MockClient_GetTask_Call{Call: _e.mock.On("GetTask", ctx, upID)}
+func (_e *MockClient_Expecter) GetVMResources(ctx interface{}) *MockClient_GetVMResources_Call {
+	return &MockClient_GetVMResources_Call{Call: _e.mock.On("GetVMResources", ctx)}
 }
 
-func (_c *MockClient_GetTask_Call) Run(run func(ctx context.Context, upID string)) *MockClient_GetTask_Call {
+func (_c *MockClient_GetVMResources_Call) Run(run func(ctx context.Context)) *MockClient_GetVMResources_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetTask_Call) Return(_a0 *go_proxmox.Task, _a1 error) *MockClient_GetTask_Call {
+func (_c *MockClient_GetVMResources_Call) Return(_a0 []*go_proxmox.ClusterResource, _a1 error) *MockClient_GetVMResources_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetTask_Call) RunAndReturn(run func(context.Context, string) (*go_proxmox.Task, error)) *MockClient_GetTask_Call {
+func (_c *MockClient_GetVMResources_Call) RunAndReturn(run func(context.Context) ([]*go_proxmox.ClusterResource, error)) *MockClient_GetVMResources_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetVM provides a mock function with given fields: ctx, nodeName, vmID
-func (_m *MockClient) GetVM(ctx context.Context, nodeName string, vmID int64) (*go_proxmox.VirtualMachine, error) {
-	ret := _m.Called(ctx, nodeName, vmID)
+// QemuAgentNetworkInterfaces provides a mock function with given fields: ctx, vm
+func (_m *MockClient) QemuAgentNetworkInterfaces(ctx context.Context, vm *go_proxmox.VirtualMachine) ([]*go_proxmox.AgentNetworkIface, error) {
+	ret := _m.Called(ctx, vm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetVM")
+		panic("no return value specified for QemuAgentNetworkInterfaces")
 	}
 
-	var r0 *go_proxmox.VirtualMachine
+	var r0 []*go_proxmox.AgentNetworkIface
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*go_proxmox.VirtualMachine, error)); ok {
-		return rf(ctx, nodeName, vmID)
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine) ([]*go_proxmox.AgentNetworkIface, error)); ok {
+		return rf(ctx, vm)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *go_proxmox.VirtualMachine); ok {
-		r0 = rf(ctx, nodeName, vmID)
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine) []*go_proxmox.AgentNetworkIface); ok {
+		r0 = rf(ctx, vm)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*go_proxmox.VirtualMachine)
+			r0 = ret.Get(0).([]*go_proxmox.AgentNetworkIface)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = rf(ctx, nodeName, vmID)
+	if rf, ok := ret.Get(1).(func(context.Context, *go_proxmox.VirtualMachine) error); ok {
+		r1 = rf(ctx, vm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -601,32 +2627,31 @@ func (_m *MockClient) GetVM(ctx context.Context, nodeName string, vmID int64) (*
 	return r0, r1
 }
 
-// MockClient_GetVM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVM'
-type MockClient_GetVM_Call struct {
+// MockClient_QemuAgentNetworkInterfaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QemuAgentNetworkInterfaces'
+type MockClient_QemuAgentNetworkInterfaces_Call struct {
 	*mock.Call
 }
 
-// GetVM is a helper method to define mock.On call
+// QemuAgentNetworkInterfaces is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nodeName string
-//   - vmID int64
-func (_e *MockClient_Expecter) GetVM(ctx interface{}, nodeName interface{}, vmID interface{}) *MockClient_GetVM_Call {
-	return &MockClient_GetVM_Call{Call: _e.mock.On("GetVM", ctx, nodeName, vmID)}
+//   - vm *go_proxmox.VirtualMachine
+func (_e *MockClient_Expecter) QemuAgentNetworkInterfaces(ctx interface{}, vm interface{}) *MockClient_QemuAgentNetworkInterfaces_Call {
+	return &MockClient_QemuAgentNetworkInterfaces_Call{Call: _e.mock.On("QemuAgentNetworkInterfaces", ctx, vm)}
 }
 
-func (_c *MockClient_GetVM_Call) Run(run func(ctx context.Context, nodeName string, vmID int64)) *MockClient_GetVM_Call {
+func (_c *MockClient_QemuAgentNetworkInterfaces_Call) Run(run func(ctx context.Context, vm *go_proxmox.VirtualMachine)) *MockClient_QemuAgentNetworkInterfaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+		run(args[0].(context.Context), args[1].(*go_proxmox.VirtualMachine))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetVM_Call) Return(_a0 *go_proxmox.VirtualMachine, _a1 error) *MockClient_GetVM_Call {
+func (_c *MockClient_QemuAgentNetworkInterfaces_Call) Return(_a0 []*go_proxmox.AgentNetworkIface, _a1 error) *MockClient_QemuAgentNetworkInterfaces_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetVM_Call) RunAndReturn(run func(context.Context, string, int64) (*go_proxmox.VirtualMachine, error)) *MockClient_GetVM_Call {
+func (_c *MockClient_QemuAgentNetworkInterfaces_Call) RunAndReturn(run func(context.Context, *go_proxmox.VirtualMachine) ([]*go_proxmox.AgentNetworkIface, error)) *MockClient_QemuAgentNetworkInterfaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -965,6 +2990,151 @@ func (_c *MockClient_UnmountCloudInitISO_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// UpdateHAResource provides a mock function with given fields: ctx, sid, resource
+func (_m *MockClient) UpdateHAResource(ctx context.Context, sid string, resource *go_proxmox.HAResourceUpdateOption) error {
+	ret := _m.Called(ctx, sid, resource)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateHAResource")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *go_proxmox.HAResourceUpdateOption) error); ok {
+		r0 = rf(ctx, sid, resource)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateHAResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateHAResource'
+type MockClient_UpdateHAResource_Call struct {
+	*mock.Call
+}
+
+// UpdateHAResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sid string
+//   - resource *go_proxmox.HAResourceUpdateOption
+func (_e *MockClient_Expecter) UpdateHAResource(ctx interface{}, sid interface{}, resource interface{}) *MockClient_UpdateHAResource_Call {
+	return &MockClient_UpdateHAResource_Call{Call: _e.mock.On("UpdateHAResource", ctx, sid, resource)}
+}
+
+func (_c *MockClient_UpdateHAResource_Call) Run(run func(ctx context.Context, sid string, resource *go_proxmox.HAResourceUpdateOption)) *MockClient_UpdateHAResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*go_proxmox.HAResourceUpdateOption))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateHAResource_Call) Return(_a0 error) *MockClient_UpdateHAResource_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateHAResource_Call) RunAndReturn(run func(context.Context, string, *go_proxmox.HAResourceUpdateOption) error) *MockClient_UpdateHAResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePoolACL provides a mock function with given fields: ctx, name, acl, remove
+func (_m *MockClient) UpdatePoolACL(ctx context.Context, name string, acl *go_proxmox.ACL, remove bool) error {
+	ret := _m.Called(ctx, name, acl, remove)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePoolACL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *go_proxmox.ACL, bool) error); ok {
+		r0 = rf(ctx, name, acl, remove)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdatePoolACL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePoolACL'
+type MockClient_UpdatePoolACL_Call struct {
+	*mock.Call
+}
+
+// UpdatePoolACL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - acl *go_proxmox.ACL
+//   - remove bool
+func (_e *MockClient_Expecter) UpdatePoolACL(ctx interface{}, name interface{}, acl interface{}, remove interface{}) *MockClient_UpdatePoolACL_Call {
+	return &MockClient_UpdatePoolACL_Call{Call: _e.mock.On("UpdatePoolACL", ctx, name, acl, remove)}
+}
+
+func (_c *MockClient_UpdatePoolACL_Call) Run(run func(ctx context.Context, name string, acl *go_proxmox.ACL, remove bool)) *MockClient_UpdatePoolACL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*go_proxmox.ACL), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdatePoolACL_Call) Return(_a0 error) *MockClient_UpdatePoolACL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdatePoolACL_Call) RunAndReturn(run func(context.Context, string, *go_proxmox.ACL, bool) error) *MockClient_UpdatePoolACL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVMFirewallOptions provides a mock function with given fields: ctx, vm, options
+func (_m *MockClient) UpdateVMFirewallOptions(ctx context.Context, vm *go_proxmox.VirtualMachine, options *go_proxmox.FirewallVirtualMachineOption) error {
+	ret := _m.Called(ctx, vm, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVMFirewallOptions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *go_proxmox.VirtualMachine, *go_proxmox.FirewallVirtualMachineOption) error); ok {
+		r0 = rf(ctx, vm, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateVMFirewallOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVMFirewallOptions'
+type MockClient_UpdateVMFirewallOptions_Call struct {
+	*mock.Call
+}
+
+// UpdateVMFirewallOptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vm *go_proxmox.VirtualMachine
+//   - options *go_proxmox.FirewallVirtualMachineOption
+func (_e *MockClient_Expecter) UpdateVMFirewallOptions(ctx interface{}, vm interface{}, options interface{}) *MockClient_UpdateVMFirewallOptions_Call {
+	return &MockClient_UpdateVMFirewallOptions_Call{Call: _e.mock.On("UpdateVMFirewallOptions", ctx, vm, options)}
+}
+
+func (_c *MockClient_UpdateVMFirewallOptions_Call) Run(run func(ctx context.Context, vm *go_proxmox.VirtualMachine, options *go_proxmox.FirewallVirtualMachineOption)) *MockClient_UpdateVMFirewallOptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*go_proxmox.VirtualMachine), args[2].(*go_proxmox.FirewallVirtualMachineOption))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateVMFirewallOptions_Call) Return(_a0 error) *MockClient_UpdateVMFirewallOptions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateVMFirewallOptions_Call) RunAndReturn(run func(context.Context, *go_proxmox.VirtualMachine, *go_proxmox.FirewallVirtualMachineOption) error) *MockClient_UpdateVMFirewallOptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockClient creates a new instance of MockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockClient(t interface {
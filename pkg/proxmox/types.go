@@ -38,5 +38,11 @@ type VMCloneResponse struct {
 	Task  *proxmox.Task `json:"task,omitempty"`
 }
 
+// SDNZone is a SDN zone of the cluster. proxmox.SDNZone lacks the bridge of VLAN and QinQ zones.
+type SDNZone struct {
+	proxmox.SDNZone
+	Bridge string `json:"bridge,omitempty"`
+}
+
 // VirtualMachineOption is an alias for VirtualMachineOption to prevent import conflicts.
 type VirtualMachineOption = proxmox.VirtualMachineOption
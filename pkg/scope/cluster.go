@@ -208,6 +208,12 @@ func (s *ClusterScope) KubernetesClusterName() string {
 	return s.Cluster.Name
 }
 
+// VMOwnerTag returns the Proxmox tag which marks virtual machines as created for this cluster.
+// Namespaces can not contain dots, which keeps the tag unambiguous.
+func (s *ClusterScope) VMOwnerTag() string {
+	return "capmox-cluster." + s.Namespace() + "." + s.Name()
+}
+
 // PatchObject persists the cluster configuration and status.
 func (s *ClusterScope) PatchObject() error {
 	// always update the readyCondition.
@@ -236,6 +242,29 @@ func (s *ClusterScope) ListProxmoxMachinesForCluster(ctx context.Context) ([]inf
 	return machineList.Items, nil
 }
 
+// ListProxmoxMachineTemplatesForCluster returns the ProxmoxMachineTemplates that are used by this cluster.
+// Cluster API makes the Cluster an owner of the templates referenced by its control plane and
+// machine deployments; templates which carry the cluster name label are included as well.
+func (s *ClusterScope) ListProxmoxMachineTemplatesForCluster(ctx context.Context) ([]infrav1.ProxmoxMachineTemplate, error) {
+	var templateList infrav1.ProxmoxMachineTemplateList
+
+	if err := s.client.List(ctx, &templateList, client.InNamespace(s.Namespace())); err != nil {
+		return nil, err
+	}
+
+	var templates []infrav1.ProxmoxMachineTemplate
+	for _, template := range templateList.Items {
+		owned := slices.ContainsFunc(template.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+			return ref.Kind == "Cluster" && ref.Name == s.Name()
+		})
+		if owned || template.GetLabels()[clusterv1.ClusterNameLabel] == s.Name() {
+			templates = append(templates, template)
+		}
+	}
+
+	return templates, nil
+}
+
 // Close closes the current scope persisting the cluster configuration and status.
 func (s *ClusterScope) Close() error {
 	return s.PatchObject()
@@ -189,6 +189,28 @@ func (m *MachineScope) HasFailed() bool {
 		cond.Reason == infrav1.ProxmoxMachineVirtualMachineProvisionedVMProvisionFailedReason
 }
 
+// TaskRetryPolicy returns the policy for failed Proxmox tasks of the machine.
+// The policy of the ProxmoxMachine takes precedence over the one of the ProxmoxCluster.
+func (m *MachineScope) TaskRetryPolicy() *infrav1.TaskRetryPolicy {
+	if m.ProxmoxMachine.Spec.TaskRetryPolicy != nil {
+		return m.ProxmoxMachine.Spec.TaskRetryPolicy
+	}
+	return m.InfraCluster.ProxmoxCluster.Spec.TaskRetryPolicy
+}
+
+// HighAvailability returns the Proxmox HA settings of the machine, or nil if its VM is not registered as HA resource.
+// The settings of the ProxmoxMachine replace the ones of the ProxmoxCluster.
+func (m *MachineScope) HighAvailability() *infrav1.HighAvailabilitySpec {
+	if m.ProxmoxMachine.Spec.HighAvailability != nil {
+		return m.ProxmoxMachine.Spec.HighAvailability
+	}
+	ha := m.InfraCluster.ProxmoxCluster.Spec.HighAvailability
+	if ha == nil || (ptr.Deref(ha.ControlPlaneOnly, true) && !m.IsControlPlane()) {
+		return nil
+	}
+	return &ha.HighAvailabilitySpec
+}
+
 // SetVirtualMachine sets the Proxmox VirtualMachine object to the machinescope.
 func (m *MachineScope) SetVirtualMachine(vm *proxmox.VirtualMachine) {
 	m.VirtualMachine = vm
@@ -198,7 +220,12 @@ func (m *MachineScope) SetVirtualMachine(vm *proxmox.VirtualMachine) {
 func (m *MachineScope) PatchObject() error {
 	// always update the readyCondition.
 	_ = conditions.SetSummaryCondition(m.ProxmoxMachine, m.ProxmoxMachine, "Ready",
-		conditions.ForConditionTypes{infrav1.ProxmoxMachineVirtualMachineProvisionedCondition},
+		conditions.ForConditionTypes{
+			infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
+			infrav1.ProxmoxMachineVirtualMachineHealthyCondition,
+		},
+		// the runtime health is only observed once the machine is provisioned.
+		conditions.IgnoreTypesIfMissing{infrav1.ProxmoxMachineVirtualMachineHealthyCondition},
 	)
 
 	// Patch the ProxmoxMachine resource.
@@ -208,6 +235,8 @@ func (m *MachineScope) PatchObject() error {
 		patch.WithOwnedConditions{Conditions: []string{
 			"Ready",
 			infrav1.ProxmoxMachineVirtualMachineProvisionedCondition,
+			infrav1.ProxmoxMachineVirtualMachineHealthyCondition,
+			infrav1.ProxmoxMachineConfigDriftCondition,
 		}})
 }
 
@@ -121,6 +121,34 @@ func TestMachineScope_HasFailed(t *testing.T) {
 	require.False(t, scope.HasFailed())
 }
 
+func TestMachineScope_HighAvailability(t *testing.T) {
+	m := clusterv1.Machine{}
+	cluster := infrav1.ProxmoxCluster{}
+	scope := MachineScope{
+		Machine:        &m,
+		ProxmoxMachine: &infrav1.ProxmoxMachine{},
+		InfraCluster:   &ClusterScope{ProxmoxCluster: &cluster},
+	}
+
+	require.Nil(t, scope.HighAvailability())
+
+	// by default, the cluster settings only apply to control plane machines.
+	cluster.Spec.HighAvailability = &infrav1.ClusterHighAvailabilitySpec{
+		HighAvailabilitySpec: infrav1.HighAvailabilitySpec{Group: new("cluster")},
+	}
+	require.Nil(t, scope.HighAvailability())
+
+	m.SetLabels(map[string]string{clusterv1.MachineControlPlaneLabel: "kcp"})
+	require.Equal(t, "cluster", *scope.HighAvailability().Group)
+
+	m.SetLabels(nil)
+	cluster.Spec.HighAvailability.ControlPlaneOnly = new(false)
+	require.Equal(t, "cluster", *scope.HighAvailability().Group)
+
+	scope.ProxmoxMachine.Spec.HighAvailability = &infrav1.HighAvailabilitySpec{Group: new("machine")}
+	require.Equal(t, "machine", *scope.HighAvailability().Group)
+}
+
 func TestMachineScope_SkipQemuCheckEnabled(t *testing.T) {
 	p := infrav1.ProxmoxMachine{
 		Spec: infrav1.ProxmoxMachineSpec{
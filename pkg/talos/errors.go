@@ -0,0 +1,52 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package talos
+
+import (
+	"errors"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+var (
+	// The following are structural errors shared with other renderers; they
+	// live in pkg/network and are re-exported here for convenience.
+
+	// ErrMissingGateway returns an error if no device contributes a default gateway.
+	ErrMissingGateway = network.ErrMissingGateway
+
+	// ErrConflictingMetrics returns an error if a metric for a route already exists.
+	ErrConflictingMetrics = network.ErrConflictingMetrics
+
+	// ErrMissingNetworkConfigData returns an error if required network config data is empty.
+	ErrMissingNetworkConfigData = network.ErrMissingNetworkConfigData
+
+	// ErrUnsupportedDevice is returned for device types Talos can not configure.
+	ErrUnsupportedDevice = errors.New("device type is not supported by talos")
+
+	// ErrUnsupportedRoutingTable is returned for routes or routing policies
+	// which require a routing table other than main.
+	ErrUnsupportedRoutingTable = errors.New("routing tables and routing policies are not supported by talos")
+
+	// ErrUnsupportedIPv6Mode is returned for devices with an ipv6 mode, router
+	// advertisement or token setting Talos can not configure.
+	ErrUnsupportedIPv6Mode = errors.New("ipv6 modes, router advertisements and tokens are not supported by talos")
+
+	// ErrMissingMachineConfig is returned if the bootstrap data does not contain
+	// a v1alpha1 machine config document.
+	ErrMissingMachineConfig = errors.New("bootstrap data does not contain a talos machine config")
+)
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package talos
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"k8s.io/utils/ptr"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+// machinePatch is a Talos machine config patch which only carries network settings.
+type machinePatch struct {
+	Machine struct {
+		Network networkPatch `yaml:"network"`
+	} `yaml:"machine"`
+}
+
+// networkPatch mirrors the subset of the Talos v1alpha1 NetworkConfig used by CAPMOX.
+type networkPatch struct {
+	Hostname    string        `yaml:"hostname,omitempty"`
+	Interfaces  []devicePatch `yaml:"interfaces,omitempty"`
+	Nameservers []string      `yaml:"nameservers,omitempty"`
+}
+
+type devicePatch struct {
+	DeviceSelector deviceSelector `yaml:"deviceSelector"`
+	ipPatch        `yaml:",inline"`
+	VLANs          []vlanPatch `yaml:"vlans,omitempty"`
+}
+
+type vlanPatch struct {
+	VLANID  int32 `yaml:"vlanId"`
+	ipPatch `yaml:",inline"`
+}
+
+// ipPatch holds the settings shared by interfaces and VLANs.
+type ipPatch struct {
+	Addresses   []string     `yaml:"addresses,omitempty"`
+	Routes      []routePatch `yaml:"routes,omitempty"`
+	MTU         int32        `yaml:"mtu,omitempty"`
+	DHCP        bool         `yaml:"dhcp,omitempty"`
+	DHCPOptions *dhcpOptions `yaml:"dhcpOptions,omitempty"`
+}
+
+type deviceSelector struct {
+	HardwareAddr string `yaml:"hardwareAddr"`
+}
+
+type routePatch struct {
+	Network string `yaml:"network"`
+	Gateway string `yaml:"gateway,omitempty"`
+	Metric  int32  `yaml:"metric,omitempty"`
+}
+
+type dhcpOptions struct {
+	IPv4 bool `yaml:"ipv4"`
+	IPv6 bool `yaml:"ipv6"`
+}
+
+// NetworkConfig renders machine network-config into a Talos machine config patch.
+//
+// It embeds network.Network to inherit the shared, renderer-agnostic validation
+// and layers its own talos-specific checks on top via Validate.
+type NetworkConfig struct {
+	network.Network
+	Hostname string
+}
+
+// NewNetworkConfig returns a new NetworkConfig object.
+func NewNetworkConfig(hostname string, configs []network.ConfigData) *NetworkConfig {
+	return &NetworkConfig{Network: network.Network{Devices: configs}, Hostname: hostname}
+}
+
+// Inspect returns a serialized copy of the NetworkData. This is useful when
+// wanting to immutably inspect what goes into the renderer.
+func (r *NetworkConfig) Inspect() ([]byte, error) {
+	return json.Marshal(r.Devices)
+}
+
+// Render returns the rendered machine config patch.
+func (r *NetworkConfig) Render() ([]byte, error) {
+	patch, err := r.patch()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(patch); err != nil {
+		return nil, errors.Wrap(err, "encoding network patch")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "encoding network patch")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Validate runs the shared, renderer-agnostic validation (embedded
+// network.Network) and rejects what the Talos v1alpha1 network config
+// can not express: VRFs, bonds, bridges, VLANs on anything but an
+// ethernet device, ipv6 modes besides dhcpv6, routing policies and routes
+// into other tables.
+func (r *NetworkConfig) Validate() error {
+	if err := r.Network.Validate(); err != nil {
+		return err
+	}
+
+	ethernets := make(map[string]struct{})
+	for _, d := range r.Devices {
+		if d.Type == network.TypeEthernet {
+			ethernets[d.Name] = struct{}{}
+		}
+	}
+
+	for _, d := range r.Devices {
+		switch d.Type {
+		case network.TypeEthernet:
+		case network.TypeVLAN:
+			if _, ok := ethernets[d.Link]; !ok {
+				return ErrUnsupportedDevice
+			}
+		default:
+			return ErrUnsupportedDevice
+		}
+		if d.IPv6AcceptRA() != nil || d.IPv6Token != "" {
+			return ErrUnsupportedIPv6Mode
+		}
+		if len(d.FIBRules) > 0 {
+			return ErrUnsupportedRoutingTable
+		}
+		for _, route := range d.Routes {
+			if route.Table != nil {
+				return ErrUnsupportedRoutingTable
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *NetworkConfig) patch() (*machinePatch, error) {
+	// Validate inputs to the patch.
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	patch := &machinePatch{}
+	patch.Machine.Network.Hostname = r.Hostname
+
+	// VLANs are nested below their link, so interfaces are added first.
+	interfaces := make(map[string]int)
+	for _, d := range r.Devices {
+		if d.Type != network.TypeEthernet {
+			continue
+		}
+		interfaces[d.Name] = len(patch.Machine.Network.Interfaces)
+		patch.Machine.Network.Interfaces = append(patch.Machine.Network.Interfaces, devicePatch{
+			DeviceSelector: deviceSelector{HardwareAddr: d.MacAddress},
+			ipPatch:        newIPPatch(d),
+		})
+	}
+
+	for _, d := range r.Devices {
+		if d.Type == network.TypeVLAN {
+			device := &patch.Machine.Network.Interfaces[interfaces[d.Link]]
+			device.VLANs = append(device.VLANs, vlanPatch{
+				VLANID:  ptr.Deref(d.VLANID, 0),
+				ipPatch: newIPPatch(d),
+			})
+		}
+
+		for _, dns := range d.DNSServers {
+			if !slices.Contains(patch.Machine.Network.Nameservers, dns) {
+				patch.Machine.Network.Nameservers = append(patch.Machine.Network.Nameservers, dns)
+			}
+		}
+	}
+
+	return patch, nil
+}
+
+func newIPPatch(d network.ConfigData) ipPatch {
+	ip := ipPatch{MTU: ptr.Deref(d.LinkMTU, 0)}
+
+	for _, ipconfig := range d.IPConfigs {
+		ip.Addresses = append(ip.Addresses, ipconfig.IPAddress.String())
+	}
+
+	for _, route := range d.Routes {
+		rp := routePatch{Metric: ptr.Deref(route.Metric, 0)}
+		if route.To.IsValid() {
+			rp.Network = route.To.String()
+		}
+		if route.Via.IsValid() {
+			rp.Gateway = route.Via.String()
+		}
+		ip.Routes = append(ip.Routes, rp)
+	}
+
+	if d.DHCP4 || d.DHCP6 {
+		ip.DHCP = true
+		ip.DHCPOptions = &dhcpOptions{IPv4: d.DHCP4, IPv6: d.DHCP6}
+	}
+
+	return ip
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package talos
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const expectedValidNetworkPatch = `machine:
+  network:
+    hostname: test-machine
+    interfaces:
+      - deviceSelector:
+          hardwareAddr: 92:60:a0:5b:22:c2
+        addresses:
+          - 10.10.10.12/24
+          - 2001:db8::2/64
+        routes:
+          - network: 0.0.0.0/0
+            gateway: 10.10.10.1
+            metric: 100
+          - network: ::/0
+            gateway: 2001:db8::1
+        mtu: 9000
+      - deviceSelector:
+          hardwareAddr: b4:87:18:bf:a3:60
+        dhcp: true
+        dhcpOptions:
+          ipv4: true
+          ipv6: false
+    nameservers:
+      - 8.8.8.8
+      - 8.8.4.4
+`
+
+const expectedVLANNetworkPatch = `machine:
+  network:
+    hostname: test-machine
+    interfaces:
+      - deviceSelector:
+          hardwareAddr: 92:60:a0:5b:22:c2
+        dhcp: true
+        dhcpOptions:
+          ipv4: true
+          ipv6: false
+        vlans:
+          - vlanId: 100
+            addresses:
+              - 10.20.0.12/24
+            routes:
+              - network: 10.30.0.0/16
+                gateway: 10.20.0.1
+    nameservers:
+      - 8.8.8.8
+`
+
+func TestNetworkConfig_Render(t *testing.T) {
+	type args struct {
+		hostname string
+		devices  []network.ConfigData
+	}
+
+	tests := map[string]struct {
+		args    args
+		want    string
+		wantErr error
+	}{
+		"ValidNetworkPatch": {
+			args: args{
+				hostname: "test-machine",
+				devices: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("10.10.10.12/24")},
+							{IPAddress: netip.MustParsePrefix("2001:db8::2/64")},
+						},
+						Routes: []network.RoutingData{
+							{To: netip.MustParsePrefix("0.0.0.0/0"), Via: netip.MustParseAddr("10.10.10.1"), Metric: new(int32(100))},
+							{To: netip.MustParsePrefix("::/0"), Via: netip.MustParseAddr("2001:db8::1")},
+						},
+						DNSServers: []string{"8.8.8.8", "8.8.4.4"},
+						LinkMTU:    new(int32(9000)),
+					},
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth1",
+						MacAddress: "b4:87:18:bf:a3:60",
+						DHCP4:      true,
+						DNSServers: []string{"8.8.8.8"},
+					},
+				},
+			},
+			want: expectedValidNetworkPatch,
+		},
+		"VLANOnEthernet": {
+			args: args{
+				hostname: "test-machine",
+				devices: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+					},
+					{
+						Type:   network.TypeVLAN,
+						Name:   "vlan100",
+						Link:   "eth0",
+						VLANID: new(int32(100)),
+						IPConfigs: []network.IPConfig{
+							{IPAddress: netip.MustParsePrefix("10.20.0.12/24")},
+						},
+						Routes: []network.RoutingData{
+							{To: netip.MustParsePrefix("10.30.0.0/16"), Via: netip.MustParseAddr("10.20.0.1")},
+						},
+						DNSServers: []string{"8.8.8.8"},
+					},
+				},
+			},
+			want: expectedVLANNetworkPatch,
+		},
+		"BondIsNotSupported": {
+			args: args{
+				devices: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+					},
+					{
+						Type:     network.TypeBond,
+						Name:     "bond0",
+						DHCP4:    true,
+						Children: []string{"eth0"},
+					},
+				},
+			},
+			wantErr: ErrUnsupportedDevice,
+		},
+		"MissingNetworkConfigData": {
+			args:    args{hostname: "test-machine"},
+			wantErr: ErrMissingNetworkConfigData,
+		},
+		"VRFIsNotSupported": {
+			args: args{
+				devices: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+					},
+					{
+						Type:     network.TypeVRF,
+						Name:     "vrf-blue",
+						Table:    new(int32(500)),
+						Children: []string{"eth0"},
+					},
+				},
+			},
+			wantErr: ErrUnsupportedDevice,
+		},
+		"IPv6ModeIsNotSupported": {
+			args: args{
+				devices: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						IPv6Mode:   infrav1.IPv6ModeDisabled,
+					},
+				},
+			},
+			wantErr: ErrUnsupportedIPv6Mode,
+		},
+		"RoutingTableIsNotSupported": {
+			args: args{
+				devices: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						Routes: []network.RoutingData{
+							{To: netip.MustParsePrefix("10.0.0.0/8"), Via: netip.MustParseAddr("10.10.10.1"), Table: new(int32(500))},
+						},
+					},
+				},
+			},
+			wantErr: ErrUnsupportedRoutingTable,
+		},
+		"RoutingPolicyIsNotSupported": {
+			args: args{
+				devices: []network.ConfigData{
+					{
+						Type:       network.TypeEthernet,
+						Name:       "eth0",
+						MacAddress: "92:60:a0:5b:22:c2",
+						DHCP4:      true,
+						FIBRules: []network.FIBRuleData{
+							{From: netip.MustParsePrefix("10.10.10.0/24"), Table: new(int32(500))},
+						},
+					},
+				},
+			},
+			wantErr: ErrUnsupportedRoutingTable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			nc := NewNetworkConfig(test.args.hostname, test.args.devices)
+			got, err := nc.Render()
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, string(got))
+		})
+	}
+}
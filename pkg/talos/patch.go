@@ -0,0 +1,179 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package talos implements network patching of Talos machine configs.
+package talos
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+// Patcher is responsible for patching the Talos machine config with the machine network.
+type Patcher struct {
+	BootstrapData []byte
+	Hostname      string
+	Network       []network.ConfigData
+}
+
+// Patch merges the generated network settings into the v1alpha1 machine config
+// document of the bootstrap data. All other documents are passed through.
+//
+// Interfaces are matched by their hardware address: generated settings replace
+// the same keys of an existing interface, while other keys (e.g. a VIP) are kept.
+func (p *Patcher) Patch() ([]byte, error) {
+	patch, err := NewNetworkConfig(p.Hostname, p.Network).patch()
+	if err != nil {
+		return nil, errors.Wrap(err, "rendering network patch")
+	}
+
+	var patchNode yaml.Node
+	if err := patchNode.Encode(patch.Machine.Network); err != nil {
+		return nil, errors.Wrap(err, "encoding network patch")
+	}
+
+	docs, err := decodeDocuments(p.BootstrapData)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing talos machine config")
+	}
+
+	var machine *yaml.Node
+	for _, doc := range docs {
+		if m := mappingValue(doc.Content[0], "machine"); m != nil && m.Kind == yaml.MappingNode {
+			machine = m
+			break
+		}
+	}
+	if machine == nil {
+		return nil, ErrMissingMachineConfig
+	}
+
+	networkNode := mappingValue(machine, "network")
+	if networkNode == nil || networkNode.Kind != yaml.MappingNode {
+		networkNode = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(machine, "network", networkNode)
+	}
+
+	mergeNetwork(networkNode, &patchNode)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return nil, errors.Wrap(err, "encoding talos machine config")
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "encoding talos machine config")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeDocuments(data []byte) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := &yaml.Node{}
+		err := dec.Decode(doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func mergeNetwork(dst, patch *yaml.Node) {
+	for i := 0; i+1 < len(patch.Content); i += 2 {
+		key, value := patch.Content[i].Value, patch.Content[i+1]
+		if key != "interfaces" {
+			setMappingValue(dst, key, value)
+			continue
+		}
+
+		interfaces := mappingValue(dst, key)
+		if interfaces == nil || interfaces.Kind != yaml.SequenceNode {
+			setMappingValue(dst, key, value)
+			continue
+		}
+
+		for _, device := range value.Content {
+			if existing := findInterface(interfaces, hardwareAddr(device)); existing != nil {
+				for j := 0; j+1 < len(device.Content); j += 2 {
+					setMappingValue(existing, device.Content[j].Value, device.Content[j+1])
+				}
+				continue
+			}
+			interfaces.Content = append(interfaces.Content, device)
+		}
+	}
+}
+
+func findInterface(interfaces *yaml.Node, mac string) *yaml.Node {
+	for _, device := range interfaces.Content {
+		if device.Kind == yaml.MappingNode && strings.EqualFold(hardwareAddr(device), mac) {
+			return device
+		}
+	}
+	return nil
+}
+
+func hardwareAddr(device *yaml.Node) string {
+	selector := mappingValue(device, "deviceSelector")
+	if selector == nil {
+		return ""
+	}
+	if addr := mappingValue(selector, "hardwareAddr"); addr != nil {
+		return addr.Value
+	}
+	return ""
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
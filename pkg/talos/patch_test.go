@@ -0,0 +1,164 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package talos
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/network"
+)
+
+const machineConfig = `version: v1alpha1
+debug: false
+machine:
+  type: controlplane
+  token: abc.def
+  network:
+    interfaces:
+      - deviceSelector:
+          hardwareAddr: 92:60:A0:5B:22:C2
+        vip:
+          ip: 10.10.10.10
+        dhcp: true
+cluster:
+  clusterName: test
+---
+apiVersion: v1alpha1
+kind: ExtensionServiceConfig
+name: test
+`
+
+const expectedPatchedMachineConfig = `version: v1alpha1
+debug: false
+machine:
+  type: controlplane
+  token: abc.def
+  network:
+    interfaces:
+      - deviceSelector:
+          hardwareAddr: 92:60:a0:5b:22:c2
+        vip:
+          ip: 10.10.10.10
+        dhcp: true
+        addresses:
+          - 10.10.10.12/24
+        routes:
+          - network: 0.0.0.0/0
+            gateway: 10.10.10.1
+      - deviceSelector:
+          hardwareAddr: b4:87:18:bf:a3:60
+        addresses:
+          - 10.20.10.12/24
+    hostname: test-machine
+    nameservers:
+      - 8.8.8.8
+cluster:
+  clusterName: test
+---
+apiVersion: v1alpha1
+kind: ExtensionServiceConfig
+name: test
+`
+
+func TestPatcher_Patch(t *testing.T) {
+	devices := []network.ConfigData{
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth0",
+			MacAddress: "92:60:a0:5b:22:c2",
+			IPConfigs:  []network.IPConfig{{IPAddress: netip.MustParsePrefix("10.10.10.12/24")}},
+			Routes:     []network.RoutingData{{To: netip.MustParsePrefix("0.0.0.0/0"), Via: netip.MustParseAddr("10.10.10.1")}},
+			DNSServers: []string{"8.8.8.8"},
+		},
+		{
+			Type:       network.TypeEthernet,
+			Name:       "eth1",
+			MacAddress: "b4:87:18:bf:a3:60",
+			IPConfigs:  []network.IPConfig{{IPAddress: netip.MustParsePrefix("10.20.10.12/24")}},
+		},
+	}
+
+	p := &Patcher{
+		BootstrapData: []byte(machineConfig),
+		Hostname:      "test-machine",
+		Network:       devices,
+	}
+
+	got, err := p.Patch()
+	require.NoError(t, err)
+	require.Equal(t, expectedPatchedMachineConfig, string(got))
+}
+
+func TestPatcher_PatchWithoutNetwork(t *testing.T) {
+	p := &Patcher{
+		BootstrapData: []byte("version: v1alpha1\nmachine:\n  type: worker\n"),
+		Hostname:      "test-machine",
+		Network: []network.ConfigData{
+			{
+				Type:       network.TypeEthernet,
+				Name:       "eth0",
+				MacAddress: "92:60:a0:5b:22:c2",
+				DHCP4:      true,
+			},
+		},
+	}
+
+	got, err := p.Patch()
+	require.NoError(t, err)
+	require.Equal(t, `version: v1alpha1
+machine:
+  type: worker
+  network:
+    hostname: test-machine
+    interfaces:
+      - deviceSelector:
+          hardwareAddr: 92:60:a0:5b:22:c2
+        dhcp: true
+        dhcpOptions:
+          ipv4: true
+          ipv6: false
+`, string(got))
+}
+
+func TestPatcher_PatchMissingMachineConfig(t *testing.T) {
+	p := &Patcher{
+		BootstrapData: []byte("apiVersion: v1alpha1\nkind: ExtensionServiceConfig\n"),
+		Network: []network.ConfigData{
+			{
+				Type:       network.TypeEthernet,
+				Name:       "eth0",
+				MacAddress: "92:60:a0:5b:22:c2",
+				DHCP4:      true,
+			},
+		},
+	}
+
+	_, err := p.Patch()
+	require.ErrorIs(t, err, ErrMissingMachineConfig)
+}
+
+func TestPatcher_PatchInvalidNetwork(t *testing.T) {
+	p := &Patcher{
+		BootstrapData: []byte(machineConfig),
+	}
+
+	_, err := p.Patch()
+	require.ErrorIs(t, err, ErrMissingNetworkConfigData)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-proxmox/api/v1alpha2"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/kubernetes/ipam"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/proxmox/proxmoxtest"
+	"github.com/ionos-cloud/cluster-api-provider-proxmox/pkg/scope"
+)
+
+// NewClusterScope returns a cluster scope for unit tests of the cluster services. The scope is backed by
+// a fake client holding the ProxmoxCluster, a Cluster of the same name and the given objects, and by a
+// mocked Proxmox client.
+func NewClusterScope(t *testing.T, proxmoxCluster *infrav1.ProxmoxCluster, objects ...client.Object) (*scope.ClusterScope, *proxmoxtest.MockClient) {
+	t.Helper()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      proxmoxCluster.GetName(),
+			Namespace: proxmoxCluster.GetNamespace(),
+		},
+	}
+
+	kubeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append([]client.Object{cluster, proxmoxCluster}, objects...)...).
+		WithStatusSubresource(&infrav1.ProxmoxCluster{}, &infrav1.ProxmoxMachine{}).
+		Build()
+
+	logger := logr.Discard()
+	mockClient := proxmoxtest.NewMockClient(t)
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:         kubeClient,
+		Logger:         &logger,
+		Cluster:        cluster,
+		ProxmoxCluster: proxmoxCluster,
+		ProxmoxClient:  mockClient,
+		IPAMHelper:     ipam.NewHelper(kubeClient, proxmoxCluster),
+	})
+	require.NoError(t, err)
+
+	return clusterScope, mockClient
+}